@@ -0,0 +1,326 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/app/access/mocks"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modelaccess "github.com/mcdev12/lumo/go/internal/models/access"
+	"github.com/stretchr/testify/suite"
+)
+
+// AuthorizerTestSuite is a test suite for the Authorizer
+type AuthorizerTestSuite struct {
+	suite.Suite
+	mockRepo   *mocks.MockOwnerRepository
+	authorizer *Authorizer
+	owner      *modelaccess.Owner
+}
+
+// SetupTest is called before each test
+func (s *AuthorizerTestSuite) SetupTest() {
+	s.mockRepo = mocks.NewMockOwnerRepository(s.T())
+	s.authorizer = NewAuthorizer(s.mockRepo)
+	s.owner = modelaccess.NewOwner(uuid.New().String(), uuid.New().String())
+}
+
+// TestAuthorizerSuite runs the test suite
+func TestAuthorizerSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizerTestSuite))
+}
+
+// asUser returns a context authenticated as the given user
+func asUser(userID string) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{UserID: userID})
+}
+
+// Test every Authorize method against creators, strangers and missing entities
+func (s *AuthorizerTestSuite) TestAuthorize() {
+	lumoID := uuid.New().String()
+	lumeID := uuid.New().String()
+	linkID := uuid.New().String()
+	var id int64 = 42
+
+	methods := []struct {
+		name      string
+		lookup    string
+		arg       interface{}
+		authorize func(ctx context.Context) (*modelaccess.Owner, error)
+	}{
+		{"AuthorizeLumo", "GetLumoOwner", lumoID, func(ctx context.Context) (*modelaccess.Owner, error) {
+			return s.authorizer.AuthorizeLumo(ctx, lumoID, modelaccess.RoleViewer)
+		}},
+		{"AuthorizeLumoByID", "GetLumoOwnerByID", id, func(ctx context.Context) (*modelaccess.Owner, error) {
+			return s.authorizer.AuthorizeLumoByID(ctx, id, modelaccess.RoleViewer)
+		}},
+		{"AuthorizeLume", "GetLumeOwner", lumeID, func(ctx context.Context) (*modelaccess.Owner, error) {
+			return s.authorizer.AuthorizeLume(ctx, lumeID, modelaccess.RoleViewer)
+		}},
+		{"AuthorizeLumeByID", "GetLumeOwnerByID", id, func(ctx context.Context) (*modelaccess.Owner, error) {
+			return s.authorizer.AuthorizeLumeByID(ctx, id, modelaccess.RoleViewer)
+		}},
+		{"AuthorizeLink", "GetLinkOwner", linkID, func(ctx context.Context) (*modelaccess.Owner, error) {
+			return s.authorizer.AuthorizeLink(ctx, linkID, modelaccess.RoleViewer)
+		}},
+		{"AuthorizeLinkByID", "GetLinkOwnerByID", id, func(ctx context.Context) (*modelaccess.Owner, error) {
+			return s.authorizer.AuthorizeLinkByID(ctx, id, modelaccess.RoleViewer)
+		}},
+	}
+
+	databaseErr := errors.New("database error")
+
+	cases := []struct {
+		name      string
+		ctx       func() context.Context
+		lookupErr error
+		wantErr   error
+	}{
+		{"creator", func() context.Context { return asUser(s.owner.UserID) }, nil, nil},
+		{"other user", func() context.Context { return asUser(uuid.New().String()) }, nil, ErrNotFound},
+		{"missing", func() context.Context { return asUser(s.owner.UserID) }, modelaccess.ErrNotFound, ErrNotFound},
+		{"unauthenticated", context.Background, nil, nil},
+		{"unauthenticated missing", context.Background, modelaccess.ErrNotFound, ErrNotFound},
+		{"lookup error", func() context.Context { return asUser(s.owner.UserID) }, databaseErr, databaseErr},
+	}
+
+	for _, m := range methods {
+		for _, tc := range cases {
+			s.Run(m.name+"/"+tc.name, func() {
+				// Arrange
+				s.SetupTest()
+				ctx := tc.ctx()
+
+				// Set up expectations
+				var found *modelaccess.Owner
+				if tc.lookupErr == nil {
+					found = s.owner
+				}
+				s.mockRepo.On(m.lookup, ctx, m.arg).Return(found, tc.lookupErr)
+				if identity, ok := auth.IdentityFromContext(ctx); ok && found != nil && identity.UserID != found.UserID {
+					s.mockRepo.On("GetMemberRole", ctx, found.LumoID, identity.UserID).Return(modelaccess.RoleUnspecified, modelaccess.ErrNotFound)
+				}
+
+				// Act
+				owner, err := m.authorize(ctx)
+
+				// Assert
+				if tc.wantErr != nil {
+					s.ErrorIs(err, tc.wantErr)
+					s.Nil(owner)
+					return
+				}
+				s.NoError(err)
+				s.Equal(s.owner, owner)
+				s.Equal(modelaccess.RoleOwner, owner.Role)
+			})
+		}
+	}
+}
+
+// Test members get exactly as far as their role allows
+func (s *AuthorizerTestSuite) TestAuthorizeMemberRoles() {
+	lumoID := uuid.New().String()
+
+	cases := []struct {
+		role     modelaccess.Role
+		required modelaccess.Role
+		wantErr  error
+	}{
+		{modelaccess.RoleViewer, modelaccess.RoleViewer, nil},
+		{modelaccess.RoleViewer, modelaccess.RoleEditor, ErrPermissionDenied},
+		{modelaccess.RoleCommenter, modelaccess.RoleViewer, nil},
+		{modelaccess.RoleCommenter, modelaccess.RoleEditor, ErrPermissionDenied},
+		{modelaccess.RoleEditor, modelaccess.RoleEditor, nil},
+		{modelaccess.RoleEditor, modelaccess.RoleOwner, ErrPermissionDenied},
+		{modelaccess.RoleOwner, modelaccess.RoleOwner, nil},
+	}
+
+	for _, tc := range cases {
+		s.Run(string(tc.role)+" needs "+string(tc.required), func() {
+			// Arrange
+			s.SetupTest()
+			memberID := uuid.New().String()
+			ctx := asUser(memberID)
+
+			// Set up expectations
+			s.mockRepo.On("GetLumoOwner", ctx, lumoID).Return(s.owner, nil)
+			s.mockRepo.On("GetMemberRole", ctx, s.owner.LumoID, memberID).Return(tc.role, nil)
+
+			// Act
+			owner, err := s.authorizer.AuthorizeLumo(ctx, lumoID, tc.required)
+
+			// Assert
+			if tc.wantErr != nil {
+				s.ErrorIs(err, tc.wantErr)
+				s.Nil(owner)
+				return
+			}
+			s.NoError(err)
+			s.Equal(tc.role, owner.Role)
+		})
+	}
+}
+
+// Test member lookup failures are passed through
+func (s *AuthorizerTestSuite) TestAuthorizeMemberRoleError() {
+	// Arrange
+	memberID := uuid.New().String()
+	ctx := asUser(memberID)
+	expectedErr := errors.New("database error")
+
+	// Set up expectations
+	s.mockRepo.On("GetLumoOwner", ctx, s.owner.LumoID).Return(s.owner, nil)
+	s.mockRepo.On("GetMemberRole", ctx, s.owner.LumoID, memberID).Return(modelaccess.RoleUnspecified, expectedErr)
+
+	// Act
+	owner, err := s.authorizer.AuthorizeLumo(ctx, s.owner.LumoID, modelaccess.RoleViewer)
+
+	// Assert
+	s.ErrorIs(err, expectedErr)
+	s.Nil(owner)
+}
+
+// Test AuthorizeConnection only allows Links within one Lumo of the caller
+func (s *AuthorizerTestSuite) TestAuthorizeConnection() {
+	fromLumeID := uuid.New().String()
+	toLumeID := uuid.New().String()
+
+	cases := []struct {
+		name    string
+		from    func() *modelaccess.Owner
+		to      func() *modelaccess.Owner
+		wantErr error
+	}{
+		{
+			name:    "same lumo",
+			from:    func() *modelaccess.Owner { return s.owner },
+			to:      func() *modelaccess.Owner { return s.owner },
+			wantErr: nil,
+		},
+		{
+			name: "different lumos of the caller",
+			from: func() *modelaccess.Owner { return s.owner },
+			to: func() *modelaccess.Owner {
+				return modelaccess.NewOwner(uuid.New().String(), s.owner.UserID)
+			},
+			wantErr: ErrCrossLumoLink,
+		},
+		{
+			name: "target in a lumo the caller isn't a member of",
+			from: func() *modelaccess.Owner { return s.owner },
+			to: func() *modelaccess.Owner {
+				return modelaccess.NewOwner(s.owner.LumoID, uuid.New().String())
+			},
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			// Arrange
+			s.SetupTest()
+			ctx := asUser(s.owner.UserID)
+
+			// Set up expectations
+			to := tc.to()
+			s.mockRepo.On("GetLumeOwner", ctx, fromLumeID).Return(tc.from(), nil)
+			s.mockRepo.On("GetLumeOwner", ctx, toLumeID).Return(to, nil)
+			if to.UserID != s.owner.UserID {
+				s.mockRepo.On("GetMemberRole", ctx, to.LumoID, s.owner.UserID).Return(modelaccess.RoleUnspecified, modelaccess.ErrNotFound)
+			}
+
+			// Act
+			owner, err := s.authorizer.AuthorizeConnection(ctx, fromLumeID, toLumeID)
+
+			// Assert
+			if tc.wantErr != nil {
+				s.ErrorIs(err, tc.wantErr)
+				s.Nil(owner)
+				return
+			}
+			s.NoError(err)
+			s.Equal(s.owner, owner)
+		})
+	}
+}
+
+// Test AuthorizeConnection stops at a source Lume the caller can't see
+func (s *AuthorizerTestSuite) TestAuthorizeConnectionSourceNotFound() {
+	// Arrange
+	ctx := asUser(s.owner.UserID)
+	fromLumeID := uuid.New().String()
+	toLumeID := uuid.New().String()
+
+	// Set up expectations
+	s.mockRepo.On("GetLumeOwner", ctx, fromLumeID).Return(nil, modelaccess.ErrNotFound)
+
+	// Act
+	owner, err := s.authorizer.AuthorizeConnection(ctx, fromLumeID, toLumeID)
+
+	// Assert
+	s.ErrorIs(err, ErrNotFound)
+	s.Nil(owner)
+	s.mockRepo.AssertNotCalled(s.T(), "GetLumeOwner", ctx, toLumeID)
+}
+
+// Test API keys reach no further than their scope allows
+func (s *AuthorizerTestSuite) TestAuthorizeAPIKeyScopes() {
+	cases := []struct {
+		name     string
+		identity func() *auth.Identity
+		required modelaccess.Role
+		wantRole modelaccess.Role
+		wantErr  error
+	}{
+		{
+			name:     "read-only key reads",
+			identity: func() *auth.Identity { return &auth.Identity{UserID: s.owner.UserID, ReadOnly: true} },
+			required: modelaccess.RoleViewer,
+			wantRole: modelaccess.RoleViewer,
+		},
+		{
+			name:     "read-only key edits",
+			identity: func() *auth.Identity { return &auth.Identity{UserID: s.owner.UserID, ReadOnly: true} },
+			required: modelaccess.RoleEditor,
+			wantErr:  ErrPermissionDenied,
+		},
+		{
+			name:     "key of the lumo",
+			identity: func() *auth.Identity { return &auth.Identity{UserID: s.owner.UserID, LumoID: s.owner.LumoID} },
+			required: modelaccess.RoleOwner,
+			wantRole: modelaccess.RoleOwner,
+		},
+		{
+			name:     "key of another lumo",
+			identity: func() *auth.Identity { return &auth.Identity{UserID: s.owner.UserID, LumoID: uuid.New().String()} },
+			required: modelaccess.RoleViewer,
+			wantErr:  ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			// Arrange
+			s.SetupTest()
+			ctx := auth.WithIdentity(context.Background(), tc.identity())
+
+			// Set up expectations
+			s.mockRepo.On("GetLumoOwner", ctx, s.owner.LumoID).Return(s.owner, nil)
+
+			// Act
+			owner, err := s.authorizer.AuthorizeLumo(ctx, s.owner.LumoID, tc.required)
+
+			// Assert
+			if tc.wantErr != nil {
+				s.ErrorIs(err, tc.wantErr)
+				s.Nil(owner)
+				return
+			}
+			s.NoError(err)
+			s.Equal(tc.wantRole, owner.Role)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modelaccess "github.com/mcdev12/lumo/go/internal/models/access"
+	modelapikey "github.com/mcdev12/lumo/go/internal/models/apikey"
+	modeluser "github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Domain errors
+var (
+	ErrInvalidUserID   = errors.New("invalid user ID")
+	ErrInvalidAPIKeyID = errors.New("invalid api key ID")
+	ErrInvalidLumoID   = errors.New("invalid lumo ID")
+	ErrEmptyName       = errors.New("name cannot be empty")
+	ErrInvalidScope    = errors.New("invalid api key scope")
+	ErrLumoScope       = errors.New("a lumo ID is required for lumo scoped keys and not allowed for others")
+
+	ErrAPIKeyNotFound   = modelapikey.ErrAPIKeyNotFound
+	ErrUserNotFound     = modeluser.ErrUserNotFound
+	ErrNotFound         = modelaccess.ErrNotFound
+	ErrPermissionDenied = auth.ErrPermissionDenied
+)
+
+// APIKeyRepository defines what the app layer needs from the repository
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key *modelapikey.APIKey) (*modelapikey.APIKey, error)
+	GetAPIKeyByAPIKeyID(ctx context.Context, apiKeyID string) (*modelapikey.APIKey, error)
+	ListAPIKeysByUserID(ctx context.Context, userID string, limit, offset int32) ([]*modelapikey.APIKey, error)
+	RevokeAPIKey(ctx context.Context, apiKeyID string) (*modelapikey.APIKey, error)
+	RotateAPIKey(ctx context.Context, key *modelapikey.APIKey) (*modelapikey.APIKey, error)
+	UseAPIKey(ctx context.Context, secret string) (*modelapikey.APIKey, error)
+}
+
+// Authorizer checks the caller's role in a Lumo
+type Authorizer interface {
+	AuthorizeLumo(ctx context.Context, lumoID string, required modelaccess.Role) (*modelaccess.Owner, error)
+}
+
+// App handles business logic for API keys
+type App struct {
+	repo  APIKeyRepository
+	authz Authorizer
+}
+
+// NewAPIKeyApp creates a new APIKey App
+func NewAPIKeyApp(repo APIKeyRepository, authz Authorizer) *App {
+	return &App{
+		repo:  repo,
+		authz: authz,
+	}
+}
+
+// CreateAPIKey creates a key for the caller. Keys limited to a Lumo can only
+// be created by its members. The returned key is the only place its secret
+// can be read from.
+func (a *App) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (_ *modelapikey.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "apikey.App.CreateAPIKey")
+	defer tracing.End(span, &err)
+
+	if err := requireFullAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	userID, err := auth.ResolveUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, ErrInvalidUserID
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	if !req.Scope.IsValid() {
+		return nil, ErrInvalidScope
+	}
+	if (req.Scope == modelapikey.ScopeLumo) != (req.LumoID != "") {
+		return nil, ErrLumoScope
+	}
+
+	if req.LumoID != "" {
+		if _, err := uuid.Parse(req.LumoID); err != nil {
+			return nil, ErrInvalidLumoID
+		}
+		if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, modelaccess.RoleViewer); err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := modelapikey.NewAPIKey(userID, name, req.Scope, req.LumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.repo.CreateAPIKey(ctx, key)
+}
+
+// ListAPIKeys lists the keys of the caller, revoked ones included
+func (a *App) ListAPIKeys(ctx context.Context, req ListAPIKeysRequest) (_ []*modelapikey.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "apikey.App.ListAPIKeys")
+	defer tracing.End(span, &err)
+
+	userID, err := auth.ResolveUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, ErrInvalidUserID
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	return a.repo.ListAPIKeysByUserID(ctx, userID, limit, req.Offset)
+}
+
+// RevokeAPIKey stops one of the caller's keys from working
+func (a *App) RevokeAPIKey(ctx context.Context, apiKeyID string) (_ *modelapikey.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "apikey.App.RevokeAPIKey")
+	defer tracing.End(span, &err)
+
+	if _, err := a.getOwnKey(ctx, apiKeyID); err != nil {
+		return nil, err
+	}
+
+	return a.repo.RevokeAPIKey(ctx, apiKeyID)
+}
+
+// RotateAPIKey replaces the secret of one of the caller's keys. The old
+// secret stops working right away, and the returned key is the only place the
+// new one can be read from.
+func (a *App) RotateAPIKey(ctx context.Context, apiKeyID string) (_ *modelapikey.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "apikey.App.RotateAPIKey")
+	defer tracing.End(span, &err)
+
+	key, err := a.getOwnKey(ctx, apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := key.NewSecret(); err != nil {
+		return nil, err
+	}
+
+	return a.repo.RotateAPIKey(ctx, key)
+}
+
+// AuthenticateAPIKey returns the caller a key's secret stands for, and
+// records that the key was used
+func (a *App) AuthenticateAPIKey(ctx context.Context, secret string) (_ *auth.Identity, err error) {
+	ctx, span := tracing.Start(ctx, "apikey.App.AuthenticateAPIKey")
+	defer tracing.End(span, &err)
+
+	key, err := a.repo.UseAPIKey(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.Identity{
+		UserID:   key.UserID,
+		APIKeyID: key.APIKeyID,
+		ReadOnly: key.Scope == modelapikey.ScopeReadOnly,
+		LumoID:   key.LumoID,
+	}, nil
+}
+
+// getOwnKey retrieves a key the caller may manage. Keys of other users are
+// reported as ErrAPIKeyNotFound.
+func (a *App) getOwnKey(ctx context.Context, apiKeyID string) (*modelapikey.APIKey, error) {
+	if err := requireFullAccess(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(apiKeyID); err != nil {
+		return nil, ErrInvalidAPIKeyID
+	}
+
+	key, err := a.repo.GetAPIKeyByAPIKeyID(ctx, apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity, ok := auth.IdentityFromContext(ctx); ok && identity.UserID != key.UserID {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	return key, nil
+}
+
+// requireFullAccess turns away callers whose key has a narrower scope, so a
+// leaked key can't be used to mint broader ones
+func requireFullAccess(ctx context.Context) error {
+	if identity, ok := auth.IdentityFromContext(ctx); ok && !identity.FullAccess() {
+		return ErrPermissionDenied
+	}
+	return nil
+}
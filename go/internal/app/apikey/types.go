@@ -0,0 +1,23 @@
+package apikey
+
+import (
+	modelapikey "github.com/mcdev12/lumo/go/internal/models/apikey"
+)
+
+// CreateAPIKeyRequest represents the business layer's create request
+type CreateAPIKeyRequest struct {
+	// Optional, defaults to the caller
+	UserID string
+	Name   string
+	Scope  modelapikey.Scope
+	// Required for modelapikey.ScopeLumo, not allowed otherwise
+	LumoID string
+}
+
+// ListAPIKeysRequest represents the business layer's list request
+type ListAPIKeysRequest struct {
+	// Optional, defaults to the caller
+	UserID string
+	Limit  int32
+	Offset int32
+}
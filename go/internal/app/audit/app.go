@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modelaccess "github.com/mcdev12/lumo/go/internal/models/access"
+	modelaudit "github.com/mcdev12/lumo/go/internal/models/audit"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Domain errors
+var (
+	ErrInvalidLumoID    = errors.New("invalid lumo ID")
+	ErrInvalidTimeRange = errors.New("end time must be after start time")
+
+	ErrNotFound         = modelaccess.ErrNotFound
+	ErrPermissionDenied = auth.ErrPermissionDenied
+)
+
+// AuditRepository defines what the app layer needs from the repository
+type AuditRepository interface {
+	CreateEntry(ctx context.Context, entry *modelaudit.Entry) (*modelaudit.Entry, error)
+	ListEntries(ctx context.Context, filter modelaudit.Filter, limit, offset int32) ([]*modelaudit.Entry, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Authorizer checks the caller's role in a Lumo
+type Authorizer interface {
+	AuthorizeLumo(ctx context.Context, lumoID string, required modelaccess.Role) (*modelaccess.Owner, error)
+}
+
+// App handles business logic for the audit log
+type App struct {
+	repo  AuditRepository
+	authz Authorizer
+}
+
+// NewAuditApp creates a new Audit App
+func NewAuditApp(repo AuditRepository, authz Authorizer) *App {
+	return &App{
+		repo:  repo,
+		authz: authz,
+	}
+}
+
+// Record adds an entry to the audit log
+func (a *App) Record(ctx context.Context, entry *modelaudit.Entry) (err error) {
+	ctx, span := tracing.Start(ctx, "audit.App.Record")
+	defer tracing.End(span, &err)
+
+	_, err = a.repo.CreateEntry(ctx, entry)
+	return err
+}
+
+// ListAuditLog lists audit log entries, newest first. The entries of a Lumo
+// can only be listed by its owners; otherwise callers can only list their
+// own entries.
+func (a *App) ListAuditLog(ctx context.Context, req ListAuditLogRequest) (_ []*modelaudit.Entry, err error) {
+	ctx, span := tracing.Start(ctx, "audit.App.ListAuditLog")
+	defer tracing.End(span, &err)
+
+	if req.StartTime != nil && req.EndTime != nil && !req.EndTime.After(*req.StartTime) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	filter := modelaudit.Filter{
+		LumoID: req.LumoID,
+		Actor:  req.Actor,
+		Since:  req.StartTime,
+		Until:  req.EndTime,
+	}
+
+	if req.LumoID != "" {
+		if _, err := uuid.Parse(req.LumoID); err != nil {
+			return nil, ErrInvalidLumoID
+		}
+		if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, modelaccess.RoleOwner); err != nil {
+			return nil, err
+		}
+	} else {
+		actor, err := auth.ResolveUserID(ctx, req.Actor)
+		if err != nil {
+			return nil, err
+		}
+		filter.Actor = actor
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	return a.repo.ListEntries(ctx, filter, limit, req.Offset)
+}
+
+// Purge permanently deletes the entries older than the retention period and
+// returns how many there were
+func (a *App) Purge(ctx context.Context, retention time.Duration) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "audit.App.Purge")
+	defer tracing.End(span, &err)
+
+	return a.repo.Purge(ctx, time.Now().Add(-retention))
+}
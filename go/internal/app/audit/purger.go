@@ -0,0 +1,23 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Purger returns a worker deleting the audit log entries past the retention
+// period, to run with worker.Every. Failures are logged, the next run
+// retries.
+func Purger(app *App, retention time.Duration) func(context.Context) {
+	return func(ctx context.Context) {
+		purged, err := app.Purge(ctx, retention)
+		if err != nil {
+			slog.WarnContext(ctx, "audit log purge failed", slog.Any("error", err))
+			return
+		}
+		if purged > 0 {
+			slog.InfoContext(ctx, "purged entries from the audit log", slog.Int64("purged", purged))
+		}
+	}
+}
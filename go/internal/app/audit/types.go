@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"time"
+)
+
+// ListAuditLogRequest represents the business layer's list request
+type ListAuditLogRequest struct {
+	// Optional, without it callers can only list their own entries
+	LumoID string
+	// Optional, defaults to the caller when there is no LumoID
+	Actor string
+	// Entries recorded at or after StartTime and before EndTime
+	StartTime *time.Time
+	EndTime   *time.Time
+	Limit     int32
+	Offset    int32
+}
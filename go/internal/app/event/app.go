@@ -0,0 +1,142 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modelaccess "github.com/mcdev12/lumo/go/internal/models/access"
+	modelevent "github.com/mcdev12/lumo/go/internal/models/event"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Domain errors
+var (
+	ErrInvalidLumoID      = errors.New("invalid lumo ID")
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+
+	ErrNotFound         = modelaccess.ErrNotFound
+	ErrPermissionDenied = auth.ErrPermissionDenied
+)
+
+const (
+	// eventPageSize is how many events are read from the log at once
+	eventPageSize = 100
+
+	// pollInterval bounds how long a watcher waits without a notification
+	// before checking the log anyway
+	pollInterval = 30 * time.Second
+)
+
+// EventRepository defines what the app layer needs from the repository
+type EventRepository interface {
+	GetLatestEventID(ctx context.Context, lumoID string) (int64, error)
+	ListEventsAfter(ctx context.Context, lumoID string, afterID int64, limit int32) ([]*modelevent.Event, error)
+}
+
+// Notifier signals when new events may be available for a Lumo
+type Notifier interface {
+	Subscribe(lumoID string) (<-chan struct{}, func())
+}
+
+// Authorizer checks the caller's role in a Lumo
+type Authorizer interface {
+	AuthorizeLumo(ctx context.Context, lumoID string, required modelaccess.Role) (*modelaccess.Owner, error)
+}
+
+// App handles business logic for change events
+type App struct {
+	repo     EventRepository
+	notifier Notifier
+	authz    Authorizer
+}
+
+// NewEventApp creates a new Event App
+func NewEventApp(repo EventRepository, notifier Notifier, authz Authorizer) *App {
+	return &App{
+		repo:     repo,
+		notifier: notifier,
+		authz:    authz,
+	}
+}
+
+// WatchLumo calls send for every event of the Lumo, in order, until the
+// context is done or send fails. Events after the resume token are replayed
+// first, then live events follow as the notifier reports them.
+func (a *App) WatchLumo(ctx context.Context, req WatchLumoRequest, send func(*modelevent.Event) error) (err error) {
+	ctx, span := tracing.Start(ctx, "event.App.WatchLumo")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(req.LumoID); err != nil {
+		return ErrInvalidLumoID
+	}
+
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, modelaccess.RoleViewer); err != nil {
+		return err
+	}
+
+	var cursor int64
+	if req.ResumeToken != nil {
+		var err error
+		if cursor, err = modelevent.ParseResumeToken(*req.ResumeToken); err != nil {
+			return ErrInvalidResumeToken
+		}
+	}
+
+	// Subscribe before reading the log so nothing committed in between is missed
+	wake, unsubscribe := a.notifier.Subscribe(req.LumoID)
+	defer unsubscribe()
+
+	if req.ResumeToken == nil {
+		latest, err := a.repo.GetLatestEventID(ctx, req.LumoID)
+		if err != nil {
+			return err
+		}
+		cursor = latest
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		if cursor, err = a.sendEventsAfter(ctx, req.LumoID, cursor, send); err != nil {
+			if ctx.Err() != nil {
+				// The client went away mid-read
+				return nil
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendEventsAfter sends every stored event after the cursor and returns the
+// new cursor
+func (a *App) sendEventsAfter(ctx context.Context, lumoID string, cursor int64, send func(*modelevent.Event) error) (int64, error) {
+	for {
+		events, err := a.repo.ListEventsAfter(ctx, lumoID, cursor, eventPageSize)
+		if err != nil {
+			return cursor, err
+		}
+
+		for _, e := range events {
+			if err := send(e); err != nil {
+				return cursor, err
+			}
+			cursor = e.ID
+		}
+
+		if len(events) < eventPageSize {
+			return cursor, nil
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package event
+
+// WatchLumoRequest represents the business layer's watch request
+type WatchLumoRequest struct {
+	LumoID string
+	// Resume token of the last event the client saw, nil to only receive
+	// changes made from now on
+	ResumeToken *string
+}
@@ -0,0 +1,123 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modelaccess "github.com/mcdev12/lumo/go/internal/models/access"
+	modelhistory "github.com/mcdev12/lumo/go/internal/models/history"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Domain errors
+var (
+	ErrInvalidLumoID   = errors.New("invalid lumo ID")
+	ErrInvalidEntityID = errors.New("invalid entity ID")
+	ErrMissingTarget   = errors.New("exactly one of lumo ID and entity ID is required")
+	ErrInvalidTime     = errors.New("restore time must be in the past")
+
+	ErrNotRestorable    = modelhistory.ErrNotRestorable
+	ErrNotFound         = modelaccess.ErrNotFound
+	ErrPermissionDenied = auth.ErrPermissionDenied
+)
+
+// HistoryRepository defines what the app layer needs from the repository
+type HistoryRepository interface {
+	ListEntityHistory(ctx context.Context, entityID string, limit, offset int32) ([]*modelhistory.Entry, error)
+	ListLumoHistory(ctx context.Context, lumoID string, limit, offset int32) ([]*modelhistory.Entry, error)
+	RestoreLumo(ctx context.Context, lumoID string, at time.Time) (*modelhistory.Graph, error)
+}
+
+// Authorizer checks the caller's role in a Lumo
+type Authorizer interface {
+	AuthorizeLumo(ctx context.Context, lumoID string, required modelaccess.Role) (*modelaccess.Owner, error)
+}
+
+// App handles business logic for change history
+type App struct {
+	repo  HistoryRepository
+	authz Authorizer
+}
+
+// NewHistoryApp creates a new History Service
+func NewHistoryApp(repo HistoryRepository, authz Authorizer) *App {
+	return &App{
+		repo:  repo,
+		authz: authz,
+	}
+}
+
+// ListHistory retrieves the changes to a Lumo or to a single entity, newest first
+func (a *App) ListHistory(ctx context.Context, req ListHistoryRequest) (_ []*modelhistory.Entry, err error) {
+	ctx, span := tracing.Start(ctx, "history.App.ListHistory")
+	defer tracing.End(span, &err)
+
+	if (req.LumoID == "") == (req.EntityID == "") {
+		return nil, ErrMissingTarget
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	if req.EntityID != "" {
+		if _, err := uuid.Parse(req.EntityID); err != nil {
+			return nil, ErrInvalidEntityID
+		}
+		entries, err := a.repo.ListEntityHistory(ctx, req.EntityID, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		// The entity may be long gone, so its history is checked against the
+		// Lumos it was recorded in
+		authorized := make(map[string]bool)
+		for _, entry := range entries {
+			if authorized[entry.LumoID] {
+				continue
+			}
+			if _, err := a.authz.AuthorizeLumo(ctx, entry.LumoID, modelaccess.RoleViewer); err != nil {
+				return nil, err
+			}
+			authorized[entry.LumoID] = true
+		}
+		return entries, nil
+	}
+
+	if _, err := uuid.Parse(req.LumoID); err != nil {
+		return nil, ErrInvalidLumoID
+	}
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, modelaccess.RoleViewer); err != nil {
+		return nil, err
+	}
+	return a.repo.ListLumoHistory(ctx, req.LumoID, limit, offset)
+}
+
+// RestoreLumo rebuilds a Lumo, its Lumes and Links as they were at a past instant
+func (a *App) RestoreLumo(ctx context.Context, req RestoreLumoRequest) (_ *modelhistory.Graph, err error) {
+	ctx, span := tracing.Start(ctx, "history.App.RestoreLumo")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(req.LumoID); err != nil {
+		return nil, ErrInvalidLumoID
+	}
+
+	if req.At.IsZero() || req.At.After(time.Now()) {
+		return nil, ErrInvalidTime
+	}
+
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, modelaccess.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	return a.repo.RestoreLumo(ctx, req.LumoID, req.At)
+}
@@ -0,0 +1,18 @@
+package history
+
+import "time"
+
+// ListHistoryRequest represents the business layer's list request. Exactly
+// one of LumoID and EntityID is set.
+type ListHistoryRequest struct {
+	LumoID   string
+	EntityID string
+	Limit    int32
+	Offset   int32
+}
+
+// RestoreLumoRequest represents the business layer's restore request
+type RestoreLumoRequest struct {
+	LumoID string
+	At     time.Time
+}
@@ -0,0 +1,121 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	modelidempotency "github.com/mcdev12/lumo/go/internal/models/idempotency"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Domain errors
+var (
+	ErrKeyReused   = errors.New("idempotency key was already used for a different request")
+	ErrInProgress  = errors.New("a request with this idempotency key is still in progress")
+	ErrKeyNotFound = modelidempotency.ErrKeyNotFound
+)
+
+// IdempotencyRepository defines what the app layer needs from the repository
+type IdempotencyRepository interface {
+	Claim(ctx context.Context, key *modelidempotency.Key) (bool, error)
+	Get(ctx context.Context, scope, key string) (*modelidempotency.Key, error)
+	Complete(ctx context.Context, key *modelidempotency.Key) error
+	Release(ctx context.Context, key *modelidempotency.Key) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// App handles business logic for idempotency keys
+type App struct {
+	repo  IdempotencyRepository
+	ttl   time.Duration
+	lease time.Duration
+}
+
+// NewIdempotencyApp creates a new Idempotency App. Responses are replayed for
+// ttl after the first call with a key. A call holds its key for lease, after
+// which a retry takes over a key the call left without a response, e.g.
+// because the process crashed.
+func NewIdempotencyApp(repo IdempotencyRepository, ttl, lease time.Duration) *App {
+	return &App{
+		repo:  repo,
+		ttl:   ttl,
+		lease: lease,
+	}
+}
+
+// Begin claims a key for a call with the given request hash. It returns nil
+// if the caller now holds the key and must either Complete or Release it, or
+// the stored response if a call with the same request already completed. A
+// key that was used for a different request is rejected with ErrKeyReused,
+// one whose first call is still running within its lease with ErrInProgress.
+func (a *App) Begin(ctx context.Context, scope, key string, requestHash []byte) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "idempotency.App.Begin")
+	defer tracing.End(span, &err)
+
+	now := time.Now()
+	claimed, err := a.repo.Claim(ctx, &modelidempotency.Key{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		LockedUntil: now.Add(a.lease),
+		ExpiresAt:   now.Add(a.ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if claimed {
+		return nil, nil
+	}
+
+	existing, err := a.repo.Get(ctx, scope, key)
+	if errors.Is(err, ErrKeyNotFound) {
+		// The call holding the key failed and released it just now
+		return nil, ErrInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(existing.RequestHash, requestHash) {
+		return nil, ErrKeyReused
+	}
+	if !existing.Completed() {
+		return nil, ErrInProgress
+	}
+	return existing.Response, nil
+}
+
+// Complete stores the response of a call that holds the key, to be replayed
+// to its retries
+func (a *App) Complete(ctx context.Context, scope, key string, requestHash, response []byte) (err error) {
+	ctx, span := tracing.Start(ctx, "idempotency.App.Complete")
+	defer tracing.End(span, &err)
+
+	return a.repo.Complete(ctx, &modelidempotency.Key{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		Response:    response,
+	})
+}
+
+// Release frees the key of a call that failed, so a retry runs it again
+func (a *App) Release(ctx context.Context, scope, key string, requestHash []byte) (err error) {
+	ctx, span := tracing.Start(ctx, "idempotency.App.Release")
+	defer tracing.End(span, &err)
+
+	return a.repo.Release(ctx, &modelidempotency.Key{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+	})
+}
+
+// Purge permanently deletes the expired keys and returns how many there were
+func (a *App) Purge(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "idempotency.App.Purge")
+	defer tracing.End(span, &err)
+
+	return a.repo.Purge(ctx, time.Now())
+}
@@ -0,0 +1,170 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	modelidempotency "github.com/mcdev12/lumo/go/internal/models/idempotency"
+	"github.com/stretchr/testify/suite"
+)
+
+// stubRepository keeps keys in memory
+type stubRepository struct {
+	keys map[string]*modelidempotency.Key
+}
+
+// Claim stores the key unless an unexpired one is held, like the query does
+func (r *stubRepository) Claim(_ context.Context, key *modelidempotency.Key) (bool, error) {
+	existing, ok := r.keys[key.Scope+"/"+key.Key]
+	abandoned := ok && !existing.Completed() && !existing.LockedUntil.After(time.Now()) && bytes.Equal(existing.RequestHash, key.RequestHash)
+	if ok && existing.ExpiresAt.After(time.Now()) && !abandoned {
+		return false, nil
+	}
+	copied := *key
+	r.keys[key.Scope+"/"+key.Key] = &copied
+	return true, nil
+}
+
+// Get returns a stored key
+func (r *stubRepository) Get(_ context.Context, scope, key string) (*modelidempotency.Key, error) {
+	existing, ok := r.keys[scope+"/"+key]
+	if !ok {
+		return nil, modelidempotency.ErrKeyNotFound
+	}
+	copied := *existing
+	return &copied, nil
+}
+
+// Complete stores the response of a pending key
+func (r *stubRepository) Complete(_ context.Context, key *modelidempotency.Key) error {
+	if existing, ok := r.keys[key.Scope+"/"+key.Key]; ok && !existing.Completed() {
+		existing.Response = key.Response
+	}
+	return nil
+}
+
+// Release deletes a pending key
+func (r *stubRepository) Release(_ context.Context, key *modelidempotency.Key) error {
+	if existing, ok := r.keys[key.Scope+"/"+key.Key]; ok && !existing.Completed() {
+		delete(r.keys, key.Scope+"/"+key.Key)
+	}
+	return nil
+}
+
+// Purge isn't needed by the tests
+func (r *stubRepository) Purge(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+// AppTestSuite is a test suite for the idempotency App
+type AppTestSuite struct {
+	suite.Suite
+	repo *stubRepository
+	app  *App
+}
+
+// SetupTest is called before each test
+func (s *AppTestSuite) SetupTest() {
+	s.repo = &stubRepository{keys: map[string]*modelidempotency.Key{}}
+	s.app = NewIdempotencyApp(s.repo, time.Hour, time.Minute)
+}
+
+// TestAppSuite runs the test suite
+func TestAppSuite(t *testing.T) {
+	suite.Run(t, new(AppTestSuite))
+}
+
+// Test a completed call is replayed to retries with the same request
+func (s *AppTestSuite) TestBeginReplaysCompletedCall() {
+	// Arrange
+	ctx := context.Background()
+	hash := []byte("request")
+
+	// Act
+	first, err1 := s.app.Begin(ctx, "user-1", "key-1", hash)
+	s.Require().NoError(s.app.Complete(ctx, "user-1", "key-1", hash, []byte("response")))
+	second, err2 := s.app.Begin(ctx, "user-1", "key-1", hash)
+
+	// Assert
+	s.NoError(err1)
+	s.Nil(first)
+	s.NoError(err2)
+	s.Equal([]byte("response"), second)
+}
+
+// Test a key can't be reused for a different request
+func (s *AppTestSuite) TestBeginRejectsDifferentRequest() {
+	// Arrange
+	ctx := context.Background()
+	_, err := s.app.Begin(ctx, "user-1", "key-1", []byte("request"))
+	s.Require().NoError(err)
+	s.Require().NoError(s.app.Complete(ctx, "user-1", "key-1", []byte("request"), []byte("response")))
+
+	// Act
+	_, err = s.app.Begin(ctx, "user-1", "key-1", []byte("other request"))
+
+	// Assert
+	s.ErrorIs(err, ErrKeyReused)
+}
+
+// Test retries wait for a call that is still running, and run again once it
+// failed and released the key
+func (s *AppTestSuite) TestBeginWhileInProgress() {
+	// Arrange
+	ctx := context.Background()
+	hash := []byte("request")
+	_, err := s.app.Begin(ctx, "user-1", "key-1", hash)
+	s.Require().NoError(err)
+
+	// Act
+	_, errRunning := s.app.Begin(ctx, "user-1", "key-1", hash)
+	s.Require().NoError(s.app.Release(ctx, "user-1", "key-1", hash))
+	retried, errReleased := s.app.Begin(ctx, "user-1", "key-1", hash)
+
+	// Assert
+	s.ErrorIs(errRunning, ErrInProgress)
+	s.NoError(errReleased)
+	s.Nil(retried)
+}
+
+// Test keys belong to the user who used them
+func (s *AppTestSuite) TestBeginScopesKeysToUsers() {
+	// Arrange
+	ctx := context.Background()
+	_, err := s.app.Begin(ctx, "user-1", "key-1", []byte("request"))
+	s.Require().NoError(err)
+
+	// Act
+	response, err := s.app.Begin(ctx, "user-2", "key-1", []byte("other request"))
+
+	// Assert
+	s.NoError(err)
+	s.Nil(response)
+}
+
+// Test a key whose call never completed nor released it, e.g. because the
+// process crashed, is taken over by a retry once its lease ran out
+func (s *AppTestSuite) TestBeginTakesOverAbandonedKey() {
+	// Arrange
+	ctx := context.Background()
+	hash := []byte("request")
+	_, err := s.app.Begin(ctx, "user-1", "key-1", hash)
+	s.Require().NoError(err)
+	_, errLocked := s.app.Begin(ctx, "user-1", "key-1", hash)
+	s.repo.keys["user-1/key-1"].LockedUntil = time.Now().Add(-time.Second)
+
+	// Act
+	_, errOther := s.app.Begin(ctx, "user-1", "key-1", []byte("other request"))
+	retried, errRetried := s.app.Begin(ctx, "user-1", "key-1", hash)
+	_, errRunning := s.app.Begin(ctx, "user-1", "key-1", hash)
+
+	// Assert
+	s.ErrorIs(errLocked, ErrInProgress)
+	s.ErrorIs(errOther, ErrKeyReused)
+	s.NoError(errRetried)
+	s.Nil(retried)
+	s.ErrorIs(errRunning, ErrInProgress)
+	s.True(s.repo.keys["user-1/key-1"].LockedUntil.After(time.Now()))
+}
@@ -0,0 +1,21 @@
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Purger returns a worker deleting the expired idempotency keys, to run with
+// worker.Every. Failures are logged, the next run retries.
+func Purger(app *App) func(context.Context) {
+	return func(ctx context.Context) {
+		purged, err := app.Purge(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "idempotency key purge failed", slog.Any("error", err))
+			return
+		}
+		if purged > 0 {
+			slog.InfoContext(ctx, "purged expired idempotency keys", slog.Int64("purged", purged))
+		}
+	}
+}
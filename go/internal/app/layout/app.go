@@ -0,0 +1,295 @@
+package layout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modelaccess "github.com/mcdev12/lumo/go/internal/models/access"
+	modellayout "github.com/mcdev12/lumo/go/internal/models/layout"
+	modellink "github.com/mcdev12/lumo/go/internal/models/link"
+	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Domain errors
+var (
+	ErrInvalidLumoID    = errors.New("invalid lumo ID")
+	ErrInvalidLumeID    = errors.New("invalid lume ID")
+	ErrLumeNotInLumo    = errors.New("lume does not belong to lumo")
+	ErrInvalidSize      = errors.New("node size must be positive")
+	ErrInvalidZoom      = errors.New("viewport zoom must be positive")
+	ErrTooManyNodes     = errors.New("too many nodes in one batch")
+	ErrInvalidAlgorithm = errors.New("invalid layout algorithm")
+
+	ErrNotFound         = modelaccess.ErrNotFound
+	ErrPermissionDenied = auth.ErrPermissionDenied
+)
+
+// maxNodesPerSave caps the size of a single SaveLayout batch
+const maxNodesPerSave = 500
+
+// LayoutRepository defines what the app layer needs from the repository
+type LayoutRepository interface {
+	GetLayoutByLumoID(ctx context.Context, lumoID string) (*modellayout.Layout, error)
+	ListLumeIDsByLumoID(ctx context.Context, lumoID string) ([]string, error)
+	SaveLayout(ctx context.Context, lumoID string, nodes []*modellayout.NodeLayout, viewport *modellayout.Viewport) (*modellayout.Layout, error)
+}
+
+// LumeRepository defines what the app layer needs to read a Lumo's Lumes
+type LumeRepository interface {
+	ListLumesByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*modellume.Lume, error)
+}
+
+// LinkRepository defines what the app layer needs to read a Lumo's Links
+type LinkRepository interface {
+	ListLinksByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*modellink.Link, error)
+}
+
+// Authorizer checks the caller's role in a Lumo
+type Authorizer interface {
+	AuthorizeLumo(ctx context.Context, lumoID string, required modelaccess.Role) (*modelaccess.Owner, error)
+}
+
+// App handles business logic for canvas layouts
+type App struct {
+	repo     LayoutRepository
+	lumeRepo LumeRepository
+	linkRepo LinkRepository
+	authz    Authorizer
+}
+
+// NewLayoutApp creates a new Layout App
+func NewLayoutApp(repo LayoutRepository, lumeRepo LumeRepository, linkRepo LinkRepository, authz Authorizer) *App {
+	return &App{
+		repo:     repo,
+		lumeRepo: lumeRepo,
+		linkRepo: linkRepo,
+		authz:    authz,
+	}
+}
+
+// GetLayout retrieves the saved layout of a Lumo
+func (a *App) GetLayout(ctx context.Context, lumoID string) (_ *modellayout.Layout, err error) {
+	ctx, span := tracing.Start(ctx, "layout.App.GetLayout")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(lumoID); err != nil {
+		return nil, ErrInvalidLumoID
+	}
+
+	if _, err := a.authz.AuthorizeLumo(ctx, lumoID, modelaccess.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return a.repo.GetLayoutByLumoID(ctx, lumoID)
+}
+
+// SaveLayout merges a batch of partial node updates into the stored layout
+// and persists the result. Lumes missing from the batch are left untouched
+// and, when a Lume appears more than once, the last update wins, so clients
+// can debounce drags and flush whatever accumulated in between.
+func (a *App) SaveLayout(ctx context.Context, req SaveLayoutRequest) (_ *modellayout.Layout, err error) {
+	ctx, span := tracing.Start(ctx, "layout.App.SaveLayout")
+	defer tracing.End(span, &err)
+
+	if err := a.validateSaveRequest(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, modelaccess.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	existing, err := a.repo.GetLayoutByLumoID(ctx, req.LumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []*modellayout.NodeLayout
+	if len(req.Nodes) > 0 {
+		lumeIDs, err := a.repo.ListLumeIDsByLumoID(ctx, req.LumoID)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes, err = a.mergeNodeUpdates(req.LumoID, existing, lumeIDs, req.Nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var viewport *modellayout.Viewport
+	if req.Viewport != nil {
+		viewport = a.mergeViewportUpdate(req.LumoID, existing.Viewport, *req.Viewport)
+	}
+
+	return a.repo.SaveLayout(ctx, req.LumoID, nodes, viewport)
+}
+
+// AutoLayout computes a placement for every Lume of a Lumo with the requested
+// algorithm. Sizes and collapsed state are carried over from the stored
+// layout. When Persist is set the placements replace the stored positions.
+func (a *App) AutoLayout(ctx context.Context, req AutoLayoutRequest) (_ []*modellayout.NodeLayout, err error) {
+	ctx, span := tracing.Start(ctx, "layout.App.AutoLayout")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(req.LumoID); err != nil {
+		return nil, ErrInvalidLumoID
+	}
+
+	// Computing a layout only reads the Lumo, persisting it edits the Lumo
+	required := modelaccess.RoleViewer
+	if req.Persist {
+		required = modelaccess.RoleEditor
+	}
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, required); err != nil {
+		return nil, err
+	}
+
+	lumes, err := modellume.ListAll(ctx, a.lumeRepo, req.LumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions map[string]point
+	switch req.Algorithm {
+	case modellayout.AlgorithmLayered:
+		links, err := modellink.ListAll(ctx, a.linkRepo, req.LumoID)
+		if err != nil {
+			return nil, err
+		}
+		positions = layeredLayout(lumes, links)
+	case modellayout.AlgorithmGeographic:
+		positions = geographicLayout(lumes)
+	case modellayout.AlgorithmTimeline:
+		positions = timelineLayout(lumes)
+	default:
+		return nil, ErrInvalidAlgorithm
+	}
+
+	existing, err := a.repo.GetLayoutByLumoID(ctx, req.LumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	nodes := make([]*modellayout.NodeLayout, len(lumes))
+	for i, l := range lumes {
+		node := existing.NodeByLumeID(l.LumeID)
+		if node == nil {
+			node = modellayout.NewNodeLayout(req.LumoID, l.LumeID)
+		}
+		node.X = positions[l.LumeID].X
+		node.Y = positions[l.LumeID].Y
+		node.UpdatedAt = now
+		nodes[i] = node
+	}
+
+	if !req.Persist || len(nodes) == 0 {
+		return nodes, nil
+	}
+
+	saved, err := a.repo.SaveLayout(ctx, req.LumoID, nodes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return saved.Nodes, nil
+}
+
+// Validation methods
+func (a *App) validateSaveRequest(req SaveLayoutRequest) error {
+	if _, err := uuid.Parse(req.LumoID); err != nil {
+		return ErrInvalidLumoID
+	}
+
+	if len(req.Nodes) > maxNodesPerSave {
+		return ErrTooManyNodes
+	}
+
+	for _, node := range req.Nodes {
+		if _, err := uuid.Parse(node.LumeID); err != nil {
+			return ErrInvalidLumeID
+		}
+		if (node.Width != nil && *node.Width <= 0) || (node.Height != nil && *node.Height <= 0) {
+			return ErrInvalidSize
+		}
+	}
+
+	if req.Viewport != nil && req.Viewport.Zoom <= 0 {
+		return ErrInvalidZoom
+	}
+
+	return nil
+}
+
+// mergeNodeUpdates applies the updates on top of the stored placements and
+// returns one NodeLayout per distinct Lume, in first-seen order
+func (a *App) mergeNodeUpdates(lumoID string, existing *modellayout.Layout, lumeIDs []string, updates []NodeLayoutUpdate) ([]*modellayout.NodeLayout, error) {
+	inLumo := make(map[string]bool, len(lumeIDs))
+	for _, lumeID := range lumeIDs {
+		inLumo[lumeID] = true
+	}
+
+	now := time.Now()
+	merged := make(map[string]*modellayout.NodeLayout, len(updates))
+	nodes := make([]*modellayout.NodeLayout, 0, len(updates))
+
+	for _, update := range updates {
+		if !inLumo[update.LumeID] {
+			return nil, ErrLumeNotInLumo
+		}
+
+		node, seen := merged[update.LumeID]
+		if !seen {
+			node = existing.NodeByLumeID(update.LumeID)
+			if node == nil {
+				node = modellayout.NewNodeLayout(lumoID, update.LumeID)
+			}
+			merged[update.LumeID] = node
+			nodes = append(nodes, node)
+		}
+
+		a.updateNodeModel(node, update)
+		node.UpdatedAt = now
+	}
+
+	return nodes, nil
+}
+
+// updateNodeModel copies the fields that are set on the update onto the node
+func (a *App) updateNodeModel(node *modellayout.NodeLayout, update NodeLayoutUpdate) {
+	if update.X != nil {
+		node.X = *update.X
+	}
+	if update.Y != nil {
+		node.Y = *update.Y
+	}
+	if update.Width != nil {
+		node.Width = update.Width
+	}
+	if update.Height != nil {
+		node.Height = update.Height
+	}
+	if update.Collapsed != nil {
+		node.Collapsed = *update.Collapsed
+	}
+}
+
+// mergeViewportUpdate applies the update on top of the stored viewport
+func (a *App) mergeViewportUpdate(lumoID string, existing *modellayout.Viewport, update ViewportUpdate) *modellayout.Viewport {
+	viewport := existing
+	if viewport == nil {
+		viewport = modellayout.NewViewport(lumoID)
+	}
+
+	viewport.X = update.X
+	viewport.Y = update.Y
+	viewport.Zoom = update.Zoom
+	viewport.UpdatedAt = time.Now()
+
+	return viewport
+}
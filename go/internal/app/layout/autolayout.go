@@ -0,0 +1,457 @@
+package layout
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	modellink "github.com/mcdev12/lumo/go/internal/models/link"
+	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+)
+
+// Canvas dimensions used by the automatic layouts
+const (
+	// Distance between neighbouring nodes
+	nodeSpacingX = 280.0
+	nodeSpacingY = 160.0
+
+	// Area the geographic and timeline layouts are scaled into
+	canvasWidth  = 2400.0
+	canvasHeight = 1600.0
+
+	// Nodes per row when leftovers are laid out in a grid
+	gridColumns = 6
+
+	// Number of barycenter sweeps used to reduce edge crossings
+	crossingSweeps = 8
+
+	// Web Mercator is undefined at the poles
+	maxMercatorLatitude = 85.05112878
+)
+
+// point is a position on the canvas
+type point struct {
+	X float64
+	Y float64
+}
+
+// layeredLayout arranges the Lumes left to right along TRAVEL links, one
+// column per step of the trip. Cycles are broken by reversing back edges,
+// columns are assigned by longest path and the order within each column is
+// refined with barycenter sweeps, falling back to sequence_index on ties.
+// Lumes without TRAVEL links are placed in a grid below the graph.
+func layeredLayout(lumes []*modellume.Lume, links []*modellink.Link) map[string]point {
+	index := make(map[string]int, len(lumes))
+	for i, l := range lumes {
+		index[l.LumeID] = i
+	}
+
+	// Collect TRAVEL edges between Lumes of this Lumo, ordered by sequence_index
+	travel := make([]*modellink.Link, 0, len(links))
+	for _, l := range links {
+		if l.Type != modellink.LinkTypeTravel || l.FromLumeID == l.ToLumeID {
+			continue
+		}
+		if _, ok := index[l.FromLumeID]; !ok {
+			continue
+		}
+		if _, ok := index[l.ToLumeID]; !ok {
+			continue
+		}
+		travel = append(travel, l)
+	}
+	sort.SliceStable(travel, func(i, j int) bool {
+		return sequenceLess(travel[i].SequenceIndex, travel[j].SequenceIndex)
+	})
+
+	n := len(lumes)
+	succ := make([][]int, n)
+	pred := make([][]int, n)
+	rank := make([]int, n) // lowest sequence_index touching the node
+	for i := range rank {
+		rank[i] = math.MaxInt
+	}
+	connected := make([]bool, n)
+	seenEdge := make(map[[2]int]bool, len(travel))
+	for _, l := range travel {
+		from, to := index[l.FromLumeID], index[l.ToLumeID]
+		if l.SequenceIndex != nil {
+			rank[from] = min(rank[from], int(*l.SequenceIndex))
+			rank[to] = min(rank[to], int(*l.SequenceIndex))
+		}
+		connected[from], connected[to] = true, true
+		if seenEdge[[2]int{from, to}] {
+			continue
+		}
+		seenEdge[[2]int{from, to}] = true
+		succ[from] = append(succ[from], to)
+	}
+
+	removeCycles(succ, rank)
+	for from, tos := range succ {
+		for _, to := range tos {
+			pred[to] = append(pred[to], from)
+		}
+	}
+
+	layers := assignLayers(succ, pred, connected)
+	orderLayers(layers, succ, pred, rank)
+
+	positions := make(map[string]point, n)
+	tallest := 0
+	for _, layer := range layers {
+		tallest = max(tallest, len(layer))
+	}
+	for col, layer := range layers {
+		offset := float64(tallest-len(layer)) * nodeSpacingY / 2
+		for row, node := range layer {
+			positions[lumes[node].LumeID] = point{
+				X: float64(col) * nodeSpacingX,
+				Y: offset + float64(row)*nodeSpacingY,
+			}
+		}
+	}
+
+	var isolated []*modellume.Lume
+	for i, l := range lumes {
+		if !connected[i] {
+			isolated = append(isolated, l)
+		}
+	}
+	placeInGrid(positions, isolated, float64(tallest)*nodeSpacingY)
+
+	return positions
+}
+
+// removeCycles reverses every edge that closes a cycle so the graph becomes
+// acyclic. Nodes are visited in sequence order so that the earliest leg of
+// the trip keeps its direction.
+func removeCycles(succ [][]int, rank []int) {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+
+	state := make([]int, len(succ))
+	var backEdges [][2]int
+	var visit func(v int)
+	visit = func(v int) {
+		state[v] = active
+		kept := succ[v][:0]
+		for _, w := range succ[v] {
+			switch state[w] {
+			case active:
+				backEdges = append(backEdges, [2]int{v, w})
+				continue
+			case unvisited:
+				visit(w)
+			}
+			kept = append(kept, w)
+		}
+		succ[v] = kept
+		state[v] = done
+	}
+
+	for _, v := range byRank(len(succ), rank) {
+		if state[v] == unvisited {
+			visit(v)
+		}
+	}
+
+	for _, edge := range backEdges {
+		from, to := edge[1], edge[0]
+		if !containsInt(succ[from], to) {
+			succ[from] = append(succ[from], to)
+		}
+	}
+}
+
+// assignLayers puts every connected node one column after its furthest
+// predecessor (longest-path layering)
+func assignLayers(succ, pred [][]int, connected []bool) [][]int {
+	n := len(succ)
+	layer := make([]int, n)
+	inDegree := make([]int, n)
+	queue := make([]int, 0, n)
+	for v := 0; v < n; v++ {
+		inDegree[v] = len(pred[v])
+		if connected[v] && inDegree[v] == 0 {
+			queue = append(queue, v)
+		}
+	}
+
+	depth := 0
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		depth = max(depth, layer[v]+1)
+		for _, w := range succ[v] {
+			layer[w] = max(layer[w], layer[v]+1)
+			inDegree[w]--
+			if inDegree[w] == 0 {
+				queue = append(queue, w)
+			}
+		}
+	}
+
+	layers := make([][]int, depth)
+	for v := 0; v < n; v++ {
+		if connected[v] {
+			layers[layer[v]] = append(layers[layer[v]], v)
+		}
+	}
+	return layers
+}
+
+// orderLayers reduces edge crossings by repeatedly sorting each column on the
+// average position of its neighbours in the previous (or next) column
+func orderLayers(layers [][]int, succ, pred [][]int, rank []int) {
+	pos := make([]float64, len(succ))
+	for _, layer := range layers {
+		sortByRank(layer, rank)
+		for i, v := range layer {
+			pos[v] = float64(i)
+		}
+	}
+
+	for sweep := 0; sweep < crossingSweeps; sweep++ {
+		if sweep%2 == 0 {
+			for i := 1; i < len(layers); i++ {
+				reorderLayer(layers[i], pred, pos, rank)
+			}
+		} else {
+			for i := len(layers) - 2; i >= 0; i-- {
+				reorderLayer(layers[i], succ, pos, rank)
+			}
+		}
+	}
+}
+
+// reorderLayer sorts a column on the barycenter of each node's neighbours
+func reorderLayer(layer []int, neighbours [][]int, pos []float64, rank []int) {
+	barycenter := make(map[int]float64, len(layer))
+	for _, v := range layer {
+		if len(neighbours[v]) == 0 {
+			// Nodes without neighbours on this side keep their place
+			barycenter[v] = pos[v]
+			continue
+		}
+		sum := 0.0
+		for _, w := range neighbours[v] {
+			sum += pos[w]
+		}
+		barycenter[v] = sum / float64(len(neighbours[v]))
+	}
+
+	sort.SliceStable(layer, func(i, j int) bool {
+		a, b := layer[i], layer[j]
+		if barycenter[a] != barycenter[b] {
+			return barycenter[a] < barycenter[b]
+		}
+		if rank[a] != rank[b] {
+			return rank[a] < rank[b]
+		}
+		return a < b
+	})
+	for i, v := range layer {
+		pos[v] = float64(i)
+	}
+}
+
+// geographicLayout projects each Lume's coordinates onto the canvas with Web
+// Mercator, north up, keeping the aspect ratio of the covered area. Lumes
+// sharing a location are fanned out so they don't hide each other, and Lumes
+// without coordinates are placed in a grid below the map.
+func geographicLayout(lumes []*modellume.Lume) map[string]point {
+	positions := make(map[string]point, len(lumes))
+
+	var located []*modellume.Lume
+	var unlocated []*modellume.Lume
+	projected := make(map[string]point, len(lumes))
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, l := range lumes {
+		if l.Latitude == nil || l.Longitude == nil {
+			unlocated = append(unlocated, l)
+			continue
+		}
+		p := mercator(*l.Latitude, *l.Longitude)
+		projected[l.LumeID] = p
+		located = append(located, l)
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+
+	height := 0.0
+	if len(located) > 0 {
+		scale := math.Inf(1)
+		if maxX > minX {
+			scale = canvasWidth / (maxX - minX)
+		}
+		if maxY > minY {
+			scale = math.Min(scale, canvasHeight/(maxY-minY))
+		}
+		if math.IsInf(scale, 1) {
+			// Every Lume is at the same spot
+			scale = 0
+		}
+
+		stacked := make(map[point]int, len(located))
+		for _, l := range located {
+			p := projected[l.LumeID]
+			at := point{
+				X: math.Round((p.X - minX) * scale),
+				Y: math.Round((maxY - p.Y) * scale),
+			}
+			// Fan out Lumes at the same spot in a diagonal staircase
+			k := stacked[at]
+			stacked[at]++
+			positions[l.LumeID] = point{
+				X: at.X + float64(k)*nodeSpacingX/4,
+				Y: at.Y + float64(k)*nodeSpacingY/4,
+			}
+			height = math.Max(height, positions[l.LumeID].Y)
+		}
+		height += nodeSpacingY
+	}
+
+	placeInGrid(positions, unlocated, height)
+
+	return positions
+}
+
+// mercator projects a latitude/longitude pair in degrees onto the unit Web
+// Mercator plane, with y growing northwards
+func mercator(lat, lng float64) point {
+	lat = math.Max(-maxMercatorLatitude, math.Min(maxMercatorLatitude, lat))
+	phi := lat * math.Pi / 180
+	return point{
+		X: lng * math.Pi / 180,
+		Y: math.Log(math.Tan(math.Pi/4 + phi/2)),
+	}
+}
+
+// timelineLayout places each Lume along the x axis at its DateStart and
+// packs overlapping Lumes into lanes, so a Lume spanning several days pushes
+// the ones starting during its stay onto the next lane. Lumes without a start
+// date are placed in a grid below the timeline.
+func timelineLayout(lumes []*modellume.Lume) map[string]point {
+	positions := make(map[string]point, len(lumes))
+
+	var dated []*modellume.Lume
+	var undated []*modellume.Lume
+	for _, l := range lumes {
+		if l.DateStart == nil {
+			undated = append(undated, l)
+			continue
+		}
+		dated = append(dated, l)
+	}
+	sort.SliceStable(dated, func(i, j int) bool {
+		return dated[i].DateStart.Before(*dated[j].DateStart)
+	})
+
+	height := 0.0
+	if len(dated) > 0 {
+		start := *dated[0].DateStart
+		end := start
+		for _, l := range dated {
+			end = latest(end, *l.DateStart)
+			if l.DateEnd != nil {
+				end = latest(end, *l.DateEnd)
+			}
+		}
+
+		scale := 0.0
+		if span := end.Sub(start); span > 0 {
+			scale = canvasWidth / float64(span)
+		}
+		at := func(t time.Time) float64 {
+			return math.Round(float64(t.Sub(start)) * scale)
+		}
+
+		// laneEnds holds the x where each lane becomes free again
+		var laneEnds []float64
+		for _, l := range dated {
+			x := at(*l.DateStart)
+			right := x + nodeSpacingX
+			if l.DateEnd != nil {
+				right = math.Max(right, at(*l.DateEnd))
+			}
+
+			lane := len(laneEnds)
+			for i, free := range laneEnds {
+				if free <= x {
+					lane = i
+					break
+				}
+			}
+			if lane == len(laneEnds) {
+				laneEnds = append(laneEnds, 0)
+			}
+			laneEnds[lane] = right
+
+			positions[l.LumeID] = point{X: x, Y: float64(lane) * nodeSpacingY}
+		}
+		height = float64(len(laneEnds)) * nodeSpacingY
+	}
+
+	placeInGrid(positions, undated, height)
+
+	return positions
+}
+
+// placeInGrid lays the given Lumes out in rows starting at top
+func placeInGrid(positions map[string]point, lumes []*modellume.Lume, top float64) {
+	for i, l := range lumes {
+		positions[l.LumeID] = point{
+			X: float64(i%gridColumns) * nodeSpacingX,
+			Y: top + float64(i/gridColumns)*nodeSpacingY,
+		}
+	}
+}
+
+// sequenceLess orders sequence indexes ascending with unset ones last
+func sequenceLess(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a != nil && b == nil
+	}
+	return *a < *b
+}
+
+// byRank returns the node indexes ordered by rank, then by index
+func byRank(n int, rank []int) []int {
+	nodes := make([]int, n)
+	for i := range nodes {
+		nodes[i] = i
+	}
+	sortByRank(nodes, rank)
+	return nodes
+}
+
+// sortByRank sorts nodes by rank, then by index
+func sortByRank(nodes []int, rank []int) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if rank[nodes[i]] != rank[nodes[j]] {
+			return rank[nodes[i]] < rank[nodes[j]]
+		}
+		return nodes[i] < nodes[j]
+	})
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
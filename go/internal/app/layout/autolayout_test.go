@@ -0,0 +1,156 @@
+package layout
+
+import (
+	"testing"
+	"time"
+
+	modellink "github.com/mcdev12/lumo/go/internal/models/link"
+	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/stretchr/testify/suite"
+)
+
+// AutoLayoutTestSuite is a test suite for the auto layout algorithms
+type AutoLayoutTestSuite struct {
+	suite.Suite
+	lumoID string
+}
+
+// SetupTest is called before each test
+func (s *AutoLayoutTestSuite) SetupTest() {
+	s.lumoID = "11111111-1111-1111-1111-111111111111"
+}
+
+// TestAutoLayoutSuite runs the test suite
+func TestAutoLayoutSuite(t *testing.T) {
+	suite.Run(t, new(AutoLayoutTestSuite))
+}
+
+// Helper function to create a test Lume
+func (s *AutoLayoutTestSuite) newLume(name string) *modellume.Lume {
+	return modellume.NewLume(s.lumoID, modellume.LumeTypeCity, name)
+}
+
+// Helper function to create a test TRAVEL Link with a sequence index
+func newTravelLink(from, to *modellume.Lume, sequence int32) *modellink.Link {
+	l := modellink.NewLink(from.LumeID, to.LumeID, modellink.LinkTypeTravel)
+	l.SequenceIndex = &sequence
+	return l
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+// Test layered layout follows TRAVEL direction
+func (s *AutoLayoutTestSuite) TestLayeredLayoutFollowsTravelLinks() {
+	// Arrange
+	paris, lyon, nice := s.newLume("Paris"), s.newLume("Lyon"), s.newLume("Nice")
+	museum := s.newLume("Museum")
+	links := []*modellink.Link{
+		newTravelLink(lyon, nice, 2),
+		newTravelLink(paris, lyon, 1),
+		modellink.NewLink(paris.LumeID, museum.LumeID, modellink.LinkTypeRecommended),
+	}
+
+	// Act
+	positions := layeredLayout([]*modellume.Lume{nice, museum, lyon, paris}, links)
+
+	// Assert
+	s.Len(positions, 4)
+	s.Less(positions[paris.LumeID].X, positions[lyon.LumeID].X)
+	s.Less(positions[lyon.LumeID].X, positions[nice.LumeID].X)
+	// Lumes without TRAVEL links go below the graph
+	s.Greater(positions[museum.LumeID].Y, positions[paris.LumeID].Y)
+}
+
+// Test layered layout breaks cycles
+func (s *AutoLayoutTestSuite) TestLayeredLayoutWithCycle() {
+	// Arrange
+	a, b, c := s.newLume("A"), s.newLume("B"), s.newLume("C")
+	links := []*modellink.Link{
+		newTravelLink(a, b, 1),
+		newTravelLink(b, c, 2),
+		newTravelLink(c, a, 3),
+	}
+
+	// Act
+	positions := layeredLayout([]*modellume.Lume{a, b, c}, links)
+
+	// Assert
+	s.Len(positions, 3)
+	s.Less(positions[a.LumeID].X, positions[b.LumeID].X)
+	s.Less(positions[b.LumeID].X, positions[c.LumeID].X)
+}
+
+// Test layered layout orders siblings by sequence index
+func (s *AutoLayoutTestSuite) TestLayeredLayoutOrdersSiblingsBySequence() {
+	// Arrange
+	hub, first, second := s.newLume("Hub"), s.newLume("First"), s.newLume("Second")
+	links := []*modellink.Link{
+		newTravelLink(hub, second, 2),
+		newTravelLink(hub, first, 1),
+	}
+
+	// Act
+	positions := layeredLayout([]*modellume.Lume{second, hub, first}, links)
+
+	// Assert
+	s.Equal(positions[first.LumeID].X, positions[second.LumeID].X)
+	s.Less(positions[first.LumeID].Y, positions[second.LumeID].Y)
+}
+
+// Test geographic layout keeps north up and west left
+func (s *AutoLayoutTestSuite) TestGeographicLayout() {
+	// Arrange
+	paris := s.newLume("Paris")
+	paris.Latitude, paris.Longitude = ptr(48.8566), ptr(2.3522)
+	nice := s.newLume("Nice")
+	nice.Latitude, nice.Longitude = ptr(43.7102), ptr(7.2620)
+	hotel := s.newLume("Hotel")
+	hotel.Latitude, hotel.Longitude = ptr(43.7102), ptr(7.2620)
+	unknown := s.newLume("Somewhere")
+
+	// Act
+	positions := geographicLayout([]*modellume.Lume{paris, nice, hotel, unknown})
+
+	// Assert
+	s.Len(positions, 4)
+	s.Less(positions[paris.LumeID].X, positions[nice.LumeID].X)
+	s.Less(positions[paris.LumeID].Y, positions[nice.LumeID].Y)
+	s.LessOrEqual(positions[nice.LumeID].X, canvasWidth)
+	s.LessOrEqual(positions[nice.LumeID].Y, canvasHeight)
+	// Lumes at the same spot don't overlap
+	s.NotEqual(positions[nice.LumeID], positions[hotel.LumeID])
+	// Lumes without coordinates go below the map
+	s.Greater(positions[unknown.LumeID].Y, positions[hotel.LumeID].Y)
+}
+
+// Test timeline layout orders by start date and packs overlaps into lanes
+func (s *AutoLayoutTestSuite) TestTimelineLayout() {
+	// Arrange
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		t := start.AddDate(0, 0, days)
+		return &t
+	}
+
+	hotel := s.newLume("Hotel")
+	hotel.DateStart, hotel.DateEnd = at(0), at(5)
+	museum := s.newLume("Museum")
+	museum.DateStart = at(1)
+	flight := s.newLume("Flight")
+	flight.DateStart = at(10)
+	undated := s.newLume("Someday")
+
+	// Act
+	positions := timelineLayout([]*modellume.Lume{flight, undated, museum, hotel})
+
+	// Assert
+	s.Len(positions, 4)
+	s.Less(positions[hotel.LumeID].X, positions[museum.LumeID].X)
+	s.Less(positions[museum.LumeID].X, positions[flight.LumeID].X)
+	// The museum visit happens during the hotel stay
+	s.NotEqual(positions[hotel.LumeID].Y, positions[museum.LumeID].Y)
+	// Lumes without a start date go below the timeline
+	s.Greater(positions[undated.LumeID].Y, positions[museum.LumeID].Y)
+}
@@ -0,0 +1,36 @@
+package layout
+
+import modellayout "github.com/mcdev12/lumo/go/internal/models/layout"
+
+// NodeLayoutUpdate represents a partial placement update for one Lume.
+// Nil fields keep their stored value.
+type NodeLayoutUpdate struct {
+	LumeID    string
+	X         *float64
+	Y         *float64
+	Width     *float64
+	Height    *float64
+	Collapsed *bool
+}
+
+// ViewportUpdate represents the new pan/zoom state of a canvas
+type ViewportUpdate struct {
+	X    float64
+	Y    float64
+	Zoom float64
+}
+
+// SaveLayoutRequest represents the business layer's save request
+type SaveLayoutRequest struct {
+	LumoID   string
+	Nodes    []NodeLayoutUpdate
+	Viewport *ViewportUpdate
+}
+
+// AutoLayoutRequest represents the business layer's auto layout request
+type AutoLayoutRequest struct {
+	LumoID    string
+	Algorithm modellayout.Algorithm
+	// Persist saves the computed positions as the Lumo's layout
+	Persist bool
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"errors"
 	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	"github.com/mcdev12/lumo/go/internal/models/access"
 	modellink "github.com/mcdev12/lumo/go/internal/models/link"
+	modeluser "github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/mcdev12/lumo/go/internal/models/version"
+	"github.com/mcdev12/lumo/go/internal/tracing"
 	"time"
 )
 
@@ -13,9 +18,16 @@ var (
 	ErrLinkNotFound      = errors.New("link not found")
 	ErrInvalidLinkID     = errors.New("invalid link ID")
 	ErrInvalidLumeID     = errors.New("invalid lume ID")
+	ErrInvalidLumoID     = errors.New("invalid lumo ID")
 	ErrInvalidLinkType   = errors.New("invalid link type")
 	ErrInvalidTravelMode = errors.New("invalid travel mode")
+	ErrInvalidCurrency   = errors.New("currency must be an ISO 4217 code")
 	ErrEmptyNotes        = errors.New("notes cannot be empty")
+
+	ErrVersionConflict  = version.ErrConflict
+	ErrNotFound         = access.ErrNotFound
+	ErrCrossLumoLink    = access.ErrCrossLumoLink
+	ErrPermissionDenied = auth.ErrPermissionDenied
 )
 
 // LinkRepository defines what the app layer needs from the repository
@@ -28,51 +40,110 @@ type LinkRepository interface {
 	ListLinksByEitherLumeID(ctx context.Context, lumeID string, limit, offset int32) ([]*modellink.Link, error)
 	ListLinksByType(ctx context.Context, linkType modellink.LinkType, limit, offset int32) ([]*modellink.Link, error)
 	ListLinksByLumeIDAndType(ctx context.Context, lumeID string, linkType modellink.LinkType, limit, offset int32) ([]*modellink.Link, error)
+	ListLinksByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*modellink.Link, error)
 	UpdateLink(ctx context.Context, domainLink *modellink.Link) (*modellink.Link, error)
-	DeleteLink(ctx context.Context, id int64) error
-	DeleteLinkByLinkID(ctx context.Context, linkID string) error
+	DeleteLink(ctx context.Context, id int64, expectedVersion *int64) error
+	DeleteLinkByLinkID(ctx context.Context, linkID string, expectedVersion *int64) error
 	CountLinksByLumeID(ctx context.Context, lumeID string) (int64, error)
 	CountLinksByFromLumeID(ctx context.Context, fromLumeID string) (int64, error)
 	CountLinksByToLumeID(ctx context.Context, toLumeID string) (int64, error)
 }
 
+// Authorizer checks the caller's role in the Lumo a Link belongs to
+type Authorizer interface {
+	AuthorizeLumo(ctx context.Context, lumoID string, required access.Role) (*access.Owner, error)
+	AuthorizeLume(ctx context.Context, lumeID string, required access.Role) (*access.Owner, error)
+	AuthorizeLink(ctx context.Context, linkID string, required access.Role) (*access.Owner, error)
+	AuthorizeLinkByID(ctx context.Context, id int64, required access.Role) (*access.Owner, error)
+	AuthorizeConnection(ctx context.Context, fromLumeID, toLumeID string) (*access.Owner, error)
+}
+
+// PreferenceReader looks up the preferences of the caller
+type PreferenceReader interface {
+	CallerPreferences(ctx context.Context) (modeluser.Preferences, error)
+}
+
 // App handles business logic for Links
 type App struct {
-	repo LinkRepository
+	repo  LinkRepository
+	authz Authorizer
+	prefs PreferenceReader
 }
 
 // NewLinkApp creates a new Link App
-func NewLinkApp(repo LinkRepository) *App {
+func NewLinkApp(repo LinkRepository, authz Authorizer, prefs PreferenceReader) *App {
 	return &App{
-		repo: repo,
+		repo:  repo,
+		authz: authz,
+		prefs: prefs,
 	}
 }
 
 // CreateLink creates a new Link with business logic validation
-func (a *App) CreateLink(ctx context.Context, req CreateLinkRequest) (*modellink.Link, error) {
+func (a *App) CreateLink(ctx context.Context, req CreateLinkRequest) (_ *modellink.Link, err error) {
+	ctx, span := tracing.Start(ctx, "link.App.CreateLink")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(req.FromLumeID); err != nil {
+		return nil, ErrInvalidLumeID
+	}
+	if _, err := uuid.Parse(req.ToLumeID); err != nil {
+		return nil, ErrInvalidLumeID
+	}
+	if err := a.validateTravelDetails(req.TravelDetails); err != nil {
+		return nil, err
+	}
+
+	// Both Lumes must be the caller's and in the same Lumo
+	if _, err := a.authz.AuthorizeConnection(ctx, req.FromLumeID, req.ToLumeID); err != nil {
+		return nil, err
+	}
+
 	domainLink := a.toDomainModelForCreate(req)
+	if err := a.applyHomeCurrency(ctx, domainLink); err != nil {
+		return nil, err
+	}
 	return a.repo.CreateLink(ctx, domainLink)
 }
 
 // GetLinkByID retrieves a Link by its internal ID
-func (a *App) GetLinkByID(ctx context.Context, id int64) (*modellink.Link, error) {
+func (a *App) GetLinkByID(ctx context.Context, id int64) (_ *modellink.Link, err error) {
+	ctx, span := tracing.Start(ctx, "link.App.GetLinkByID")
+	defer tracing.End(span, &err)
+
+	if _, err := a.authz.AuthorizeLinkByID(ctx, id, access.RoleViewer); err != nil {
+		return nil, err
+	}
 	return a.repo.GetLinkByID(ctx, id)
 }
 
 // GetLinkByLinkID retrieves a Link by its UUID
-func (a *App) GetLinkByLinkID(ctx context.Context, linkID string) (*modellink.Link, error) {
+func (a *App) GetLinkByLinkID(ctx context.Context, linkID string) (_ *modellink.Link, err error) {
+	ctx, span := tracing.Start(ctx, "link.App.GetLinkByLinkID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(linkID); err != nil {
 		return nil, ErrInvalidLinkID
 	}
+	if _, err := a.authz.AuthorizeLink(ctx, linkID, access.RoleViewer); err != nil {
+		return nil, err
+	}
 	return a.repo.GetLinkByLinkID(ctx, linkID)
 }
 
 // ListLinksByFromLumeID retrieves all Links from a specific Lume
-func (a *App) ListLinksByFromLumeID(ctx context.Context, fromLumeID string, req ListLinksRequest) ([]*modellink.Link, error) {
+func (a *App) ListLinksByFromLumeID(ctx context.Context, fromLumeID string, req ListLinksRequest) (_ []*modellink.Link, err error) {
+	ctx, span := tracing.Start(ctx, "link.App.ListLinksByFromLumeID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(fromLumeID); err != nil {
 		return nil, ErrInvalidLumeID
 	}
 
+	if _, err := a.authz.AuthorizeLume(ctx, fromLumeID, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	limit := req.Limit
 	if limit <= 0 {
 		limit = 10 // Default limit
@@ -87,11 +158,18 @@ func (a *App) ListLinksByFromLumeID(ctx context.Context, fromLumeID string, req
 }
 
 // ListLinksByToLumeID retrieves all Links to a specific Lume
-func (a *App) ListLinksByToLumeID(ctx context.Context, toLumeID string, req ListLinksRequest) ([]*modellink.Link, error) {
+func (a *App) ListLinksByToLumeID(ctx context.Context, toLumeID string, req ListLinksRequest) (_ []*modellink.Link, err error) {
+	ctx, span := tracing.Start(ctx, "link.App.ListLinksByToLumeID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(toLumeID); err != nil {
 		return nil, ErrInvalidLumeID
 	}
 
+	if _, err := a.authz.AuthorizeLume(ctx, toLumeID, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	limit := req.Limit
 	if limit <= 0 {
 		limit = 10 // Default limit
@@ -106,11 +184,18 @@ func (a *App) ListLinksByToLumeID(ctx context.Context, toLumeID string, req List
 }
 
 // ListLinksByEitherLumeID retrieves all Links connected to a specific Lume (either from or to)
-func (a *App) ListLinksByEitherLumeID(ctx context.Context, lumeID string, req ListLinksRequest) ([]*modellink.Link, error) {
+func (a *App) ListLinksByEitherLumeID(ctx context.Context, lumeID string, req ListLinksRequest) (_ []*modellink.Link, err error) {
+	ctx, span := tracing.Start(ctx, "link.App.ListLinksByEitherLumeID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(lumeID); err != nil {
 		return nil, ErrInvalidLumeID
 	}
 
+	if _, err := a.authz.AuthorizeLume(ctx, lumeID, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	limit := req.Limit
 	if limit <= 0 {
 		limit = 10 // Default limit
@@ -124,53 +209,176 @@ func (a *App) ListLinksByEitherLumeID(ctx context.Context, lumeID string, req Li
 	return a.repo.ListLinksByEitherLumeID(ctx, lumeID, limit, offset)
 }
 
+// ListLinksByLumoID retrieves all Links between the Lumes of a Lumo
+func (a *App) ListLinksByLumoID(ctx context.Context, lumoID string, req ListLinksRequest) (_ []*modellink.Link, err error) {
+	ctx, span := tracing.Start(ctx, "link.App.ListLinksByLumoID")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(lumoID); err != nil {
+		return nil, ErrInvalidLumoID
+	}
+
+	if _, err := a.authz.AuthorizeLumo(ctx, lumoID, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	return a.repo.ListLinksByLumoID(ctx, lumoID, limit, offset)
+}
+
 // UpdateLink updates an existing Link
-func (a *App) UpdateLink(ctx context.Context, id int64, req UpdateLinkRequest) (*modellink.Link, error) {
+func (a *App) UpdateLink(ctx context.Context, id int64, req UpdateLinkRequest) (_ *modellink.Link, err error) {
+	ctx, span := tracing.Start(ctx, "link.App.UpdateLink")
+	defer tracing.End(span, &err)
+
+	owner, err := a.authz.AuthorizeLinkByID(ctx, id, access.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
 	existingLink, err := a.repo.GetLinkByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	updatedLink := a.updateDomainModel(existingLink, req)
-	return a.repo.UpdateLink(ctx, updatedLink)
+	return a.updateLink(ctx, owner, existingLink, req)
 }
 
 // UpdateLinkByLinkID updates an existing Link by its UUID
-func (a *App) UpdateLinkByLinkID(ctx context.Context, linkID string, req UpdateLinkRequest) (*modellink.Link, error) {
+func (a *App) UpdateLinkByLinkID(ctx context.Context, linkID string, req UpdateLinkRequest) (_ *modellink.Link, err error) {
+	ctx, span := tracing.Start(ctx, "link.App.UpdateLinkByLinkID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(linkID); err != nil {
 		return nil, ErrInvalidLinkID
 	}
 
+	owner, err := a.authz.AuthorizeLink(ctx, linkID, access.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
 	existingLink, err := a.repo.GetLinkByLinkID(ctx, linkID)
 	if err != nil {
 		return nil, err
 	}
 
+	return a.updateLink(ctx, owner, existingLink, req)
+}
+
+// updateLink applies the update to an authorized Link. A Link moved to other
+// Lumes must still connect two Lumes of its own Lumo.
+func (a *App) updateLink(ctx context.Context, owner *access.Owner, existingLink *modellink.Link, req UpdateLinkRequest) (*modellink.Link, error) {
+	if err := version.Check(req.ExpectedVersion, existingLink.Version); err != nil {
+		return nil, err
+	}
+	if err := a.validateTravelDetails(req.TravelDetails); err != nil {
+		return nil, err
+	}
+
+	fromLumeID, toLumeID := existingLink.FromLumeID, existingLink.ToLumeID
 	updatedLink := a.updateDomainModel(existingLink, req)
+
+	// Only new travel details are in the caller's currency
+	if req.TravelDetails != nil {
+		if err := a.applyHomeCurrency(ctx, updatedLink); err != nil {
+			return nil, err
+		}
+	}
+
+	if updatedLink.FromLumeID != fromLumeID || updatedLink.ToLumeID != toLumeID {
+		if _, err := uuid.Parse(updatedLink.FromLumeID); err != nil {
+			return nil, ErrInvalidLumeID
+		}
+		if _, err := uuid.Parse(updatedLink.ToLumeID); err != nil {
+			return nil, ErrInvalidLumeID
+		}
+
+		moved, err := a.authz.AuthorizeConnection(ctx, updatedLink.FromLumeID, updatedLink.ToLumeID)
+		if err != nil {
+			return nil, err
+		}
+		if moved.LumoID != owner.LumoID {
+			return nil, ErrCrossLumoLink
+		}
+	}
+
 	return a.repo.UpdateLink(ctx, updatedLink)
 }
 
-// DeleteLink deletes a Link by its internal ID
-func (a *App) DeleteLink(ctx context.Context, id int64) error {
-	return a.repo.DeleteLink(ctx, id)
+// DeleteLink deletes a Link by its internal ID, optionally only if it is
+// still at expectedVersion
+func (a *App) DeleteLink(ctx context.Context, id int64, expectedVersion *int64) (err error) {
+	ctx, span := tracing.Start(ctx, "link.App.DeleteLink")
+	defer tracing.End(span, &err)
+
+	if _, err := a.authz.AuthorizeLinkByID(ctx, id, access.RoleEditor); err != nil {
+		return err
+	}
+	return a.repo.DeleteLink(ctx, id, expectedVersion)
 }
 
-// DeleteLinkByLinkID deletes a Link by its UUID
-func (a *App) DeleteLinkByLinkID(ctx context.Context, linkID string) error {
+// DeleteLinkByLinkID deletes a Link by its UUID, optionally only if it is
+// still at expectedVersion
+func (a *App) DeleteLinkByLinkID(ctx context.Context, linkID string, expectedVersion *int64) (err error) {
+	ctx, span := tracing.Start(ctx, "link.App.DeleteLinkByLinkID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(linkID); err != nil {
 		return ErrInvalidLinkID
 	}
-	return a.repo.DeleteLinkByLinkID(ctx, linkID)
+	if _, err := a.authz.AuthorizeLink(ctx, linkID, access.RoleEditor); err != nil {
+		return err
+	}
+	return a.repo.DeleteLinkByLinkID(ctx, linkID, expectedVersion)
 }
 
 // CountLinksByLumeID returns the total count of Links connected to a Lume
-func (a *App) CountLinksByLumeID(ctx context.Context, lumeID string) (int64, error) {
+func (a *App) CountLinksByLumeID(ctx context.Context, lumeID string) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "link.App.CountLinksByLumeID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(lumeID); err != nil {
 		return 0, ErrInvalidLumeID
 	}
+	if _, err := a.authz.AuthorizeLume(ctx, lumeID, access.RoleViewer); err != nil {
+		return 0, err
+	}
 	return a.repo.CountLinksByLumeID(ctx, lumeID)
 }
 
+// validateTravelDetails checks the parts of travel details the API can't
+func (a *App) validateTravelDetails(details *TravelDetailsRequest) error {
+	if details != nil && details.Currency != "" && !modeluser.IsValidCurrency(details.Currency) {
+		return ErrInvalidCurrency
+	}
+	return nil
+}
+
+// applyHomeCurrency gives a cost estimate without a currency the caller's
+// home currency
+func (a *App) applyHomeCurrency(ctx context.Context, domainLink *modellink.Link) error {
+	if domainLink.Travel == nil || domainLink.Travel.Currency != "" {
+		return nil
+	}
+
+	preferences, err := a.prefs.CallerPreferences(ctx)
+	if err != nil {
+		return err
+	}
+	domainLink.Travel.Currency = preferences.HomeCurrency
+	return nil
+}
+
 // toDomainModelForCreate converts a create request to a domain model
 func (a *App) toDomainModelForCreate(req CreateLinkRequest) *modellink.Link {
 	domainLink := modellink.NewLink(req.FromLumeID, req.ToLumeID, req.Type)
@@ -189,6 +397,7 @@ func (a *App) toDomainModelForCreate(req CreateLinkRequest) *modellink.Link {
 			DurationSec:    req.TravelDetails.DurationSec,
 			CostEstimate:   req.TravelDetails.CostEstimate,
 			DistanceMeters: req.TravelDetails.DistanceMeters,
+			Currency:       req.TravelDetails.Currency,
 		}
 	}
 
@@ -213,6 +422,7 @@ func (a *App) updateDomainModel(existingLink *modellink.Link, req UpdateLinkRequ
 				DurationSec:    req.TravelDetails.DurationSec,
 				CostEstimate:   req.TravelDetails.CostEstimate,
 				DistanceMeters: req.TravelDetails.DistanceMeters,
+				Currency:       req.TravelDetails.Currency,
 			}
 		}
 		if req.Notes != nil {
@@ -239,6 +449,7 @@ func (a *App) updateDomainModel(existingLink *modellink.Link, req UpdateLinkRequ
 					DurationSec:    req.TravelDetails.DurationSec,
 					CostEstimate:   req.TravelDetails.CostEstimate,
 					DistanceMeters: req.TravelDetails.DistanceMeters,
+					Currency:       req.TravelDetails.Currency,
 				}
 			}
 		case "notes":
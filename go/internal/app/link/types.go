@@ -18,6 +18,8 @@ type TravelDetailsRequest struct {
 	DurationSec    int32
 	CostEstimate   float64
 	DistanceMeters float64
+	// ISO 4217 code of CostEstimate, defaults to the caller's home currency
+	Currency string
 }
 
 // UpdateLinkRequest represents the business layer's update request
@@ -30,6 +32,9 @@ type UpdateLinkRequest struct {
 	TravelDetails *TravelDetailsRequest
 	// Fields to update (from field mask)
 	UpdateFields []string
+	// Optional version the caller last read, the update fails with
+	// ErrVersionConflict if the Link has moved on since
+	ExpectedVersion *int64
 }
 
 // ListLinksRequest represents pagination parameters
@@ -7,7 +7,12 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	"github.com/mcdev12/lumo/go/internal/models/access"
 	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+	modeluser "github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/mcdev12/lumo/go/internal/models/version"
+	"github.com/mcdev12/lumo/go/internal/tracing"
 )
 
 // Domain errors
@@ -18,64 +23,137 @@ var (
 	ErrInvalidLumeType = errors.New("invalid lume type")
 	ErrEmptyName       = errors.New("name cannot be empty")
 	ErrInvalidMetadata = errors.New("invalid metadata")
+	ErrInvalidTimeZone = errors.New("unknown time zone")
+	ErrTooManyLumes    = modellume.ErrTooManyLumes
+
+	ErrVersionConflict  = version.ErrConflict
+	ErrNotFound         = access.ErrNotFound
+	ErrPermissionDenied = auth.ErrPermissionDenied
 )
 
 // LumeRepository defines what the app layer needs from the repository
 type LumeRepository interface {
-	CreateLume(ctx context.Context, domainLume *modellume.Lume) (*modellume.Lume, error)
+	CreateLumeWithQuota(ctx context.Context, domainLume *modellume.Lume, maxLumes int64) (*modellume.Lume, error)
 	GetLumeByID(ctx context.Context, id int64) (*modellume.Lume, error)
 	GetLumeByLumeID(ctx context.Context, lumeID string) (*modellume.Lume, error)
 	ListLumesByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*modellume.Lume, error)
 	ListLumesByType(ctx context.Context, lumoID string, lumeType modellume.LumeType, limit, offset int32) ([]*modellume.Lume, error)
 	SearchLumesByLocation(ctx context.Context, lumoID string, minLat, maxLat, minLng, maxLng float64, limit, offset int32) ([]*modellume.Lume, error)
 	UpdateLume(ctx context.Context, domainLume *modellume.Lume) (*modellume.Lume, error)
-	DeleteLume(ctx context.Context, id int64) error
-	DeleteLumeByLumeID(ctx context.Context, lumeID string) error
+	DeleteLume(ctx context.Context, id int64, expectedVersion *int64) error
+	DeleteLumeByLumeID(ctx context.Context, lumeID string, expectedVersion *int64) error
 	CountLumesByLumo(ctx context.Context, lumoID string) (int64, error)
 }
 
+// Authorizer checks the caller's role in the Lumo a Lume belongs to
+type Authorizer interface {
+	AuthorizeLumo(ctx context.Context, lumoID string, required access.Role) (*access.Owner, error)
+	AuthorizeLume(ctx context.Context, lumeID string, required access.Role) (*access.Owner, error)
+	AuthorizeLumeByID(ctx context.Context, id int64, required access.Role) (*access.Owner, error)
+}
+
+// PreferenceReader looks up the preferences of the caller
+type PreferenceReader interface {
+	CallerPreferences(ctx context.Context) (modeluser.Preferences, error)
+}
+
 // App handles business logic for Lumes
 type App struct {
-	repo LumeRepository
+	repo  LumeRepository
+	authz Authorizer
+	prefs PreferenceReader
+
+	// maxLumes is how many Lumes a Lumo may hold, 0 for no limit
+	maxLumes int64
 }
 
-// NewLumeApp creates a new Lume Service
-func NewLumeApp(repo LumeRepository) *App {
+// NewLumeApp creates a new Lume Service. Creating a Lume in a Lumo that
+// already holds maxLumes, outside the trash, fails unless maxLumes is 0.
+func NewLumeApp(repo LumeRepository, authz Authorizer, prefs PreferenceReader, maxLumes int64) *App {
 	return &App{
-		repo: repo,
+		repo:     repo,
+		authz:    authz,
+		prefs:    prefs,
+		maxLumes: maxLumes,
 	}
 }
 
 // CreateLume creates a new Lume with business logic validation
-func (a *App) CreateLume(ctx context.Context, req CreateLumeRequest) (*modellume.Lume, error) {
+func (a *App) CreateLume(ctx context.Context, req CreateLumeRequest) (_ *modellume.Lume, err error) {
+	ctx, span := tracing.Start(ctx, "lume.App.CreateLume")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(req.LumoID); err != nil {
+		return nil, ErrInvalidLumoID
+	}
+	if req.TimeZone != "" && !modeluser.IsValidTimeZone(req.TimeZone) {
+		return nil, ErrInvalidTimeZone
+	}
+
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, access.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Dates are in the creator's time zone unless they say otherwise
+	if req.TimeZone == "" {
+		preferences, err := a.prefs.CallerPreferences(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.TimeZone = preferences.TimeZone
+	}
+
 	domainLume, err := a.toDomainModelForCreate(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert request: %w", err)
 	}
 
-	return a.repo.CreateLume(ctx, domainLume)
+	// The quota is checked together with the insert, so concurrent creates
+	// can't overshoot it
+	return a.repo.CreateLumeWithQuota(ctx, domainLume, a.maxLumes)
 }
 
 // GetLumeByID retrieves a Lume by its internal ID
-func (a *App) GetLumeByID(ctx context.Context, id int64) (*modellume.Lume, error) {
+func (a *App) GetLumeByID(ctx context.Context, id int64) (_ *modellume.Lume, err error) {
+	ctx, span := tracing.Start(ctx, "lume.App.GetLumeByID")
+	defer tracing.End(span, &err)
+
+	if _, err := a.authz.AuthorizeLumeByID(ctx, id, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	return a.repo.GetLumeByID(ctx, id)
 }
 
 // GetLumeByLumeID retrieves a Lume by its UUID string
-func (a *App) GetLumeByLumeID(ctx context.Context, lumeID string) (*modellume.Lume, error) {
+func (a *App) GetLumeByLumeID(ctx context.Context, lumeID string) (_ *modellume.Lume, err error) {
+	ctx, span := tracing.Start(ctx, "lume.App.GetLumeByLumeID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(lumeID); err != nil {
 		return nil, ErrInvalidLumeID
 	}
 
+	if _, err := a.authz.AuthorizeLume(ctx, lumeID, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	return a.repo.GetLumeByLumeID(ctx, lumeID)
 }
 
 // ListLumesByLumoID retrieves all Lumes for a given Lumo
-func (a *App) ListLumesByLumoID(ctx context.Context, req ListLumesRequest) ([]*modellume.Lume, error) {
+func (a *App) ListLumesByLumoID(ctx context.Context, req ListLumesRequest) (_ []*modellume.Lume, err error) {
+	ctx, span := tracing.Start(ctx, "lume.App.ListLumesByLumoID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(req.LumoID); err != nil {
 		return nil, ErrInvalidLumoID
 	}
 
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	limit := req.Limit
 	if limit <= 0 || limit > 100 {
 		limit = 50
@@ -90,11 +168,18 @@ func (a *App) ListLumesByLumoID(ctx context.Context, req ListLumesRequest) ([]*m
 }
 
 // ListLumesByType retrieves all Lumes of a specific type for a Lumo
-func (a *App) ListLumesByType(ctx context.Context, req ListLumesByTypeRequest) ([]*modellume.Lume, error) {
+func (a *App) ListLumesByType(ctx context.Context, req ListLumesByTypeRequest) (_ []*modellume.Lume, err error) {
+	ctx, span := tracing.Start(ctx, "lume.App.ListLumesByType")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(req.LumoID); err != nil {
 		return nil, ErrInvalidLumoID
 	}
 
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	limit := req.Limit
 	if limit <= 0 || limit > 100 {
 		limit = 50
@@ -109,11 +194,18 @@ func (a *App) ListLumesByType(ctx context.Context, req ListLumesByTypeRequest) (
 }
 
 // SearchLumesByLocation finds Lumes within a bounding box for a specific Lumo
-func (a *App) SearchLumesByLocation(ctx context.Context, req SearchLumesByLocationRequest) ([]*modellume.Lume, error) {
+func (a *App) SearchLumesByLocation(ctx context.Context, req SearchLumesByLocationRequest) (_ []*modellume.Lume, err error) {
+	ctx, span := tracing.Start(ctx, "lume.App.SearchLumesByLocation")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(req.LumoID); err != nil {
 		return nil, ErrInvalidLumoID
 	}
 
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	limit := req.Limit
 	if limit <= 0 || limit > 100 {
 		limit = 50
@@ -128,13 +220,28 @@ func (a *App) SearchLumesByLocation(ctx context.Context, req SearchLumesByLocati
 }
 
 // UpdateLume updates an existing Lume
-func (a *App) UpdateLume(ctx context.Context, id int64, req UpdateLumeRequest) (*modellume.Lume, error) {
+func (a *App) UpdateLume(ctx context.Context, id int64, req UpdateLumeRequest) (_ *modellume.Lume, err error) {
+	ctx, span := tracing.Start(ctx, "lume.App.UpdateLume")
+	defer tracing.End(span, &err)
+
+	if req.TimeZone != "" && !modeluser.IsValidTimeZone(req.TimeZone) {
+		return nil, ErrInvalidTimeZone
+	}
+
+	if _, err := a.authz.AuthorizeLumeByID(ctx, id, access.RoleEditor); err != nil {
+		return nil, err
+	}
+
 	// First get the existing lume
 	existingLume, err := a.repo.GetLumeByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := version.Check(req.ExpectedVersion, existingLume.Version); err != nil {
+		return nil, err
+	}
+
 	// Update the domain model with new values
 	updatedLume := a.updateDomainModel(existingLume, req)
 
@@ -142,10 +249,20 @@ func (a *App) UpdateLume(ctx context.Context, id int64, req UpdateLumeRequest) (
 }
 
 // UpdateLumeByLumeID updates a Lume by its UUID
-func (a *App) UpdateLumeByLumeID(ctx context.Context, lumeID string, req UpdateLumeRequest) (*modellume.Lume, error) {
+func (a *App) UpdateLumeByLumeID(ctx context.Context, lumeID string, req UpdateLumeRequest) (_ *modellume.Lume, err error) {
+	ctx, span := tracing.Start(ctx, "lume.App.UpdateLumeByLumeID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(lumeID); err != nil {
 		return nil, ErrInvalidLumeID
 	}
+	if req.TimeZone != "" && !modeluser.IsValidTimeZone(req.TimeZone) {
+		return nil, ErrInvalidTimeZone
+	}
+
+	if _, err := a.authz.AuthorizeLume(ctx, lumeID, access.RoleEditor); err != nil {
+		return nil, err
+	}
 
 	// First get the lume to find its internal ID
 	existingLume, err := a.repo.GetLumeByLumeID(ctx, lumeID)
@@ -153,32 +270,59 @@ func (a *App) UpdateLumeByLumeID(ctx context.Context, lumeID string, req UpdateL
 		return nil, err
 	}
 
+	if err := version.Check(req.ExpectedVersion, existingLume.Version); err != nil {
+		return nil, err
+	}
+
 	// Update the domain model with new values
 	updatedLume := a.updateDomainModel(existingLume, req)
 
 	return a.repo.UpdateLume(ctx, updatedLume)
 }
 
-// DeleteLume deletes a Lume by its ID
-func (a *App) DeleteLume(ctx context.Context, id int64) error {
-	return a.repo.DeleteLume(ctx, id)
+// DeleteLume deletes a Lume by its ID, optionally only if it is still at
+// expectedVersion
+func (a *App) DeleteLume(ctx context.Context, id int64, expectedVersion *int64) (err error) {
+	ctx, span := tracing.Start(ctx, "lume.App.DeleteLume")
+	defer tracing.End(span, &err)
+
+	if _, err := a.authz.AuthorizeLumeByID(ctx, id, access.RoleEditor); err != nil {
+		return err
+	}
+
+	return a.repo.DeleteLume(ctx, id, expectedVersion)
 }
 
-// DeleteLumeByLumeID deletes a Lume by its UUID
-func (a *App) DeleteLumeByLumeID(ctx context.Context, lumeID string) error {
+// DeleteLumeByLumeID deletes a Lume by its UUID, optionally only if it is
+// still at expectedVersion
+func (a *App) DeleteLumeByLumeID(ctx context.Context, lumeID string, expectedVersion *int64) (err error) {
+	ctx, span := tracing.Start(ctx, "lume.App.DeleteLumeByLumeID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(lumeID); err != nil {
 		return ErrInvalidLumeID
 	}
 
-	return a.repo.DeleteLumeByLumeID(ctx, lumeID)
+	if _, err := a.authz.AuthorizeLume(ctx, lumeID, access.RoleEditor); err != nil {
+		return err
+	}
+
+	return a.repo.DeleteLumeByLumeID(ctx, lumeID, expectedVersion)
 }
 
 // CountLumesByLumo returns the total count of Lumes for a Lumo
-func (a *App) CountLumesByLumo(ctx context.Context, lumoID string) (int64, error) {
+func (a *App) CountLumesByLumo(ctx context.Context, lumoID string) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "lume.App.CountLumesByLumo")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(lumoID); err != nil {
 		return 0, ErrInvalidLumoID
 	}
 
+	if _, err := a.authz.AuthorizeLumo(ctx, lumoID, access.RoleViewer); err != nil {
+		return 0, err
+	}
+
 	return a.repo.CountLumesByLumo(ctx, lumoID)
 }
 
@@ -199,6 +343,7 @@ func (a *App) toDomainModelForCreate(req CreateLumeRequest) (*modellume.Lume, er
 		Images:       req.Images,
 		CategoryTags: req.CategoryTags,
 		BookingLink:  req.BookingLink,
+		TimeZone:     req.TimeZone,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -230,6 +375,9 @@ func (a *App) updateDomainModel(existingLume *modellume.Lume, req UpdateLumeRequ
 		existingLume.Longitude = req.Longitude
 		existingLume.Address = req.Address
 		existingLume.BookingLink = req.BookingLink
+		if req.TimeZone != "" {
+			existingLume.TimeZone = req.TimeZone
+		}
 
 		// Update arrays if provided
 		if req.Images != nil {
@@ -263,6 +411,10 @@ func (a *App) updateDomainModel(existingLume *modellume.Lume, req UpdateLumeRequ
 			existingLume.Address = req.Address
 		case "booking_link":
 			existingLume.BookingLink = req.BookingLink
+		case "time_zone":
+			if req.TimeZone != "" {
+				existingLume.TimeZone = req.TimeZone
+			}
 		case "images":
 			if req.Images != nil {
 				existingLume.Images = req.Images
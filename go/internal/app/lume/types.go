@@ -21,6 +21,8 @@ type CreateLumeRequest struct {
 	Images       []string
 	CategoryTags []string
 	BookingLink  *string
+	// IANA time zone of the dates, defaults to the caller's time zone
+	TimeZone string
 }
 
 // UpdateLumeRequest represents the business layer's update request
@@ -37,8 +39,13 @@ type UpdateLumeRequest struct {
 	Images       []string
 	CategoryTags []string
 	BookingLink  *string
+	// Left unchanged when empty
+	TimeZone string
 	// Fields to update (from field mask)
 	UpdateFields []string
+	// Optional version the caller last read, the update fails with
+	// ErrVersionConflict if the Lume has moved on since
+	ExpectedVersion *int64
 }
 
 // ListLumesRequest represents pagination parameters
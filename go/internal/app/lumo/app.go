@@ -7,7 +7,12 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	"github.com/mcdev12/lumo/go/internal/models/access"
 	modellumo "github.com/mcdev12/lumo/go/internal/models/lumo"
+	modeluser "github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/mcdev12/lumo/go/internal/models/version"
+	"github.com/mcdev12/lumo/go/internal/tracing"
 )
 
 // Domain errors
@@ -16,18 +21,38 @@ var (
 	ErrInvalidUserID = errors.New("invalid user ID")
 	ErrInvalidLumoID = errors.New("invalid lumo ID")
 	ErrEmptyTitle    = errors.New("title cannot be empty")
+	ErrInvalidRole   = errors.New("invalid member role")
+	ErrCreatorRole   = errors.New("the creator of a lumo always stays its owner")
+	ErrTooManyLumos  = modellumo.ErrTooManyLumos
+
+	ErrVersionConflict  = version.ErrConflict
+	ErrNotFound         = access.ErrNotFound
+	ErrPermissionDenied = auth.ErrPermissionDenied
+	ErrMemberNotFound   = modellumo.ErrMemberNotFound
+	ErrAlreadyMember    = modellumo.ErrAlreadyMember
+	ErrUserNotFound     = modeluser.ErrUserNotFound
 )
 
 // LumoRepository defines what the app layer needs from the repository
 type LumoRepository interface {
-	CreateLumo(ctx context.Context, domainLumo *modellumo.Lumo) (*modellumo.Lumo, error)
+	CreateLumoWithQuota(ctx context.Context, domainLumo *modellumo.Lumo, maxLumos int64) (*modellumo.Lumo, error)
 	GetLumoByID(ctx context.Context, id int64) (*modellumo.Lumo, error)
 	GetLumoByLumoID(ctx context.Context, lumoID string) (*modellumo.Lumo, error)
 	ListLumosByUserID(ctx context.Context, userID string, limit, offset int32) ([]*modellumo.Lumo, error)
 	UpdateLumo(ctx context.Context, domainLumo *modellumo.Lumo) (*modellumo.Lumo, error)
-	DeleteLumo(ctx context.Context, id int64) error
-	DeleteLumoByLumoID(ctx context.Context, lumoID string) error
+	DeleteLumo(ctx context.Context, id int64, expectedVersion *int64) error
+	DeleteLumoByLumoID(ctx context.Context, lumoID string, expectedVersion *int64) error
 	CountLumosByUserID(ctx context.Context, userID string) (int64, error)
+	CreateMember(ctx context.Context, domainMember *modellumo.Member) (*modellumo.Member, error)
+	ListMembers(ctx context.Context, lumoID string, limit, offset int32) ([]*modellumo.Member, error)
+	UpdateMemberRole(ctx context.Context, lumoID, userID string, role access.Role) (*modellumo.Member, error)
+	DeleteMember(ctx context.Context, lumoID, userID string) error
+}
+
+// Authorizer checks the caller's role in the Lumo they ask for
+type Authorizer interface {
+	AuthorizeLumo(ctx context.Context, lumoID string, required access.Role) (*access.Owner, error)
+	AuthorizeLumoByID(ctx context.Context, id int64, required access.Role) (*access.Owner, error)
 }
 
 // CreateLumoRequest represents the business layer's create request
@@ -39,6 +64,10 @@ type CreateLumoRequest struct {
 // UpdateLumoRequest represents the business layer's update request
 type UpdateLumoRequest struct {
 	Title string
+
+	// Optional version the caller last read, the update fails with
+	// ErrVersionConflict if the Lumo has moved on since
+	ExpectedVersion *int64
 }
 
 // ListLumosRequest represents pagination parameters
@@ -48,20 +77,51 @@ type ListLumosRequest struct {
 	Offset int32
 }
 
+// ShareLumoRequest represents the business layer's share request
+type ShareLumoRequest struct {
+	LumoID string
+	UserID string
+	Role   access.Role
+}
+
+// ListMembersRequest represents pagination parameters
+type ListMembersRequest struct {
+	LumoID string
+	Limit  int32
+	Offset int32
+}
+
+// UpdateMemberRoleRequest represents the business layer's role change request
+type UpdateMemberRoleRequest struct {
+	LumoID string
+	UserID string
+	Role   access.Role
+}
+
 // App handles business logic for Lumos
 type App struct {
-	repo LumoRepository
+	repo  LumoRepository
+	authz Authorizer
+
+	// maxLumos is how many Lumos a user may create, 0 for no limit
+	maxLumos int64
 }
 
-// NewLumoApp creates a new Lumo Service
-func NewLumoApp(repo LumoRepository) *App {
+// NewLumoApp creates a new Lumo Service. Creating a Lumo fails for a user who
+// already owns maxLumos, outside the trash, unless maxLumos is 0.
+func NewLumoApp(repo LumoRepository, authz Authorizer, maxLumos int64) *App {
 	return &App{
-		repo: repo,
+		repo:     repo,
+		authz:    authz,
+		maxLumos: maxLumos,
 	}
 }
 
 // CreateLumo creates a new Lumo with business logic validation
-func (a *App) CreateLumo(ctx context.Context, req CreateLumoRequest) (*modellumo.Lumo, error) {
+func (a *App) CreateLumo(ctx context.Context, req CreateLumoRequest) (_ *modellumo.Lumo, err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.CreateLumo")
+	defer tracing.End(span, &err)
+
 	if err := a.validateCreateRequest(req); err != nil {
 		return nil, err
 	}
@@ -71,25 +131,44 @@ func (a *App) CreateLumo(ctx context.Context, req CreateLumoRequest) (*modellumo
 		return nil, fmt.Errorf("failed to convert request: %w", err)
 	}
 
-	return a.repo.CreateLumo(ctx, domainLumo)
+	// The quota is checked together with the insert, so concurrent creates
+	// can't overshoot it
+	return a.repo.CreateLumoWithQuota(ctx, domainLumo, a.maxLumos)
 }
 
 // GetLumoByID retrieves a Lumo by its internal ID
-func (a *App) GetLumoByID(ctx context.Context, id int64) (*modellumo.Lumo, error) {
+func (a *App) GetLumoByID(ctx context.Context, id int64) (_ *modellumo.Lumo, err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.GetLumoByID")
+	defer tracing.End(span, &err)
+
+	if _, err := a.authz.AuthorizeLumoByID(ctx, id, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	return a.repo.GetLumoByID(ctx, id)
 }
 
 // GetLumoByLumoID retrieves a Lumo by its UUID string
-func (a *App) GetLumoByLumoID(ctx context.Context, lumoID string) (*modellumo.Lumo, error) {
+func (a *App) GetLumoByLumoID(ctx context.Context, lumoID string) (_ *modellumo.Lumo, err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.GetLumoByLumoID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(lumoID); err != nil {
 		return nil, ErrInvalidLumoID
 	}
 
+	if _, err := a.authz.AuthorizeLumo(ctx, lumoID, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	return a.repo.GetLumoByLumoID(ctx, lumoID)
 }
 
 // ListLumosByUserID retrieves all Lumos for a given user
-func (a *App) ListLumosByUserID(ctx context.Context, req ListLumosRequest) ([]*modellumo.Lumo, error) {
+func (a *App) ListLumosByUserID(ctx context.Context, req ListLumosRequest) (_ []*modellumo.Lumo, err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.ListLumosByUserID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(req.UserID); err != nil {
 		return nil, ErrInvalidUserID
 	}
@@ -108,17 +187,28 @@ func (a *App) ListLumosByUserID(ctx context.Context, req ListLumosRequest) ([]*m
 }
 
 // UpdateLumo updates an existing Lumo
-func (a *App) UpdateLumo(ctx context.Context, id int64, req UpdateLumoRequest) (*modellumo.Lumo, error) {
+func (a *App) UpdateLumo(ctx context.Context, id int64, req UpdateLumoRequest) (_ *modellumo.Lumo, err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.UpdateLumo")
+	defer tracing.End(span, &err)
+
 	if err := a.validateUpdateRequest(req); err != nil {
 		return nil, err
 	}
 
+	if _, err := a.authz.AuthorizeLumoByID(ctx, id, access.RoleEditor); err != nil {
+		return nil, err
+	}
+
 	// First get the existing lumo
 	existingLumo, err := a.repo.GetLumoByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := version.Check(req.ExpectedVersion, existingLumo.Version); err != nil {
+		return nil, err
+	}
+
 	// Update the domain model with new values
 	updatedLumo := a.updateDomainModel(existingLumo, req)
 
@@ -126,11 +216,18 @@ func (a *App) UpdateLumo(ctx context.Context, id int64, req UpdateLumoRequest) (
 }
 
 // UpdateLumoByLumoID updates a Lumo by its UUID
-func (a *App) UpdateLumoByLumoID(ctx context.Context, lumoID string, req UpdateLumoRequest) (*modellumo.Lumo, error) {
+func (a *App) UpdateLumoByLumoID(ctx context.Context, lumoID string, req UpdateLumoRequest) (_ *modellumo.Lumo, err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.UpdateLumoByLumoID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(lumoID); err != nil {
 		return nil, ErrInvalidLumoID
 	}
 
+	if _, err := a.authz.AuthorizeLumo(ctx, lumoID, access.RoleEditor); err != nil {
+		return nil, err
+	}
+
 	// First get the lumo to find its internal ID
 	existingLumo, err := a.repo.GetLumoByLumoID(ctx, lumoID)
 	if err != nil {
@@ -141,36 +238,166 @@ func (a *App) UpdateLumoByLumoID(ctx context.Context, lumoID string, req UpdateL
 		return nil, err
 	}
 
+	if err := version.Check(req.ExpectedVersion, existingLumo.Version); err != nil {
+		return nil, err
+	}
+
 	// Update the domain model with new values
 	updatedLumo := a.updateDomainModel(existingLumo, req)
 
 	return a.repo.UpdateLumo(ctx, updatedLumo)
 }
 
-// DeleteLumo deletes a Lumo by its ID
-func (a *App) DeleteLumo(ctx context.Context, id int64) error {
-	return a.repo.DeleteLumo(ctx, id)
+// DeleteLumo deletes a Lumo by its ID, optionally only if it is still at
+// expectedVersion
+func (a *App) DeleteLumo(ctx context.Context, id int64, expectedVersion *int64) (err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.DeleteLumo")
+	defer tracing.End(span, &err)
+
+	if _, err := a.authz.AuthorizeLumoByID(ctx, id, access.RoleOwner); err != nil {
+		return err
+	}
+
+	return a.repo.DeleteLumo(ctx, id, expectedVersion)
 }
 
-// DeleteLumoByLumoID deletes a Lumo by its UUID
-func (a *App) DeleteLumoByLumoID(ctx context.Context, lumoID string) error {
+// DeleteLumoByLumoID deletes a Lumo by its UUID, optionally only if it is
+// still at expectedVersion
+func (a *App) DeleteLumoByLumoID(ctx context.Context, lumoID string, expectedVersion *int64) (err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.DeleteLumoByLumoID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(lumoID); err != nil {
 		return ErrInvalidLumoID
 	}
-	
-	return a.repo.DeleteLumoByLumoID(ctx, lumoID)
+
+	if _, err := a.authz.AuthorizeLumo(ctx, lumoID, access.RoleOwner); err != nil {
+		return err
+	}
+
+	return a.repo.DeleteLumoByLumoID(ctx, lumoID, expectedVersion)
 }
 
 // CountLumosByUserID returns the total count of Lumos for a user
-func (a *App) CountLumosByUserID(ctx context.Context, userID string) (int64, error) {
+func (a *App) CountLumosByUserID(ctx context.Context, userID string) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.CountLumosByUserID")
+	defer tracing.End(span, &err)
+
 	if _, err := uuid.Parse(userID); err != nil {
 		return 0, ErrInvalidUserID
 	}
-	
+
 	return a.repo.CountLumosByUserID(ctx, userID)
 }
 
+// ShareLumo makes a user a member of a Lumo. Only owners can share.
+func (a *App) ShareLumo(ctx context.Context, req ShareLumoRequest) (_ *modellumo.Member, err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.ShareLumo")
+	defer tracing.End(span, &err)
+
+	if err := a.validateMemberKey(req.LumoID, req.UserID); err != nil {
+		return nil, err
+	}
+	if !req.Role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, access.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	return a.repo.CreateMember(ctx, modellumo.NewMember(req.LumoID, req.UserID, req.Role))
+}
+
+// ListMembers retrieves the members of a Lumo, including its creator
+func (a *App) ListMembers(ctx context.Context, req ListMembersRequest) (_ []*modellumo.Member, err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.ListMembers")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(req.LumoID); err != nil {
+		return nil, ErrInvalidLumoID
+	}
+
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, access.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	return a.repo.ListMembers(ctx, req.LumoID, limit, offset)
+}
+
+// UpdateMemberRole changes the role of a member. Only owners can change
+// roles and the creator's can't be changed at all.
+func (a *App) UpdateMemberRole(ctx context.Context, req UpdateMemberRoleRequest) (_ *modellumo.Member, err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.UpdateMemberRole")
+	defer tracing.End(span, &err)
+
+	if err := a.validateMemberKey(req.LumoID, req.UserID); err != nil {
+		return nil, err
+	}
+	if !req.Role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	owner, err := a.authz.AuthorizeLumo(ctx, req.LumoID, access.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserID == owner.UserID {
+		return nil, ErrCreatorRole
+	}
+
+	return a.repo.UpdateMemberRole(ctx, req.LumoID, req.UserID, req.Role)
+}
+
+// RemoveMember stops sharing a Lumo with a user. Owners can remove anyone
+// but the creator, other members can only leave themselves.
+func (a *App) RemoveMember(ctx context.Context, lumoID, userID string) (err error) {
+	ctx, span := tracing.Start(ctx, "lumo.App.RemoveMember")
+	defer tracing.End(span, &err)
+
+	if err := a.validateMemberKey(lumoID, userID); err != nil {
+		return err
+	}
+
+	owner, err := a.authz.AuthorizeLumo(ctx, lumoID, access.RoleViewer)
+	if err != nil {
+		return err
+	}
+	if !owner.Role.Allows(access.RoleOwner) {
+		if identity, ok := auth.IdentityFromContext(ctx); !ok || identity.UserID != userID {
+			return ErrPermissionDenied
+		}
+	}
+	if userID == owner.UserID {
+		return ErrCreatorRole
+	}
+
+	return a.repo.DeleteMember(ctx, lumoID, userID)
+}
+
 // Validation methods
+func (a *App) validateMemberKey(lumoID, userID string) error {
+	if _, err := uuid.Parse(lumoID); err != nil {
+		return ErrInvalidLumoID
+	}
+
+	if _, err := uuid.Parse(userID); err != nil {
+		return ErrInvalidUserID
+	}
+
+	return nil
+}
+
 func (a *App) validateCreateRequest(req CreateLumoRequest) error {
 	if req.Title == "" {
 		return ErrEmptyTitle
@@ -205,4 +432,4 @@ func (a *App) updateDomainModel(existingLumo *modellumo.Lumo, req UpdateLumoRequ
 	existingLumo.Title = req.Title
 	existingLumo.UpdatedAt = time.Now()
 	return existingLumo
-}
\ No newline at end of file
+}
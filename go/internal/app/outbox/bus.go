@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	modeloutbox "github.com/mcdev12/lumo/go/internal/models/outbox"
+)
+
+// Handler reacts to a domain event. Returning an error has the event
+// delivered again, to every handler.
+type Handler func(ctx context.Context, event *modeloutbox.Event) error
+
+// Bus is a Sink that hands events to handlers in this process
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+// NewBus creates a new Bus without handlers
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe adds a handler that is called with every event
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Name identifies the bus in logs and delivery errors
+func (b *Bus) Name() string {
+	return "bus"
+}
+
+// Publish calls every handler in the order they subscribed
+func (b *Bus) Publish(ctx context.Context, event *modeloutbox.Event) error {
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,116 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	modeloutbox "github.com/mcdev12/lumo/go/internal/models/outbox"
+)
+
+const (
+	// batchSize is how many events the relay leases at once
+	batchSize = 100
+	// lease is how long other relays leave leased events alone, long enough
+	// to deliver a whole batch
+	lease = 5 * time.Minute
+	// maxBackoff caps the wait between attempts to deliver an event
+	maxBackoff = time.Hour
+)
+
+// Sink is a destination domain events are published to. Events are delivered
+// at least once, so sinks and whoever listens on them must cope with
+// duplicates, e.g. by remembering event IDs.
+type Sink interface {
+	// Name identifies the sink in logs and delivery errors
+	Name() string
+	// Publish delivers an event, returning an error if it should be retried
+	Publish(ctx context.Context, event *modeloutbox.Event) error
+}
+
+// OutboxRepository defines what the relay needs from the repository
+type OutboxRepository interface {
+	ClaimEvents(ctx context.Context, lease time.Duration, batchSize int32) ([]*modeloutbox.Event, error)
+	MarkDelivered(ctx context.Context, id int64) error
+	MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id int64, lastError string) error
+}
+
+// Relay publishes the events in the outbox to every sink. An event is
+// delivered once all sinks accepted it; otherwise it is retried with
+// exponential backoff, to every sink again, until maxAttempts is reached.
+// Several relays can share an outbox, each event is leased to one of them.
+type Relay struct {
+	repo        OutboxRepository
+	sinks       []Sink
+	maxAttempts int32
+}
+
+// NewRelay creates a new Relay
+func NewRelay(repo OutboxRepository, sinks []Sink, maxAttempts int32) *Relay {
+	return &Relay{
+		repo:        repo,
+		sinks:       sinks,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// DeliverDue delivers the events that are due until there are none left,
+// logging rather than failing so the next run can retry. It's run on an
+// interval with worker.Every.
+func (r *Relay) DeliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		events, err := r.repo.ClaimEvents(ctx, lease, batchSize)
+		if err != nil {
+			slog.WarnContext(ctx, "outbox relay failed to claim events", slog.Any("error", err))
+			return
+		}
+
+		for _, event := range events {
+			r.deliver(ctx, event)
+		}
+
+		if len(events) < batchSize {
+			return
+		}
+	}
+}
+
+// deliver publishes one event to every sink and records how it went
+func (r *Relay) deliver(ctx context.Context, event *modeloutbox.Event) {
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+
+	var err error
+	switch {
+	case len(errs) == 0:
+		err = r.repo.MarkDelivered(ctx, event.ID)
+	case event.Attempts >= r.maxAttempts:
+		slog.WarnContext(ctx, "giving up on outbox event", slog.String("event_id", event.EventID), slog.Int("attempts", int(event.Attempts)), slog.Any("error", errors.Join(errs...)))
+		err = r.repo.MarkFailed(ctx, event.ID, errors.Join(errs...).Error())
+	default:
+		err = r.repo.MarkRetry(ctx, event.ID, time.Now().Add(Backoff(event.Attempts)), errors.Join(errs...).Error())
+	}
+	if err != nil {
+		// The lease runs out and the event is tried again
+		slog.WarnContext(ctx, "outbox relay failed to record delivery", slog.String("event_id", event.EventID), slog.Any("error", err))
+	}
+}
+
+// Backoff returns how long to wait before the next attempt after the given
+// number of failed ones: a second, doubling every time up to an hour
+func Backoff(attempts int32) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 12 {
+		return maxBackoff
+	}
+	return min(time.Second<<(attempts-1), maxBackoff)
+}
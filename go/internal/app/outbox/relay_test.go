@@ -0,0 +1,152 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	modeloutbox "github.com/mcdev12/lumo/go/internal/models/outbox"
+	"github.com/stretchr/testify/suite"
+)
+
+// stubRepository hands out its events once and remembers how each delivery went
+type stubRepository struct {
+	events    []*modeloutbox.Event
+	delivered []int64
+	retries   map[int64]time.Time
+	failed    map[int64]string
+}
+
+// ClaimEvents returns the events not claimed yet
+func (r *stubRepository) ClaimEvents(_ context.Context, _ time.Duration, batchSize int32) ([]*modeloutbox.Event, error) {
+	n := min(int(batchSize), len(r.events))
+	claimed := r.events[:n]
+	r.events = r.events[n:]
+	return claimed, nil
+}
+
+// MarkDelivered records a delivered event
+func (r *stubRepository) MarkDelivered(_ context.Context, id int64) error {
+	r.delivered = append(r.delivered, id)
+	return nil
+}
+
+// MarkRetry records an event to retry
+func (r *stubRepository) MarkRetry(_ context.Context, id int64, nextAttemptAt time.Time, _ string) error {
+	r.retries[id] = nextAttemptAt
+	return nil
+}
+
+// MarkFailed records an event given up on
+func (r *stubRepository) MarkFailed(_ context.Context, id int64, lastError string) error {
+	r.failed[id] = lastError
+	return nil
+}
+
+// stubSink records what it published and fails the events it is told to
+type stubSink struct {
+	name      string
+	published []int64
+	failing   map[int64]bool
+}
+
+// Name identifies the sink
+func (s *stubSink) Name() string {
+	return s.name
+}
+
+// Publish records the event, failing it if asked to
+func (s *stubSink) Publish(_ context.Context, event *modeloutbox.Event) error {
+	s.published = append(s.published, event.ID)
+	if s.failing[event.ID] {
+		return errors.New("unavailable")
+	}
+	return nil
+}
+
+// RelayTestSuite is a test suite for the Relay
+type RelayTestSuite struct {
+	suite.Suite
+	repo  *stubRepository
+	sinkA *stubSink
+	sinkB *stubSink
+	relay *Relay
+}
+
+// SetupTest is called before each test
+func (s *RelayTestSuite) SetupTest() {
+	s.repo = &stubRepository{retries: map[int64]time.Time{}, failed: map[int64]string{}}
+	s.sinkA = &stubSink{name: "a", failing: map[int64]bool{}}
+	s.sinkB = &stubSink{name: "b", failing: map[int64]bool{}}
+	s.relay = NewRelay(s.repo, []Sink{s.sinkA, s.sinkB}, 3)
+}
+
+// TestRelaySuite runs the test suite
+func TestRelaySuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
+
+// Helper function to create a pending event that was claimed attempts times
+func newEvent(id int64, attempts int32) *modeloutbox.Event {
+	return &modeloutbox.Event{
+		ID:       id,
+		EventID:  uuid.New().String(),
+		Type:     "LumeCreated",
+		Status:   modeloutbox.StatusPending,
+		Attempts: attempts,
+	}
+}
+
+// Test events every sink accepted are marked delivered, in order
+func (s *RelayTestSuite) TestDelivered() {
+	s.repo.events = []*modeloutbox.Event{newEvent(1, 1), newEvent(2, 1)}
+
+	s.relay.DeliverDue(context.Background())
+
+	s.Equal([]int64{1, 2}, s.repo.delivered)
+	s.Equal([]int64{1, 2}, s.sinkA.published)
+	s.Equal([]int64{1, 2}, s.sinkB.published)
+	s.Empty(s.repo.retries)
+	s.Empty(s.repo.failed)
+}
+
+// Test a failing sink has the event retried with backoff, and given up on
+// after the last attempt, without holding up other events
+func (s *RelayTestSuite) TestFailedSink() {
+	s.repo.events = []*modeloutbox.Event{newEvent(1, 2), newEvent(2, 3), newEvent(3, 1)}
+	s.sinkB.failing[1] = true
+	s.sinkB.failing[2] = true
+	before := time.Now()
+
+	s.relay.DeliverDue(context.Background())
+
+	s.Equal([]int64{3}, s.repo.delivered)
+	s.Require().Contains(s.repo.retries, int64(1))
+	s.WithinDuration(before.Add(Backoff(2)), s.repo.retries[1], time.Second)
+	s.Equal("b: unavailable", s.repo.failed[2])
+	// Sinks that accepted the event get it again on the next attempt
+	s.Equal([]int64{1, 2, 3}, s.sinkA.published)
+}
+
+// Test the relay keeps claiming while batches come back full
+func (s *RelayTestSuite) TestDrainsFullBatches() {
+	for i := int64(1); i <= batchSize+1; i++ {
+		s.repo.events = append(s.repo.events, newEvent(i, 1))
+	}
+
+	s.relay.DeliverDue(context.Background())
+
+	s.Len(s.repo.delivered, batchSize+1)
+}
+
+// Test the backoff doubles from a second and stops at an hour
+func (s *RelayTestSuite) TestBackoff() {
+	s.Equal(time.Second, Backoff(0))
+	s.Equal(time.Second, Backoff(1))
+	s.Equal(2*time.Second, Backoff(2))
+	s.Equal(8*time.Second, Backoff(4))
+	s.Equal(time.Hour, Backoff(13))
+	s.Equal(time.Hour, Backoff(1000))
+}
@@ -0,0 +1,199 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	modeloutbox "github.com/mcdev12/lumo/go/internal/models/outbox"
+	"github.com/stretchr/testify/suite"
+)
+
+// SinksTestSuite is a test suite for the Sinks
+type SinksTestSuite struct {
+	suite.Suite
+	event *modeloutbox.Event
+}
+
+// SetupTest is called before each test
+func (s *SinksTestSuite) SetupTest() {
+	s.event = &modeloutbox.Event{
+		ID:       1,
+		EventID:  uuid.New().String(),
+		Type:     "LinkUpdated",
+		LumoID:   uuid.New().String(),
+		EntityID: uuid.New().String(),
+		Payload:  json.RawMessage(`{"notes":"Ferry"}`),
+	}
+}
+
+// TestSinksSuite runs the test suite
+func TestSinksSuite(t *testing.T) {
+	suite.Run(t, new(SinksTestSuite))
+}
+
+// Test the bus calls every handler and reports their errors
+func (s *SinksTestSuite) TestBus() {
+	bus := NewBus()
+	var calls []string
+	bus.Subscribe(func(_ context.Context, event *modeloutbox.Event) error {
+		calls = append(calls, "first:"+event.Type)
+		return nil
+	})
+	bus.Subscribe(func(_ context.Context, event *modeloutbox.Event) error {
+		calls = append(calls, "second:"+event.Type)
+		return errors.New("busy")
+	})
+
+	err := bus.Publish(context.Background(), s.event)
+
+	s.EqualError(err, "busy")
+	s.Equal([]string{"first:LinkUpdated", "second:LinkUpdated"}, calls)
+}
+
+// Test the webhook posts the event and only counts 2xx responses as delivered
+func (s *SinksTestSuite) TestWebhook() {
+	status := http.StatusNoContent
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPost, r.Method)
+		s.Equal("application/json", r.Header.Get("Content-Type"))
+		s.Equal(s.event.EventID, r.Header.Get("Lumo-Event-Id"))
+		s.Equal("LinkUpdated", r.Header.Get("Lumo-Event-Type"))
+		s.NoError(json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	sink := NewWebhookSink(server.URL, server.Client())
+
+	s.Require().NoError(sink.Publish(context.Background(), s.event))
+	s.Equal(s.event.EventID, received["id"])
+	s.Equal(s.event.LumoID, received["lumo_id"])
+	s.Equal(map[string]any{"notes": "Ferry"}, received["data"])
+
+	status = http.StatusServiceUnavailable
+	s.ErrorContains(sink.Publish(context.Background(), s.event), "503")
+}
+
+// fakeNATS is a NATS server that accepts one connection and records what was
+// published. Publishing to a subject starting with "deny." is refused.
+type fakeNATS struct {
+	listener  net.Listener
+	connects  chan string
+	published chan string
+}
+
+// newFakeNATS starts a fakeNATS on a free port
+func newFakeNATS(s *SinksTestSuite) *fakeNATS {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	f := &fakeNATS{listener: listener, connects: make(chan string, 4), published: make(chan string, 4)}
+	go f.serve()
+	return f
+}
+
+// serve answers the client protocol on every connection
+func (f *fakeNATS) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+// handle answers the client protocol on one connection
+func (f *fakeNATS) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	_, _ = io.WriteString(conn, `INFO {"server_id":"fake","max_payload":1048576}`+"\r\n")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "CONNECT "):
+			f.connects <- strings.TrimPrefix(line, "CONNECT ")
+		case line == "PING":
+			_, _ = io.WriteString(conn, "PONG\r\n")
+		case strings.HasPrefix(line, "PUB "):
+			fields := strings.Fields(line)
+			size, _ := strconv.Atoi(fields[2])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			if strings.HasPrefix(fields[1], "deny.") {
+				_, _ = io.WriteString(conn, "-ERR 'Permissions Violation for Publish to "+fields[1]+"'\r\n")
+				return
+			}
+			f.published <- fields[1] + " " + string(payload[:size])
+		}
+	}
+}
+
+// Test the NATS publisher authenticates, publishes on the event's subject and
+// waits for the server
+func (s *SinksTestSuite) TestNATSPublisher() {
+	server := newFakeNATS(s)
+	defer server.listener.Close()
+	publisher, err := NewNATSPublisher("nats://lumo:secret@"+server.listener.Addr().String(), "lumo.events")
+	s.Require().NoError(err)
+	defer publisher.Close()
+
+	s.Require().NoError(publisher.Publish(context.Background(), s.event))
+	s.Require().NoError(publisher.Publish(context.Background(), s.event))
+
+	var options map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(<-server.connects), &options))
+	s.Equal("lumo", options["user"])
+	s.Equal("secret", options["pass"])
+	// Both events went over the same connection
+	s.Empty(server.connects)
+
+	subject, payload, _ := strings.Cut(<-server.published, " ")
+	s.Equal("lumo.events."+s.event.LumoID+".LinkUpdated", subject)
+	var received map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(payload), &received))
+	s.Equal(s.event.EventID, received["id"])
+	s.Len(server.published, 1)
+}
+
+// Test the NATS publisher reports server errors and reconnects afterwards
+func (s *SinksTestSuite) TestNATSPublisherError() {
+	server := newFakeNATS(s)
+	defer server.listener.Close()
+	publisher, err := NewNATSPublisher("nats://"+server.listener.Addr().String(), "deny")
+	s.Require().NoError(err)
+	defer publisher.Close()
+
+	s.ErrorContains(publisher.Publish(context.Background(), s.event), "Permissions Violation")
+
+	publisher.subjectPrefix = "lumo.events"
+	s.NoError(publisher.Publish(context.Background(), s.event))
+	s.Len(server.connects, 2)
+}
+
+// Test NATS URLs are validated
+func (s *SinksTestSuite) TestNewNATSPublisher() {
+	publisher, err := NewNATSPublisher("nats://token@localhost", "lumo")
+	s.Require().NoError(err)
+	s.Equal("localhost:4222", publisher.addr)
+	s.Equal("token", publisher.token)
+
+	_, err = NewNATSPublisher("http://localhost:4222", "lumo")
+	s.Error(err)
+}
@@ -0,0 +1,62 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	modeloutbox "github.com/mcdev12/lumo/go/internal/models/outbox"
+)
+
+// WebhookSink is a Sink that POSTs every event as JSON to a URL. Any 2xx
+// response counts as delivered.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a new WebhookSink posting to url with client
+func NewWebhookSink(url string, client *http.Client) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: client,
+	}
+}
+
+// Name identifies the webhook in logs and delivery errors
+func (w *WebhookSink) Name() string {
+	return "webhook"
+}
+
+// Publish posts the event, also naming it in the Lumo-Event-Id and
+// Lumo-Event-Type headers
+func (w *WebhookSink) Publish(ctx context.Context, event *modeloutbox.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Lumo-Event-Id", event.EventID)
+	req.Header.Set("Lumo-Event-Type", event.Type)
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", res.Status)
+	}
+	return nil
+}
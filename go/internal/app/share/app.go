@@ -0,0 +1,188 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modelaccess "github.com/mcdev12/lumo/go/internal/models/access"
+	modellink "github.com/mcdev12/lumo/go/internal/models/link"
+	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+	modellumo "github.com/mcdev12/lumo/go/internal/models/lumo"
+	modelshare "github.com/mcdev12/lumo/go/internal/models/share"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Domain errors
+var (
+	ErrInvalidLumoID      = errors.New("invalid lumo ID")
+	ErrInvalidShareLinkID = errors.New("invalid share link ID")
+	ErrEmptyToken         = errors.New("share token is required")
+	ErrExpiryInPast       = errors.New("expiry must be in the future")
+
+	ErrShareLinkNotFound = modelshare.ErrShareLinkNotFound
+	ErrNotFound          = modelaccess.ErrNotFound
+	ErrPermissionDenied  = auth.ErrPermissionDenied
+)
+
+// ShareRepository defines what the app layer needs from the repository
+type ShareRepository interface {
+	CreateShareLink(ctx context.Context, link *modelshare.ShareLink) (*modelshare.ShareLink, error)
+	GetShareLinkByShareLinkID(ctx context.Context, shareLinkID string) (*modelshare.ShareLink, error)
+	ListShareLinksByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*modelshare.ShareLink, error)
+	RevokeShareLink(ctx context.Context, shareLinkID string) (*modelshare.ShareLink, error)
+	UseShareLink(ctx context.Context, token string) (*modelshare.ShareLink, error)
+}
+
+// LumoRepository defines what the app layer needs to read a shared Lumo
+type LumoRepository interface {
+	GetLumoByLumoID(ctx context.Context, lumoID string) (*modellumo.Lumo, error)
+}
+
+// LumeRepository defines what the app layer needs to read a Lumo's Lumes
+type LumeRepository interface {
+	ListLumesByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*modellume.Lume, error)
+}
+
+// LinkRepository defines what the app layer needs to read a Lumo's Links
+type LinkRepository interface {
+	ListLinksByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*modellink.Link, error)
+}
+
+// Authorizer checks the caller's role in a Lumo
+type Authorizer interface {
+	AuthorizeLumo(ctx context.Context, lumoID string, required modelaccess.Role) (*modelaccess.Owner, error)
+}
+
+// App handles business logic for share links
+type App struct {
+	repo     ShareRepository
+	lumoRepo LumoRepository
+	lumeRepo LumeRepository
+	linkRepo LinkRepository
+	authz    Authorizer
+}
+
+// NewShareApp creates a new Share App
+func NewShareApp(repo ShareRepository, lumoRepo LumoRepository, lumeRepo LumeRepository, linkRepo LinkRepository, authz Authorizer) *App {
+	return &App{
+		repo:     repo,
+		lumoRepo: lumoRepo,
+		lumeRepo: lumeRepo,
+		linkRepo: linkRepo,
+		authz:    authz,
+	}
+}
+
+// CreateShareLink creates a read-only link to a Lumo. The returned link is
+// the only place its token can be read from.
+func (a *App) CreateShareLink(ctx context.Context, req CreateShareLinkRequest) (_ *modelshare.ShareLink, err error) {
+	ctx, span := tracing.Start(ctx, "share.App.CreateShareLink")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(req.LumoID); err != nil {
+		return nil, ErrInvalidLumoID
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrExpiryInPast
+	}
+
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, modelaccess.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	var createdBy string
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		createdBy = identity.UserID
+	}
+
+	link, err := modelshare.NewShareLink(req.LumoID, createdBy, req.Policy, req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.repo.CreateShareLink(ctx, link)
+}
+
+// ListShareLinks lists the share links of a Lumo, revoked and expired ones included
+func (a *App) ListShareLinks(ctx context.Context, req ListShareLinksRequest) (_ []*modelshare.ShareLink, err error) {
+	ctx, span := tracing.Start(ctx, "share.App.ListShareLinks")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(req.LumoID); err != nil {
+		return nil, ErrInvalidLumoID
+	}
+
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, modelaccess.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	return a.repo.ListShareLinksByLumoID(ctx, req.LumoID, limit, req.Offset)
+}
+
+// RevokeShareLink stops a share link from working. Revoking a revoked link
+// keeps its original revocation time.
+func (a *App) RevokeShareLink(ctx context.Context, shareLinkID string) (_ *modelshare.ShareLink, err error) {
+	ctx, span := tracing.Start(ctx, "share.App.RevokeShareLink")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(shareLinkID); err != nil {
+		return nil, ErrInvalidShareLinkID
+	}
+
+	existing, err := a.repo.GetShareLinkByShareLinkID(ctx, shareLinkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := a.authz.AuthorizeLumo(ctx, existing.LumoID, modelaccess.RoleOwner); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			// Don't tell strangers the link exists
+			return nil, ErrShareLinkNotFound
+		}
+		return nil, err
+	}
+
+	return a.repo.RevokeShareLink(ctx, shareLinkID)
+}
+
+// GetSharedLumo returns the Lumo behind a share token, with its Lumes and
+// Links, redacted by the link's policy. It needs no identity: holding the
+// token is the permission. Every call counts as an access of the link.
+func (a *App) GetSharedLumo(ctx context.Context, token string) (_ *modelshare.SharedLumo, err error) {
+	ctx, span := tracing.Start(ctx, "share.App.GetSharedLumo")
+	defer tracing.End(span, &err)
+
+	if token == "" {
+		return nil, ErrEmptyToken
+	}
+
+	link, err := a.repo.UseShareLink(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	lumo, err := a.lumoRepo.GetLumoByLumoID(ctx, link.LumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	lumes, err := modellume.ListAll(ctx, a.lumeRepo, link.LumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := modellink.ListAll(ctx, a.linkRepo, link.LumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return redact(&modelshare.SharedLumo{Lumo: lumo, Lumes: lumes, Links: links}, link.Policy), nil
+}
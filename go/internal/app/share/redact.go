@@ -0,0 +1,37 @@
+package share
+
+import (
+	modelshare "github.com/mcdev12/lumo/go/internal/models/share"
+)
+
+// redact strips a shared Lumo of what the policy hides, along with what
+// anonymous viewers never get to see, such as the creator's user ID. The
+// entities are modified in place, so pass copies if the originals are still
+// needed.
+func redact(shared *modelshare.SharedLumo, policy modelshare.Policy) *modelshare.SharedLumo {
+	if shared.Lumo != nil {
+		shared.Lumo.UserID = ""
+	}
+
+	for _, l := range shared.Lumes {
+		if policy.HideBookingLinks {
+			l.BookingLink = nil
+		}
+		if policy.HideNotes {
+			l.Description = ""
+		}
+	}
+
+	for _, l := range shared.Links {
+		if policy.HideCosts && l.Travel != nil {
+			travel := *l.Travel
+			travel.CostEstimate = 0
+			l.Travel = &travel
+		}
+		if policy.HideNotes {
+			l.Notes = nil
+		}
+	}
+
+	return shared
+}
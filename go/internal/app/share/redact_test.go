@@ -0,0 +1,102 @@
+package share
+
+import (
+	"testing"
+
+	modellink "github.com/mcdev12/lumo/go/internal/models/link"
+	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+	modellumo "github.com/mcdev12/lumo/go/internal/models/lumo"
+	modelshare "github.com/mcdev12/lumo/go/internal/models/share"
+	"github.com/stretchr/testify/suite"
+)
+
+// RedactTestSuite is a test suite for share link redaction
+type RedactTestSuite struct {
+	suite.Suite
+	shared *modelshare.SharedLumo
+}
+
+// SetupTest is called before each test
+func (s *RedactTestSuite) SetupTest() {
+	lumo := modellumo.NewLumo("22222222-2222-2222-2222-222222222222", "Italy")
+
+	rome := modellume.NewLume(lumo.LumoID, modellume.LumeTypeCity, "Rome")
+	hotel := modellume.NewLume(lumo.LumoID, modellume.LumeTypeCity, "Hotel")
+	booking := "https://example.com/booking"
+	hotel.BookingLink = &booking
+	hotel.Description = "Ask for a room facing the courtyard"
+
+	notes := "Buy tickets the day before"
+	train := modellink.NewLink(rome.LumeID, hotel.LumeID, modellink.LinkTypeTravel)
+	train.Travel = &modellink.TravelDetails{Mode: modellink.TravelModeTrain, CostEstimate: 42}
+	train.Notes = &notes
+
+	s.shared = &modelshare.SharedLumo{
+		Lumo:  lumo,
+		Lumes: []*modellume.Lume{rome, hotel},
+		Links: []*modellink.Link{train},
+	}
+}
+
+// TestRedactSuite runs the test suite
+func TestRedactSuite(t *testing.T) {
+	suite.Run(t, new(RedactTestSuite))
+}
+
+// Test each policy flag hides exactly its fields
+func (s *RedactTestSuite) TestRedactPolicies() {
+	cases := []struct {
+		name        string
+		policy      modelshare.Policy
+		wantBooking bool
+		wantCost    bool
+		wantNotes   bool
+	}{
+		{"nothing hidden", modelshare.Policy{}, true, true, true},
+		{"hide booking links", modelshare.Policy{HideBookingLinks: true}, false, true, true},
+		{"hide costs", modelshare.Policy{HideCosts: true}, true, false, true},
+		{"hide notes", modelshare.Policy{HideNotes: true}, true, true, false},
+		{"hide everything", modelshare.Policy{HideBookingLinks: true, HideCosts: true, HideNotes: true}, false, false, false},
+	}
+
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			// Arrange
+			s.SetupTest()
+
+			// Act
+			shared := redact(s.shared, tc.policy)
+
+			// Assert
+			hotel, train := shared.Lumes[1], shared.Links[0]
+			s.Equal(tc.wantBooking, hotel.BookingLink != nil)
+			s.Equal(tc.wantCost, train.Travel.CostEstimate != 0)
+			s.Equal(tc.wantNotes, hotel.Description != "")
+			s.Equal(tc.wantNotes, train.Notes != nil)
+			s.Equal(modellink.TravelModeTrain, train.Travel.Mode)
+		})
+	}
+}
+
+// Test the creator's user ID is never shared
+func (s *RedactTestSuite) TestRedactHidesCreator() {
+	// Act
+	shared := redact(s.shared, modelshare.Policy{})
+
+	// Assert
+	s.Empty(shared.Lumo.UserID)
+	s.Equal("Italy", shared.Lumo.Title)
+}
+
+// Test hiding costs doesn't touch travel details shared with other callers
+func (s *RedactTestSuite) TestRedactCopiesTravelDetails() {
+	// Arrange
+	original := s.shared.Links[0].Travel
+
+	// Act
+	redact(s.shared, modelshare.Policy{HideCosts: true})
+
+	// Assert
+	s.Equal(float64(42), original.CostEstimate)
+	s.Zero(s.shared.Links[0].Travel.CostEstimate)
+}
@@ -0,0 +1,21 @@
+package share
+
+import (
+	"time"
+
+	modelshare "github.com/mcdev12/lumo/go/internal/models/share"
+)
+
+// CreateShareLinkRequest represents the business layer's create request
+type CreateShareLinkRequest struct {
+	LumoID    string
+	Policy    modelshare.Policy
+	ExpiresAt *time.Time
+}
+
+// ListShareLinksRequest represents the business layer's list request
+type ListShareLinksRequest struct {
+	LumoID string
+	Limit  int32
+	Offset int32
+}
@@ -0,0 +1,208 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modelaccess "github.com/mcdev12/lumo/go/internal/models/access"
+	modellink "github.com/mcdev12/lumo/go/internal/models/link"
+	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+	modellumo "github.com/mcdev12/lumo/go/internal/models/lumo"
+	modeltrash "github.com/mcdev12/lumo/go/internal/models/trash"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Domain errors
+var (
+	ErrInvalidLumoID     = errors.New("invalid lumo ID")
+	ErrInvalidUserID     = errors.New("invalid user ID")
+	ErrInvalidEntityID   = errors.New("invalid entity ID")
+	ErrInvalidEntityType = errors.New("invalid entity type")
+	ErrMissingTarget     = errors.New("exactly one of lumo ID and user ID is required")
+
+	ErrNotInTrash       = modeltrash.ErrNotInTrash
+	ErrParentInTrash    = modeltrash.ErrParentInTrash
+	ErrConflict         = modeltrash.ErrConflict
+	ErrNotFound         = modelaccess.ErrNotFound
+	ErrPermissionDenied = auth.ErrPermissionDenied
+)
+
+// TrashRepository defines what the app layer needs from the repository
+type TrashRepository interface {
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// LumoTrash defines the trashed Lumo operations the app layer needs
+type LumoTrash interface {
+	ListTrashedLumosByUserID(ctx context.Context, userID string, limit, offset int32) ([]*modellumo.Lumo, error)
+	RestoreLumoByLumoID(ctx context.Context, lumoID string) (*modellumo.Lumo, error)
+}
+
+// LumeTrash defines the trashed Lume operations the app layer needs
+type LumeTrash interface {
+	ListTrashedLumesByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*modellume.Lume, error)
+	RestoreLumeByLumeID(ctx context.Context, lumeID string) (*modellume.Lume, error)
+}
+
+// LinkTrash defines the trashed Link operations the app layer needs
+type LinkTrash interface {
+	ListTrashedLinksByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*modellink.Link, error)
+	RestoreLinkByLinkID(ctx context.Context, linkID string) (*modellink.Link, error)
+}
+
+// Authorizer checks the caller's role in the Lumo a trashed entity belongs to
+type Authorizer interface {
+	AuthorizeLumo(ctx context.Context, lumoID string, required modelaccess.Role) (*modelaccess.Owner, error)
+	AuthorizeLume(ctx context.Context, lumeID string, required modelaccess.Role) (*modelaccess.Owner, error)
+	AuthorizeLink(ctx context.Context, linkID string, required modelaccess.Role) (*modelaccess.Owner, error)
+}
+
+// App handles business logic for the trash
+type App struct {
+	repo  TrashRepository
+	lumos LumoTrash
+	lumes LumeTrash
+	links LinkTrash
+	authz Authorizer
+}
+
+// NewTrashApp creates a new Trash Service
+func NewTrashApp(repo TrashRepository, lumos LumoTrash, lumes LumeTrash, links LinkTrash, authz Authorizer) *App {
+	return &App{
+		repo:  repo,
+		lumos: lumos,
+		lumes: lumes,
+		links: links,
+		authz: authz,
+	}
+}
+
+// ListTrash retrieves what was deleted from a Lumo or by a user, most
+// recently deleted first. Lumes and Links that went along with a deleted
+// parent aren't listed; they come back when the parent is restored.
+func (a *App) ListTrash(ctx context.Context, req ListTrashRequest) (_ []*modeltrash.Item, err error) {
+	ctx, span := tracing.Start(ctx, "trash.App.ListTrash")
+	defer tracing.End(span, &err)
+
+	if (req.LumoID == "") == (req.UserID == "") {
+		return nil, ErrMissingTarget
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	if req.UserID != "" {
+		if _, err := uuid.Parse(req.UserID); err != nil {
+			return nil, ErrInvalidUserID
+		}
+		lumos, err := a.lumos.ListTrashedLumosByUserID(ctx, req.UserID, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]*modeltrash.Item, len(lumos))
+		for i, lumo := range lumos {
+			items[i] = modeltrash.LumoItem(lumo)
+		}
+		return items, nil
+	}
+
+	if _, err := uuid.Parse(req.LumoID); err != nil {
+		return nil, ErrInvalidLumoID
+	}
+	if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, modelaccess.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	// Lumes and Links are paged together, so both lists are read up to the
+	// end of the page and merged
+	lumes, err := a.lumes.ListTrashedLumesByLumoID(ctx, req.LumoID, offset+limit, 0)
+	if err != nil {
+		return nil, err
+	}
+	links, err := a.links.ListTrashedLinksByLumoID(ctx, req.LumoID, offset+limit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*modeltrash.Item, 0, len(lumes)+len(links))
+	for _, lume := range lumes {
+		items = append(items, modeltrash.LumeItem(lume))
+	}
+	for _, link := range links {
+		items = append(items, modeltrash.LinkItem(link))
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+
+	if int(offset) >= len(items) {
+		return []*modeltrash.Item{}, nil
+	}
+	end := int(offset + limit)
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end], nil
+}
+
+// Restore takes an entity out of the trash, along with whatever went with it
+func (a *App) Restore(ctx context.Context, req RestoreRequest) (_ *modeltrash.Item, err error) {
+	ctx, span := tracing.Start(ctx, "trash.App.Restore")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(req.EntityID); err != nil {
+		return nil, ErrInvalidEntityID
+	}
+
+	switch req.EntityType {
+	case modeltrash.EntityTypeLumo:
+		if _, err := a.authz.AuthorizeLumo(ctx, req.EntityID, modelaccess.RoleOwner); err != nil {
+			return nil, err
+		}
+		lumo, err := a.lumos.RestoreLumoByLumoID(ctx, req.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		return modeltrash.LumoItem(lumo), nil
+	case modeltrash.EntityTypeLume:
+		if _, err := a.authz.AuthorizeLume(ctx, req.EntityID, modelaccess.RoleEditor); err != nil {
+			return nil, err
+		}
+		lume, err := a.lumes.RestoreLumeByLumeID(ctx, req.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		return modeltrash.LumeItem(lume), nil
+	case modeltrash.EntityTypeLink:
+		if _, err := a.authz.AuthorizeLink(ctx, req.EntityID, modelaccess.RoleEditor); err != nil {
+			return nil, err
+		}
+		link, err := a.links.RestoreLinkByLinkID(ctx, req.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		return modeltrash.LinkItem(link), nil
+	default:
+		return nil, ErrInvalidEntityType
+	}
+}
+
+// Purge permanently deletes everything that has been in the trash longer
+// than the retention period
+func (a *App) Purge(ctx context.Context, retention time.Duration) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "trash.App.Purge")
+	defer tracing.End(span, &err)
+
+	return a.repo.Purge(ctx, time.Now().Add(-retention))
+}
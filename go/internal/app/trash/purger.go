@@ -0,0 +1,23 @@
+package trash
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Purger returns a worker emptying the trash of everything past the
+// retention period, to run with worker.Every. Failures are logged, the next
+// run retries.
+func Purger(app *App, retention time.Duration) func(context.Context) {
+	return func(ctx context.Context) {
+		purged, err := app.Purge(ctx, retention)
+		if err != nil {
+			slog.WarnContext(ctx, "trash purge failed", slog.Any("error", err))
+			return
+		}
+		if purged > 0 {
+			slog.InfoContext(ctx, "purged entities from the trash", slog.Int64("purged", purged))
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package trash
+
+import modeltrash "github.com/mcdev12/lumo/go/internal/models/trash"
+
+// ListTrashRequest represents the business layer's list request. Exactly one
+// of LumoID and UserID is set: a Lumo's trash holds its deleted Lumes and
+// Links, a user's trash holds their deleted Lumos.
+type ListTrashRequest struct {
+	LumoID string
+	UserID string
+	Limit  int32
+	Offset int32
+}
+
+// RestoreRequest represents the business layer's restore request
+type RestoreRequest struct {
+	EntityType modeltrash.EntityType
+	EntityID   string
+}
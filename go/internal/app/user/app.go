@@ -0,0 +1,229 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modeluser "github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Domain errors
+var (
+	ErrInvalidUserID       = errors.New("invalid user ID")
+	ErrInvalidEmail        = errors.New("invalid email")
+	ErrInvalidCurrency     = errors.New("home currency must be an ISO 4217 code")
+	ErrInvalidDistanceUnit = errors.New("invalid distance unit")
+	ErrInvalidTimeZone     = errors.New("unknown time zone")
+	ErrInvalidLocale       = errors.New("locale must be a BCP 47 language tag")
+
+	ErrUserNotFound     = modeluser.ErrUserNotFound
+	ErrUserExists       = modeluser.ErrUserExists
+	ErrEmailTaken       = modeluser.ErrEmailTaken
+	ErrPermissionDenied = auth.ErrPermissionDenied
+)
+
+// UserRepository defines what the app layer needs from the repository
+type UserRepository interface {
+	CreateUser(ctx context.Context, user *modeluser.User) (*modeluser.User, error)
+	GetUserByUserID(ctx context.Context, userID string) (*modeluser.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*modeluser.User, error)
+	UpdateUser(ctx context.Context, user *modeluser.User) (*modeluser.User, error)
+}
+
+// App handles business logic for users
+type App struct {
+	repo UserRepository
+}
+
+// NewUserApp creates a new User App
+func NewUserApp(repo UserRepository) *App {
+	return &App{
+		repo: repo,
+	}
+}
+
+// CreateUser creates the profile of a user. Authenticated callers can only
+// create their own.
+func (a *App) CreateUser(ctx context.Context, req CreateUserRequest) (_ *modeluser.User, err error) {
+	ctx, span := tracing.Start(ctx, "user.App.CreateUser")
+	defer tracing.End(span, &err)
+
+	userID, err := auth.ResolveUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != "" {
+		if _, err := uuid.Parse(userID); err != nil {
+			return nil, ErrInvalidUserID
+		}
+	}
+
+	newUser := modeluser.NewUser(userID, req.Email, req.DisplayName, req.Preferences)
+	if err := a.validateUser(newUser); err != nil {
+		return nil, err
+	}
+
+	return a.repo.CreateUser(ctx, newUser)
+}
+
+// GetUser retrieves a user by UUID. Callers get their own full profile, and
+// only the Public view of anyone else's.
+func (a *App) GetUser(ctx context.Context, userID string) (_ *modeluser.User, err error) {
+	ctx, span := tracing.Start(ctx, "user.App.GetUser")
+	defer tracing.End(span, &err)
+
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, ErrInvalidUserID
+	}
+
+	found, err := a.repo.GetUserByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return visibleTo(ctx, found), nil
+}
+
+// GetUserByEmail retrieves a user by email, ignoring case, e.g. to find the
+// ID of someone to share a Lumo with. Callers only get the Public view of
+// anyone but themselves.
+func (a *App) GetUserByEmail(ctx context.Context, email string) (_ *modeluser.User, err error) {
+	ctx, span := tracing.Start(ctx, "user.App.GetUserByEmail")
+	defer tracing.End(span, &err)
+
+	if !isValidEmail(modeluser.NormalizeEmail(email)) {
+		return nil, ErrInvalidEmail
+	}
+
+	found, err := a.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return visibleTo(ctx, found), nil
+}
+
+// visibleTo returns what the caller may see of a user: everything if it's
+// them, or if authentication is disabled, and the Public view otherwise
+func visibleTo(ctx context.Context, u *modeluser.User) *modeluser.User {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok || identity.UserID == u.UserID {
+		return u
+	}
+	return u.Public()
+}
+
+// UpdateUser updates the profile and preferences of a user. Authenticated
+// callers can only update their own.
+func (a *App) UpdateUser(ctx context.Context, req UpdateUserRequest) (_ *modeluser.User, err error) {
+	ctx, span := tracing.Start(ctx, "user.App.UpdateUser")
+	defer tracing.End(span, &err)
+
+	userID, err := auth.ResolveUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, ErrInvalidUserID
+	}
+
+	existing, err := a.repo.GetUserByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := a.updateDomainModel(existing, req)
+	if err := a.validateUser(updated); err != nil {
+		return nil, err
+	}
+
+	return a.repo.UpdateUser(ctx, updated)
+}
+
+// CallerPreferences returns the preferences of the calling user. Callers
+// without a profile, and requests without an identity, get the defaults.
+func (a *App) CallerPreferences(ctx context.Context) (_ modeluser.Preferences, err error) {
+	ctx, span := tracing.Start(ctx, "user.App.CallerPreferences")
+	defer tracing.End(span, &err)
+
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return modeluser.DefaultPreferences(), nil
+	}
+
+	caller, err := a.repo.GetUserByUserID(ctx, identity.UserID)
+	if errors.Is(err, ErrUserNotFound) {
+		return modeluser.DefaultPreferences(), nil
+	}
+	if err != nil {
+		return modeluser.Preferences{}, err
+	}
+
+	return caller.Preferences.WithDefaults(), nil
+}
+
+// updateDomainModel updates an existing domain model with values from the update request
+func (a *App) updateDomainModel(existingUser *modeluser.User, req UpdateUserRequest) *modeluser.User {
+	// Always update the UpdatedAt timestamp
+	existingUser.UpdatedAt = time.Now()
+
+	// If UpdateFields is empty, update all fields
+	if len(req.UpdateFields) == 0 {
+		existingUser.Email = modeluser.NormalizeEmail(req.Email)
+		existingUser.DisplayName = req.DisplayName
+		existingUser.Preferences = req.Preferences.WithDefaults()
+		return existingUser
+	}
+
+	// Otherwise, only update fields specified in UpdateFields
+	preferences := &existingUser.Preferences
+	for _, field := range req.UpdateFields {
+		switch field {
+		case "email":
+			existingUser.Email = modeluser.NormalizeEmail(req.Email)
+		case "display_name":
+			existingUser.DisplayName = req.DisplayName
+		case "preferences":
+			*preferences = req.Preferences.WithDefaults()
+		case "preferences.home_currency":
+			preferences.HomeCurrency = req.Preferences.HomeCurrency
+		case "preferences.distance_unit":
+			preferences.DistanceUnit = req.Preferences.DistanceUnit
+		case "preferences.time_zone":
+			preferences.TimeZone = req.Preferences.TimeZone
+		case "preferences.locale":
+			preferences.Locale = req.Preferences.Locale
+		}
+	}
+
+	return existingUser
+}
+
+// Validation methods
+func (a *App) validateUser(u *modeluser.User) error {
+	if !isValidEmail(u.Email) {
+		return ErrInvalidEmail
+	}
+	if !modeluser.IsValidCurrency(u.Preferences.HomeCurrency) {
+		return ErrInvalidCurrency
+	}
+	if !u.Preferences.DistanceUnit.IsValid() {
+		return ErrInvalidDistanceUnit
+	}
+	if !modeluser.IsValidTimeZone(u.Preferences.TimeZone) {
+		return ErrInvalidTimeZone
+	}
+	if !modeluser.IsValidLocale(u.Preferences.Locale) {
+		return ErrInvalidLocale
+	}
+	return nil
+}
+
+// isValidEmail reports whether email is a bare address such as "ada@example.com"
+func isValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modeluser "github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/stretchr/testify/suite"
+)
+
+// stubRepository holds a single user
+type stubRepository struct {
+	user *modeluser.User
+}
+
+// CreateUser isn't needed by the tests
+func (r *stubRepository) CreateUser(_ context.Context, u *modeluser.User) (*modeluser.User, error) {
+	return u, nil
+}
+
+// GetUserByUserID returns the user
+func (r *stubRepository) GetUserByUserID(context.Context, string) (*modeluser.User, error) {
+	copied := *r.user
+	return &copied, nil
+}
+
+// GetUserByEmail returns the user
+func (r *stubRepository) GetUserByEmail(context.Context, string) (*modeluser.User, error) {
+	copied := *r.user
+	return &copied, nil
+}
+
+// UpdateUser isn't needed by the tests
+func (r *stubRepository) UpdateUser(_ context.Context, u *modeluser.User) (*modeluser.User, error) {
+	return u, nil
+}
+
+// AppTestSuite is a test suite for the User App
+type AppTestSuite struct {
+	suite.Suite
+	app  *App
+	user *modeluser.User
+}
+
+// SetupTest is called before each test
+func (s *AppTestSuite) SetupTest() {
+	s.user = &modeluser.User{
+		ID:          1,
+		UserID:      "6f1c2f4e-8a53-4c3e-9d5b-2f3e1a7c9b10",
+		Email:       "ada@example.com",
+		DisplayName: "Ada",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	s.app = NewUserApp(&stubRepository{user: s.user})
+}
+
+// TestAppSuite runs the test suite
+func TestAppSuite(t *testing.T) {
+	suite.Run(t, new(AppTestSuite))
+}
+
+// Test users get their own full profile
+func (s *AppTestSuite) TestGetUserSelf() {
+	// Arrange
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: s.user.UserID})
+
+	// Act
+	found, err := s.app.GetUser(ctx, s.user.UserID)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(s.user, found)
+}
+
+// Test other users only get the public view of a profile
+func (s *AppTestSuite) TestGetUserOther() {
+	// Arrange
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: "someone-else"})
+
+	// Act
+	found, err := s.app.GetUser(ctx, s.user.UserID)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(&modeluser.User{UserID: s.user.UserID, DisplayName: "Ada"}, found)
+}
+
+// Test looking someone up by email resolves their ID without echoing their profile
+func (s *AppTestSuite) TestGetUserByEmailOther() {
+	// Arrange
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: "someone-else"})
+
+	// Act
+	found, err := s.app.GetUserByEmail(ctx, "Ada@Example.com")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(s.user.UserID, found.UserID)
+	s.Empty(found.Email)
+	s.Zero(found.Preferences)
+	s.True(found.CreatedAt.IsZero())
+}
@@ -0,0 +1,24 @@
+package user
+
+import modeluser "github.com/mcdev12/lumo/go/internal/models/user"
+
+// CreateUserRequest represents the business layer's create request
+type CreateUserRequest struct {
+	// Defaults to the caller
+	UserID      string
+	Email       string
+	DisplayName string
+	// Blank fields get the defaults
+	Preferences modeluser.Preferences
+}
+
+// UpdateUserRequest represents the business layer's update request
+type UpdateUserRequest struct {
+	// Defaults to the caller
+	UserID      string
+	Email       string
+	DisplayName string
+	Preferences modeluser.Preferences
+	// Fields to update (from field mask)
+	UpdateFields []string
+}
@@ -0,0 +1,329 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modelaccess "github.com/mcdev12/lumo/go/internal/models/access"
+	modeloutbox "github.com/mcdev12/lumo/go/internal/models/outbox"
+	modeluser "github.com/mcdev12/lumo/go/internal/models/user"
+	modelwebhook "github.com/mcdev12/lumo/go/internal/models/webhook"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Domain errors
+var (
+	ErrInvalidUserID     = errors.New("invalid user ID")
+	ErrInvalidLumoID     = errors.New("invalid lumo ID")
+	ErrInvalidWebhookID  = errors.New("invalid webhook ID")
+	ErrInvalidDeliveryID = errors.New("invalid webhook delivery ID")
+	ErrInvalidURL        = errors.New("webhook URL must be an absolute http or https URL")
+	ErrInvalidFilter     = errors.New("webhook filter values must be specified")
+
+	ErrWebhookNotFound  = modelwebhook.ErrWebhookNotFound
+	ErrDeliveryNotFound = modelwebhook.ErrDeliveryNotFound
+	ErrUserNotFound     = modeluser.ErrUserNotFound
+	ErrNotFound         = modelaccess.ErrNotFound
+	ErrPermissionDenied = auth.ErrPermissionDenied
+)
+
+// WebhookRepository defines what the app layer needs from the repository
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *modelwebhook.Webhook) (*modelwebhook.Webhook, error)
+	GetWebhookByWebhookID(ctx context.Context, webhookID string) (*modelwebhook.Webhook, error)
+	ListWebhooksByUserID(ctx context.Context, userID, lumoID string, limit, offset int32) ([]*modelwebhook.Webhook, error)
+	ListWebhooksForEvent(ctx context.Context, lumoID string) ([]*modelwebhook.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *modelwebhook.Webhook) (*modelwebhook.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	CreateDelivery(ctx context.Context, delivery *modelwebhook.Delivery) (*modelwebhook.Delivery, error)
+	GetDeliveryByDeliveryID(ctx context.Context, deliveryID string) (*modelwebhook.Delivery, error)
+	ListDeliveries(ctx context.Context, webhookID string, limit, offset int32) ([]*modelwebhook.Delivery, error)
+}
+
+// Authorizer checks the caller's role in a Lumo
+type Authorizer interface {
+	AuthorizeLumo(ctx context.Context, lumoID string, required modelaccess.Role) (*modelaccess.Owner, error)
+}
+
+// App handles business logic for webhooks
+type App struct {
+	repo  WebhookRepository
+	authz Authorizer
+}
+
+// NewWebhookApp creates a new Webhook App
+func NewWebhookApp(repo WebhookRepository, authz Authorizer) *App {
+	return &App{
+		repo:  repo,
+		authz: authz,
+	}
+}
+
+// CreateWebhook registers a webhook for the caller. Webhooks limited to a
+// Lumo can only be created by its members. The returned webhook is the only
+// place its signing secret can be read from.
+func (a *App) CreateWebhook(ctx context.Context, req CreateWebhookRequest) (_ *modelwebhook.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "webhook.App.CreateWebhook")
+	defer tracing.End(span, &err)
+
+	if err := requireFullAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	userID, err := auth.ResolveUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, ErrInvalidUserID
+	}
+
+	if !modelwebhook.IsValidURL(req.URL) {
+		return nil, ErrInvalidURL
+	}
+	if !req.Filter.IsValid() {
+		return nil, ErrInvalidFilter
+	}
+
+	if req.LumoID != "" {
+		if _, err := uuid.Parse(req.LumoID); err != nil {
+			return nil, ErrInvalidLumoID
+		}
+		if _, err := a.authz.AuthorizeLumo(ctx, req.LumoID, modelaccess.RoleViewer); err != nil {
+			return nil, err
+		}
+	}
+
+	webhook, err := modelwebhook.NewWebhook(userID, req.LumoID, req.URL, req.Description, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := a.repo.CreateWebhook(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	created.Secret = webhook.Secret
+	return created, nil
+}
+
+// ListWebhooks lists the webhooks of the caller, disabled ones included
+func (a *App) ListWebhooks(ctx context.Context, req ListWebhooksRequest) (_ []*modelwebhook.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "webhook.App.ListWebhooks")
+	defer tracing.End(span, &err)
+
+	if err := requireFullAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	userID, err := auth.ResolveUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, ErrInvalidUserID
+	}
+	if req.LumoID != "" {
+		if _, err := uuid.Parse(req.LumoID); err != nil {
+			return nil, ErrInvalidLumoID
+		}
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	return a.repo.ListWebhooksByUserID(ctx, userID, req.LumoID, limit, req.Offset)
+}
+
+// UpdateWebhook updates one of the caller's webhooks
+func (a *App) UpdateWebhook(ctx context.Context, req UpdateWebhookRequest) (_ *modelwebhook.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "webhook.App.UpdateWebhook")
+	defer tracing.End(span, &err)
+
+	existing, err := a.getOwnWebhook(ctx, req.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := a.updateDomainModel(existing, req)
+
+	if !modelwebhook.IsValidURL(updated.URL) {
+		return nil, ErrInvalidURL
+	}
+	if !updated.Filter.IsValid() {
+		return nil, ErrInvalidFilter
+	}
+
+	return a.repo.UpdateWebhook(ctx, updated)
+}
+
+// DeleteWebhook deletes one of the caller's webhooks together with its
+// delivery log
+func (a *App) DeleteWebhook(ctx context.Context, webhookID string) (err error) {
+	ctx, span := tracing.Start(ctx, "webhook.App.DeleteWebhook")
+	defer tracing.End(span, &err)
+
+	if _, err := a.getOwnWebhook(ctx, webhookID); err != nil {
+		return err
+	}
+
+	return a.repo.DeleteWebhook(ctx, webhookID)
+}
+
+// ListDeliveries lists the deliveries to one of the caller's webhooks, newest
+// first
+func (a *App) ListDeliveries(ctx context.Context, req ListDeliveriesRequest) (_ []*modelwebhook.Delivery, err error) {
+	ctx, span := tracing.Start(ctx, "webhook.App.ListDeliveries")
+	defer tracing.End(span, &err)
+
+	if _, err := a.getOwnWebhook(ctx, req.WebhookID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	return a.repo.ListDeliveries(ctx, req.WebhookID, limit, req.Offset)
+}
+
+// RedeliverDelivery queues the event of a delivery to one of the caller's
+// webhooks once more, as a new delivery. It is sent even if the original one
+// succeeded, but not before the webhook is enabled.
+func (a *App) RedeliverDelivery(ctx context.Context, deliveryID string) (_ *modelwebhook.Delivery, err error) {
+	ctx, span := tracing.Start(ctx, "webhook.App.RedeliverDelivery")
+	defer tracing.End(span, &err)
+
+	if err := requireFullAccess(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(deliveryID); err != nil {
+		return nil, ErrInvalidDeliveryID
+	}
+
+	delivery, err := a.repo.GetDeliveryByDeliveryID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.getOwnWebhook(ctx, delivery.WebhookID); errors.Is(err, ErrWebhookNotFound) {
+		return nil, ErrDeliveryNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return a.repo.CreateDelivery(ctx, delivery.Redeliver())
+}
+
+// Dispatch queues a delivery of an event to every enabled webhook whose
+// filter it passes. It is meant to be subscribed to the outbox event bus, so
+// an error has the event dispatched again later; webhooks it was already
+// queued for are skipped then.
+func (a *App) Dispatch(ctx context.Context, event *modeloutbox.Event) (err error) {
+	ctx, span := tracing.Start(ctx, "webhook.App.Dispatch")
+	defer tracing.End(span, &err)
+
+	webhooks, err := a.repo.ListWebhooksForEvent(ctx, event.LumoID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, webhook := range webhooks {
+		if !webhook.Filter.Matches(event) {
+			continue
+		}
+		_, err := a.repo.CreateDelivery(ctx, modelwebhook.NewDelivery(webhook.WebhookID, event, payload))
+		if err != nil && !errors.Is(err, modelwebhook.ErrDeliveryExists) {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", webhook.WebhookID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// getOwnWebhook retrieves a webhook the caller may manage. Webhooks of other
+// users are reported as ErrWebhookNotFound.
+func (a *App) getOwnWebhook(ctx context.Context, webhookID string) (*modelwebhook.Webhook, error) {
+	if err := requireFullAccess(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return nil, ErrInvalidWebhookID
+	}
+
+	webhook, err := a.repo.GetWebhookByWebhookID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity, ok := auth.IdentityFromContext(ctx); ok && identity.UserID != webhook.UserID {
+		return nil, ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+// Helper method to update domain model from request
+func (a *App) updateDomainModel(existingWebhook *modelwebhook.Webhook, req UpdateWebhookRequest) *modelwebhook.Webhook {
+	// Always update the UpdatedAt timestamp
+	existingWebhook.UpdatedAt = time.Now()
+
+	// If UpdateFields is empty, update all fields
+	if len(req.UpdateFields) == 0 {
+		existingWebhook.URL = req.URL
+		existingWebhook.Description = req.Description
+		existingWebhook.Filter = req.Filter
+		setEnabled(existingWebhook, req.Enabled)
+		return existingWebhook
+	}
+
+	// Otherwise, only update fields specified in UpdateFields
+	for _, field := range req.UpdateFields {
+		switch field {
+		case "url":
+			existingWebhook.URL = req.URL
+		case "description":
+			existingWebhook.Description = req.Description
+		case "filter":
+			existingWebhook.Filter = req.Filter
+		case "enabled":
+			setEnabled(existingWebhook, req.Enabled)
+		}
+	}
+
+	return existingWebhook
+}
+
+// setEnabled enables or disables a webhook, leaving one that already is as
+// it is
+func setEnabled(webhook *modelwebhook.Webhook, enabled bool) {
+	switch {
+	case enabled && !webhook.Enabled():
+		webhook.Enable()
+	case !enabled:
+		webhook.Disable()
+	}
+}
+
+// requireFullAccess turns away callers whose key has a narrower scope, so a
+// leaked key can't be used to have events sent elsewhere
+func requireFullAccess(ctx context.Context) error {
+	if identity, ok := auth.IdentityFromContext(ctx); ok && !identity.FullAccess() {
+		return ErrPermissionDenied
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modelhistory "github.com/mcdev12/lumo/go/internal/models/history"
+	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+	modeloutbox "github.com/mcdev12/lumo/go/internal/models/outbox"
+	modelwebhook "github.com/mcdev12/lumo/go/internal/models/webhook"
+	"github.com/stretchr/testify/suite"
+)
+
+// stubRepository keeps webhooks and deliveries in memory, remembering how
+// each attempt went
+type stubRepository struct {
+	webhooks   map[string]*modelwebhook.Webhook
+	deliveries []*modelwebhook.Delivery
+	pending    []*modelwebhook.Delivery
+	delivered  map[int64]int32
+	retries    map[int64]time.Time
+	failed     map[int64]string
+}
+
+// newStubRepository creates an empty stubRepository
+func newStubRepository() *stubRepository {
+	return &stubRepository{
+		webhooks:  map[string]*modelwebhook.Webhook{},
+		delivered: map[int64]int32{},
+		retries:   map[int64]time.Time{},
+		failed:    map[int64]string{},
+	}
+}
+
+// CreateWebhook stores a webhook
+func (r *stubRepository) CreateWebhook(_ context.Context, webhook *modelwebhook.Webhook) (*modelwebhook.Webhook, error) {
+	r.webhooks[webhook.WebhookID] = webhook
+	return webhook, nil
+}
+
+// GetWebhookByWebhookID returns a stored webhook
+func (r *stubRepository) GetWebhookByWebhookID(_ context.Context, webhookID string) (*modelwebhook.Webhook, error) {
+	webhook, ok := r.webhooks[webhookID]
+	if !ok {
+		return nil, modelwebhook.ErrWebhookNotFound
+	}
+	copied := *webhook
+	return &copied, nil
+}
+
+// ListWebhooksByUserID isn't needed by the tests
+func (r *stubRepository) ListWebhooksByUserID(context.Context, string, string, int32, int32) ([]*modelwebhook.Webhook, error) {
+	return nil, nil
+}
+
+// ListWebhooksForEvent returns the enabled webhooks of the event's Lumo or of no Lumo
+func (r *stubRepository) ListWebhooksForEvent(_ context.Context, lumoID string) ([]*modelwebhook.Webhook, error) {
+	var webhooks []*modelwebhook.Webhook
+	for _, webhook := range r.webhooks {
+		if webhook.Enabled() && (webhook.LumoID == "" || webhook.LumoID == lumoID) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook stores a webhook
+func (r *stubRepository) UpdateWebhook(_ context.Context, webhook *modelwebhook.Webhook) (*modelwebhook.Webhook, error) {
+	r.webhooks[webhook.WebhookID] = webhook
+	return webhook, nil
+}
+
+// DeleteWebhook removes a webhook
+func (r *stubRepository) DeleteWebhook(_ context.Context, webhookID string) error {
+	delete(r.webhooks, webhookID)
+	return nil
+}
+
+// CreateDelivery queues a delivery unless the event is already queued for the webhook
+func (r *stubRepository) CreateDelivery(_ context.Context, delivery *modelwebhook.Delivery) (*modelwebhook.Delivery, error) {
+	for _, existing := range r.deliveries {
+		if delivery.RedeliveryOf == "" && existing.RedeliveryOf == "" &&
+			existing.WebhookID == delivery.WebhookID && existing.EventID == delivery.EventID {
+			return nil, modelwebhook.ErrDeliveryExists
+		}
+	}
+	delivery.ID = int64(len(r.deliveries) + 1)
+	r.deliveries = append(r.deliveries, delivery)
+	r.pending = append(r.pending, delivery)
+	return delivery, nil
+}
+
+// GetDeliveryByDeliveryID returns a queued delivery
+func (r *stubRepository) GetDeliveryByDeliveryID(_ context.Context, deliveryID string) (*modelwebhook.Delivery, error) {
+	for _, delivery := range r.deliveries {
+		if delivery.DeliveryID == deliveryID {
+			return delivery, nil
+		}
+	}
+	return nil, modelwebhook.ErrDeliveryNotFound
+}
+
+// ListDeliveries isn't needed by the tests
+func (r *stubRepository) ListDeliveries(context.Context, string, int32, int32) ([]*modelwebhook.Delivery, error) {
+	return nil, nil
+}
+
+// ClaimDeliveries returns the deliveries not claimed yet, counting the attempt
+func (r *stubRepository) ClaimDeliveries(_ context.Context, _ time.Duration, batchSize int32) ([]*modelwebhook.Delivery, error) {
+	n := min(int(batchSize), len(r.pending))
+	claimed := r.pending[:n]
+	r.pending = r.pending[n:]
+	for _, delivery := range claimed {
+		delivery.Attempts++
+	}
+	return claimed, nil
+}
+
+// MarkDelivered records a delivered delivery
+func (r *stubRepository) MarkDelivered(_ context.Context, id int64, responseCode int32) error {
+	r.delivered[id] = responseCode
+	return nil
+}
+
+// MarkRetry records a delivery to retry
+func (r *stubRepository) MarkRetry(_ context.Context, id int64, nextAttemptAt time.Time, _ int32, _ string) error {
+	r.retries[id] = nextAttemptAt
+	return nil
+}
+
+// MarkFailed records a delivery given up on
+func (r *stubRepository) MarkFailed(_ context.Context, id int64, _ int32, lastError string) error {
+	r.failed[id] = lastError
+	return nil
+}
+
+// RecordSuccess resets the failures of a webhook
+func (r *stubRepository) RecordSuccess(_ context.Context, webhookID string) error {
+	r.webhooks[webhookID].ConsecutiveFailures = 0
+	return nil
+}
+
+// RecordFailure counts a failure of a webhook, disabling it at disableAfter
+func (r *stubRepository) RecordFailure(_ context.Context, webhookID string, disableAfter int32) (*modelwebhook.Webhook, error) {
+	webhook := r.webhooks[webhookID]
+	webhook.ConsecutiveFailures++
+	if webhook.ConsecutiveFailures >= disableAfter {
+		webhook.Disable()
+	}
+	copied := *webhook
+	return &copied, nil
+}
+
+// AppTestSuite is a test suite for the App
+type AppTestSuite struct {
+	suite.Suite
+	repo   *stubRepository
+	app    *App
+	userID string
+	lumoID string
+}
+
+// SetupTest is called before each test
+func (s *AppTestSuite) SetupTest() {
+	s.repo = newStubRepository()
+	s.app = NewWebhookApp(s.repo, nil)
+	s.userID = uuid.New().String()
+	s.lumoID = uuid.New().String()
+}
+
+// TestAppSuite runs the test suite
+func TestAppSuite(t *testing.T) {
+	suite.Run(t, new(AppTestSuite))
+}
+
+// addWebhook stores an enabled webhook of the test user
+func (s *AppTestSuite) addWebhook(lumoID string, filter modelwebhook.Filter) *modelwebhook.Webhook {
+	webhook, err := modelwebhook.NewWebhook(s.userID, lumoID, "https://example.com/hooks", "", filter)
+	s.Require().NoError(err)
+	s.repo.webhooks[webhook.WebhookID] = webhook
+	return webhook
+}
+
+// newEvent creates an event of the test Lumo
+func (s *AppTestSuite) newEvent(eventType string, payload string) *modeloutbox.Event {
+	return &modeloutbox.Event{
+		EventID:  uuid.New().String(),
+		Type:     eventType,
+		LumoID:   s.lumoID,
+		EntityID: uuid.New().String(),
+		Payload:  json.RawMessage(payload),
+	}
+}
+
+// Test Dispatch queues an event only for the webhooks whose filter it passes
+func (s *AppTestSuite) TestDispatchFilters() {
+	// Arrange
+	all := s.addWebhook("", modelwebhook.Filter{})
+	cities := s.addWebhook(s.lumoID, modelwebhook.Filter{LumeTypes: []modellume.LumeType{modellume.LumeTypeCity}})
+	links := s.addWebhook(s.lumoID, modelwebhook.Filter{EntityTypes: []modelhistory.EntityType{modelhistory.EntityTypeLink}})
+	s.addWebhook(uuid.New().String(), modelwebhook.Filter{})
+	event := s.newEvent("LumeCreated", `{"type":"LUME_TYPE_CITY"}`)
+
+	// Act
+	err := s.app.Dispatch(context.Background(), event)
+
+	// Assert
+	s.NoError(err)
+	var webhookIDs []string
+	for _, delivery := range s.repo.deliveries {
+		webhookIDs = append(webhookIDs, delivery.WebhookID)
+		s.Equal(event.EventID, delivery.EventID)
+		s.Equal("LumeCreated", delivery.EventType)
+		s.Equal(modelwebhook.StatusPending, delivery.Status)
+		s.JSONEq(`{"id":"`+event.EventID+`","type":"LumeCreated","lumo_id":"`+s.lumoID+`","entity_id":"`+event.EntityID+`","data":{"type":"LUME_TYPE_CITY"},"occurred_at":"0001-01-01T00:00:00Z"}`, string(delivery.Payload))
+	}
+	s.ElementsMatch([]string{all.WebhookID, cities.WebhookID}, webhookIDs)
+	s.NotContains(webhookIDs, links.WebhookID)
+}
+
+// Test dispatching an event again doesn't queue it twice
+func (s *AppTestSuite) TestDispatchTwice() {
+	// Arrange
+	s.addWebhook("", modelwebhook.Filter{})
+	event := s.newEvent("LumoUpdated", `{}`)
+	s.Require().NoError(s.app.Dispatch(context.Background(), event))
+
+	// Act
+	err := s.app.Dispatch(context.Background(), event)
+
+	// Assert
+	s.NoError(err)
+	s.Len(s.repo.deliveries, 1)
+}
+
+// Test a delivery can be sent again, but only by the webhook's user
+func (s *AppTestSuite) TestRedeliverDelivery() {
+	// Arrange
+	webhook := s.addWebhook("", modelwebhook.Filter{})
+	s.Require().NoError(s.app.Dispatch(context.Background(), s.newEvent("LinkDeleted", `{}`)))
+	original := s.repo.deliveries[0]
+	owner := auth.WithIdentity(context.Background(), &auth.Identity{UserID: s.userID})
+	stranger := auth.WithIdentity(context.Background(), &auth.Identity{UserID: uuid.New().String()})
+
+	// Act
+	redelivery, err := s.app.RedeliverDelivery(owner, original.DeliveryID)
+	_, strangerErr := s.app.RedeliverDelivery(stranger, original.DeliveryID)
+
+	// Assert
+	s.NoError(err)
+	s.NotEqual(original.DeliveryID, redelivery.DeliveryID)
+	s.Equal(original.DeliveryID, redelivery.RedeliveryOf)
+	s.Equal(webhook.WebhookID, redelivery.WebhookID)
+	s.Equal(original.Payload, redelivery.Payload)
+	s.ErrorIs(strangerErr, ErrDeliveryNotFound)
+	s.Len(s.repo.deliveries, 2)
+}
+
+// Test enabling a disabled webhook clears its failures
+func (s *AppTestSuite) TestUpdateWebhookEnable() {
+	// Arrange
+	webhook := s.addWebhook("", modelwebhook.Filter{})
+	webhook.ConsecutiveFailures = 20
+	webhook.Disable()
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: s.userID})
+
+	// Act
+	updated, err := s.app.UpdateWebhook(ctx, UpdateWebhookRequest{
+		WebhookID:    webhook.WebhookID,
+		Enabled:      true,
+		UpdateFields: []string{"enabled"},
+	})
+
+	// Assert
+	s.NoError(err)
+	s.True(updated.Enabled())
+	s.Zero(updated.ConsecutiveFailures)
+	s.Equal("https://example.com/hooks", updated.URL)
+}
+
+// Test webhooks can't be managed with narrower keys
+func (s *AppTestSuite) TestCreateWebhookRequiresFullAccess() {
+	// Arrange
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: s.userID, ReadOnly: true})
+
+	// Act
+	webhook, err := s.app.CreateWebhook(ctx, CreateWebhookRequest{URL: "https://example.com/hooks"})
+
+	// Assert
+	s.ErrorIs(err, ErrPermissionDenied)
+	s.Nil(webhook)
+}
+
+// Test CreateWebhook rejects URLs events can't be POSTed to
+func (s *AppTestSuite) TestCreateWebhookInvalidURL() {
+	// Arrange
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: s.userID})
+
+	for _, url := range []string{"", "example.com/hooks", "ftp://example.com/hooks", "https:///hooks"} {
+		// Act
+		webhook, err := s.app.CreateWebhook(ctx, CreateWebhookRequest{URL: url})
+
+		// Assert
+		s.ErrorIs(err, ErrInvalidURL, url)
+		s.Nil(webhook)
+	}
+}
@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	outboxApp "github.com/mcdev12/lumo/go/internal/app/outbox"
+	modelwebhook "github.com/mcdev12/lumo/go/internal/models/webhook"
+)
+
+const (
+	// batchSize is how many deliveries the deliverer leases at once
+	batchSize = 100
+	// lease is how long other deliverers leave leased deliveries alone, long
+	// enough to send a whole batch
+	lease = 5 * time.Minute
+)
+
+// DeliveryRepository defines what the deliverer needs from the repository
+type DeliveryRepository interface {
+	GetWebhookByWebhookID(ctx context.Context, webhookID string) (*modelwebhook.Webhook, error)
+	ClaimDeliveries(ctx context.Context, lease time.Duration, batchSize int32) ([]*modelwebhook.Delivery, error)
+	MarkDelivered(ctx context.Context, id int64, responseCode int32) error
+	MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, responseCode int32, lastError string) error
+	MarkFailed(ctx context.Context, id int64, responseCode int32, lastError string) error
+	RecordSuccess(ctx context.Context, webhookID string) error
+	RecordFailure(ctx context.Context, webhookID string, disableAfter int32) (*modelwebhook.Webhook, error)
+}
+
+// Deliverer POSTs the queued deliveries to their webhooks, signed with the
+// webhook's secret. Any 2xx response counts as delivered; otherwise the
+// delivery is retried with exponential backoff until maxAttempts is reached.
+// A webhook is disabled once disableAfter attempts in a row failed, across
+// all of its deliveries. Several deliverers can share the queue, each
+// delivery is leased to one of them.
+type Deliverer struct {
+	repo         DeliveryRepository
+	client       *http.Client
+	maxAttempts  int32
+	disableAfter int32
+}
+
+// NewDeliverer creates a new Deliverer
+func NewDeliverer(repo DeliveryRepository, client *http.Client, maxAttempts, disableAfter int32) *Deliverer {
+	return &Deliverer{
+		repo:         repo,
+		client:       client,
+		maxAttempts:  maxAttempts,
+		disableAfter: disableAfter,
+	}
+}
+
+// DeliverDue sends the deliveries that are due until there are none left,
+// logging rather than failing so the next run can retry. It's run on an
+// interval with worker.Every.
+func (d *Deliverer) DeliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		deliveries, err := d.repo.ClaimDeliveries(ctx, lease, batchSize)
+		if err != nil {
+			slog.WarnContext(ctx, "webhook deliverer failed to claim deliveries", slog.Any("error", err))
+			return
+		}
+
+		// Deliveries of a batch often go to the same few webhooks
+		webhooks := make(map[string]*modelwebhook.Webhook)
+		for _, delivery := range deliveries {
+			webhook, ok := webhooks[delivery.WebhookID]
+			if !ok {
+				webhook, err = d.repo.GetWebhookByWebhookID(ctx, delivery.WebhookID)
+				if err != nil {
+					// The lease runs out and the delivery is tried again
+					slog.WarnContext(ctx, "webhook deliverer failed to look up webhook", slog.String("webhook_id", delivery.WebhookID), slog.Any("error", err))
+					continue
+				}
+				webhooks[delivery.WebhookID] = webhook
+			}
+			if !webhook.Enabled() {
+				// Disabled while this batch was sent, leave the rest queued
+				continue
+			}
+			if updated := d.deliver(ctx, webhook, delivery); updated != nil {
+				webhooks[delivery.WebhookID] = updated
+			}
+		}
+
+		if len(deliveries) < batchSize {
+			return
+		}
+	}
+}
+
+// deliver sends one delivery and records how it went, returning the webhook
+// as it is after a failure
+func (d *Deliverer) deliver(ctx context.Context, webhook *modelwebhook.Webhook, delivery *modelwebhook.Delivery) *modelwebhook.Webhook {
+	responseCode, sendErr := d.send(ctx, webhook, delivery)
+
+	if sendErr == nil {
+		if err := d.repo.MarkDelivered(ctx, delivery.ID, responseCode); err != nil {
+			slog.WarnContext(ctx, "webhook deliverer failed to record delivery", slog.String("delivery_id", delivery.DeliveryID), slog.Any("error", err))
+		}
+		if webhook.ConsecutiveFailures > 0 {
+			if err := d.repo.RecordSuccess(ctx, webhook.WebhookID); err != nil {
+				slog.WarnContext(ctx, "webhook deliverer failed to reset failures", slog.String("webhook_id", webhook.WebhookID), slog.Any("error", err))
+			}
+			webhook.ConsecutiveFailures = 0
+		}
+		return nil
+	}
+
+	var err error
+	if delivery.Attempts >= d.maxAttempts {
+		slog.WarnContext(ctx, "giving up on webhook delivery", slog.String("delivery_id", delivery.DeliveryID), slog.Int("attempts", int(delivery.Attempts)), slog.Any("error", sendErr))
+		err = d.repo.MarkFailed(ctx, delivery.ID, responseCode, sendErr.Error())
+	} else {
+		err = d.repo.MarkRetry(ctx, delivery.ID, time.Now().Add(outboxApp.Backoff(delivery.Attempts)), responseCode, sendErr.Error())
+	}
+	if err != nil {
+		// The lease runs out and the delivery is tried again
+		slog.WarnContext(ctx, "webhook deliverer failed to record delivery", slog.String("delivery_id", delivery.DeliveryID), slog.Any("error", err))
+	}
+
+	updated, err := d.repo.RecordFailure(ctx, webhook.WebhookID, d.disableAfter)
+	if err != nil {
+		slog.WarnContext(ctx, "webhook deliverer failed to count failure", slog.String("webhook_id", webhook.WebhookID), slog.Any("error", err))
+		return nil
+	}
+	if !updated.Enabled() && webhook.Enabled() {
+		slog.InfoContext(ctx, "disabled webhook after failed attempts in a row", slog.String("webhook_id", webhook.WebhookID), slog.Int("failures", int(updated.ConsecutiveFailures)))
+	}
+	return updated
+}
+
+// send POSTs a delivery's payload, returning the response status if there
+// was a response
+func (d *Deliverer) send(ctx context.Context, webhook *modelwebhook.Webhook, delivery *modelwebhook.Delivery) (int32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Lumo-Event-Id", delivery.EventID)
+	req.Header.Set("Lumo-Event-Type", delivery.EventType)
+	req.Header.Set("Lumo-Delivery-Id", delivery.DeliveryID)
+	req.Header.Set(modelwebhook.SignatureHeader, modelwebhook.Sign(webhook.Secret, time.Now(), delivery.Payload))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return int32(res.StatusCode), fmt.Errorf("webhook responded %s", res.Status)
+	}
+	return int32(res.StatusCode), nil
+}
@@ -0,0 +1,227 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	modeloutbox "github.com/mcdev12/lumo/go/internal/models/outbox"
+	modelwebhook "github.com/mcdev12/lumo/go/internal/models/webhook"
+	"github.com/stretchr/testify/suite"
+)
+
+// receivedRequest is what the test endpoint got
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// DelivererTestSuite is a test suite for the Deliverer
+type DelivererTestSuite struct {
+	suite.Suite
+	repo      *stubRepository
+	server    *httptest.Server
+	status    int
+	received  []receivedRequest
+	webhook   *modelwebhook.Webhook
+	deliverer *Deliverer
+}
+
+// SetupTest is called before each test
+func (s *DelivererTestSuite) SetupTest() {
+	s.repo = newStubRepository()
+	s.status = http.StatusNoContent
+	s.received = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.received = append(s.received, receivedRequest{header: r.Header.Clone(), body: body})
+		w.Header().Set("Location", "/redirected")
+		w.WriteHeader(s.status)
+	}))
+
+	webhook, err := modelwebhook.NewWebhook(uuid.New().String(), "", s.server.URL, "", modelwebhook.Filter{})
+	s.Require().NoError(err)
+	s.repo.webhooks[webhook.WebhookID] = webhook
+	s.webhook = webhook
+
+	s.deliverer = NewDeliverer(s.repo, s.server.Client(), 3, 5)
+}
+
+// TearDownTest is called after each test
+func (s *DelivererTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// TestDelivererSuite runs the test suite
+func TestDelivererSuite(t *testing.T) {
+	suite.Run(t, new(DelivererTestSuite))
+}
+
+// queue adds a pending delivery of a new event to the test webhook
+func (s *DelivererTestSuite) queue() *modelwebhook.Delivery {
+	event := &modeloutbox.Event{EventID: uuid.New().String(), Type: "LumeUpdated"}
+	delivery, err := s.repo.CreateDelivery(context.Background(), modelwebhook.NewDelivery(s.webhook.WebhookID, event, []byte(`{"type":"LumeUpdated"}`)))
+	s.Require().NoError(err)
+	return delivery
+}
+
+// Test a delivery is POSTed signed with the webhook's secret
+func (s *DelivererTestSuite) TestDeliverSigned() {
+	// Arrange
+	delivery := s.queue()
+	s.webhook.ConsecutiveFailures = 2
+
+	// Act
+	s.deliverer.DeliverDue(context.Background())
+
+	// Assert
+	s.Require().Len(s.received, 1)
+	req := s.received[0]
+	s.Equal(`{"type":"LumeUpdated"}`, string(req.body))
+	s.Equal("application/json", req.header.Get("Content-Type"))
+	s.Equal(delivery.EventID, req.header.Get("Lumo-Event-Id"))
+	s.Equal("LumeUpdated", req.header.Get("Lumo-Event-Type"))
+	s.Equal(delivery.DeliveryID, req.header.Get("Lumo-Delivery-Id"))
+	s.NoError(modelwebhook.Verify(s.webhook.Secret, req.header.Get(modelwebhook.SignatureHeader), req.body, time.Minute))
+	s.ErrorIs(modelwebhook.Verify("whsec_other", req.header.Get(modelwebhook.SignatureHeader), req.body, time.Minute), modelwebhook.ErrInvalidSignature)
+
+	s.Equal(map[int64]int32{delivery.ID: http.StatusNoContent}, s.repo.delivered)
+	s.Zero(s.webhook.ConsecutiveFailures)
+}
+
+// Test failed deliveries are retried with backoff until attempts run out
+func (s *DelivererTestSuite) TestDeliverRetries() {
+	// Arrange
+	s.status = http.StatusServiceUnavailable
+	retried := s.queue()
+	exhausted := s.queue()
+	exhausted.Attempts = 2
+
+	// Act
+	before := time.Now()
+	s.deliverer.DeliverDue(context.Background())
+
+	// Assert
+	s.Len(s.received, 2)
+	s.Empty(s.repo.delivered)
+	s.Require().Contains(s.repo.retries, retried.ID)
+	s.WithinDuration(before.Add(time.Second), s.repo.retries[retried.ID], time.Second)
+	s.Equal("webhook responded 503 Service Unavailable", s.repo.failed[exhausted.ID])
+	s.Equal(int32(2), s.webhook.ConsecutiveFailures)
+	s.True(s.webhook.Enabled())
+}
+
+// Test a webhook that keeps failing is disabled and the rest of its
+// deliveries stay queued
+func (s *DelivererTestSuite) TestDeliverDisables() {
+	// Arrange
+	s.status = http.StatusInternalServerError
+	s.webhook.ConsecutiveFailures = 4
+	s.queue()
+	skipped := s.queue()
+
+	// Act
+	s.deliverer.DeliverDue(context.Background())
+
+	// Assert
+	s.Len(s.received, 1)
+	s.False(s.webhook.Enabled())
+	s.NotContains(s.repo.retries, skipped.ID)
+	s.NotContains(s.repo.failed, skipped.ID)
+}
+
+// Test an unreachable endpoint counts as a failed attempt without a response
+func (s *DelivererTestSuite) TestDeliverUnreachable() {
+	// Arrange
+	delivery := s.queue()
+	s.server.Close()
+
+	// Act
+	s.deliverer.DeliverDue(context.Background())
+
+	// Assert
+	s.Contains(s.repo.retries, delivery.ID)
+	s.Equal(int32(1), s.webhook.ConsecutiveFailures)
+}
+
+// Test webhooks on loopback addresses are refused without being sent
+func (s *DelivererTestSuite) TestDeliverRefusesPrivateAddress() {
+	// Arrange
+	s.deliverer.client = NewHTTPClient(time.Second, false)
+	delivery := s.queue()
+	delivery.Attempts = 2
+
+	// Act
+	s.deliverer.DeliverDue(context.Background())
+
+	// Assert
+	s.Empty(s.received)
+	s.Empty(s.repo.delivered)
+	s.Contains(s.repo.failed[delivery.ID], "webhook address is not allowed: 127.0.0.1")
+}
+
+// Test private addresses can be allowed, e.g. for local receivers
+func (s *DelivererTestSuite) TestDeliverAllowsPrivateAddress() {
+	// Arrange
+	s.deliverer.client = NewHTTPClient(time.Second, true)
+	delivery := s.queue()
+
+	// Act
+	s.deliverer.DeliverDue(context.Background())
+
+	// Assert
+	s.Len(s.received, 1)
+	s.Equal(map[int64]int32{delivery.ID: http.StatusNoContent}, s.repo.delivered)
+}
+
+// Test redirects aren't followed and count as failed attempts
+func (s *DelivererTestSuite) TestDeliverIgnoresRedirect() {
+	// Arrange
+	s.deliverer.client = NewHTTPClient(time.Second, true)
+	s.status = http.StatusTemporaryRedirect
+	delivery := s.queue()
+	delivery.Attempts = 2
+
+	// Act
+	s.deliverer.DeliverDue(context.Background())
+
+	// Assert
+	s.Len(s.received, 1)
+	s.Equal("webhook responded 307 Temporary Redirect", s.repo.failed[delivery.ID])
+}
+
+// Test which dialed addresses are refused
+func (s *DelivererTestSuite) TestDenyPrivate() {
+	tests := []struct {
+		address string
+		allowed bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:5432", false},
+		{"[::1]:80", false},
+		{"[::ffff:127.0.0.1]:80", false},
+		{"169.254.169.254:80", false},
+		{"10.1.2.3:80", false},
+		{"172.16.0.1:80", false},
+		{"192.168.1.1:80", false},
+		{"[fd00::1]:80", false},
+		{"0.0.0.0:80", false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.address, func() {
+			err := denyPrivate("tcp", tt.address, nil)
+
+			if tt.allowed {
+				s.NoError(err)
+			} else {
+				s.ErrorIs(err, ErrForbiddenAddress)
+			}
+		})
+	}
+}
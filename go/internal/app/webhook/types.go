@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	modelwebhook "github.com/mcdev12/lumo/go/internal/models/webhook"
+)
+
+// CreateWebhookRequest represents the business layer's create request
+type CreateWebhookRequest struct {
+	// Optional, defaults to the caller
+	UserID string
+	// Optional, only send the events of this Lumo
+	LumoID      string
+	URL         string
+	Description string
+	Filter      modelwebhook.Filter
+}
+
+// ListWebhooksRequest represents the business layer's list request
+type ListWebhooksRequest struct {
+	// Optional, defaults to the caller
+	UserID string
+	// Optional, only list the webhooks of this Lumo
+	LumoID string
+	Limit  int32
+	Offset int32
+}
+
+// UpdateWebhookRequest represents the business layer's update request
+type UpdateWebhookRequest struct {
+	WebhookID   string
+	URL         string
+	Description string
+	Filter      modelwebhook.Filter
+	Enabled     bool
+	// Fields to update, all of them if empty
+	UpdateFields []string
+}
+
+// ListDeliveriesRequest represents the business layer's list deliveries request
+type ListDeliveriesRequest struct {
+	WebhookID string
+	Limit     int32
+	Offset    int32
+}
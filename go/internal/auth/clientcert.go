@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+)
+
+// ClientCertAuthenticator authenticates internal services by the TLS client
+// certificate they connect with. Each service is known by a name its
+// verified certificate carries, as common name or as DNS or URI subject
+// alternative name, and acts as the user it is mapped to.
+type ClientCertAuthenticator struct {
+	users map[string]string
+}
+
+// NewClientCertAuthenticator creates a ClientCertAuthenticator for services,
+// which maps service names to the user IDs they act as
+func NewClientCertAuthenticator(services map[string]string) *ClientCertAuthenticator {
+	return &ClientCertAuthenticator{users: services}
+}
+
+// Middleware puts the Identity of a caller whose verified client certificate
+// names a known service in the request context. The Interceptor
+// authenticates requests without a bearer token as that caller.
+func (a *ClientCertAuthenticator) Middleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+			if identity, ok := a.identify(r.TLS.VerifiedChains[0][0]); ok {
+				r = r.WithContext(withClientCertIdentity(r.Context(), identity))
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// identify returns the Identity of the service a certificate names
+func (a *ClientCertAuthenticator) identify(cert *x509.Certificate) (*Identity, bool) {
+	names := append([]string{cert.Subject.CommonName}, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+
+	for _, name := range names {
+		if userID, ok := a.users[name]; ok && name != "" {
+			return &Identity{UserID: userID, Service: name}, true
+		}
+	}
+	return nil, false
+}
+
+// clientCertIdentityKey is the context key of the Identity of a client
+// certificate
+type clientCertIdentityKey struct{}
+
+// withClientCertIdentity returns a context carrying the Identity of the
+// caller's client certificate
+func withClientCertIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, clientCertIdentityKey{}, identity)
+}
+
+// clientCertIdentity returns the Identity stored by withClientCertIdentity
+func clientCertIdentity(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(clientCertIdentityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
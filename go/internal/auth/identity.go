@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrPermissionDenied is returned when a request names a user other than the caller
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Identity is the authenticated caller of a request
+type Identity struct {
+	// UserID is the UUID of the calling user, taken from the token subject
+	UserID string
+
+	// Claims are the verified claims of the caller's token, nil for API keys
+	Claims *Claims
+
+	// APIKeyID is the UUID of the API key the caller authenticated with, if any
+	APIKeyID string
+
+	// ReadOnly callers may only call procedures without side effects
+	ReadOnly bool
+
+	// LumoID, if set, is the only Lumo the caller may reach
+	LumoID string
+
+	// Service is the name of the internal service the caller authenticated
+	// as with a client certificate, if any
+	Service string
+}
+
+// CanReach reports whether the caller's credential extends to the given Lumo
+func (i *Identity) CanReach(lumoID string) bool {
+	return i.LumoID == "" || i.LumoID == lumoID
+}
+
+// FullAccess reports whether the caller may do anything their user may
+func (i *Identity) FullAccess() bool {
+	return !i.ReadOnly && i.LumoID == ""
+}
+
+// identityKey is the context key of the caller's Identity
+type identityKey struct{}
+
+// WithIdentity returns a context carrying the caller's Identity
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the Identity stored by WithIdentity
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
+
+// ResolveUserID returns the user a request acts for. Authenticated callers
+// always act for themselves: an empty user ID is filled in with theirs and any
+// other user ID is denied, as is acting for the user with a credential limited
+// to one Lumo. Without an Identity, i.e. when authentication is disabled, the
+// requested user ID is used as is.
+func ResolveUserID(ctx context.Context, requested string) (string, error) {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok {
+		return requested, nil
+	}
+	if identity.LumoID != "" {
+		return "", ErrPermissionDenied
+	}
+	if requested != "" && requested != identity.UserID {
+		return "", ErrPermissionDenied
+	}
+	return identity.UserID, nil
+}
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	"github.com/mcdev12/lumo/go/internal/models/apikey"
+	"github.com/mcdev12/lumo/go/internal/models/history"
+)
+
+// APIKeyAuthenticator looks up the caller an API key secret belongs to
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, secret string) (*Identity, error)
+}
+
+// Interceptor authenticates Connect requests with a JWT bearer token, or an
+// API key, in the Authorization header, or else with a client certificate
+// the ClientCertAuthenticator middleware identified. The caller's Identity is put in the
+// context, and their user ID is recorded as the actor of any changes they
+// make. Read-only API keys can only call procedures without side effects.
+type Interceptor struct {
+	verifier *Verifier
+	apiKeys  APIKeyAuthenticator
+
+	// Procedures anyone may call without a token
+	public map[string]bool
+}
+
+// NewInterceptor creates a new authentication Interceptor. API keys are
+// turned away if apiKeys is nil. The given public procedures, such as
+// "/share.v1.ShareService/GetSharedLumo", are let through without
+// authentication.
+func NewInterceptor(verifier *Verifier, apiKeys APIKeyAuthenticator, publicProcedures ...string) *Interceptor {
+	public := make(map[string]bool, len(publicProcedures))
+	for _, procedure := range publicProcedures {
+		public[procedure] = true
+	}
+
+	return &Interceptor{
+		verifier: verifier,
+		apiKeys:  apiKeys,
+		public:   public,
+	}
+}
+
+// WrapUnary authenticates unary requests
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient || i.public[req.Spec().Procedure] {
+			return next(ctx, req)
+		}
+		ctx, err := i.authenticate(ctx, req.Spec(), req.Header())
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient leaves outgoing streams alone
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler authenticates streaming requests
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if i.public[conn.Spec().Procedure] {
+			return next(ctx, conn)
+		}
+		ctx, err := i.authenticate(ctx, conn.Spec(), conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// authenticate verifies the bearer token and returns a context carrying the caller
+func (i *Interceptor) authenticate(ctx context.Context, spec connect.Spec, header http.Header) (context.Context, error) {
+	token, ok := bearerToken(header)
+	identity, service := clientCertIdentity(ctx)
+	if !ok && !service {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing bearer token"))
+	}
+
+	switch {
+	case !ok:
+		// The caller is an internal service known by its client certificate
+	case apikey.IsSecret(token):
+		var err error
+		if identity, err = i.authenticateAPIKey(ctx, token); err != nil {
+			return nil, err
+		}
+	default:
+		claims, err := i.verifier.Verify(ctx, token)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		}
+		identity = &Identity{
+			UserID: claims.Subject,
+			Claims: claims,
+		}
+	}
+
+	if identity.ReadOnly && spec.IdempotencyLevel != connect.IdempotencyNoSideEffects {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("api key is read-only"))
+	}
+
+	ctx = WithIdentity(ctx, identity)
+	return history.WithActor(ctx, identity.UserID), nil
+}
+
+// authenticateAPIKey looks up the caller of an API key secret
+func (i *Interceptor) authenticateAPIKey(ctx context.Context, secret string) (*Identity, error) {
+	if i.apiKeys == nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("api keys are not accepted"))
+	}
+
+	identity, err := i.apiKeys.AuthenticateAPIKey(ctx, secret)
+	if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid api key"))
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return identity, nil
+}
+
+// bearerToken extracts the token of an "Authorization: Bearer <token>" header
+func bearerToken(header http.Header) (string, bool) {
+	scheme, token, ok := strings.Cut(header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
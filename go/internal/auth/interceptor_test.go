@@ -0,0 +1,315 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/mcdev12/lumo/go/internal/models/apikey"
+	"github.com/mcdev12/lumo/go/internal/models/history"
+)
+
+// InterceptorTestSuite is a test suite for the Interceptor
+type InterceptorTestSuite struct {
+	suite.Suite
+	interceptor *Interceptor
+}
+
+// SetupTest is called before each test
+func (s *InterceptorTestSuite) SetupTest() {
+	verifier, err := NewVerifier(VerifierConfig{HS256Secret: testSecret})
+	s.Require().NoError(err)
+	s.interceptor = NewInterceptor(verifier, nil)
+}
+
+// TestInterceptorSuite runs the test suite
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorTestSuite))
+}
+
+// Helper function to call a unary handler through the interceptor and capture its context
+func (s *InterceptorTestSuite) call(authorization string) (context.Context, error) {
+	var handlerCtx context.Context
+	next := func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		handlerCtx = ctx
+		return nil, nil
+	}
+
+	req := connect.NewRequest(&struct{}{})
+	if authorization != "" {
+		req.Header().Set("Authorization", authorization)
+	}
+
+	_, err := s.interceptor.WrapUnary(next)(context.Background(), req)
+	return handlerCtx, err
+}
+
+// Test a valid bearer token puts the caller in the context
+func (s *InterceptorTestSuite) TestAuthenticated() {
+	userID := uuid.New().String()
+	token := signHS256(testSecret, map[string]any{
+		"sub": userID,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	ctx, err := s.call("Bearer " + token)
+
+	s.Require().NoError(err)
+	identity, ok := IdentityFromContext(ctx)
+	s.Require().True(ok)
+	s.Equal(userID, identity.UserID)
+	actor, ok := history.ActorFromContext(ctx)
+	s.True(ok)
+	s.Equal(userID, actor)
+
+	resolved, err := ResolveUserID(ctx, "")
+	s.NoError(err)
+	s.Equal(userID, resolved)
+
+	_, err = ResolveUserID(ctx, uuid.New().String())
+	s.ErrorIs(err, ErrPermissionDenied)
+}
+
+// Test requests without a valid bearer token are rejected
+func (s *InterceptorTestSuite) TestUnauthenticated() {
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{"missing header", ""},
+		{"wrong scheme", "Basic dXNlcjpwYXNz"},
+		{"empty token", "Bearer "},
+		{"invalid token", "Bearer not-a-token"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ctx, err := s.call(tt.authorization)
+
+			s.Equal(connect.CodeUnauthenticated, connect.CodeOf(err))
+			s.Nil(ctx)
+		})
+	}
+}
+
+// Test public procedures are served without a token, and others still aren't
+func (s *InterceptorTestSuite) TestPublicProcedures() {
+	const publicProcedure = "/test.v1.TestService/Public"
+	const privateProcedure = "/test.v1.TestService/Private"
+
+	verifier, err := NewVerifier(VerifierConfig{HS256Secret: testSecret})
+	s.Require().NoError(err)
+	interceptor := NewInterceptor(verifier, nil, publicProcedure)
+
+	handle := func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+		_, ok := IdentityFromContext(ctx)
+		s.False(ok)
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle(publicProcedure, connect.NewUnaryHandler(publicProcedure, handle, connect.WithInterceptors(interceptor)))
+	mux.Handle(privateProcedure, connect.NewUnaryHandler(privateProcedure, handle, connect.WithInterceptors(interceptor)))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	call := func(procedure string) error {
+		client := connect.NewClient[emptypb.Empty, emptypb.Empty](server.Client(), server.URL+procedure)
+		_, err := client.CallUnary(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+		return err
+	}
+
+	s.NoError(call(publicProcedure))
+	s.Equal(connect.CodeUnauthenticated, connect.CodeOf(call(privateProcedure)))
+}
+
+// Test ResolveUserID trusts the request when authentication is disabled
+func (s *InterceptorTestSuite) TestResolveUserIDWithoutIdentity() {
+	userID := uuid.New().String()
+
+	resolved, err := ResolveUserID(context.Background(), userID)
+
+	s.NoError(err)
+	s.Equal(userID, resolved)
+}
+
+// stubAPIKeys authenticates the API key secrets it was given
+type stubAPIKeys map[string]*Identity
+
+// AuthenticateAPIKey returns the Identity of a known secret
+func (k stubAPIKeys) AuthenticateAPIKey(_ context.Context, secret string) (*Identity, error) {
+	identity, ok := k[secret]
+	if !ok {
+		return nil, apikey.ErrAPIKeyNotFound
+	}
+	return identity, nil
+}
+
+// Test API keys authenticate their user within the key's scope
+func (s *InterceptorTestSuite) TestAPIKeys() {
+	const readProcedure = "/test.v1.TestService/Read"
+	const writeProcedure = "/test.v1.TestService/Write"
+
+	userID := uuid.New().String()
+	lumoID := uuid.New().String()
+	keys := stubAPIKeys{
+		"lumo_full":     {UserID: userID, APIKeyID: uuid.New().String()},
+		"lumo_readonly": {UserID: userID, APIKeyID: uuid.New().String(), ReadOnly: true},
+		"lumo_lumo":     {UserID: userID, APIKeyID: uuid.New().String(), LumoID: lumoID},
+	}
+
+	verifier, err := NewVerifier(VerifierConfig{HS256Secret: testSecret})
+	s.Require().NoError(err)
+	interceptor := NewInterceptor(verifier, keys)
+
+	handle := func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+		identity, ok := IdentityFromContext(ctx)
+		s.True(ok)
+		s.Equal(userID, identity.UserID)
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle(readProcedure, connect.NewUnaryHandler(readProcedure, handle,
+		connect.WithInterceptors(interceptor), connect.WithIdempotency(connect.IdempotencyNoSideEffects)))
+	mux.Handle(writeProcedure, connect.NewUnaryHandler(writeProcedure, handle, connect.WithInterceptors(interceptor)))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	call := func(procedure, secret string) error {
+		client := connect.NewClient[emptypb.Empty, emptypb.Empty](server.Client(), server.URL+procedure)
+		req := connect.NewRequest(&emptypb.Empty{})
+		req.Header().Set("Authorization", "Bearer "+secret)
+		_, err := client.CallUnary(context.Background(), req)
+		return err
+	}
+
+	tests := []struct {
+		name      string
+		procedure string
+		secret    string
+		wantCode  connect.Code
+	}{
+		{"full key reads", readProcedure, "lumo_full", 0},
+		{"full key writes", writeProcedure, "lumo_full", 0},
+		{"read-only key reads", readProcedure, "lumo_readonly", 0},
+		{"read-only key writes", writeProcedure, "lumo_readonly", connect.CodePermissionDenied},
+		{"lumo key writes", writeProcedure, "lumo_lumo", 0},
+		{"unknown key", readProcedure, "lumo_unknown", connect.CodeUnauthenticated},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := call(tt.procedure, tt.secret)
+
+			if tt.wantCode == 0 {
+				s.NoError(err)
+				return
+			}
+			s.Equal(tt.wantCode, connect.CodeOf(err))
+		})
+	}
+}
+
+// Test API keys are turned away when the interceptor doesn't accept them
+func (s *InterceptorTestSuite) TestAPIKeysNotAccepted() {
+	ctx, err := s.call("Bearer lumo_secret")
+
+	s.Equal(connect.CodeUnauthenticated, connect.CodeOf(err))
+	s.Nil(ctx)
+}
+
+// Test credentials limited to one Lumo can't act for their user elsewhere
+func (s *InterceptorTestSuite) TestLumoScope() {
+	lumoID := uuid.New().String()
+	identity := &Identity{UserID: uuid.New().String(), LumoID: lumoID}
+	ctx := WithIdentity(context.Background(), identity)
+
+	s.True(identity.CanReach(lumoID))
+	s.False(identity.CanReach(uuid.New().String()))
+	s.False(identity.FullAccess())
+
+	_, err := ResolveUserID(ctx, "")
+	s.ErrorIs(err, ErrPermissionDenied)
+}
+
+// Test internal services are authenticated by their verified client
+// certificate unless they send a bearer token
+func (s *InterceptorTestSuite) TestClientCertificates() {
+	serviceUserID := uuid.New().String()
+	authenticator := NewClientCertAuthenticator(map[string]string{
+		"importer":                        serviceUserID,
+		"spiffe://lumo.internal/exporter": serviceUserID,
+	})
+
+	// requestContext returns the context the middleware gives a request
+	// whose TLS client verified the certificate
+	requestContext := func(cert *x509.Certificate) context.Context {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if cert != nil {
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		}
+		var ctx context.Context
+		authenticator.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			ctx = r.Context()
+		})).ServeHTTP(httptest.NewRecorder(), req)
+		return ctx
+	}
+	exporterURI, err := url.Parse("spiffe://lumo.internal/exporter")
+	s.Require().NoError(err)
+
+	tokenUserID := uuid.New().String()
+	token := signHS256(testSecret, map[string]any{
+		"sub": tokenUserID,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	tests := []struct {
+		name          string
+		cert          *x509.Certificate
+		authorization string
+		wantUserID    string
+		wantService   string
+	}{
+		{"common name", &x509.Certificate{Subject: pkix.Name{CommonName: "importer"}}, "", serviceUserID, "importer"},
+		{"URI name", &x509.Certificate{URIs: []*url.URL{exporterURI}}, "", serviceUserID, "spiffe://lumo.internal/exporter"},
+		{"bearer token wins", &x509.Certificate{Subject: pkix.Name{CommonName: "importer"}}, "Bearer " + token, tokenUserID, ""},
+		{"unknown service", &x509.Certificate{Subject: pkix.Name{CommonName: "stranger"}}, "", "", ""},
+		{"no certificate", nil, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var handlerCtx context.Context
+			next := func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				handlerCtx = ctx
+				return nil, nil
+			}
+			req := connect.NewRequest(&struct{}{})
+			if tt.authorization != "" {
+				req.Header().Set("Authorization", tt.authorization)
+			}
+
+			_, err := s.interceptor.WrapUnary(next)(requestContext(tt.cert), req)
+
+			if tt.wantUserID == "" {
+				s.Equal(connect.CodeUnauthenticated, connect.CodeOf(err))
+				return
+			}
+			s.Require().NoError(err)
+			identity, ok := IdentityFromContext(handlerCtx)
+			s.Require().True(ok)
+			s.Equal(tt.wantUserID, identity.UserID)
+			s.Equal(tt.wantService, identity.Service)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrUnknownKey is returned when no key in the set matches a token's kid
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// minRefreshInterval limits how often an unknown kid triggers a reload, so
+// tokens with made-up key IDs can't hammer the JWKS source
+const minRefreshInterval = time.Minute
+
+// fetchTimeout bounds a reload of the JWKS source. Reloads don't use the
+// context of the request that triggered them, as they serve every request.
+const fetchTimeout = 10 * time.Second
+
+// jwk is a single JSON Web Key. Only RSA signing keys are used.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// KeySet holds the RSA public keys of a JWKS loaded from a local file or an
+// http(s) URL. It reloads the source when a token names a key it doesn't
+// know, which picks up key rotation.
+type KeySet struct {
+	source string
+	client *http.Client
+
+	// reloads shares a reload between the requests that ask for one
+	reloads singleflight.Group
+
+	mu       sync.RWMutex
+	keys     map[string]*rsa.PublicKey
+	loadedAt time.Time
+}
+
+// LoadKeySet loads a JWKS from a file path or an http(s) URL
+func LoadKeySet(ctx context.Context, source string) (*KeySet, error) {
+	keySet := &KeySet{
+		source: source,
+		client: &http.Client{Timeout: fetchTimeout},
+	}
+	if err := keySet.load(ctx); err != nil {
+		return nil, err
+	}
+	return keySet, nil
+}
+
+// Key returns the key with the given ID. An empty kid is accepted when the
+// set holds exactly one key. Requests for an unknown kid wait for a single
+// shared reload, while lookups of known keys never wait on the source.
+func (k *KeySet) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	k.mu.RLock()
+	key, ok := k.lookup(kid)
+	recent := time.Since(k.loadedAt) < minRefreshInterval
+	k.mu.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+	if recent {
+		return nil, ErrUnknownKey
+	}
+
+	reload := k.reloads.DoChan("reload", func() (any, error) {
+		return nil, k.reload()
+	})
+	select {
+	case result := <-reload:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if key, ok := k.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// reload loads the source again unless another reload just did
+func (k *KeySet) reload() error {
+	k.mu.RLock()
+	recent := time.Since(k.loadedAt) < minRefreshInterval
+	k.mu.RUnlock()
+	if recent {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	return k.load(ctx)
+}
+
+// lookup finds a key without reloading. Callers must hold mu.
+func (k *KeySet) lookup(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// load reads the source and replaces the keys. The source is read without
+// holding mu, and the time of the load is only recorded once it succeeded.
+func (k *KeySet) load(ctx context.Context) error {
+	data, err := k.read(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read JWKS from %s: %w", k.source, err)
+	}
+
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse JWKS from %s: %w", k.source, err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = keys
+	k.loadedAt = time.Now()
+
+	return nil
+}
+
+// read returns the raw JWKS document from the file or URL
+func (k *KeySet) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(k.source, "http://") && !strings.HasPrefix(k.source, "https://") {
+		return os.ReadFile(k.source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+// ParseJWKS returns the RSA signing keys of a JWKS document by key ID
+func ParseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range doc.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") || (key.Alg != "" && key.Alg != AlgRS256) {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: invalid modulus: %w", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: invalid exponent: %w", key.Kid, err)
+		}
+
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("key %q: invalid exponent", key.Kid)
+		}
+
+		keys[key.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(exponent.Int64()),
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no RSA signing keys")
+	}
+	return keys, nil
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidToken is returned for malformed tokens and bad signatures
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrTokenExpired is returned for tokens past their expiry or not yet valid
+	ErrTokenExpired = errors.New("token expired or not yet valid")
+
+	// ErrNoKeys is returned when a Verifier is configured without any keys
+	ErrNoKeys = errors.New("either an HS256 secret or a JWKS is required")
+)
+
+// Supported signing algorithms
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+)
+
+// Claims are the registered JWT claims the server relies on
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+}
+
+// Audience is the aud claim, which may be a single string or a list
+type Audience []string
+
+// UnmarshalJSON accepts both forms of the aud claim
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// Contains reports whether the audience includes the given value
+func (a Audience) Contains(value string) bool {
+	for _, aud := range a {
+		if aud == value {
+			return true
+		}
+	}
+	return false
+}
+
+// header is the JOSE header of a token
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// VerifierConfig configures how tokens are verified. At least one of
+// HS256Secret and KeySet must be set.
+type VerifierConfig struct {
+	// HS256Secret verifies HS256 tokens
+	HS256Secret []byte
+
+	// KeySet verifies RS256 tokens
+	KeySet *KeySet
+
+	// Issuer, if set, must match the iss claim
+	Issuer string
+
+	// Audience, if set, must be among the aud claim
+	Audience string
+
+	// Leeway allows for clock skew when checking exp and nbf
+	Leeway time.Duration
+}
+
+// Verifier checks JWT bearer tokens
+type Verifier struct {
+	config VerifierConfig
+	now    func() time.Time
+}
+
+// NewVerifier creates a new Verifier
+func NewVerifier(config VerifierConfig) (*Verifier, error) {
+	if len(config.HS256Secret) == 0 && config.KeySet == nil {
+		return nil, ErrNoKeys
+	}
+	return &Verifier{
+		config: config,
+		now:    time.Now,
+	}, nil
+}
+
+// Verify checks a token's signature and claims and returns its claims. The
+// subject must be the caller's user UUID.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var hdr header
+	if err := decodeSegment(parts[0], &hdr); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidToken)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+
+	if err := v.verifySignature(ctx, hdr, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+
+	if err := v.validateClaims(&claims); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
+
+// verifySignature checks the signature with the key matching the token's algorithm
+func (v *Verifier) verifySignature(ctx context.Context, hdr header, signingInput string, signature []byte) error {
+	switch hdr.Alg {
+	case AlgHS256:
+		if len(v.config.HS256Secret) == 0 {
+			return fmt.Errorf("%w: HS256 tokens are not accepted", ErrInvalidToken)
+		}
+		mac := hmac.New(sha256.New, v.config.HS256Secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+	case AlgRS256:
+		if v.config.KeySet == nil {
+			return fmt.Errorf("%w: RS256 tokens are not accepted", ErrInvalidToken)
+		}
+		key, err := v.config.KeySet.Key(ctx, hdr.Kid)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, hdr.Alg)
+	}
+}
+
+// validateClaims checks the time window, issuer, audience and subject
+func (v *Verifier) validateClaims(claims *Claims) error {
+	now := v.now()
+
+	if claims.ExpiresAt == 0 {
+		return fmt.Errorf("%w: missing exp claim", ErrInvalidToken)
+	}
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(v.config.Leeway)) {
+		return ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Before(time.Unix(claims.NotBefore, 0).Add(-v.config.Leeway)) {
+		return ErrTokenExpired
+	}
+
+	if v.config.Issuer != "" && claims.Issuer != v.config.Issuer {
+		return fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if v.config.Audience != "" && !claims.Audience.Contains(v.config.Audience) {
+		return fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+
+	if _, err := uuid.Parse(claims.Subject); err != nil {
+		return fmt.Errorf("%w: subject is not a user ID", ErrInvalidToken)
+	}
+
+	return nil
+}
+
+// decodeSegment decodes a base64url JSON segment of a token
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
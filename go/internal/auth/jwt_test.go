@@ -0,0 +1,277 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+var testSecret = []byte("test-secret")
+
+// JWTTestSuite is a test suite for the Verifier
+type JWTTestSuite struct {
+	suite.Suite
+	rsaKey   *rsa.PrivateKey
+	keySet   *KeySet
+	userID   string
+	now      time.Time
+	verifier *Verifier
+}
+
+// SetupSuite generates the RSA key once, it's slow
+func (s *JWTTestSuite) SetupSuite() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.rsaKey = key
+}
+
+// SetupTest is called before each test
+func (s *JWTTestSuite) SetupTest() {
+	path := filepath.Join(s.T().TempDir(), "jwks.json")
+	s.Require().NoError(os.WriteFile(path, createTestJWKS("key-1", &s.rsaKey.PublicKey), 0o600))
+
+	keySet, err := LoadKeySet(context.Background(), path)
+	s.Require().NoError(err)
+	s.keySet = keySet
+
+	verifier, err := NewVerifier(VerifierConfig{
+		HS256Secret: testSecret,
+		KeySet:      keySet,
+		Issuer:      "https://issuer.example.com",
+		Audience:    "lumo",
+	})
+	s.Require().NoError(err)
+
+	s.now = time.Unix(1_700_000_000, 0)
+	verifier.now = func() time.Time { return s.now }
+	s.verifier = verifier
+	s.userID = uuid.New().String()
+}
+
+// TestJWTSuite runs the test suite
+func TestJWTSuite(t *testing.T) {
+	suite.Run(t, new(JWTTestSuite))
+}
+
+// Helper function to create valid claims for the test user
+func (s *JWTTestSuite) validClaims() map[string]any {
+	return map[string]any{
+		"sub": s.userID,
+		"iss": "https://issuer.example.com",
+		"aud": []string{"other", "lumo"},
+		"exp": s.now.Add(time.Hour).Unix(),
+		"iat": s.now.Unix(),
+	}
+}
+
+// Helper function to encode a JWT segment
+func encodeSegment(v any) string {
+	data, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Helper function to create an HS256 token
+func signHS256(secret []byte, claims map[string]any) string {
+	signingInput := encodeSegment(map[string]string{"alg": AlgHS256, "typ": "JWT"}) + "." + encodeSegment(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Helper function to create an RS256 token
+func signRS256(key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	signingInput := encodeSegment(map[string]string{"alg": AlgRS256, "typ": "JWT", "kid": kid}) + "." + encodeSegment(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// Helper function to create a JWKS document holding one RSA key
+func createTestJWKS(kid string, key *rsa.PublicKey) []byte {
+	doc, _ := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"alg": AlgRS256,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	return doc
+}
+
+// Test NewVerifier without keys
+func (s *JWTTestSuite) TestNewVerifierWithoutKeys() {
+	verifier, err := NewVerifier(VerifierConfig{})
+
+	s.ErrorIs(err, ErrNoKeys)
+	s.Nil(verifier)
+}
+
+// Test Verify with a valid HS256 token
+func (s *JWTTestSuite) TestVerifyHS256() {
+	claims, err := s.verifier.Verify(context.Background(), signHS256(testSecret, s.validClaims()))
+
+	s.NoError(err)
+	s.Equal(s.userID, claims.Subject)
+	s.Equal(Audience{"other", "lumo"}, claims.Audience)
+}
+
+// Test Verify with a valid RS256 token from the JWKS
+func (s *JWTTestSuite) TestVerifyRS256() {
+	claims, err := s.verifier.Verify(context.Background(), signRS256(s.rsaKey, "key-1", s.validClaims()))
+
+	s.NoError(err)
+	s.Equal(s.userID, claims.Subject)
+}
+
+// Test Verify accepts a single string audience
+func (s *JWTTestSuite) TestVerifySingleAudience() {
+	claims := s.validClaims()
+	claims["aud"] = "lumo"
+
+	_, err := s.verifier.Verify(context.Background(), signHS256(testSecret, claims))
+
+	s.NoError(err)
+}
+
+// Test Verify rejects bad tokens
+func (s *JWTTestSuite) TestVerifyRejects() {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	withClaim := func(name string, value any) map[string]any {
+		claims := s.validClaims()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"malformed", "not-a-token", ErrInvalidToken},
+		{"wrong secret", signHS256([]byte("other-secret"), s.validClaims()), ErrInvalidToken},
+		{"wrong RSA key", signRS256(otherKey, "key-1", s.validClaims()), ErrInvalidToken},
+		{"unknown kid", signRS256(s.rsaKey, "key-2", s.validClaims()), ErrInvalidToken},
+		{"alg none", encodeSegment(map[string]string{"alg": "none"}) + "." + encodeSegment(s.validClaims()) + ".", ErrInvalidToken},
+		{"expired", signHS256(testSecret, withClaim("exp", s.now.Add(-time.Minute).Unix())), ErrTokenExpired},
+		{"not yet valid", signHS256(testSecret, withClaim("nbf", s.now.Add(time.Minute).Unix())), ErrTokenExpired},
+		{"missing exp", signHS256(testSecret, withClaim("exp", nil)), ErrInvalidToken},
+		{"wrong issuer", signHS256(testSecret, withClaim("iss", "https://evil.example.com")), ErrInvalidToken},
+		{"wrong audience", signHS256(testSecret, withClaim("aud", "other")), ErrInvalidToken},
+		{"subject not a user ID", signHS256(testSecret, withClaim("sub", "alice")), ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			claims, err := s.verifier.Verify(context.Background(), tt.token)
+
+			s.ErrorIs(err, tt.err)
+			s.Nil(claims)
+		})
+	}
+}
+
+// Test Verify rejects algorithms without a configured key
+func (s *JWTTestSuite) TestVerifyRS256WithoutKeySet() {
+	verifier, err := NewVerifier(VerifierConfig{HS256Secret: testSecret})
+	s.Require().NoError(err)
+	verifier.now = func() time.Time { return s.now }
+
+	_, err = verifier.Verify(context.Background(), signRS256(s.rsaKey, "key-1", s.validClaims()))
+
+	s.ErrorIs(err, ErrInvalidToken)
+}
+
+// Test Key reloads the JWKS when a rotated key shows up
+func (s *JWTTestSuite) TestKeySetReloadsOnUnknownKid() {
+	rotated, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.Require().NoError(os.WriteFile(s.keySet.source, createTestJWKS("key-2", &rotated.PublicKey), 0o600))
+
+	// Too soon after loading, the source isn't read again
+	_, err = s.keySet.Key(context.Background(), "key-2")
+	s.ErrorIs(err, ErrUnknownKey)
+
+	s.keySet.loadedAt = time.Now().Add(-2 * minRefreshInterval)
+	key, err := s.keySet.Key(context.Background(), "key-2")
+
+	s.NoError(err)
+	s.Equal(rotated.PublicKey.N, key.N)
+}
+
+// Test a slow reload for an unknown kid doesn't hold up known keys, and a
+// caller giving up on it doesn't abort the reload for everyone else
+func (s *JWTTestSuite) TestKeySetReloadOutsideLock() {
+	rotated, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(createTestJWKS("key-1", &s.rsaKey.PublicKey))
+	}))
+	defer server.Close()
+	keySet, err := LoadKeySet(context.Background(), server.URL)
+	s.Require().NoError(err)
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = w.Write(createTestJWKS("key-2", &rotated.PublicKey))
+	})
+	keySet.loadedAt = time.Now().Add(-2 * minRefreshInterval)
+
+	// The caller waiting for the reload gives up
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = keySet.Key(ctx, "key-2")
+	s.ErrorIs(err, context.DeadlineExceeded)
+
+	// Cached keys are still served while the reload is running
+	key, err := keySet.Key(context.Background(), "key-1")
+	s.Require().NoError(err)
+	s.Equal(s.rsaKey.PublicKey.N, key.N)
+
+	// The reload carries on and picks up the rotated key
+	close(release)
+	key, err = keySet.Key(context.Background(), "key-2")
+	s.Require().NoError(err)
+	s.Equal(rotated.PublicKey.N, key.N)
+}
+
+// Test a failed reload doesn't hold off the next one
+func (s *JWTTestSuite) TestKeySetRetriesFailedReload() {
+	rotated, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.Require().NoError(os.WriteFile(s.keySet.source, []byte("not json"), 0o600))
+	s.keySet.loadedAt = time.Now().Add(-2 * minRefreshInterval)
+
+	_, err = s.keySet.Key(context.Background(), "key-2")
+	s.ErrorContains(err, "failed to parse JWKS")
+
+	s.Require().NoError(os.WriteFile(s.keySet.source, createTestJWKS("key-2", &rotated.PublicKey), 0o600))
+	key, err := s.keySet.Key(context.Background(), "key-2")
+
+	s.NoError(err)
+	s.Equal(rotated.PublicKey.N, key.N)
+}
@@ -0,0 +1,133 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Reloader serves a TLS certificate, and optionally the CAs client
+// certificates are verified with, from PEM files. Checking the files on an
+// interval picks up rotated ones without a restart; connections already open
+// keep the certificate they started with.
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	// modTimes are the modification times of the files last loaded
+	modTimes [3]time.Time
+}
+
+// NewReloader loads the certificate, key and, if clientCAFile is set, the
+// client CAs, failing if any of them can't be used
+func NewReloader(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	r := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Check reloads the files if they changed, logging rather than failing so the
+// current certificate stays in use until the next check. It's run on an
+// interval with worker.Every.
+func (r *Reloader) Check(ctx context.Context) {
+	reloaded, err := r.Reload()
+	if err != nil {
+		slog.WarnContext(ctx, "failed to reload TLS certificate, keeping the current one", slog.Any("error", err))
+		return
+	}
+	if reloaded {
+		slog.InfoContext(ctx, "reloaded TLS certificate", slog.String("cert_file", r.certFile))
+	}
+}
+
+// Reload reads the files again if any of them changed since they were last
+// loaded and reports whether they did. Nothing is replaced unless all of
+// them load, so a half-written rotation is retried on the next run.
+func (r *Reloader) Reload() (bool, error) {
+	var modTimes [3]time.Time
+	for i, file := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+
+	r.mu.RLock()
+	unchanged := r.cert != nil && modTimes == r.modTimes
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to read client CAs: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return false, errors.New("failed to load client CAs: no PEM certificates in " + r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	return true, nil
+}
+
+// TLSConfig returns the server TLS config, which uses whatever certificate
+// and client CAs are current when a connection is made. Client certificates
+// are verified according to clientAuth, which needs client CAs for anything
+// but tls.NoClientCert.
+func (r *Reloader) TLSConfig(clientAuth tls.ClientAuthType) *tls.Config {
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+		ClientAuth: clientAuth,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.cert, nil
+		},
+	}
+
+	config := base.Clone()
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		config := base.Clone()
+		config.ClientCAs = r.clientCAs
+		return config, nil
+	}
+	return config
+}
@@ -0,0 +1,228 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// ReloaderTestSuite is a test suite for the Reloader
+type ReloaderTestSuite struct {
+	suite.Suite
+	dir      string
+	certFile string
+	keyFile  string
+	caFile   string
+	ca       *x509.Certificate
+	caKey    *ecdsa.PrivateKey
+}
+
+// SetupTest is called before each test
+func (s *ReloaderTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.certFile = filepath.Join(s.dir, "server.pem")
+	s.keyFile = filepath.Join(s.dir, "server-key.pem")
+	s.caFile = filepath.Join(s.dir, "ca.pem")
+
+	s.ca, s.caKey = s.issue("Test CA", nil, nil)
+	s.writePEM(s.caFile, "CERTIFICATE", s.ca.Raw)
+	s.writeServerCert("localhost", time.Now())
+}
+
+// TestReloaderSuite runs the test suite
+func TestReloaderSuite(t *testing.T) {
+	suite.Run(t, new(ReloaderTestSuite))
+}
+
+// issue creates a certificate for name signed by parent, or a self-signed CA
+// if parent is nil
+func (s *ReloaderTestSuite) issue(name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	s.Require().NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	s.Require().NoError(err)
+	return cert, key
+}
+
+// writePEM writes a PEM block to a file
+func (s *ReloaderTestSuite) writePEM(path, blockType string, der []byte) {
+	s.Require().NoError(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+}
+
+// writeServerCert writes a new server certificate for name, dated modTime
+func (s *ReloaderTestSuite) writeServerCert(name string, modTime time.Time) {
+	cert, key := s.issue(name, s.ca, s.caKey)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	s.Require().NoError(err)
+
+	s.writePEM(s.certFile, "CERTIFICATE", cert.Raw)
+	s.writePEM(s.keyFile, "EC PRIVATE KEY", keyDER)
+	s.Require().NoError(os.Chtimes(s.certFile, modTime, modTime))
+	s.Require().NoError(os.Chtimes(s.keyFile, modTime, modTime))
+}
+
+// clientCert returns a client certificate signed by the CA
+func (s *ReloaderTestSuite) clientCert(name string) tls.Certificate {
+	cert, key := s.issue(name, s.ca, s.caKey)
+	return tls.Certificate{Certificate: [][]byte{cert.Raw}, PrivateKey: key, Leaf: cert}
+}
+
+// handshake connects to a TLS listener with config and returns the name of
+// the server certificate and the client certificates the server verified
+func (s *ReloaderTestSuite) handshake(config *tls.Config, client *tls.Config) (string, [][]*x509.Certificate, error) {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	s.Require().NoError(err)
+	defer listener.Close()
+
+	verified := make(chan [][]*x509.Certificate, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			verified <- nil
+			return
+		}
+		defer conn.Close()
+		tlsConn := conn.(*tls.Conn)
+		_ = tlsConn.Handshake()
+		verified <- tlsConn.ConnectionState().VerifiedChains
+	}()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(s.ca)
+	client.RootCAs = roots
+	client.ServerName = "localhost"
+	conn, err := tls.Dial("tcp", listener.Addr().String(), client)
+	if err != nil {
+		<-verified
+		return "", nil, err
+	}
+	defer conn.Close()
+	// The server only sees a rejected client certificate after the first read
+	_, _ = conn.Write([]byte("ping"))
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName, <-verified, nil
+}
+
+// Test rotated certificates are picked up and unchanged ones aren't reloaded
+func (s *ReloaderTestSuite) TestReload() {
+	// Arrange
+	reloader, err := NewReloader(s.certFile, s.keyFile, "")
+	s.Require().NoError(err)
+	config := reloader.TLSConfig(tls.NoClientCert)
+
+	// Act
+	unchangedReloaded, unchangedErr := reloader.Reload()
+	s.writeServerCert("rotated.localhost", time.Now().Add(time.Minute))
+	rotatedReloaded, rotatedErr := reloader.Reload()
+
+	// Assert
+	s.NoError(unchangedErr)
+	s.False(unchangedReloaded)
+	s.NoError(rotatedErr)
+	s.True(rotatedReloaded)
+
+	cert, err := config.GetCertificate(nil)
+	s.Require().NoError(err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	s.Require().NoError(err)
+	s.Equal("rotated.localhost", leaf.Subject.CommonName)
+}
+
+// Test a broken rotation keeps the current certificate
+func (s *ReloaderTestSuite) TestReloadBroken() {
+	// Arrange
+	reloader, err := NewReloader(s.certFile, s.keyFile, "")
+	s.Require().NoError(err)
+	s.Require().NoError(os.WriteFile(s.keyFile, []byte("not a key"), 0o600))
+	later := time.Now().Add(time.Minute)
+	s.Require().NoError(os.Chtimes(s.keyFile, later, later))
+
+	// Act
+	reloaded, err := reloader.Reload()
+
+	// Assert
+	s.Error(err)
+	s.False(reloaded)
+	name, _, err := s.handshake(reloader.TLSConfig(tls.NoClientCert), &tls.Config{})
+	s.NoError(err)
+	s.Equal("localhost", name)
+}
+
+// Test missing files fail at startup
+func (s *ReloaderTestSuite) TestNewReloaderMissingFile() {
+	// Act
+	_, err := NewReloader(s.certFile, filepath.Join(s.dir, "missing.pem"), "")
+
+	// Assert
+	s.Error(err)
+}
+
+// Test client certificates are verified with the client CAs
+func (s *ReloaderTestSuite) TestClientCertificates() {
+	// Arrange
+	reloader, err := NewReloader(s.certFile, s.keyFile, s.caFile)
+	s.Require().NoError(err)
+	importer := s.clientCert("importer")
+
+	for _, tc := range []struct {
+		name       string
+		clientAuth tls.ClientAuthType
+		client     *tls.Config
+		verified   string
+		fails      bool
+	}{
+		{"optional with certificate", tls.VerifyClientCertIfGiven, &tls.Config{Certificates: []tls.Certificate{importer}}, "importer", false},
+		{"optional without certificate", tls.VerifyClientCertIfGiven, &tls.Config{}, "", false},
+		{"required with certificate", tls.RequireAndVerifyClientCert, &tls.Config{Certificates: []tls.Certificate{importer}}, "importer", false},
+		{"required without certificate", tls.RequireAndVerifyClientCert, &tls.Config{}, "", true},
+	} {
+		s.Run(tc.name, func() {
+			// Act
+			_, verified, err := s.handshake(reloader.TLSConfig(tc.clientAuth), tc.client)
+
+			// Assert
+			if tc.fails {
+				s.Empty(verified)
+				return
+			}
+			s.NoError(err)
+			if tc.verified == "" {
+				s.Empty(verified)
+				return
+			}
+			s.Require().NotEmpty(verified)
+			s.Equal(tc.verified, verified[0][0].Subject.CommonName)
+		})
+	}
+}
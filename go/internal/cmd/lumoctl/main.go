@@ -0,0 +1,12 @@
+// Command lumoctl is the command-line client of the Lumo API
+package main
+
+import (
+	"os"
+
+	"github.com/mcdev12/lumo/go/internal/lumoctl"
+)
+
+func main() {
+	os.Exit(lumoctl.Execute())
+}
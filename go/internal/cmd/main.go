@@ -1,122 +1,457 @@
 package main
 
 import (
-	"log"
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"log/slog"
 	"net/http"
 	"os"
-	"strconv"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"connectrpc.com/connect"
 	"connectrpc.com/grpcreflect"
+	"connectrpc.com/otelconnect"
 	"connectrpc.com/validate"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 
-	"golang.org/x/net/http2"
-	"golang.org/x/net/http2/h2c"
-
+	accessApp "github.com/mcdev12/lumo/go/internal/app/access"
+	apiKeyApp "github.com/mcdev12/lumo/go/internal/app/apikey"
+	auditApp "github.com/mcdev12/lumo/go/internal/app/audit"
+	eventApp "github.com/mcdev12/lumo/go/internal/app/event"
+	historyApp "github.com/mcdev12/lumo/go/internal/app/history"
+	idempotencyApp "github.com/mcdev12/lumo/go/internal/app/idempotency"
+	layoutApp "github.com/mcdev12/lumo/go/internal/app/layout"
 	linkApp "github.com/mcdev12/lumo/go/internal/app/link"
 	lumeApp "github.com/mcdev12/lumo/go/internal/app/lume"
 	lumoApp "github.com/mcdev12/lumo/go/internal/app/lumo"
+	outboxApp "github.com/mcdev12/lumo/go/internal/app/outbox"
+	shareApp "github.com/mcdev12/lumo/go/internal/app/share"
+	trashApp "github.com/mcdev12/lumo/go/internal/app/trash"
+	userApp "github.com/mcdev12/lumo/go/internal/app/user"
+	webhookApp "github.com/mcdev12/lumo/go/internal/app/webhook"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	"github.com/mcdev12/lumo/go/internal/certs"
+	"github.com/mcdev12/lumo/go/internal/config"
+	"github.com/mcdev12/lumo/go/internal/cors"
+	apikeyconnect "github.com/mcdev12/lumo/go/internal/genproto/apikey/v1/apikeyv1connect"
+	auditconnect "github.com/mcdev12/lumo/go/internal/genproto/audit/v1/auditv1connect"
+	eventconnect "github.com/mcdev12/lumo/go/internal/genproto/event/v1/eventv1connect"
+	historyconnect "github.com/mcdev12/lumo/go/internal/genproto/history/v1/historyv1connect"
+	layoutconnect "github.com/mcdev12/lumo/go/internal/genproto/layout/v1/layoutv1connect"
 	linkconnect "github.com/mcdev12/lumo/go/internal/genproto/link/v1/linkv1connect"
 	lumeconnect "github.com/mcdev12/lumo/go/internal/genproto/lume/v1/lumev1connect"
 	lumoconnect "github.com/mcdev12/lumo/go/internal/genproto/lumo/v1/lumov1connect"
+	shareconnect "github.com/mcdev12/lumo/go/internal/genproto/share/v1/sharev1connect"
+	trashconnect "github.com/mcdev12/lumo/go/internal/genproto/trash/v1/trashv1connect"
+	userconnect "github.com/mcdev12/lumo/go/internal/genproto/user/v1/userv1connect"
+	webhookconnect "github.com/mcdev12/lumo/go/internal/genproto/webhook/v1/webhookv1connect"
+	"github.com/mcdev12/lumo/go/internal/health"
+	"github.com/mcdev12/lumo/go/internal/logging"
+	"github.com/mcdev12/lumo/go/internal/metrics"
+	"github.com/mcdev12/lumo/go/internal/ratelimit"
+	accessRepo "github.com/mcdev12/lumo/go/internal/repository/access"
+	apiKeyRepo "github.com/mcdev12/lumo/go/internal/repository/apikey"
+	auditRepo "github.com/mcdev12/lumo/go/internal/repository/audit"
 	"github.com/mcdev12/lumo/go/internal/repository/db"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	eventRepo "github.com/mcdev12/lumo/go/internal/repository/event"
+	historyRepo "github.com/mcdev12/lumo/go/internal/repository/history"
+	idempotencyRepo "github.com/mcdev12/lumo/go/internal/repository/idempotency"
+	layoutRepo "github.com/mcdev12/lumo/go/internal/repository/layout"
 	linkRepo "github.com/mcdev12/lumo/go/internal/repository/link"
 	lumeRepo "github.com/mcdev12/lumo/go/internal/repository/lume"
 	lumoRepo "github.com/mcdev12/lumo/go/internal/repository/lumo"
+	outboxRepo "github.com/mcdev12/lumo/go/internal/repository/outbox"
+	shareRepo "github.com/mcdev12/lumo/go/internal/repository/share"
+	statsRepo "github.com/mcdev12/lumo/go/internal/repository/stats"
+	trashRepo "github.com/mcdev12/lumo/go/internal/repository/trash"
+	userRepo "github.com/mcdev12/lumo/go/internal/repository/user"
+	webhookRepo "github.com/mcdev12/lumo/go/internal/repository/webhook"
+	apiKeyService "github.com/mcdev12/lumo/go/internal/service/apikey"
+	auditService "github.com/mcdev12/lumo/go/internal/service/audit"
+	eventService "github.com/mcdev12/lumo/go/internal/service/event"
+	historyService "github.com/mcdev12/lumo/go/internal/service/history"
+	layoutService "github.com/mcdev12/lumo/go/internal/service/layout"
 	linkService "github.com/mcdev12/lumo/go/internal/service/link"
 	lumeService "github.com/mcdev12/lumo/go/internal/service/lume"
 	lumoService "github.com/mcdev12/lumo/go/internal/service/lumo"
+	shareService "github.com/mcdev12/lumo/go/internal/service/share"
+	trashService "github.com/mcdev12/lumo/go/internal/service/trash"
+	userService "github.com/mcdev12/lumo/go/internal/service/user"
+	webhookService "github.com/mcdev12/lumo/go/internal/service/webhook"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+	"github.com/mcdev12/lumo/go/internal/worker"
 )
 
-// getEnv returns the value of an environment variable or a default value if not set
-func getEnv(key, defaultValue string) string {
-	value, exists := os.LookupEnv(key)
-	if !exists {
-		return defaultValue
+// fatal logs an error that keeps the server from running and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, slog.Any("error", err))
+	os.Exit(1)
+}
+
+// loadConfig loads the configuration from args, the environment and the
+// config file, exiting if it isn't valid
+func loadConfig(args []string) *config.Config {
+	cfg, err := config.Load(args, os.LookupEnv, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
 	}
-	return value
+	if err != nil {
+		fatal("invalid configuration", err)
+	}
+	return cfg
 }
 
-// getEnvAsInt returns the value of an environment variable as an integer or a default value if not set
-func getEnvAsInt(key string, defaultValue int) int {
-	valueStr := getEnv(key, "")
-	if valueStr == "" {
-		return defaultValue
+// newAuthInterceptor creates the JWT interceptor from the HS256 secret and/or
+// the JWKS, a file path or URL for RS256 tokens. Reading a shared Lumo is left
+// open, the share token is the credential there.
+func newAuthInterceptor(cfg config.AuthConfig, apiKeys auth.APIKeyAuthenticator) (*auth.Interceptor, error) {
+	verifierConfig := auth.VerifierConfig{
+		HS256Secret: []byte(cfg.HS256Secret),
+		Issuer:      cfg.Issuer,
+		Audience:    cfg.Audience,
+		Leeway:      cfg.Leeway,
+	}
+
+	if source := cfg.JWKS; source != "" {
+		keySet, err := auth.LoadKeySet(context.Background(), source)
+		if err != nil {
+			return nil, err
+		}
+		verifierConfig.KeySet = keySet
 	}
-	value, err := strconv.Atoi(valueStr)
+
+	verifier, err := auth.NewVerifier(verifierConfig)
 	if err != nil {
-		log.Printf("Warning: Environment variable %s is not a valid integer, using default value %d", key, defaultValue)
-		return defaultValue
+		return nil, err
 	}
-	return value
+	return auth.NewInterceptor(verifier, apiKeys, shareconnect.ShareServiceGetSharedLumoProcedure), nil
 }
 
 func main() {
+	// SIGINT and SIGTERM start a graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// `lumo config print` prints the configuration the server would run with,
+	// secrets redacted
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "print" {
+		if err := config.Print(os.Stdout, loadConfig(os.Args[3:])); err != nil {
+			fatal("failed to print the configuration", err)
+		}
+		return
+	}
+	cfg := loadConfig(os.Args[1:])
+
+	// Log JSON lines at the configured level, also for whatever still uses the
+	// log package. The level was validated with the config.
+	logLevel, _ := logging.ParseLevel(cfg.Log.Level)
+	logger := logging.NewLogger(os.Stderr, logLevel)
+	slog.SetDefault(logger)
+
+	// Export traces of requests (otlp, stdout or none)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing.Exporter, "lumo")
+	if err != nil {
+		fatal("failed to set up tracing", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Warn("failed to flush traces", slog.Any("error", err))
+		}
+	}()
+
 	// Initialize database
-	config := &db.Config{
-		Host:     getEnv("DB_HOST", "localhost"),
-		Port:     getEnvAsInt("DB_PORT", 5432),
-		User:     getEnv("DB_USER", "postgres"),
-		Password: getEnv("DB_PASSWORD", "postgres"),
-		DBName:   getEnv("DB_NAME", "lumo_db"),
-		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+	dbConfig := &db.Config{
+		ConnString:      cfg.DB.DSN,
+		Host:            cfg.DB.Host,
+		Port:            cfg.DB.Port,
+		User:            cfg.DB.User,
+		Password:        cfg.DB.Password,
+		DBName:          cfg.DB.Name,
+		SSLMode:         cfg.DB.SSLMode,
+		MaxOpenConns:    cfg.DB.MaxOpenConns,
+		MaxIdleConns:    cfg.DB.MaxIdleConns,
+		ConnMaxLifetime: cfg.DB.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.DB.ConnMaxIdleTime,
 	}
 
-	dbConn, err := db.NewConnection(config)
+	sqlDB, err := db.NewConnection(dbConfig)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("failed to connect to database", err)
+	}
+	defer sqlDB.Close()
+
+	// The server is ready while the database answers and has the schema the
+	// code expects
+	checker := health.NewChecker(cfg.Server.HealthCheckTimeout)
+	checker.Add("database", sqlDB.PingContext)
+	checker.Add("schema", func(ctx context.Context) error {
+		return db.CheckSchema(ctx, sqlDB)
+	})
+
+	// Background workers run until the server has drained, and the pool is
+	// only closed once they've stopped
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+	runWorker := func(run func(context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(workerCtx)
+		}()
+	}
+
+	// Prometheus metrics of requests, queries, the connection pool and what
+	// is stored, unless turned off
+	registry := metrics.NewRegistry()
+
+	// Every query names the request it's made for, is timed and traced
+	dbConn := db.WithRequestTags(sqlDB)
+	if cfg.Features.Metrics {
+		registry.MustRegister(collectors.NewDBStatsCollector(sqlDB, "lumo"))
+		dbConn = db.WithQueryObserver(dbConn, metrics.NewQueryObserver(registry))
+	}
+	dbConn = db.WithTracing(dbConn)
+
+	if cfg.Features.Metrics {
+		domainCollector := metrics.NewDomainCollector(registry, statsRepo.NewRepository(dbConn))
+		runWorker(worker.Every(cfg.Metrics.DomainInterval, domainCollector.Collect))
 	}
-	defer dbConn.Close()
 
 	// Initialize layers
+	// Ownership checks shared by every service
+	accessRepository := accessRepo.NewRepository(dbConn)
+	authorizer := accessApp.NewAuthorizer(accessRepository)
+
+	// User service, whose preferences the other services fall back on
+	userRepository := userRepo.NewRepository(dbConn)
+	userApplication := userApp.NewUserApp(userRepository)
+	userSvc := userService.NewService(userApplication)
+
+	// Idempotency keys, replaying the responses to retried create calls
+	idempotencyRepository := idempotencyRepo.NewRepository(dbConn)
+	idempotencyApplication := idempotencyApp.NewIdempotencyApp(idempotencyRepository, cfg.Idempotency.TTL, cfg.Idempotency.Lease)
+	runWorker(worker.Every(cfg.Idempotency.PurgeInterval, idempotencyApp.Purger(idempotencyApplication)))
+
 	// Lume service
 	lumeRepository := lumeRepo.NewRepository(dbConn)
-	lumeApplication := lumeApp.NewLumeApp(lumeRepository)
-	lumeSvc := lumeService.NewService(lumeApplication)
+	lumeApplication := lumeApp.NewLumeApp(lumeRepository, authorizer, userApplication, int64(cfg.Quota.MaxLumesPerLumo))
+	lumeSvc := lumeService.NewService(lumeApplication, idempotencyApplication)
 
 	// Lumo service
 	lumoRepository := lumoRepo.NewRepository(dbConn)
-	lumoApplication := lumoApp.NewLumoApp(lumoRepository)
-	lumoSvc := lumoService.NewService(lumoApplication)
+	lumoApplication := lumoApp.NewLumoApp(lumoRepository, authorizer, int64(cfg.Quota.MaxLumosPerUser))
+	lumoSvc := lumoService.NewService(lumoApplication, idempotencyApplication)
 
 	// Link service
 	linkRepository := linkRepo.NewRepository(dbConn)
-	linkApplication := linkApp.NewLinkApp(linkRepository)
-	linkSvc := linkService.NewService(linkApplication)
+	linkApplication := linkApp.NewLinkApp(linkRepository, authorizer, userApplication)
+	linkSvc := linkService.NewService(linkApplication, idempotencyApplication)
+
+	// Layout service
+	layoutRepository := layoutRepo.NewRepository(dbConn)
+	layoutApplication := layoutApp.NewLayoutApp(layoutRepository, lumeRepository, linkRepository, authorizer)
+	layoutSvc := layoutService.NewService(layoutApplication)
+
+	// Event service
+	eventListener, err := eventRepo.NewListener(dbConfig.DSN())
+	if err != nil {
+		fatal("failed to listen for events", err)
+	}
+	defer eventListener.Close()
+
+	eventRepository := eventRepo.NewRepository(dbConn)
+	eventApplication := eventApp.NewEventApp(eventRepository, eventListener, authorizer)
+	eventSvc := eventService.NewService(eventApplication)
+
+	// History service
+	historyRepository := historyRepo.NewRepository(dbConn, func(tx sqlc.DBTX) historyRepo.Writers {
+		return historyRepo.Writers{
+			Lumos: lumoRepository.WithTx(tx),
+			Lumes: lumeRepository.WithTx(tx),
+			Links: linkRepository.WithTx(tx),
+		}
+	})
+	historyApplication := historyApp.NewHistoryApp(historyRepository, authorizer)
+	historySvc := historyService.NewService(historyApplication)
+
+	// Trash service
+	trashRepository := trashRepo.NewRepository(dbConn)
+	trashApplication := trashApp.NewTrashApp(trashRepository, lumoRepository, lumeRepository, linkRepository, authorizer)
+	trashSvc := trashService.NewService(trashApplication)
+
+	runWorker(worker.Every(cfg.Trash.PurgeInterval, trashApp.Purger(trashApplication, cfg.Trash.Retention)))
+
+	// Share service
+	shareRepository := shareRepo.NewRepository(dbConn)
+	shareApplication := shareApp.NewShareApp(shareRepository, lumoRepository, lumeRepository, linkRepository, authorizer)
+	shareSvc := shareService.NewService(shareApplication)
+
+	// API key service, whose keys the auth interceptor accepts
+	apiKeyRepository := apiKeyRepo.NewRepository(dbConn)
+	apiKeyApplication := apiKeyApp.NewAPIKeyApp(apiKeyRepository, authorizer)
+	apiKeySvc := apiKeyService.NewService(apiKeyApplication)
+
+	// Audit service, recording the calls that change Lumos, Lumes and Links
+	auditRepository := auditRepo.NewRepository(dbConn)
+	auditApplication := auditApp.NewAuditApp(auditRepository, authorizer)
+	auditSvc := auditService.NewService(auditApplication)
+
+	// A retention of 0 keeps the audit log forever
+	if cfg.Audit.Retention > 0 {
+		runWorker(worker.Every(cfg.Audit.PurgeInterval, auditApp.Purger(auditApplication, cfg.Audit.Retention)))
+	}
+
+	// Outbox relay, publishing the domain events written along with every
+	// change to an in-process bus and, if configured, NATS and a webhook
+	eventBus := outboxApp.NewBus()
+	sinks := []outboxApp.Sink{eventBus}
+	if cfg.Outbox.NATSURL != "" {
+		natsPublisher, err := outboxApp.NewNATSPublisher(cfg.Outbox.NATSURL, cfg.Outbox.NATSSubjectPrefix)
+		if err != nil {
+			fatal("failed to create NATS publisher", err)
+		}
+		defer natsPublisher.Close()
+		sinks = append(sinks, natsPublisher)
+	}
+	if cfg.Outbox.WebhookURL != "" {
+		sinks = append(sinks, outboxApp.NewWebhookSink(cfg.Outbox.WebhookURL, &http.Client{Timeout: 10 * time.Second}))
+	}
+
+	relay := outboxApp.NewRelay(
+		outboxRepo.NewRepository(dbConn),
+		sinks,
+		int32(cfg.Outbox.MaxAttempts),
+	)
+	runWorker(worker.Every(cfg.Outbox.PollInterval, relay.DeliverDue))
+
+	// Webhook service, queueing the events on the bus for the endpoints users
+	// registered and delivering them
+	webhookRepository := webhookRepo.NewRepository(dbConn)
+	webhookApplication := webhookApp.NewWebhookApp(webhookRepository, authorizer)
+	webhookSvc := webhookService.NewService(webhookApplication)
+	if cfg.Features.Webhooks {
+		eventBus.Subscribe(webhookApplication.Dispatch)
+
+		webhookDeliverer := webhookApp.NewDeliverer(
+			webhookRepository,
+			webhookApp.NewHTTPClient(cfg.Webhook.Timeout, cfg.Webhook.AllowPrivateNetworks),
+			int32(cfg.Webhook.MaxAttempts),
+			int32(cfg.Webhook.DisableAfter),
+		)
+		runWorker(worker.Every(cfg.Webhook.PollInterval, webhookDeliverer.DeliverDue))
+	}
 
 	interceptor, err := validate.NewInterceptor()
 	if err != nil {
-		log.Fatalf("Failed to create proto validation interceptor: %v", err)
+		fatal("failed to create proto validation interceptor", err)
+	}
+
+	// Callers are rate limited once they are known, before anything is done
+	// for them. Limited calls aren't audited, so a runaway script can't flood
+	// the audit log.
+	interceptors := []connect.Interceptor{ratelimit.NewInterceptor(cfg.RateLimit.Limits()), interceptor}
+
+	// Authentication runs first so unauthenticated requests are turned away
+	// before anything else looks at them
+	if cfg.Auth.Disabled {
+		slog.Warn("authentication is disabled, requests are trusted to name their user")
+	} else {
+		authInterceptor, err := newAuthInterceptor(cfg.Auth, apiKeyApplication)
+		if err != nil {
+			fatal("failed to create auth interceptor", err)
+		}
+		interceptors = append([]connect.Interceptor{authInterceptor}, interceptors...)
+	}
+
+	// Logging comes before everything else, so every request gets an ID and
+	// is logged however it ends, panics included
+	interceptors = append([]connect.Interceptor{logging.NewInterceptor(logger)}, interceptors...)
+
+	// Metrics wrap logging, which turns panics into the errors they count
+	if cfg.Features.Metrics {
+		interceptors = append([]connect.Interceptor{metrics.NewInterceptor(registry)}, interceptors...)
+	}
+
+	// Tracing wraps everything, so the logs of a request name its trace.
+	// Spans continue the trace context the web client sends.
+	traceInterceptor, err := otelconnect.NewInterceptor(otelconnect.WithTrustRemote(), otelconnect.WithoutMetrics())
+	if err != nil {
+		fatal("failed to create tracing interceptor", err)
 	}
+	interceptors = append([]connect.Interceptor{traceInterceptor}, interceptors...)
+
+	// Streams such as WatchLumo end when the server shuts down, so it can
+	// drain
+	interceptors = append([]connect.Interceptor{checker.DrainInterceptor()}, interceptors...)
+
+	// Audited services also record every call that changes something, after
+	// authentication so the caller is known but before validation so rejected
+	// requests are recorded too
+	auditedInterceptors := append([]connect.Interceptor{}, interceptors[:len(interceptors)-1]...)
+	auditedInterceptors = append(auditedInterceptors, auditService.NewInterceptor(auditApplication), interceptor)
 
 	// Create Connect adapters
 	lumeServicePath, lumeConnectSvc := lumeconnect.NewLumeServiceHandler(
 		lumeSvc,
-		connect.WithInterceptors(interceptor),
+		connect.WithInterceptors(auditedInterceptors...),
 	)
 	lumoServicePath, lumoConnectSvc := lumoconnect.NewLumoServiceHandler(
 		lumoSvc,
-		connect.WithInterceptors(interceptor),
+		connect.WithInterceptors(auditedInterceptors...),
 	)
 	linkServicePath, linkConnectSvc := linkconnect.NewLinkServiceHandler(
 		linkSvc,
-		connect.WithInterceptors(interceptor),
+		connect.WithInterceptors(auditedInterceptors...),
+	)
+	layoutServicePath, layoutConnectSvc := layoutconnect.NewLayoutServiceHandler(
+		layoutSvc,
+		connect.WithInterceptors(interceptors...),
+	)
+	eventServicePath, eventConnectSvc := eventconnect.NewEventServiceHandler(
+		eventSvc,
+		connect.WithInterceptors(interceptors...),
 	)
 
-	// CORS middleware
-	corsMiddleware := func(h http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Connect-Protocol-Version")
-			w.Header().Set("Access-Control-Max-Age", "3600")
-
-			if r.Method == "OPTIONS" {
-				w.WriteHeader(http.StatusOK)
-				return
-			}
-			h.ServeHTTP(w, r)
-		})
+	historyServicePath, historyConnectSvc := historyconnect.NewHistoryServiceHandler(
+		historySvc,
+		connect.WithInterceptors(interceptors...),
+	)
+	trashServicePath, trashConnectSvc := trashconnect.NewTrashServiceHandler(
+		trashSvc,
+		connect.WithInterceptors(interceptors...),
+	)
+	shareServicePath, shareConnectSvc := shareconnect.NewShareServiceHandler(
+		shareSvc,
+		connect.WithInterceptors(interceptors...),
+	)
+	userServicePath, userConnectSvc := userconnect.NewUserServiceHandler(
+		userSvc,
+		connect.WithInterceptors(interceptors...),
+	)
+	apiKeyServicePath, apiKeyConnectSvc := apikeyconnect.NewApiKeyServiceHandler(
+		apiKeySvc,
+		connect.WithInterceptors(interceptors...),
+	)
+	auditServicePath, auditConnectSvc := auditconnect.NewAuditServiceHandler(
+		auditSvc,
+		connect.WithInterceptors(interceptors...),
+	)
+	webhookServicePath, webhookConnectSvc := webhookconnect.NewWebhookServiceHandler(
+		webhookSvc,
+		connect.WithInterceptors(interceptors...),
+	)
+
+	// Browsers may call the API from the configured origins
+	corsPolicy, err := cors.New(cfg.CORS.Policy())
+	if err != nil {
+		fatal("failed to create CORS policy", err)
 	}
 
 	// Set up HTTP mux and handlers
@@ -124,29 +459,186 @@ func main() {
 	mux.Handle(lumeServicePath, lumeConnectSvc)
 	mux.Handle(lumoServicePath, lumoConnectSvc)
 	mux.Handle(linkServicePath, linkConnectSvc)
+	mux.Handle(layoutServicePath, layoutConnectSvc)
+	mux.Handle(eventServicePath, eventConnectSvc)
+	mux.Handle(historyServicePath, historyConnectSvc)
+	mux.Handle(trashServicePath, trashConnectSvc)
+	mux.Handle(shareServicePath, shareConnectSvc)
+	mux.Handle(userServicePath, userConnectSvc)
+	mux.Handle(apiKeyServicePath, apiKeyConnectSvc)
+	mux.Handle(auditServicePath, auditConnectSvc)
 
 	// === Reflection for grpcui/grpcurl ===
-	reflector := grpcreflect.NewStaticReflector(
+	healthServices := []string{
 		lumeconnect.LumeServiceName,
 		lumoconnect.LumoServiceName,
 		linkconnect.LinkServiceName,
-	)
-	// Register both v1 and v1alpha reflection handlers
-	pathV1, handlerV1 := grpcreflect.NewHandlerV1(reflector)
-	mux.Handle(pathV1, handlerV1)
+		layoutconnect.LayoutServiceName,
+		eventconnect.EventServiceName,
+		historyconnect.HistoryServiceName,
+		trashconnect.TrashServiceName,
+		shareconnect.ShareServiceName,
+		userconnect.UserServiceName,
+		apikeyconnect.ApiKeyServiceName,
+		auditconnect.AuditServiceName,
+	}
+	if cfg.Features.Webhooks {
+		mux.Handle(webhookServicePath, webhookConnectSvc)
+		healthServices = append(healthServices, webhookconnect.WebhookServiceName)
+	}
+	if cfg.Features.Reflection {
+		reflector := grpcreflect.NewStaticReflector(append(healthServices, health.ServiceName)...)
+		// Register both v1 and v1alpha reflection handlers
+		pathV1, handlerV1 := grpcreflect.NewHandlerV1(reflector)
+		mux.Handle(pathV1, handlerV1)
 
-	pathAlpha, handlerAlpha := grpcreflect.NewHandlerV1Alpha(reflector)
-	mux.Handle(pathAlpha, handlerAlpha)
+		pathAlpha, handlerAlpha := grpcreflect.NewHandlerV1Alpha(reflector)
+		mux.Handle(pathAlpha, handlerAlpha)
+	}
 
-	// Wrap with CORS + h2c (HTTP/2 without TLS)
-	handler := corsMiddleware(mux)
-	server := &http.Server{
-		Addr:    ":8080",
-		Handler: h2c.NewHandler(handler, &http2.Server{}),
+	// Health checks for load balancers and orchestrators, answered without
+	// authentication
+	healthPath, healthHandler := health.NewHandler(checker, healthServices...)
+	mux.Handle(healthPath, healthHandler)
+	mux.Handle("/healthz", checker.LivenessHandler())
+	mux.Handle("/readyz", checker.ReadinessHandler())
+
+	// Metrics are served on their own address if one is set, so they can be
+	// kept from the public, and next to the API otherwise
+	var metricsServer *http.Server
+	switch {
+	case !cfg.Features.Metrics:
+	case cfg.Metrics.Addr != "":
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", metrics.Handler(registry))
+		metricsServer = &http.Server{
+			Addr:              cfg.Metrics.Addr,
+			Handler:           metricsMux,
+			ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		}
+		go func() {
+			slog.Info("metrics server listening", slog.String("addr", metricsServer.Addr))
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				fatal("failed to serve metrics", err)
+			}
+		}()
+	default:
+		mux.Handle("/metrics", metrics.Handler(registry))
+	}
+
+	// Over TLS, the certificate is reloaded when it's rotated, and internal
+	// services may authenticate with client certificates
+	handler := http.Handler(mux)
+	var tlsConfig *tls.Config
+	if cfg.TLS.Enabled() {
+		reloader, err := certs.NewReloader(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ClientCAFile)
+		if err != nil {
+			fatal("failed to load TLS certificate", err)
+		}
+		runWorker(worker.Every(cfg.TLS.ReloadInterval, reloader.Check))
+
+		clientAuth := tls.NoClientCert
+		switch {
+		case cfg.TLS.RequireClientCert:
+			clientAuth = tls.RequireAndVerifyClientCert
+		case cfg.TLS.ClientCAFile != "":
+			clientAuth = tls.VerifyClientCertIfGiven
+		}
+		tlsConfig = reloader.TLSConfig(clientAuth)
+
+		// The identities were validated with the config
+		services, _ := cfg.TLS.Services()
+		handler = auth.NewClientCertAuthenticator(services).Middleware(handler)
+	}
+	handler = corsPolicy.Handler(handler)
+
+	// The API is served over TLS if a certificate is configured, and in
+	// cleartext otherwise or on a separate h2c address as well
+	servers := []*http.Server{newServer(cfg.Server, cfg.Server.Addr, handler, tlsConfig)}
+	if cfg.TLS.H2CAddr != "" {
+		servers = append(servers, newServer(cfg.Server, cfg.TLS.H2CAddr, handler, nil))
+	}
+	for _, server := range servers {
+		go serve(server)
+	}
+
+	<-ctx.Done()
+	stop()
+	if metricsServer != nil {
+		servers = append(servers, metricsServer)
+	}
+	shutdown(servers, checker, cfg.Server.ShutdownTimeout, func() {
+		stopWorkers()
+		workers.Wait()
+	})
+}
+
+// newServer creates an API server on addr speaking HTTP/1.1 and HTTP/2, over
+// TLS if tlsConfig is set and in cleartext (h2c) otherwise
+func newServer(cfg config.ServerConfig, addr string, handler http.Handler, tlsConfig *tls.Config) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	if tlsConfig != nil {
+		protocols.SetHTTP2(true)
+	} else {
+		protocols.SetUnencryptedHTTP2(true)
+	}
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		Protocols:         protocols,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+}
+
+// serve runs an API server until it is shut down
+func serve(server *http.Server) {
+	slog.Info("connect server listening", slog.String("addr", server.Addr), slog.Bool("tls", server.TLSConfig != nil))
+	var err error
+	if server.TLSConfig != nil {
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fatal("failed to serve", err)
+	}
+}
+
+// shutdown drains the servers: readiness fails and streams are ended, then
+// the servers stop accepting connections and wait for the requests in flight
+// and finally the background workers are stopped. Requests still running
+// after timeout are cut off.
+func shutdown(servers []*http.Server, checker *health.Checker, timeout time.Duration, stopWorkers func()) {
+	slog.Info("shutting down", slog.Duration("timeout", timeout))
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	checker.Drain()
+	var wg sync.WaitGroup
+	for _, server := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := server.Shutdown(ctx); err != nil {
+				slog.Warn("requests were still running at the shutdown timeout", slog.String("addr", server.Addr), slog.Any("error", err))
+				_ = server.Close()
+			}
+		}()
 	}
+	wg.Wait()
 
-	log.Printf("Connect server listening on %s", server.Addr)
-	if err := server.ListenAndServe(); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	stopped := make(chan struct{})
+	go func() {
+		stopWorkers()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		slog.Warn("background workers were still running at the shutdown timeout")
 	}
+	slog.Info("shut down")
 }
@@ -0,0 +1,300 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mcdev12/lumo/go/internal/cors"
+	"github.com/mcdev12/lumo/go/internal/ratelimit"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Config is everything the server can be configured with. Every setting
+// comes from, in increasing precedence, its default, the YAML or TOML config
+// file, its environment variable and its command-line flag, named after its
+// path in the file, e.g. -db.max_open_conns.
+//
+// Fields are described by their tags: yaml and toml name them in the file,
+// env names their environment variable, usage describes them and secret
+// marks the ones Print redacts.
+type Config struct {
+	Server      ServerConfig      `yaml:"server" toml:"server"`
+	TLS         TLSConfig         `yaml:"tls" toml:"tls"`
+	CORS        CORSConfig        `yaml:"cors" toml:"cors"`
+	Log         LogConfig         `yaml:"log" toml:"log"`
+	DB          DBConfig          `yaml:"db" toml:"db"`
+	Auth        AuthConfig        `yaml:"auth" toml:"auth"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit" toml:"rate_limit"`
+	Quota       QuotaConfig       `yaml:"quota" toml:"quota"`
+	Trash       TrashConfig       `yaml:"trash" toml:"trash"`
+	Audit       AuditConfig       `yaml:"audit" toml:"audit"`
+	Idempotency IdempotencyConfig `yaml:"idempotency" toml:"idempotency"`
+	Outbox      OutboxConfig      `yaml:"outbox" toml:"outbox"`
+	Webhook     WebhookConfig     `yaml:"webhook" toml:"webhook"`
+	Metrics     MetricsConfig     `yaml:"metrics" toml:"metrics"`
+	Tracing     TracingConfig     `yaml:"tracing" toml:"tracing"`
+	Features    FeaturesConfig    `yaml:"features" toml:"features"`
+}
+
+// ServerConfig configures the HTTP server
+type ServerConfig struct {
+	Addr               string        `yaml:"addr" toml:"addr" env:"SERVER_ADDR" usage:"address the API listens on"`
+	ReadHeaderTimeout  time.Duration `yaml:"read_header_timeout" toml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT" usage:"how long clients have to send request headers"`
+	IdleTimeout        time.Duration `yaml:"idle_timeout" toml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT" usage:"how long idle keep-alive connections are kept open"`
+	ShutdownTimeout    time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" usage:"how long requests in flight are given to finish on shutdown"`
+	HealthCheckTimeout time.Duration `yaml:"health_check_timeout" toml:"health_check_timeout" env:"HEALTH_CHECK_TIMEOUT" usage:"how long the readiness checks may take"`
+}
+
+// TLSConfig configures TLS, which is off unless a certificate is set
+type TLSConfig struct {
+	CertFile          string        `yaml:"cert_file" toml:"cert_file" env:"TLS_CERT_FILE" usage:"PEM certificate chain to serve TLS with"`
+	KeyFile           string        `yaml:"key_file" toml:"key_file" env:"TLS_KEY_FILE" usage:"PEM private key of the certificate"`
+	ReloadInterval    time.Duration `yaml:"reload_interval" toml:"reload_interval" env:"TLS_RELOAD_INTERVAL" usage:"how often the certificate files are checked for rotation"`
+	ClientCAFile      string        `yaml:"client_ca_file" toml:"client_ca_file" env:"TLS_CLIENT_CA_FILE" usage:"PEM CAs to verify client certificates with, if set"`
+	RequireClientCert bool          `yaml:"require_client_cert" toml:"require_client_cert" env:"TLS_REQUIRE_CLIENT_CERT" usage:"turn away TLS clients without a verified certificate"`
+	ClientIdentities  []string      `yaml:"client_identities" toml:"client_identities" env:"TLS_CLIENT_IDENTITIES" usage:"comma-separated service=user-id pairs mapping client certificate names to the users they act as"`
+	H2CAddr           string        `yaml:"h2c_addr" toml:"h2c_addr" env:"TLS_H2C_ADDR" usage:"address to also serve cleartext HTTP/2 on, e.g. for a service mesh, if set"`
+}
+
+// Enabled reports whether the server serves TLS
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// Services parses the ClientIdentities into the user IDs by service name
+func (c TLSConfig) Services() (map[string]string, error) {
+	services := make(map[string]string, len(c.ClientIdentities))
+	for _, pair := range c.ClientIdentities {
+		name, userID, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%q is not a service=user-id pair", pair)
+		}
+		if _, err := uuid.Parse(userID); err != nil {
+			return nil, fmt.Errorf("%q does not map to a user UUID", pair)
+		}
+		services[name] = userID
+	}
+	return services, nil
+}
+
+// CORSConfig configures which web origins may call the API
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins" toml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" usage:"comma-separated origins or patterns like https://*.example.com browsers may call the API from, \"*\" for any"`
+	AllowCredentials bool          `yaml:"allow_credentials" toml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" usage:"let browsers send cookies and client certificates, not with \"*\""`
+	AllowedHeaders   []string      `yaml:"allowed_headers" toml:"allowed_headers" env:"CORS_ALLOWED_HEADERS" usage:"comma-separated request headers to allow besides the ones Connect and gRPC-Web need"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" toml:"exposed_headers" env:"CORS_EXPOSED_HEADERS" usage:"comma-separated response headers to expose besides the ones Connect and gRPC-Web need"`
+	MaxAge           time.Duration `yaml:"max_age" toml:"max_age" env:"CORS_MAX_AGE" usage:"how long browsers may cache preflight responses"`
+}
+
+// Policy returns the configuration of the CORS policy
+func (c CORSConfig) Policy() cors.Config {
+	return cors.Config{
+		AllowedOrigins:   c.AllowedOrigins,
+		AllowCredentials: c.AllowCredentials,
+		AllowedHeaders:   c.AllowedHeaders,
+		ExposedHeaders:   c.ExposedHeaders,
+		MaxAge:           c.MaxAge,
+	}
+}
+
+// LogConfig configures logging
+type LogConfig struct {
+	Level string `yaml:"level" toml:"level" env:"LOG_LEVEL" usage:"debug, info, warn or error"`
+}
+
+// DBConfig configures the database and its connection pool
+type DBConfig struct {
+	DSN             string        `yaml:"dsn" toml:"dsn" env:"DB_DSN" secret:"true" usage:"lib/pq connection string, used instead of the host, port, user, password, name and sslmode if set"`
+	Host            string        `yaml:"host" toml:"host" env:"DB_HOST" usage:"database host"`
+	Port            int           `yaml:"port" toml:"port" env:"DB_PORT" usage:"database port"`
+	User            string        `yaml:"user" toml:"user" env:"DB_USER" usage:"database user"`
+	Password        string        `yaml:"password" toml:"password" env:"DB_PASSWORD" secret:"true" usage:"database password"`
+	Name            string        `yaml:"name" toml:"name" env:"DB_NAME" usage:"database name"`
+	SSLMode         string        `yaml:"sslmode" toml:"sslmode" env:"DB_SSLMODE" usage:"lib/pq sslmode"`
+	MaxOpenConns    int           `yaml:"max_open_conns" toml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" usage:"maximum number of open connections"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" toml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" usage:"maximum number of idle connections"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" usage:"how long a connection may be reused, 0 for ever"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" toml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" usage:"how long a connection may sit idle, 0 for ever"`
+}
+
+// AuthConfig configures how callers are authenticated
+type AuthConfig struct {
+	HS256Secret string        `yaml:"hs256_secret" toml:"hs256_secret" env:"AUTH_HS256_SECRET" secret:"true" usage:"shared secret for HS256 bearer tokens"`
+	JWKS        string        `yaml:"jwks" toml:"jwks" env:"AUTH_JWKS" usage:"path or URL of a JWKS for RS256 bearer tokens"`
+	Issuer      string        `yaml:"issuer" toml:"issuer" env:"AUTH_ISSUER" usage:"iss tokens must carry, if set"`
+	Audience    string        `yaml:"audience" toml:"audience" env:"AUTH_AUDIENCE" usage:"aud tokens must carry, if set"`
+	Leeway      time.Duration `yaml:"leeway" toml:"leeway" env:"AUTH_LEEWAY" usage:"allowed clock skew for exp and nbf"`
+	Disabled    bool          `yaml:"disabled" toml:"disabled" env:"AUTH_DISABLED" usage:"accept unauthenticated requests, for local development only"`
+}
+
+// RateLimitConfig configures how fast each caller may call each class of
+// procedures: reads, writes and imports
+type RateLimitConfig struct {
+	ReadPerMinute    int      `yaml:"read_per_minute" toml:"read_per_minute" env:"RATE_LIMIT_READ_PER_MINUTE" usage:"read requests a caller may make a minute, 0 for no limit"`
+	ReadBurst        int      `yaml:"read_burst" toml:"read_burst" env:"RATE_LIMIT_READ_BURST" usage:"read requests a caller may make at once"`
+	WritePerMinute   int      `yaml:"write_per_minute" toml:"write_per_minute" env:"RATE_LIMIT_WRITE_PER_MINUTE" usage:"write requests a caller may make a minute, 0 for no limit"`
+	WriteBurst       int      `yaml:"write_burst" toml:"write_burst" env:"RATE_LIMIT_WRITE_BURST" usage:"write requests a caller may make at once"`
+	ImportPerMinute  int      `yaml:"import_per_minute" toml:"import_per_minute" env:"RATE_LIMIT_IMPORT_PER_MINUTE" usage:"import requests a caller may make a minute, 0 for no limit"`
+	ImportBurst      int      `yaml:"import_burst" toml:"import_burst" env:"RATE_LIMIT_IMPORT_BURST" usage:"import requests a caller may make at once"`
+	ImportProcedures []string `yaml:"import_procedures" toml:"import_procedures" env:"RATE_LIMIT_IMPORT_PROCEDURES" usage:"comma-separated procedures limited as imports"`
+}
+
+// Limits returns the configuration of the rate limiter
+func (c RateLimitConfig) Limits() ratelimit.Config {
+	return ratelimit.Config{
+		Read:             ratelimit.Limit{PerMinute: c.ReadPerMinute, Burst: c.ReadBurst},
+		Write:            ratelimit.Limit{PerMinute: c.WritePerMinute, Burst: c.WriteBurst},
+		Import:           ratelimit.Limit{PerMinute: c.ImportPerMinute, Burst: c.ImportBurst},
+		ImportProcedures: c.ImportProcedures,
+	}
+}
+
+// QuotaConfig configures how much a user may store
+type QuotaConfig struct {
+	MaxLumesPerLumo int `yaml:"max_lumes_per_lumo" toml:"max_lumes_per_lumo" env:"QUOTA_MAX_LUMES_PER_LUMO" usage:"Lumes a Lumo may hold outside the trash, 0 for no limit"`
+	MaxLumosPerUser int `yaml:"max_lumos_per_user" toml:"max_lumos_per_user" env:"QUOTA_MAX_LUMOS_PER_USER" usage:"Lumos a user may own outside the trash, 0 for no limit"`
+}
+
+// TrashConfig configures how long deleted entities are kept
+type TrashConfig struct {
+	Retention     time.Duration `yaml:"retention" toml:"retention" env:"TRASH_RETENTION" usage:"how long deleted Lumos, Lumes and Links stay in the trash"`
+	PurgeInterval time.Duration `yaml:"purge_interval" toml:"purge_interval" env:"TRASH_PURGE_INTERVAL" usage:"how often the trash is purged"`
+}
+
+// AuditConfig configures how long the audit log is kept
+type AuditConfig struct {
+	Retention     time.Duration `yaml:"retention" toml:"retention" env:"AUDIT_RETENTION" usage:"how long audit log entries are kept, 0 for ever"`
+	PurgeInterval time.Duration `yaml:"purge_interval" toml:"purge_interval" env:"AUDIT_PURGE_INTERVAL" usage:"how often old audit log entries are purged"`
+}
+
+// IdempotencyConfig configures how long responses are replayed to create
+// calls retried with the same idempotency key
+type IdempotencyConfig struct {
+	TTL           time.Duration `yaml:"ttl" toml:"ttl" env:"IDEMPOTENCY_TTL" usage:"how long the response to a call with an idempotency key is replayed"`
+	Lease         time.Duration `yaml:"lease" toml:"lease" env:"IDEMPOTENCY_LEASE" usage:"how long a call holds its idempotency key before a retry may take over a key left without a response"`
+	PurgeInterval time.Duration `yaml:"purge_interval" toml:"purge_interval" env:"IDEMPOTENCY_PURGE_INTERVAL" usage:"how often expired idempotency keys are purged"`
+}
+
+// OutboxConfig configures where domain events are published
+type OutboxConfig struct {
+	NATSURL           string        `yaml:"nats_url" toml:"nats_url" env:"OUTBOX_NATS_URL" secret:"true" usage:"NATS server to publish events to, if set"`
+	NATSSubjectPrefix string        `yaml:"nats_subject_prefix" toml:"nats_subject_prefix" env:"OUTBOX_NATS_SUBJECT_PREFIX" usage:"prefix of the NATS subjects events are published on"`
+	WebhookURL        string        `yaml:"webhook_url" toml:"webhook_url" env:"OUTBOX_WEBHOOK_URL" secret:"true" usage:"URL to POST events to, if set"`
+	PollInterval      time.Duration `yaml:"poll_interval" toml:"poll_interval" env:"OUTBOX_POLL_INTERVAL" usage:"how often the relay looks for events to publish"`
+	MaxAttempts       int           `yaml:"max_attempts" toml:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS" usage:"delivery attempts before an event is marked as failed"`
+}
+
+// WebhookConfig configures the delivery of user webhooks
+type WebhookConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval" toml:"poll_interval" env:"WEBHOOK_POLL_INTERVAL" usage:"how often queued webhook deliveries are looked for"`
+	MaxAttempts  int           `yaml:"max_attempts" toml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS" usage:"attempts before a webhook delivery is marked as failed"`
+	DisableAfter int           `yaml:"disable_after" toml:"disable_after" env:"WEBHOOK_DISABLE_AFTER" usage:"failed attempts in a row after which a webhook is disabled"`
+	Timeout      time.Duration `yaml:"timeout" toml:"timeout" env:"WEBHOOK_TIMEOUT" usage:"how long an endpoint has to respond"`
+	// AllowPrivateNetworks lets webhooks reach loopback and private
+	// addresses, for local development only
+	AllowPrivateNetworks bool `yaml:"allow_private_networks" toml:"allow_private_networks" env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS" usage:"deliver webhooks to loopback, link-local and private addresses"`
+}
+
+// MetricsConfig configures the Prometheus metrics
+type MetricsConfig struct {
+	Addr           string        `yaml:"addr" toml:"addr" env:"METRICS_ADDR" usage:"address to serve /metrics on instead of next to the API, if set"`
+	DomainInterval time.Duration `yaml:"domain_interval" toml:"domain_interval" env:"METRICS_DOMAIN_INTERVAL" usage:"how often the Lumo, Lume and Link gauges are recounted"`
+}
+
+// TracingConfig configures OpenTelemetry tracing
+type TracingConfig struct {
+	Exporter string `yaml:"exporter" toml:"exporter" env:"OTEL_TRACES_EXPORTER" usage:"otlp, stdout or none"`
+}
+
+// FeaturesConfig turns optional parts of the server on and off
+type FeaturesConfig struct {
+	Reflection bool `yaml:"reflection" toml:"reflection" env:"FEATURE_REFLECTION" usage:"serve gRPC reflection for grpcui and grpcurl"`
+	Metrics    bool `yaml:"metrics" toml:"metrics" env:"FEATURE_METRICS" usage:"collect and serve Prometheus metrics"`
+	Webhooks   bool `yaml:"webhooks" toml:"webhooks" env:"FEATURE_WEBHOOKS" usage:"serve the WebhookService and deliver user webhooks"`
+}
+
+// Default returns the configuration used where nothing else is set
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:               ":8080",
+			ReadHeaderTimeout:  10 * time.Second,
+			IdleTimeout:        2 * time.Minute,
+			ShutdownTimeout:    30 * time.Second,
+			HealthCheckTimeout: 2 * time.Second,
+		},
+		TLS: TLSConfig{
+			ReloadInterval: time.Minute,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"*"},
+			MaxAge:         time.Hour,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		DB: DBConfig{
+			Host:         "localhost",
+			Port:         5432,
+			User:         "postgres",
+			Password:     "postgres",
+			Name:         "lumo_db",
+			SSLMode:      "disable",
+			MaxOpenConns: 25,
+			MaxIdleConns: 5,
+		},
+		Auth: AuthConfig{
+			Leeway: 30 * time.Second,
+		},
+		RateLimit: RateLimitConfig{
+			ReadPerMinute:   600,
+			ReadBurst:       100,
+			WritePerMinute:  120,
+			WriteBurst:      30,
+			ImportPerMinute: 10,
+			ImportBurst:     2,
+			ImportProcedures: []string{
+				"/history.v1.HistoryService/RestoreLumo",
+				"/trash.v1.TrashService/Restore",
+			},
+		},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Audit: AuditConfig{
+			Retention:     90 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:           24 * time.Hour,
+			Lease:         time.Minute,
+			PurgeInterval: time.Hour,
+		},
+		Outbox: OutboxConfig{
+			NATSSubjectPrefix: "lumo.events",
+			PollInterval:      time.Second,
+			MaxAttempts:       10,
+		},
+		Webhook: WebhookConfig{
+			PollInterval: time.Second,
+			MaxAttempts:  8,
+			DisableAfter: 20,
+			Timeout:      10 * time.Second,
+		},
+		Metrics: MetricsConfig{
+			DomainInterval: time.Minute,
+		},
+		Tracing: TracingConfig{
+			Exporter: tracing.ExporterNone,
+		},
+		Features: FeaturesConfig{
+			Reflection: true,
+			Metrics:    true,
+			Webhooks:   true,
+		},
+	}
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the environment variable the config file can be given in
+// instead of the -config flag
+const FileEnv = "LUMO_CONFIG"
+
+// field is a setting of the Config
+type field struct {
+	// Path of the setting in the file and name of its flag, e.g. db.port
+	path   string
+	env    string
+	usage  string
+	secret bool
+	value  reflect.Value
+}
+
+// fields lists the settings of cfg in order
+func fields(cfg *Config) []field {
+	var fs []field
+	sections := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		sectionName := sections.Type().Field(i).Tag.Get("yaml")
+		section := sections.Field(i)
+		for j := 0; j < section.NumField(); j++ {
+			tag := section.Type().Field(j).Tag
+			fs = append(fs, field{
+				path:   sectionName + "." + tag.Get("yaml"),
+				env:    tag.Get("env"),
+				usage:  tag.Get("usage"),
+				secret: tag.Get("secret") == "true",
+				value:  section.Field(j),
+			})
+		}
+	}
+	return fs
+}
+
+// set parses s into the field
+func (f field) set(s string) error {
+	switch v := f.value.Addr().Interface().(type) {
+	case *string:
+		*v = s
+	case *int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+		*v = n
+	case *bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", s)
+		}
+		*v = b
+	case *time.Duration:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration", s)
+		}
+		*v = d
+	case *[]string:
+		*v = splitList(s)
+	default:
+		panic("config: unsupported type of " + f.path)
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping empty items
+func splitList(s string) []string {
+	items := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Load builds the Config from the defaults, the config file named by the
+// -config flag or LUMO_CONFIG, the environment and the flags in args, and
+// validates it. lookupEnv is normally os.LookupEnv. -h and -help return
+// flag.ErrHelp after writing the flags to output.
+func Load(args []string, lookupEnv func(string) (string, bool), output io.Writer) (*Config, error) {
+	cfg := Default()
+	settings := fields(cfg)
+
+	// Flags are parsed first to find the file, but applied last
+	flagSet := flag.NewFlagSet("lumo", flag.ContinueOnError)
+	flagSet.SetOutput(output)
+	file := flagSet.String("config", "", "YAML or TOML config file, also read from $"+FileEnv)
+	flagValues := map[string]string{}
+	for _, setting := range settings {
+		usage := setting.usage
+		if setting.env != "" {
+			usage += " ($" + setting.env + ")"
+		}
+		flagSet.Func(setting.path, usage, func(s string) error {
+			flagValues[setting.path] = s
+			return nil
+		})
+	}
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+	if flagSet.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments %q", flagSet.Args())
+	}
+
+	if *file == "" {
+		*file, _ = lookupEnv(FileEnv)
+	}
+	if *file != "" {
+		if err := loadFile(cfg, *file); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, setting := range settings {
+		value, ok := lookupEnv(setting.env)
+		if setting.env == "" || !ok {
+			continue
+		}
+		if err := setting.set(value); err != nil {
+			return nil, fmt.Errorf("$%s: %w", setting.env, err)
+		}
+	}
+
+	for _, setting := range settings {
+		value, ok := flagValues[setting.path]
+		if !ok {
+			continue
+		}
+		if err := setting.set(value); err != nil {
+			return nil, fmt.Errorf("-%s: %w", setting.path, err)
+		}
+	}
+
+	// Unset lists are empty rather than nil, the same as when they are
+	// read back from a printed file
+	for _, setting := range settings {
+		if list, ok := setting.value.Addr().Interface().(*[]string); ok && *list == nil {
+			*list = []string{}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile decodes a YAML or TOML file, by its extension, over cfg.
+// Settings the file doesn't know are rejected, so typos don't go unnoticed.
+func loadFile(cfg *Config, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	case ".toml":
+		metadata, err := toml.Decode(string(content), cfg)
+		if err != nil {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("invalid config file %s: unknown settings %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("config file %s must end in .yaml, .yml or .toml", path)
+	}
+	return nil
+}
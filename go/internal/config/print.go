@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redacted replaces the value of secrets in Print
+const redacted = "REDACTED"
+
+// Print writes cfg as a YAML config file with every setting, secrets that
+// are set replaced by REDACTED
+func Print(w io.Writer, cfg *Config) error {
+	var b strings.Builder
+	section := ""
+	for _, setting := range fields(cfg) {
+		sectionName, key, _ := strings.Cut(setting.path, ".")
+		if sectionName != section {
+			if section != "" {
+				b.WriteByte('\n')
+			}
+			fmt.Fprintf(&b, "%s:\n", sectionName)
+			section = sectionName
+		}
+
+		value := formatValue(setting.value)
+		if setting.secret && !setting.value.IsZero() {
+			value = strconv.Quote(redacted)
+		}
+		fmt.Fprintf(&b, "  %s: %s\n", key, value)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatValue formats a setting as a YAML value that reads back the same
+func formatValue(v reflect.Value) string {
+	switch value := v.Interface().(type) {
+	case string:
+		return strconv.Quote(value)
+	case time.Duration:
+		return strconv.Quote(value.String())
+	case []string:
+		quoted := make([]string, len(value))
+		for i, item := range value {
+			quoted[i] = strconv.Quote(item)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mcdev12/lumo/go/internal/cors"
+	"github.com/mcdev12/lumo/go/internal/logging"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+)
+
+// Validate reports every setting that can't work, so the server fails at
+// startup rather than on the first request that needs it
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(isAddr(c.Server.Addr), "server.addr %q is not a host:port address", c.Server.Addr)
+	check(c.Server.ReadHeaderTimeout > 0, "server.read_header_timeout must be positive")
+	check(c.Server.IdleTimeout >= 0, "server.idle_timeout must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+	check(c.Server.HealthCheckTimeout > 0, "server.health_check_timeout must be positive")
+
+	check((c.TLS.CertFile == "") == (c.TLS.KeyFile == ""), "tls.cert_file and tls.key_file must be set together")
+	check(c.TLS.ReloadInterval > 0, "tls.reload_interval must be positive")
+	if !c.TLS.Enabled() {
+		check(c.TLS.ClientCAFile == "", "tls.client_ca_file needs tls.cert_file")
+		check(c.TLS.H2CAddr == "", "tls.h2c_addr needs tls.cert_file")
+	}
+	check(c.TLS.ClientCAFile != "" || !c.TLS.RequireClientCert, "tls.require_client_cert needs tls.client_ca_file")
+	check(c.TLS.ClientCAFile != "" || len(c.TLS.ClientIdentities) == 0, "tls.client_identities needs tls.client_ca_file")
+	if _, err := c.TLS.Services(); err != nil {
+		errs = append(errs, fmt.Errorf("tls.client_identities: %w", err))
+	}
+	if c.TLS.H2CAddr != "" {
+		check(isAddr(c.TLS.H2CAddr), "tls.h2c_addr %q is not a host:port address", c.TLS.H2CAddr)
+		check(c.TLS.H2CAddr != c.Server.Addr, "tls.h2c_addr must differ from server.addr")
+	}
+
+	if _, err := cors.New(c.CORS.Policy()); err != nil {
+		errs = append(errs, fmt.Errorf("cors: %w", err))
+	}
+	check(c.CORS.MaxAge >= 0, "cors.max_age must not be negative")
+
+	_, err := logging.ParseLevel(c.Log.Level)
+	check(err == nil, "log.level %q is not debug, info, warn or error", c.Log.Level)
+
+	if c.DB.DSN == "" {
+		check(c.DB.Host != "", "db.host is required unless db.dsn is set")
+		check(c.DB.Port > 0 && c.DB.Port < 65536, "db.port %d is not a port", c.DB.Port)
+		check(c.DB.Name != "", "db.name is required unless db.dsn is set")
+	}
+	check(c.DB.MaxOpenConns > 0, "db.max_open_conns must be positive")
+	check(c.DB.MaxIdleConns >= 0 && c.DB.MaxIdleConns <= c.DB.MaxOpenConns, "db.max_idle_conns must be between 0 and db.max_open_conns")
+	check(c.DB.ConnMaxLifetime >= 0, "db.conn_max_lifetime must not be negative")
+	check(c.DB.ConnMaxIdleTime >= 0, "db.conn_max_idle_time must not be negative")
+
+	if !c.Auth.Disabled {
+		check(c.Auth.HS256Secret != "" || c.Auth.JWKS != "", "auth.hs256_secret or auth.jwks is required unless auth.disabled is set")
+	}
+	check(c.Auth.Leeway >= 0, "auth.leeway must not be negative")
+
+	for _, limit := range []struct {
+		class            string
+		perMinute, burst int
+	}{
+		{"read", c.RateLimit.ReadPerMinute, c.RateLimit.ReadBurst},
+		{"write", c.RateLimit.WritePerMinute, c.RateLimit.WriteBurst},
+		{"import", c.RateLimit.ImportPerMinute, c.RateLimit.ImportBurst},
+	} {
+		check(limit.perMinute >= 0, "rate_limit.%s_per_minute must not be negative", limit.class)
+		check(limit.perMinute <= 0 || limit.burst > 0, "rate_limit.%s_burst must be positive", limit.class)
+	}
+	for _, procedure := range c.RateLimit.ImportProcedures {
+		check(strings.Count(procedure, "/") == 2 && strings.HasPrefix(procedure, "/"), "rate_limit.import_procedures: %q is not a procedure like /package.Service/Method", procedure)
+	}
+	check(c.Quota.MaxLumesPerLumo >= 0, "quota.max_lumes_per_lumo must not be negative")
+	check(c.Quota.MaxLumosPerUser >= 0, "quota.max_lumos_per_user must not be negative")
+
+	check(c.Trash.Retention > 0, "trash.retention must be positive")
+	check(c.Trash.PurgeInterval > 0, "trash.purge_interval must be positive")
+	check(c.Audit.Retention >= 0, "audit.retention must not be negative")
+	check(c.Audit.Retention == 0 || c.Audit.PurgeInterval > 0, "audit.purge_interval must be positive")
+	check(c.Idempotency.TTL > 0, "idempotency.ttl must be positive")
+	check(c.Idempotency.Lease > 0 && c.Idempotency.Lease <= c.Idempotency.TTL, "idempotency.lease must be positive and at most idempotency.ttl")
+	check(c.Idempotency.PurgeInterval > 0, "idempotency.purge_interval must be positive")
+
+	if c.Outbox.NATSURL != "" {
+		check(isURL(c.Outbox.NATSURL, "nats"), "outbox.nats_url is not a nats:// URL")
+	}
+	if c.Outbox.WebhookURL != "" {
+		check(isURL(c.Outbox.WebhookURL, "http", "https"), "outbox.webhook_url is not an http(s) URL")
+	}
+	check(c.Outbox.PollInterval > 0, "outbox.poll_interval must be positive")
+	check(c.Outbox.MaxAttempts > 0, "outbox.max_attempts must be positive")
+
+	check(c.Webhook.PollInterval > 0, "webhook.poll_interval must be positive")
+	check(c.Webhook.MaxAttempts > 0, "webhook.max_attempts must be positive")
+	check(c.Webhook.DisableAfter > 0, "webhook.disable_after must be positive")
+	check(c.Webhook.Timeout > 0, "webhook.timeout must be positive")
+
+	if c.Metrics.Addr != "" {
+		check(isAddr(c.Metrics.Addr), "metrics.addr %q is not a host:port address", c.Metrics.Addr)
+	}
+	check(c.Metrics.DomainInterval > 0, "metrics.domain_interval must be positive")
+
+	exporters := []string{tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout}
+	check(slices.Contains(exporters, c.Tracing.Exporter), "tracing.exporter %q is not otlp, stdout or none", c.Tracing.Exporter)
+
+	return errors.Join(errs...)
+}
+
+// isAddr reports whether s is a host:port address, the host being optional
+func isAddr(s string) bool {
+	_, port, err := net.SplitHostPort(s)
+	return err == nil && port != ""
+}
+
+// isURL reports whether s is an absolute URL with one of the schemes
+func isURL(s string, schemes ...string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Host != "" && slices.Contains(schemes, u.Scheme)
+}
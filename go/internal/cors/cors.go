@@ -0,0 +1,244 @@
+package cors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/models/version"
+)
+
+// allowedMethods are the methods Connect, gRPC-Web and the health endpoints
+// use
+var allowedMethods = []string{http.MethodGet, http.MethodPost}
+
+// allowedHeaders are the request headers the Connect and gRPC-Web protocols,
+// authentication, idempotency keys, request IDs and trace propagation need
+var allowedHeaders = []string{
+	"Content-Type",
+	"Connect-Protocol-Version",
+	"Connect-Timeout-Ms",
+	"Connect-Accept-Encoding",
+	"Connect-Content-Encoding",
+	"Grpc-Timeout",
+	"Grpc-Accept-Encoding",
+	"Grpc-Encoding",
+	"X-Grpc-Web",
+	"X-User-Agent",
+	"Authorization",
+	"Idempotency-Key",
+	"X-Request-Id",
+	"Traceparent",
+	"Tracestate",
+	"Baggage",
+}
+
+// exposedHeaders are the response headers browsers let clients read: the
+// status gRPC-Web sends in headers for errors without a body, the encodings
+// Connect negotiated, the request ID, how long rate limited callers wait,
+// whether a response was replayed for an idempotency key and the current
+// version of an entity an update conflicted with
+var exposedHeaders = []string{
+	"Grpc-Status",
+	"Grpc-Message",
+	"Grpc-Status-Details-Bin",
+	"Connect-Accept-Encoding",
+	"Connect-Content-Encoding",
+	"X-Request-Id",
+	"Retry-After",
+	"Idempotent-Replayed",
+	version.MetadataKey,
+}
+
+// Config configures which web origins may call the API
+type Config struct {
+	// AllowedOrigins are "*" for any origin, origins such as
+	// https://app.example.com or patterns such as https://*.example.com,
+	// which match any subdomain
+	AllowedOrigins []string
+	// AllowCredentials lets browsers send cookies and client certificates.
+	// It can't be combined with "*".
+	AllowCredentials bool
+	// AllowedHeaders and ExposedHeaders are added to the headers the
+	// protocols need
+	AllowedHeaders []string
+	ExposedHeaders []string
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+}
+
+// origin is an allowed origin or pattern
+type origin struct {
+	scheme string
+	// host is the host of an origin, or the domain whose subdomains a
+	// pattern matches
+	host     string
+	port     string
+	wildcard bool
+}
+
+// parseOrigin parses an allowed origin or pattern
+func parseOrigin(s string) (origin, error) {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return origin{}, fmt.Errorf("%q is not \"*\", an origin like https://app.example.com or a pattern like https://*.example.com", s)
+	}
+
+	o := origin{scheme: u.Scheme, host: strings.ToLower(u.Hostname()), port: u.Port()}
+	if domain, ok := strings.CutPrefix(o.host, "*."); ok {
+		o.host, o.wildcard = domain, true
+	}
+	if strings.Contains(o.host, "*") {
+		return origin{}, fmt.Errorf("%q may only start with a wildcard", s)
+	}
+	return o, nil
+}
+
+// matches reports whether the origin or pattern allows u
+func (o origin) matches(u *url.URL) bool {
+	if u.Scheme != o.scheme || u.Port() != o.port {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if o.wildcard {
+		return strings.HasSuffix(host, "."+o.host)
+	}
+	return host == o.host
+}
+
+// Policy answers preflight requests and adds CORS headers to the responses
+// for allowed origins
+type Policy struct {
+	anyOrigin        bool
+	origins          []origin
+	allowCredentials bool
+	allowedHeaders   []string
+	allowedMethods   string
+	exposedHeaders   string
+	maxAge           string
+}
+
+// New creates the Policy for cfg
+func New(cfg Config) (*Policy, error) {
+	p := &Policy{
+		allowCredentials: cfg.AllowCredentials,
+		allowedHeaders:   append(slices.Clone(allowedHeaders), cfg.AllowedHeaders...),
+		allowedMethods:   strings.Join(allowedMethods, ", "),
+		exposedHeaders:   strings.Join(append(slices.Clone(exposedHeaders), cfg.ExposedHeaders...), ", "),
+		maxAge:           strconv.Itoa(int(cfg.MaxAge.Seconds())),
+	}
+
+	var errs []error
+	for _, s := range cfg.AllowedOrigins {
+		if s == "*" {
+			p.anyOrigin = true
+			continue
+		}
+		o, err := parseOrigin(s)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		p.origins = append(p.origins, o)
+	}
+	if p.anyOrigin && cfg.AllowCredentials {
+		errs = append(errs, errors.New(`credentials can't be allowed for any origin "*"`))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// allowsOrigin reports whether requests from the Origin header value s are
+// allowed
+func (p *Policy) allowsOrigin(s string) bool {
+	if p.anyOrigin {
+		return true
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(p.origins, func(o origin) bool {
+		return o.matches(u)
+	})
+}
+
+// allowsHeaders reports whether every header of the comma-separated
+// Access-Control-Request-Headers value is allowed
+func (p *Policy) allowsHeaders(requested string) bool {
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if !slices.ContainsFunc(p.allowedHeaders, func(allowed string) bool {
+			return strings.EqualFold(allowed, header)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// setAllowOrigin allows the origin to read the response. Only "*" is the
+// same for every origin, so caches are told responses vary otherwise.
+func (p *Policy) setAllowOrigin(header http.Header, origin string) {
+	if p.anyOrigin && !p.allowCredentials {
+		header.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	header.Set("Access-Control-Allow-Origin", origin)
+	if p.allowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// Handler applies the policy in front of h. Preflight requests are answered
+// with 204 if the origin, method and headers are allowed and with 403
+// otherwise; they never reach h.
+func (p *Policy) Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		if !p.anyOrigin {
+			header.Add("Vary", "Origin")
+		}
+
+		origin := r.Header.Get("Origin")
+		requestedMethod := r.Header.Get("Access-Control-Request-Method")
+		if r.Method == http.MethodOptions && origin != "" && requestedMethod != "" {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+			requestedHeaders := r.Header.Get("Access-Control-Request-Headers")
+			if !p.allowsOrigin(origin) ||
+				!slices.Contains(allowedMethods, requestedMethod) ||
+				!p.allowsHeaders(requestedHeaders) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			p.setAllowOrigin(header, origin)
+			header.Set("Access-Control-Allow-Methods", p.allowedMethods)
+			if requestedHeaders != "" {
+				header.Set("Access-Control-Allow-Headers", requestedHeaders)
+			}
+			header.Set("Access-Control-Max-Age", p.maxAge)
+			header.Set("Access-Control-Expose-Headers", p.exposedHeaders)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if origin != "" && p.allowsOrigin(origin) {
+			p.setAllowOrigin(header, origin)
+			header.Set("Access-Control-Expose-Headers", p.exposedHeaders)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
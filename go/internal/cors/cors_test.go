@@ -0,0 +1,204 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// CORSTestSuite is a test suite for the CORS policy
+type CORSTestSuite struct {
+	suite.Suite
+	reached bool
+}
+
+// SetupTest is called before each test
+func (s *CORSTestSuite) SetupTest() {
+	s.reached = false
+}
+
+// TestCORSSuite runs the test suite
+func TestCORSSuite(t *testing.T) {
+	suite.Run(t, new(CORSTestSuite))
+}
+
+// serve sends the request through a handler with the policy for cfg
+func (s *CORSTestSuite) serve(cfg Config, r *http.Request) *httptest.ResponseRecorder {
+	policy, err := New(cfg)
+	s.Require().NoError(err)
+	handler := policy.Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.reached = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, r)
+	return recorder
+}
+
+// preflight creates a preflight request
+func preflight(origin, method, headers string) *http.Request {
+	r := httptest.NewRequest(http.MethodOptions, "/lumo.v1.LumoService/GetLumo", nil)
+	r.Header.Set("Origin", origin)
+	r.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		r.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	return r
+}
+
+// Test preflight requests are answered without reaching the handler
+func (s *CORSTestSuite) TestPreflight() {
+	cfg := Config{
+		AllowedOrigins: []string{"https://app.example.com", "https://*.preview.example.com"},
+		AllowedHeaders: []string{"Idempotency-Key"},
+		MaxAge:         time.Hour,
+	}
+
+	for _, tc := range []struct {
+		name    string
+		request *http.Request
+		allowed bool
+	}{
+		{"allowed origin", preflight("https://app.example.com", "POST", "content-type,connect-protocol-version,authorization"), true},
+		{"pattern", preflight("https://pr-12.preview.example.com", "POST", "Content-Type"), true},
+		{"configured header", preflight("https://app.example.com", "POST", "idempotency-key"), true},
+		{"GET", preflight("https://app.example.com", "GET", ""), true},
+		{"other origin", preflight("https://evil.example.com", "POST", "content-type"), false},
+		{"pattern without subdomain", preflight("https://preview.example.com", "POST", ""), false},
+		{"other scheme", preflight("http://app.example.com", "POST", ""), false},
+		{"other port", preflight("https://app.example.com:8443", "POST", ""), false},
+		{"other method", preflight("https://app.example.com", "DELETE", ""), false},
+		{"other header", preflight("https://app.example.com", "POST", "content-type,x-secret"), false},
+	} {
+		s.Run(tc.name, func() {
+			// Act
+			recorder := s.serve(cfg, tc.request)
+
+			// Assert
+			s.False(s.reached)
+			s.Contains(recorder.Header().Values("Vary"), "Origin")
+			if !tc.allowed {
+				s.Equal(http.StatusForbidden, recorder.Code)
+				s.Empty(recorder.Header().Get("Access-Control-Allow-Origin"))
+				return
+			}
+			s.Equal(http.StatusNoContent, recorder.Code)
+			s.Equal(tc.request.Header.Get("Origin"), recorder.Header().Get("Access-Control-Allow-Origin"))
+			s.Equal("GET, POST", recorder.Header().Get("Access-Control-Allow-Methods"))
+			s.Equal(tc.request.Header.Get("Access-Control-Request-Headers"), recorder.Header().Get("Access-Control-Allow-Headers"))
+			s.Equal("3600", recorder.Header().Get("Access-Control-Max-Age"))
+			s.Contains(recorder.Header().Get("Access-Control-Expose-Headers"), "Current-Version")
+			s.Empty(recorder.Header().Get("Access-Control-Allow-Credentials"))
+		})
+	}
+}
+
+// Test any origin is answered with "*" unless credentials are allowed
+func (s *CORSTestSuite) TestPreflightAnyOrigin() {
+	// Act
+	recorder := s.serve(Config{AllowedOrigins: []string{"*"}}, preflight("https://anywhere.test", "POST", "content-type"))
+
+	// Assert
+	s.Equal(http.StatusNoContent, recorder.Code)
+	s.Equal("*", recorder.Header().Get("Access-Control-Allow-Origin"))
+	s.NotContains(recorder.Header().Values("Vary"), "Origin")
+}
+
+// Test credentials echo the origin
+func (s *CORSTestSuite) TestPreflightCredentials() {
+	// Arrange
+	cfg := Config{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true}
+
+	// Act
+	recorder := s.serve(cfg, preflight("https://app.example.com", "POST", ""))
+
+	// Assert
+	s.Equal(http.StatusNoContent, recorder.Code)
+	s.Equal("https://app.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+	s.Equal("true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+// Test OPTIONS requests that aren't preflights reach the handler
+func (s *CORSTestSuite) TestOptionsWithoutPreflight() {
+	// Arrange
+	r := httptest.NewRequest(http.MethodOptions, "/", nil)
+	r.Header.Set("Origin", "https://app.example.com")
+
+	// Act
+	recorder := s.serve(Config{AllowedOrigins: []string{"https://app.example.com"}}, r)
+
+	// Assert
+	s.True(s.reached)
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+// Test responses to allowed origins expose the Connect and gRPC-Web headers
+// followed by the configured ones
+func (s *CORSTestSuite) TestActualRequest() {
+	cfg := Config{
+		AllowedOrigins: []string{"https://app.example.com"},
+		ExposedHeaders: []string{"X-Example-Header"},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		origin  string
+		allowed bool
+	}{
+		{"allowed origin", "https://app.example.com", true},
+		{"other origin", "https://evil.example.com", false},
+		{"no origin", "", false},
+	} {
+		s.Run(tc.name, func() {
+			// Arrange
+			r := httptest.NewRequest(http.MethodPost, "/lumo.v1.LumoService/GetLumo", nil)
+			if tc.origin != "" {
+				r.Header.Set("Origin", tc.origin)
+			}
+
+			// Act
+			recorder := s.serve(cfg, r)
+
+			// Assert
+			s.True(s.reached)
+			s.Equal(http.StatusOK, recorder.Code)
+			s.Contains(recorder.Header().Values("Vary"), "Origin")
+			if !tc.allowed {
+				s.Empty(recorder.Header().Get("Access-Control-Allow-Origin"))
+				s.Empty(recorder.Header().Get("Access-Control-Expose-Headers"))
+				return
+			}
+			s.Equal(tc.origin, recorder.Header().Get("Access-Control-Allow-Origin"))
+			s.Equal(
+				"Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin, Connect-Accept-Encoding, Connect-Content-Encoding, X-Request-Id, Retry-After, Idempotent-Replayed, Current-Version, X-Example-Header",
+				recorder.Header().Get("Access-Control-Expose-Headers"),
+			)
+		})
+	}
+}
+
+// Test invalid origins and credentials for any origin are rejected
+func (s *CORSTestSuite) TestNewInvalid() {
+	for _, tc := range []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{"path", Config{AllowedOrigins: []string{"https://app.example.com/"}}, `"https://app.example.com/" is not`},
+		{"scheme", Config{AllowedOrigins: []string{"app.example.com"}}, `"app.example.com" is not`},
+		{"inner wildcard", Config{AllowedOrigins: []string{"https://app.*.example.com"}}, "may only start with a wildcard"},
+		{"credentials", Config{AllowedOrigins: []string{"*"}, AllowCredentials: true}, "credentials can't be allowed"},
+	} {
+		s.Run(tc.name, func() {
+			// Act
+			_, err := New(tc.cfg)
+
+			// Assert
+			s.ErrorContains(err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+)
+
+// drainInterceptor ends streams once the server starts shutting down
+type drainInterceptor struct {
+	draining <-chan struct{}
+}
+
+// DrainInterceptor returns an interceptor cancelling the context of every
+// streaming request once the server starts shutting down, so long-lived
+// streams end and the server can finish draining. Unary requests are left to
+// complete.
+func (c *Checker) DrainInterceptor() connect.Interceptor {
+	return &drainInterceptor{draining: c.draining}
+}
+
+// WrapUnary leaves unary requests alone
+func (i *drainInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return next
+}
+
+// WrapStreamingClient leaves outgoing streams alone
+func (i *drainInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler cancels the stream's context on shutdown
+func (i *drainInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-i.draining:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		return next(ctx, conn)
+	}
+}
@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ServiceName is the fully-qualified name of the gRPC health service
+const ServiceName = "grpc.health.v1.Health"
+
+// watchInterval is how often Watch runs the checks again
+const watchInterval = 5 * time.Second
+
+// NewHandler serves the gRPC health checking protocol for the given services
+// over Connect, gRPC and gRPC-Web. The empty service name stands for the
+// whole server. Every service is serving exactly when the server is ready.
+func NewHandler(checker *Checker, services ...string) (string, http.Handler) {
+	h := &handler{checker: checker, services: map[string]bool{"": true}}
+	for _, service := range services {
+		h.services[service] = true
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/"+ServiceName+"/Check", connect.NewUnaryHandler(
+		"/"+ServiceName+"/Check",
+		h.Check,
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+	))
+	mux.Handle("/"+ServiceName+"/Watch", connect.NewServerStreamHandler(
+		"/"+ServiceName+"/Watch",
+		h.Watch,
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+	))
+	return "/" + ServiceName + "/", mux
+}
+
+// handler implements grpc.health.v1.Health
+type handler struct {
+	checker  *Checker
+	services map[string]bool
+}
+
+// Check returns the status of a service, or CodeNotFound for unknown ones
+func (h *handler) Check(ctx context.Context, req *connect.Request[healthv1.HealthCheckRequest]) (*connect.Response[healthv1.HealthCheckResponse], error) {
+	if !h.services[req.Msg.GetService()] {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("unknown service %q", req.Msg.GetService()))
+	}
+	return connect.NewResponse(&healthv1.HealthCheckResponse{Status: h.status(ctx, req.Msg.GetService())}), nil
+}
+
+// Watch sends the status of a service, and again whenever it changes, until
+// the client goes away or the server shuts down
+func (h *handler) Watch(ctx context.Context, req *connect.Request[healthv1.HealthCheckRequest], stream *connect.ServerStream[healthv1.HealthCheckResponse]) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	last := healthv1.HealthCheckResponse_UNKNOWN
+	for {
+		status := h.status(ctx, req.Msg.GetService())
+		if status != last {
+			if err := stream.Send(&healthv1.HealthCheckResponse{Status: status}); err != nil {
+				return err
+			}
+			last = status
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-h.checker.Draining():
+			if last != healthv1.HealthCheckResponse_NOT_SERVING {
+				return stream.Send(&healthv1.HealthCheckResponse{Status: healthv1.HealthCheckResponse_NOT_SERVING})
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// status runs the checks for a known service
+func (h *handler) status(ctx context.Context, service string) healthv1.HealthCheckResponse_ServingStatus {
+	if !h.services[service] {
+		return healthv1.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+	if !h.checker.Check(ctx).Ready {
+		return healthv1.HealthCheckResponse_NOT_SERVING
+	}
+	return healthv1.HealthCheckResponse_SERVING
+}
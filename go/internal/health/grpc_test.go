@@ -0,0 +1,114 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/suite"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const testService = "test.v1.TestService"
+
+// GRPCTestSuite is a test suite for the gRPC health service and draining
+type GRPCTestSuite struct {
+	suite.Suite
+	checker *Checker
+	dbErr   error
+	server  *httptest.Server
+}
+
+// SetupTest is called before each test
+func (s *GRPCTestSuite) SetupTest() {
+	s.dbErr = nil
+	s.checker = NewChecker(time.Second)
+	s.checker.Add("database", func(context.Context) error { return s.dbErr })
+
+	mux := http.NewServeMux()
+	mux.Handle(NewHandler(s.checker, testService))
+	// A stream that only ends when its context does
+	mux.Handle("/"+testService+"/Stream", connect.NewServerStreamHandler(
+		"/"+testService+"/Stream",
+		func(ctx context.Context, _ *connect.Request[emptypb.Empty], stream *connect.ServerStream[emptypb.Empty]) error {
+			if err := stream.Send(&emptypb.Empty{}); err != nil {
+				return err
+			}
+			<-ctx.Done()
+			return nil
+		},
+		connect.WithInterceptors(s.checker.DrainInterceptor()),
+	))
+	s.server = httptest.NewServer(mux)
+}
+
+// TearDownTest is called after each test
+func (s *GRPCTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// TestGRPCSuite runs the test suite
+func TestGRPCSuite(t *testing.T) {
+	suite.Run(t, new(GRPCTestSuite))
+}
+
+// check calls Check for a service
+func (s *GRPCTestSuite) check(service string) (healthv1.HealthCheckResponse_ServingStatus, error) {
+	client := connect.NewClient[healthv1.HealthCheckRequest, healthv1.HealthCheckResponse](
+		s.server.Client(), s.server.URL+"/"+ServiceName+"/Check", connect.WithGRPC())
+	res, err := client.CallUnary(context.Background(), connect.NewRequest(&healthv1.HealthCheckRequest{Service: service}))
+	if err != nil {
+		return healthv1.HealthCheckResponse_UNKNOWN, err
+	}
+	return res.Msg.GetStatus(), nil
+}
+
+// Test Check follows the checks for the server and known services
+func (s *GRPCTestSuite) TestCheck() {
+	status, err := s.check("")
+	s.NoError(err)
+	s.Equal(healthv1.HealthCheckResponse_SERVING, status)
+
+	s.dbErr = errors.New("connection refused")
+	status, err = s.check(testService)
+	s.NoError(err)
+	s.Equal(healthv1.HealthCheckResponse_NOT_SERVING, status)
+
+	_, err = s.check("other.v1.OtherService")
+	s.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
+
+// Test Watch reports NOT_SERVING and ends when the server shuts down, as
+// do other streams
+func (s *GRPCTestSuite) TestDrain() {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	watchClient := connect.NewClient[healthv1.HealthCheckRequest, healthv1.HealthCheckResponse](
+		s.server.Client(), s.server.URL+"/"+ServiceName+"/Watch", connect.WithGRPC())
+	watch, err := watchClient.CallServerStream(ctx, connect.NewRequest(&healthv1.HealthCheckRequest{}))
+	s.Require().NoError(err)
+	streamClient := connect.NewClient[emptypb.Empty, emptypb.Empty](s.server.Client(), s.server.URL+"/"+testService+"/Stream")
+	stream, err := streamClient.CallServerStream(ctx, connect.NewRequest(&emptypb.Empty{}))
+	s.Require().NoError(err)
+
+	s.Require().True(watch.Receive())
+	s.Equal(healthv1.HealthCheckResponse_SERVING, watch.Msg().GetStatus())
+	s.Require().True(stream.Receive())
+
+	// Act
+	s.checker.Drain()
+
+	// Assert
+	s.Require().True(watch.Receive())
+	s.Equal(healthv1.HealthCheckResponse_NOT_SERVING, watch.Msg().GetStatus())
+	s.False(watch.Receive())
+	s.NoError(watch.Err())
+	s.False(stream.Receive())
+	s.NoError(stream.Err())
+}
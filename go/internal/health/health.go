@@ -0,0 +1,99 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Check reports why a dependency the server needs isn't usable, if it isn't
+type Check func(ctx context.Context) error
+
+// namedCheck is a Check with the name it's reported under
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Result is the outcome of running the checks
+type Result struct {
+	// Ready is true if every check passed and the server isn't shutting down
+	Ready bool `json:"ready"`
+
+	// Checks maps every check to "ok" or what went wrong
+	Checks map[string]string `json:"checks"`
+}
+
+// Checker runs the checks deciding whether the server is ready for requests
+// and tracks whether it is shutting down
+type Checker struct {
+	checks    []namedCheck
+	timeout   time.Duration
+	draining  chan struct{}
+	drainOnce sync.Once
+}
+
+// NewChecker creates a Checker giving every check timeout to finish
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout:  timeout,
+		draining: make(chan struct{}),
+	}
+}
+
+// Add adds a check. Checks must be added before the Checker is used.
+func (c *Checker) Add(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Drain marks the server as shutting down: it isn't ready from now on and
+// Draining is closed
+func (c *Checker) Drain() {
+	c.drainOnce.Do(func() { close(c.draining) })
+}
+
+// Draining is closed once the server starts shutting down
+func (c *Checker) Draining() <-chan struct{} {
+	return c.draining
+}
+
+// isDraining reports whether Drain was called
+func (c *Checker) isDraining() bool {
+	select {
+	case <-c.draining:
+		return true
+	default:
+		return false
+	}
+}
+
+// Check runs every check at once and reports how they went
+func (c *Checker) Check(ctx context.Context) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	errs := make([]error, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = check.check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	result := Result{Ready: !c.isDraining(), Checks: map[string]string{}}
+	for i, check := range c.checks {
+		if errs[i] != nil {
+			result.Ready = false
+			result.Checks[check.name] = errs[i].Error()
+		} else {
+			result.Checks[check.name] = "ok"
+		}
+	}
+	if c.isDraining() {
+		result.Checks["server"] = "shutting down"
+	}
+	return result
+}
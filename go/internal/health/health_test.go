@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// HealthTestSuite is a test suite for the Checker
+type HealthTestSuite struct {
+	suite.Suite
+	checker *Checker
+	dbErr   error
+}
+
+// SetupTest is called before each test
+func (s *HealthTestSuite) SetupTest() {
+	s.dbErr = nil
+	s.checker = NewChecker(50 * time.Millisecond)
+	s.checker.Add("database", func(context.Context) error { return s.dbErr })
+	s.checker.Add("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+}
+
+// TestHealthSuite runs the test suite
+func TestHealthSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
+
+// Test every check is reported and a check that doesn't finish in time fails
+func (s *HealthTestSuite) TestCheck() {
+	// Arrange
+	s.dbErr = errors.New("connection refused")
+
+	// Act
+	result := s.checker.Check(context.Background())
+
+	// Assert
+	s.False(result.Ready)
+	s.Equal(map[string]string{
+		"database": "connection refused",
+		"slow":     "context deadline exceeded",
+	}, result.Checks)
+}
+
+// Test the readiness endpoint answers 200 when ready and 503 when not or
+// when shutting down, while the liveness endpoint always answers
+func (s *HealthTestSuite) TestHandlers() {
+	// Arrange
+	checker := NewChecker(time.Second)
+	checker.Add("database", func(context.Context) error { return s.dbErr })
+	ready := func() (int, Result) {
+		recorder := httptest.NewRecorder()
+		checker.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var result Result
+		s.Require().NoError(json.NewDecoder(recorder.Body).Decode(&result))
+		return recorder.Code, result
+	}
+
+	// Act & Assert
+	code, result := ready()
+	s.Equal(http.StatusOK, code)
+	s.Equal(Result{Ready: true, Checks: map[string]string{"database": "ok"}}, result)
+
+	s.dbErr = errors.New("connection refused")
+	code, _ = ready()
+	s.Equal(http.StatusServiceUnavailable, code)
+
+	s.dbErr = nil
+	checker.Drain()
+	checker.Drain()
+	code, result = ready()
+	s.Equal(http.StatusServiceUnavailable, code)
+	s.Equal("shutting down", result.Checks["server"])
+
+	recorder := httptest.NewRecorder()
+	checker.LivenessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	s.Equal(http.StatusOK, recorder.Code)
+}
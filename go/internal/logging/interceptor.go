@@ -0,0 +1,157 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+)
+
+// maxRequestIDLength caps the request IDs taken from callers
+const maxRequestIDLength = 64
+
+// errInternal is what callers see of a panic
+var errInternal = errors.New("internal error")
+
+// Interceptor gives every request an ID, logs how it went and turns panics
+// into CodeInternal errors. A caller's X-Request-Id is kept if it's a
+// reasonable ID, otherwise a new one is generated; either way it's put in the
+// context, so everything logged for the request carries it, and sent back in
+// the response. It belongs first, after metrics at most, so it sees how
+// every request ended.
+type Interceptor struct {
+	logger *slog.Logger
+}
+
+// NewInterceptor creates a new logging Interceptor
+func NewInterceptor(logger *slog.Logger) *Interceptor {
+	return &Interceptor{
+		logger: logger,
+	}
+}
+
+// WrapUnary logs unary requests
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (res connect.AnyResponse, err error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		start := time.Now()
+		requestID := requestIDOf(req.Header().Get(RequestIDHeader))
+		ctx = WithRequestID(ctx, requestID)
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = i.recovered(ctx, req.Spec().Procedure, recovered)
+				res = nil
+			}
+			if err == nil {
+				res.Header().Set(RequestIDHeader, requestID)
+			} else {
+				// Errors that aren't Connect errors yet become CodeUnknown anyway
+				connectErr := new(connect.Error)
+				if !errors.As(err, &connectErr) {
+					connectErr = connect.NewError(connect.CodeUnknown, err)
+					err = connectErr
+				}
+				connectErr.Meta().Set(RequestIDHeader, requestID)
+			}
+			i.log(ctx, req.Spec().Procedure, req.Peer().Addr, err, start)
+		}()
+
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient leaves outgoing streams alone
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler logs streaming requests once they end
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) (err error) {
+		start := time.Now()
+		requestID := requestIDOf(conn.RequestHeader().Get(RequestIDHeader))
+		ctx = WithRequestID(ctx, requestID)
+		conn.ResponseHeader().Set(RequestIDHeader, requestID)
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = i.recovered(ctx, conn.Spec().Procedure, recovered)
+			}
+			i.log(ctx, conn.Spec().Procedure, conn.Peer().Addr, err, start)
+		}()
+
+		return next(ctx, conn)
+	}
+}
+
+// recovered logs a panic with its stack and returns the error to send instead
+func (i *Interceptor) recovered(ctx context.Context, procedure string, recovered any) error {
+	i.logger.ErrorContext(ctx, "panic serving request",
+		slog.String("procedure", procedure),
+		slog.String("panic", fmt.Sprint(recovered)),
+		slog.String("stack", string(debug.Stack())),
+	)
+	return connect.NewError(connect.CodeInternal, errInternal)
+}
+
+// log writes the access log line of a request, at error level if it failed
+// on the server's side
+func (i *Interceptor) log(ctx context.Context, procedure, peer string, err error, start time.Time) {
+	code := "ok"
+	level := slog.LevelInfo
+	if err != nil {
+		connectCode := connect.CodeOf(err)
+		code = connectCode.String()
+		if isServerError(connectCode) {
+			level = slog.LevelError
+		}
+	}
+
+	attrs := []slog.Attr{
+		slog.String("procedure", procedure),
+		slog.String("code", code),
+		slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+		slog.String("peer", peer),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	i.logger.LogAttrs(ctx, level, "request", attrs...)
+}
+
+// isServerError reports whether a code means the server, rather than the
+// request, is at fault
+func isServerError(code connect.Code) bool {
+	switch code {
+	case connect.CodeUnknown, connect.CodeInternal, connect.CodeDataLoss, connect.CodeUnimplemented:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestIDOf returns the request ID a caller sent if it's short and made of
+// letters, digits, dashes, dots and underscores only, so it's safe to log and
+// to put in SQL comments, or a new one otherwise
+func requestIDOf(sent string) string {
+	if sent == "" || len(sent) > maxRequestIDLength {
+		return uuid.New().String()
+	}
+	for _, c := range sent {
+		isValid := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+			c == '-' || c == '.' || c == '_'
+		if !isValid {
+			return uuid.New().String()
+		}
+	}
+	return sent
+}
@@ -0,0 +1,202 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const testProcedure = "/test.v1.TestService/Test"
+
+// InterceptorTestSuite is a test suite for the Interceptor
+type InterceptorTestSuite struct {
+	suite.Suite
+	logs   *bytes.Buffer
+	server *httptest.Server
+	handle func(ctx context.Context) error
+	seenID string
+}
+
+// SetupTest is called before each test
+func (s *InterceptorTestSuite) SetupTest() {
+	s.logs = &bytes.Buffer{}
+	s.handle = func(context.Context) error { return nil }
+	s.seenID = ""
+
+	logger := NewLogger(s.logs, slog.LevelInfo)
+	handler := func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+		s.seenID, _ = RequestIDFromContext(ctx)
+		if err := s.handle(ctx); err != nil {
+			return nil, err
+		}
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle(testProcedure, connect.NewUnaryHandler(testProcedure, handler, connect.WithInterceptors(NewInterceptor(logger))))
+	s.server = httptest.NewServer(mux)
+}
+
+// TearDownTest is called after each test
+func (s *InterceptorTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// TestInterceptorSuite runs the test suite
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorTestSuite))
+}
+
+// call makes a request, sending requestID if it isn't empty
+func (s *InterceptorTestSuite) call(requestID string) (*connect.Response[emptypb.Empty], error) {
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](s.server.Client(), s.server.URL+testProcedure)
+	req := connect.NewRequest(&emptypb.Empty{})
+	if requestID != "" {
+		req.Header().Set(RequestIDHeader, requestID)
+	}
+	return client.CallUnary(context.Background(), req)
+}
+
+// lines returns the log lines written so far
+func (s *InterceptorTestSuite) lines() []map[string]any {
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(s.logs.String()), "\n") {
+		var decoded map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(line), &decoded))
+		lines = append(lines, decoded)
+	}
+	return lines
+}
+
+// Test a request gets an ID that is passed on, sent back and logged
+func (s *InterceptorTestSuite) TestRequestID() {
+	// Act
+	res, err := s.call("")
+
+	// Assert
+	s.NoError(err)
+	s.NotEmpty(s.seenID)
+	s.Equal(s.seenID, res.Header().Get(RequestIDHeader))
+
+	lines := s.lines()
+	s.Require().Len(lines, 1)
+	s.Equal("INFO", lines[0]["level"])
+	s.Equal("request", lines[0]["msg"])
+	s.Equal(testProcedure, lines[0]["procedure"])
+	s.Equal("ok", lines[0]["code"])
+	s.Equal(s.seenID, lines[0]["request_id"])
+	s.Contains(lines[0], "duration_ms")
+	s.NotEmpty(lines[0]["peer"])
+}
+
+// Test the request ID of a caller is kept unless it's unsafe
+func (s *InterceptorTestSuite) TestCallerRequestID() {
+	cases := []struct {
+		sent string
+		kept bool
+	}{
+		{"abc-123_x.y", true},
+		{"abc 123", false},
+		{"*/ DROP TABLE lumo; /*", false},
+		{strings.Repeat("a", 65), false},
+	}
+
+	for _, tc := range cases {
+		// Act
+		res, err := s.call(tc.sent)
+
+		// Assert
+		s.NoError(err)
+		s.Equal(tc.kept, s.seenID == tc.sent, tc.sent)
+		s.Equal(s.seenID, res.Header().Get(RequestIDHeader))
+	}
+}
+
+// Test errors are logged with their code and carry the request ID
+func (s *InterceptorTestSuite) TestError() {
+	// Arrange
+	s.handle = func(context.Context) error {
+		return connect.NewError(connect.CodeNotFound, errors.New("lumo not found"))
+	}
+
+	// Act
+	_, err := s.call("lookup-1")
+
+	// Assert
+	s.Equal(connect.CodeNotFound, connect.CodeOf(err))
+	var connectErr *connect.Error
+	s.Require().ErrorAs(err, &connectErr)
+	s.Equal("lookup-1", connectErr.Meta().Get(RequestIDHeader))
+
+	lines := s.lines()
+	s.Require().Len(lines, 1)
+	s.Equal("INFO", lines[0]["level"])
+	s.Equal("not_found", lines[0]["code"])
+	s.Equal("not_found: lumo not found", lines[0]["error"])
+}
+
+// Test a panic becomes an internal error and is logged with its stack
+func (s *InterceptorTestSuite) TestPanic() {
+	// Arrange
+	s.handle = func(context.Context) error {
+		panic("boom")
+	}
+
+	// Act
+	_, err := s.call("panic-1")
+
+	// Assert
+	s.Equal(connect.CodeInternal, connect.CodeOf(err))
+	s.NotContains(err.Error(), "boom")
+
+	lines := s.lines()
+	s.Require().Len(lines, 2)
+	s.Equal("ERROR", lines[0]["level"])
+	s.Equal("boom", lines[0]["panic"])
+	s.Equal("panic-1", lines[0]["request_id"])
+	s.Contains(lines[0]["stack"], "runtime/debug.Stack")
+	s.Equal("ERROR", lines[1]["level"])
+	s.Equal("internal", lines[1]["code"])
+}
+
+// Test records logged with a request's context carry its ID, and levels below
+// the configured one are left out
+func (s *InterceptorTestSuite) TestLogger() {
+	// Arrange
+	logger := NewLogger(s.logs, slog.LevelWarn).With(slog.String("component", "test"))
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	// Act
+	logger.InfoContext(ctx, "hidden")
+	logger.WarnContext(ctx, "shown")
+
+	// Assert
+	lines := s.lines()
+	s.Require().Len(lines, 1)
+	s.Equal("shown", lines[0]["msg"])
+	s.Equal("req-1", lines[0]["request_id"])
+	s.Equal("test", lines[0]["component"])
+}
+
+// Test log levels are parsed case-insensitively
+func (s *InterceptorTestSuite) TestParseLevel() {
+	level, err := ParseLevel("DEBUG")
+	s.NoError(err)
+	s.Equal(slog.LevelDebug, level)
+
+	level, err = ParseLevel("warn")
+	s.NoError(err)
+	s.Equal(slog.LevelWarn, level)
+
+	_, err = ParseLevel("loud")
+	s.Error(err)
+}
@@ -0,0 +1,213 @@
+package lumoctl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	linkv1 "github.com/mcdev12/lumo/go/internal/genproto/link/v1"
+	"github.com/mcdev12/lumo/go/internal/genproto/link/v1/linkv1connect"
+	lumev1 "github.com/mcdev12/lumo/go/internal/genproto/lume/v1"
+	"github.com/mcdev12/lumo/go/internal/genproto/lume/v1/lumev1connect"
+	lumov1 "github.com/mcdev12/lumo/go/internal/genproto/lumo/v1"
+	"github.com/mcdev12/lumo/go/internal/genproto/lumo/v1/lumov1connect"
+	"github.com/mcdev12/lumo/go/internal/ratelimit"
+)
+
+// fakeAPI keeps Lumos, Lumes and Links in memory and serves the parts of
+// their services lumoctl uses, replaying creates retried with the same
+// idempotency key. The next rateLimited creates are refused as if the caller
+// hit the rate limit.
+type fakeAPI struct {
+	lumov1connect.UnimplementedLumoServiceHandler
+	lumev1connect.UnimplementedLumeServiceHandler
+	linkv1connect.UnimplementedLinkServiceHandler
+
+	mu            sync.Mutex
+	lumos         []*lumov1.Lumo
+	lumes         []*lumev1.Lume
+	links         []*linkv1.Link
+	responses     map[string]proto.Message
+	authorization []string
+	rateLimited   int
+	limitedKeys   []string
+}
+
+// newFakeAPI starts a server for a fakeAPI
+func newFakeAPI() (*fakeAPI, *httptest.Server) {
+	api := &fakeAPI{responses: map[string]proto.Message{}}
+	mux := http.NewServeMux()
+	mux.Handle(lumov1connect.NewLumoServiceHandler(api))
+	mux.Handle(lumev1connect.NewLumeServiceHandler(api))
+	mux.Handle(linkv1connect.NewLinkServiceHandler(api))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		api.authorization = append(api.authorization, r.Header.Get("Authorization"))
+		api.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	return api, server
+}
+
+// replay returns the response stored for an idempotency key, or stores the
+// one create returns
+func replay[T proto.Message](a *fakeAPI, key string, create func() T) T {
+	if stored, ok := a.responses[key]; ok && key != "" {
+		return stored.(T)
+	}
+	res := create()
+	if key != "" {
+		a.responses[key] = res
+	}
+	return res
+}
+
+// limit refuses a create if it should be rate limited, remembering its
+// idempotency key
+func (a *fakeAPI) limit(key string) error {
+	if a.rateLimited == 0 {
+		return nil
+	}
+	a.rateLimited--
+	a.limitedKeys = append(a.limitedKeys, key)
+	err := connect.NewError(connect.CodeResourceExhausted, nil)
+	err.Meta().Set(ratelimit.RetryAfterKey, "0")
+	return err
+}
+
+// page returns the page of items a page token points to
+func page[T any](items []T, size int32, token string) ([]T, string) {
+	offset, _ := strconv.Atoi(token)
+	end := min(offset+int(size), len(items))
+	next := ""
+	if end < len(items) {
+		next = strconv.Itoa(end)
+	}
+	return items[offset:end], next
+}
+
+// CreateLumo stores a Lumo
+func (a *fakeAPI) CreateLumo(_ context.Context, req *connect.Request[lumov1.CreateLumoRequest]) (*connect.Response[lumov1.CreateLumoResponse], error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.limit(req.Msg.GetIdempotencyKey()); err != nil {
+		return nil, err
+	}
+	res := replay(a, req.Msg.GetIdempotencyKey(), func() *lumov1.CreateLumoResponse {
+		lumo := &lumov1.Lumo{LumoId: uuid.NewString(), Title: req.Msg.GetLumo().GetTitle(), Version: 1}
+		a.lumos = append(a.lumos, lumo)
+		return &lumov1.CreateLumoResponse{Lumo: lumo}
+	})
+	return connect.NewResponse(res), nil
+}
+
+// GetLumo returns a stored Lumo
+func (a *fakeAPI) GetLumo(_ context.Context, req *connect.Request[lumov1.GetLumoRequest]) (*connect.Response[lumov1.GetLumoResponse], error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, lumo := range a.lumos {
+		if lumo.GetLumoId() == req.Msg.GetUuid() {
+			return connect.NewResponse(&lumov1.GetLumoResponse{Lumo: lumo}), nil
+		}
+	}
+	return nil, connect.NewError(connect.CodeNotFound, nil)
+}
+
+// ListLumos returns all Lumos
+func (a *fakeAPI) ListLumos(_ context.Context, req *connect.Request[lumov1.ListLumosRequest]) (*connect.Response[lumov1.ListLumosResponse], error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	lumos, next := page(a.lumos, req.Msg.GetPageSize(), req.Msg.GetPageToken())
+	return connect.NewResponse(&lumov1.ListLumosResponse{Lumos: lumos, NextPageToken: next}), nil
+}
+
+// CreateLume stores a Lume
+func (a *fakeAPI) CreateLume(_ context.Context, req *connect.Request[lumev1.CreateLumeRequest]) (*connect.Response[lumev1.CreateLumeResponse], error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.limit(req.Msg.GetIdempotencyKey()); err != nil {
+		return nil, err
+	}
+	res := replay(a, req.Msg.GetIdempotencyKey(), func() *lumev1.CreateLumeResponse {
+		lume := &lumev1.Lume{
+			LumeId:       uuid.NewString(),
+			LumoId:       req.Msg.GetLumoId(),
+			Type:         req.Msg.GetType(),
+			Name:         req.Msg.GetName(),
+			DateStart:    req.Msg.GetDateStart(),
+			Latitude:     req.Msg.GetLatitude(),
+			Longitude:    req.Msg.GetLongitude(),
+			CategoryTags: req.Msg.GetCategoryTags(),
+			TimeZone:     req.Msg.GetTimeZone(),
+			Version:      1,
+		}
+		a.lumes = append(a.lumes, lume)
+		return &lumev1.CreateLumeResponse{Lume: lume}
+	})
+	return connect.NewResponse(res), nil
+}
+
+// ListLumes returns the Lumes of the Lumo passed as user_id
+func (a *fakeAPI) ListLumes(_ context.Context, req *connect.Request[lumev1.ListLumesRequest]) (*connect.Response[lumev1.ListLumesResponse], error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var lumes []*lumev1.Lume
+	for _, lume := range a.lumes {
+		if lume.GetLumoId() == req.Msg.GetUserId() {
+			lumes = append(lumes, lume)
+		}
+	}
+	lumes, next := page(lumes, req.Msg.GetPageSize(), req.Msg.GetPageToken())
+	return connect.NewResponse(&lumev1.ListLumesResponse{Lumes: lumes, NextPageToken: next}), nil
+}
+
+// CreateLink stores a Link, insisting on the mode the API requires
+func (a *fakeAPI) CreateLink(_ context.Context, req *connect.Request[linkv1.CreateLinkRequest]) (*connect.Response[linkv1.CreateLinkResponse], error) {
+	if req.Msg.GetMode() == linkv1.TravelMode_TRAVEL_MODE_UNSPECIFIED {
+		return nil, connect.NewError(connect.CodeInvalidArgument, nil)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.limit(req.Msg.GetIdempotencyKey()); err != nil {
+		return nil, err
+	}
+	res := replay(a, req.Msg.GetIdempotencyKey(), func() *linkv1.CreateLinkResponse {
+		link := &linkv1.Link{
+			LinkId:        uuid.NewString(),
+			FromLumeId:    req.Msg.GetFromLumeId(),
+			ToLumeId:      req.Msg.GetToLumeId(),
+			Type:          req.Msg.GetType(),
+			Travel:        req.Msg.GetTravel(),
+			Notes:         req.Msg.GetNotes(),
+			SequenceIndex: req.Msg.GetSequenceIndex(),
+			Version:       1,
+		}
+		a.links = append(a.links, link)
+		return &linkv1.CreateLinkResponse{Link: link}
+	})
+	return connect.NewResponse(res), nil
+}
+
+// ListLinks returns the Links starting at a Lume of the Lumo
+func (a *fakeAPI) ListLinks(_ context.Context, req *connect.Request[linkv1.ListLinksRequest]) (*connect.Response[linkv1.ListLinksResponse], error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	lumoOf := map[string]string{}
+	for _, lume := range a.lumes {
+		lumoOf[lume.GetLumeId()] = lume.GetLumoId()
+	}
+	var links []*linkv1.Link
+	for _, link := range a.links {
+		if lumoOf[link.GetFromLumeId()] == req.Msg.GetLumoUuid() {
+			links = append(links, link)
+		}
+	}
+	links, next := page(links, req.Msg.GetPageSize(), req.Msg.GetPageToken())
+	return connect.NewResponse(&linkv1.ListLinksResponse{Links: links, NextPageToken: next}), nil
+}
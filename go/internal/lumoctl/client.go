@@ -0,0 +1,96 @@
+package lumoctl
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	"github.com/mcdev12/lumo/go/internal/genproto/link/v1/linkv1connect"
+	"github.com/mcdev12/lumo/go/internal/genproto/lume/v1/lumev1connect"
+	"github.com/mcdev12/lumo/go/internal/genproto/lumo/v1/lumov1connect"
+)
+
+// userAgent identifies lumoctl in the server's logs
+const userAgent = "lumoctl"
+
+// Client calls the Lumo, Lume and Link services
+type Client struct {
+	Lumos lumov1connect.LumoServiceClient
+	Lumes lumev1connect.LumeServiceClient
+	Links linkv1connect.LinkServiceClient
+}
+
+// NewClient creates the service clients for a Connection
+func NewClient(conn *Connection) (*Client, error) {
+	httpClient, err := newHTTPClient(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []connect.ClientOption{
+		connect.WithInterceptors(newAuthInterceptor(conn.Token)),
+	}
+	switch conn.Protocol {
+	case ProtocolGRPC:
+		opts = append(opts, connect.WithGRPC())
+	case ProtocolGRPCWeb:
+		opts = append(opts, connect.WithGRPCWeb())
+	}
+
+	server := strings.TrimSuffix(conn.Server, "/")
+	return &Client{
+		Lumos: lumov1connect.NewLumoServiceClient(httpClient, server, opts...),
+		Lumes: lumev1connect.NewLumeServiceClient(httpClient, server, opts...),
+		Links: linkv1connect.NewLinkServiceClient(httpClient, server, opts...),
+	}, nil
+}
+
+// newHTTPClient creates the HTTP client for a Connection. gRPC needs HTTP/2,
+// which plain http:// servers only speak as h2c.
+func newHTTPClient(conn *Connection) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if conn.CAFile != "" {
+		pem, err := os.ReadFile(conn.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, errors.New("CA file holds no PEM certificates")
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    roots,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	if conn.Protocol == ProtocolGRPC && strings.HasPrefix(conn.Server, "http://") {
+		protocols := new(http.Protocols)
+		protocols.SetUnencryptedHTTP2(true)
+		transport.Protocols = protocols
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// newAuthInterceptor sends the token, if any, as a bearer token along with
+// lumoctl's user agent
+func newAuthInterceptor(token string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			req.Header().Set("User-Agent", userAgent)
+			if token != "" {
+				req.Header().Set("Authorization", "Bearer "+token)
+			}
+			return next(ctx, req)
+		}
+	}
+}
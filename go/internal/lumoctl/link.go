@@ -0,0 +1,372 @@
+package lumoctl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	linkv1 "github.com/mcdev12/lumo/go/internal/genproto/link/v1"
+)
+
+// Prefixes left out of Link types and travel modes on the command line
+const (
+	linkTypePrefix   = "LINK_TYPE_"
+	travelModePrefix = "TRAVEL_MODE_"
+)
+
+// linkColumns are the table columns of Links
+var linkColumns = []column[*linkv1.Link]{
+	{"ID", func(l *linkv1.Link) string { return l.GetLinkId() }},
+	{"TYPE", func(l *linkv1.Link) string { return enumName(l.GetType(), linkTypePrefix) }},
+	{"FROM", func(l *linkv1.Link) string { return l.GetFromLumeId() }},
+	{"TO", func(l *linkv1.Link) string { return l.GetToLumeId() }},
+	{"MODE", func(l *linkv1.Link) string { return enumName(l.GetTravel().GetMode(), travelModePrefix) }},
+	{"DURATION", func(l *linkv1.Link) string {
+		if l.GetTravel().GetDurationSec() == 0 {
+			return ""
+		}
+		return (time.Duration(l.GetTravel().GetDurationSec()) * time.Second).String()
+	}},
+	{"NOTES", func(l *linkv1.Link) string { return l.GetNotes() }},
+}
+
+// linkFields are the flags setting the fields of a Link
+type linkFields struct {
+	from     string
+	to       string
+	linkType string
+	mode     string
+	duration time.Duration
+	cost     float64
+	currency string
+	distance float64
+	notes    string
+	sequence int32
+}
+
+// linkFieldPaths maps the flags to the field mask paths of UpdateLinkRequest
+var linkFieldPaths = map[string]string{
+	"from":     "from_lume_id",
+	"to":       "to_lume_id",
+	"type":     "type",
+	"notes":    "notes",
+	"sequence": "sequence_index",
+}
+
+// travelFlags are the flags setting the travel details of a Link
+var travelFlags = []string{"mode", "duration", "cost", "currency", "distance"}
+
+// register adds the flags to a command
+func (f *linkFields) register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&f.from, "from", "", "Lume the Link starts at")
+	flags.StringVar(&f.to, "to", "", "Lume the Link ends at")
+	flags.StringVar(&f.linkType, "type", "", "type of the Link: travel, recommended or custom")
+	flags.StringVar(&f.mode, "mode", "", "how to travel, e.g. flight, train or drive")
+	flags.DurationVar(&f.duration, "duration", 0, "estimated travel time, e.g. 2h30m")
+	flags.Float64Var(&f.cost, "cost", 0, "estimated travel cost")
+	flags.StringVar(&f.currency, "currency", "", "ISO 4217 code of the cost, defaults to your home currency")
+	flags.Float64Var(&f.distance, "distance", 0, "travel distance in meters")
+	flags.StringVar(&f.notes, "notes", "", "notes")
+	flags.Int32Var(&f.sequence, "sequence", 0, "position of the Link in lists and timelines")
+
+	_ = cmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions(
+		enumNames(linkv1.LinkType(0).Descriptor(), linkTypePrefix), cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("mode", cobra.FixedCompletions(
+		enumNames(linkv1.TravelMode(0).Descriptor(), travelModePrefix), cobra.ShellCompDirectiveNoFileComp))
+}
+
+// parseType parses --type
+func (f *linkFields) parseType() (linkv1.LinkType, error) {
+	number, err := parseEnum(linkv1.LinkType(0).Descriptor(), linkTypePrefix, f.linkType)
+	return linkv1.LinkType(number), err
+}
+
+// travelChanged reports whether any travel flag was set
+func travelChanged(cmd *cobra.Command) bool {
+	for _, name := range travelFlags {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTravel sets the travel flags that were given on travel
+func (f *linkFields) applyTravel(cmd *cobra.Command, travel *linkv1.TravelDetails) error {
+	flags := cmd.Flags()
+	if flags.Changed("mode") {
+		number, err := parseEnum(linkv1.TravelMode(0).Descriptor(), travelModePrefix, f.mode)
+		if err != nil {
+			return err
+		}
+		travel.Mode = linkv1.TravelMode(number)
+	}
+	if flags.Changed("duration") {
+		travel.DurationSec = int32(f.duration / time.Second)
+	}
+	if flags.Changed("cost") {
+		travel.CostEstimate = f.cost
+	}
+	if flags.Changed("currency") {
+		travel.Currency = f.currency
+	}
+	if flags.Changed("distance") {
+		travel.DistanceMeters = f.distance
+	}
+	return nil
+}
+
+// requestMode returns the mode to send along with travel details. The API
+// requires a mode for every new Link, but only keeps the one of the travel
+// details, so Links without them are sent with an arbitrary one.
+func requestMode(travel *linkv1.TravelDetails) linkv1.TravelMode {
+	if mode := travel.GetMode(); mode != linkv1.TravelMode_TRAVEL_MODE_UNSPECIFIED {
+		return mode
+	}
+	return linkv1.TravelMode_TRAVEL_MODE_DRIVE
+}
+
+// newLinkCommand builds the link commands
+func newLinkCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "link",
+		Aliases: []string{"links"},
+		Short:   "Manage the Links between Lumes",
+	}
+	cmd.AddCommand(
+		newLinkListCommand(o),
+		newLinkGetCommand(o),
+		newLinkCreateCommand(o),
+		newLinkUpdateCommand(o),
+		newLinkDeleteCommand(o),
+	)
+	return cmd
+}
+
+// newLinkListCommand builds link list
+func newLinkListCommand(o *options) *cobra.Command {
+	var req linkv1.ListLinksRequest
+	cmd := &cobra.Command{
+		Use:     "list (--lumo LUMO_ID | --from LUME_ID | --to LUME_ID)",
+		Aliases: []string{"ls"},
+		Short:   "List the Links of a Lumo or Lume",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				links, err := listLinks(ctx, client, &req)
+				if err != nil {
+					return err
+				}
+				return printList(p, links, linkColumns)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&req.LumoUuid, "lumo", "", "list the Links of this Lumo")
+	cmd.Flags().StringVar(&req.FromLumeId, "from", "", "list the Links starting at this Lume")
+	cmd.Flags().StringVar(&req.ToLumeId, "to", "", "list the Links ending at this Lume")
+	cmd.MarkFlagsOneRequired("lumo", "from", "to")
+	_ = cmd.RegisterFlagCompletionFunc("lumo", o.completeLumoIDs)
+	return cmd
+}
+
+// newLinkGetCommand builds link get
+func newLinkGetCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get LINK_ID",
+		Short: "Show a Link",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				res, err := client.Links.GetLink(ctx, connect.NewRequest(&linkv1.GetLinkRequest{LinkId: args[0]}))
+				if err != nil {
+					return err
+				}
+				return printOne(p, res.Msg.GetLink(), linkColumns)
+			})
+		},
+	}
+}
+
+// newLinkCreateCommand builds link create
+func newLinkCreateCommand(o *options) *cobra.Command {
+	var fields linkFields
+	var idempotencyKey string
+	cmd := &cobra.Command{
+		Use:   "create --from LUME_ID --to LUME_ID --type TYPE",
+		Short: "Link two Lumes of a Lumo",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			linkType, err := fields.parseType()
+			if err != nil {
+				return err
+			}
+			req := &linkv1.CreateLinkRequest{
+				FromLumeId:     fields.from,
+				ToLumeId:       fields.to,
+				Type:           linkType,
+				Notes:          fields.notes,
+				SequenceIndex:  fields.sequence,
+				IdempotencyKey: idempotencyKey,
+			}
+			if linkType == linkv1.LinkType_LINK_TYPE_TRAVEL || travelChanged(cmd) {
+				req.Travel = &linkv1.TravelDetails{}
+				if err := fields.applyTravel(cmd, req.Travel); err != nil {
+					return err
+				}
+			}
+			req.Mode = requestMode(req.Travel)
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				res, err := client.Links.CreateLink(ctx, connect.NewRequest(req))
+				if err != nil {
+					return err
+				}
+				return printOne(p, res.Msg.GetLink(), linkColumns)
+			})
+		},
+	}
+	fields.register(cmd)
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "key to safely retry the command with")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	_ = cmd.MarkFlagRequired("type")
+	return cmd
+}
+
+// newLinkUpdateCommand builds link update
+func newLinkUpdateCommand(o *options) *cobra.Command {
+	var fields linkFields
+	var expectedVersion int64
+	cmd := &cobra.Command{
+		Use:   "update LINK_ID [flags]",
+		Short: "Change the fields of a Link given as flags",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			req := &linkv1.UpdateLinkRequest{
+				LinkId:     args[0],
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: changedPaths(cmd.Flags(), linkFieldPaths)},
+				FromLumeId: fields.from,
+				ToLumeId:   fields.to,
+			}
+			if cmd.Flags().Changed("type") {
+				linkType, err := fields.parseType()
+				if err != nil {
+					return err
+				}
+				req.Type = &linkType
+			}
+			if cmd.Flags().Changed("notes") {
+				req.Notes = &fields.notes
+			}
+			if cmd.Flags().Changed("sequence") {
+				req.SequenceIndex = &fields.sequence
+			}
+			if cmd.Flags().Changed("expected-version") {
+				req.ExpectedVersion = &expectedVersion
+			}
+			updateTravel := travelChanged(cmd)
+			if updateTravel {
+				req.UpdateMask.Paths = append(req.UpdateMask.Paths, "travel")
+			}
+			if len(req.UpdateMask.Paths) == 0 {
+				return errNothingToUpdate
+			}
+
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				if updateTravel {
+					// The travel details are replaced as a whole, so the
+					// ones not given keep their current values
+					res, err := client.Links.GetLink(ctx, connect.NewRequest(&linkv1.GetLinkRequest{LinkId: args[0]}))
+					if err != nil {
+						return err
+					}
+					req.Travel = &linkv1.TravelDetails{}
+					if current := res.Msg.GetLink().GetTravel(); current != nil {
+						req.Travel = proto.Clone(current).(*linkv1.TravelDetails)
+					}
+					if err := fields.applyTravel(cmd, req.Travel); err != nil {
+						return err
+					}
+				}
+				res, err := client.Links.UpdateLink(ctx, connect.NewRequest(req))
+				if err != nil {
+					return err
+				}
+				return printOne(p, res.Msg.GetLink(), linkColumns)
+			})
+		},
+	}
+	fields.register(cmd)
+	cmd.Flags().Int64Var(&expectedVersion, "expected-version", 0, "fail if the Link is no longer at this version")
+	return cmd
+}
+
+// newLinkDeleteCommand builds link delete
+func newLinkDeleteCommand(o *options) *cobra.Command {
+	var expectedVersion int64
+	cmd := &cobra.Command{
+		Use:     "delete LINK_ID",
+		Aliases: []string{"rm"},
+		Short:   "Move a Link to the trash",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &linkv1.DeleteLinkRequest{LinkId: args[0]}
+			if cmd.Flags().Changed("expected-version") {
+				req.ExpectedVersion = &expectedVersion
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				if _, err := client.Links.DeleteLink(ctx, connect.NewRequest(req)); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "deleted link %s\n", args[0])
+				return nil
+			})
+		},
+	}
+	cmd.Flags().Int64Var(&expectedVersion, "expected-version", 0, "fail if the Link is no longer at this version")
+	return cmd
+}
+
+// listLinks lists all Links matching the Lumo or Lume filter, page by page
+func listLinks(ctx context.Context, client *Client, filter *linkv1.ListLinksRequest) ([]*linkv1.Link, error) {
+	var links []*linkv1.Link
+	pageToken := ""
+	for {
+		res, err := client.Links.ListLinks(ctx, connect.NewRequest(&linkv1.ListLinksRequest{
+			LumoUuid:   filter.GetLumoUuid(),
+			FromLumeId: filter.GetFromLumeId(),
+			ToLumeId:   filter.GetToLumeId(),
+			PageSize:   pageSize,
+			PageToken:  pageToken,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, res.Msg.GetLinks()...)
+		pageToken = res.Msg.GetNextPageToken()
+		if pageToken == "" {
+			return links, nil
+		}
+	}
+}
@@ -0,0 +1,385 @@
+package lumoctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	lumev1 "github.com/mcdev12/lumo/go/internal/genproto/lume/v1"
+)
+
+// lumeTypePrefix is left out of Lume types on the command line
+const lumeTypePrefix = "LUME_TYPE_"
+
+// errNothingToUpdate is returned by update commands without any field flags
+var errNothingToUpdate = errors.New("nothing to update, set at least one field")
+
+// lumeColumns are the table columns of Lumes
+var lumeColumns = []column[*lumev1.Lume]{
+	{"ID", func(l *lumev1.Lume) string { return l.GetLumeId() }},
+	{"TYPE", func(l *lumev1.Lume) string { return enumName(l.GetType(), lumeTypePrefix) }},
+	{"NAME", func(l *lumev1.Lume) string { return l.GetName() }},
+	{"START", func(l *lumev1.Lume) string { return formatLumeTime(l, l.GetDateStart()) }},
+	{"END", func(l *lumev1.Lume) string { return formatLumeTime(l, l.GetDateEnd()) }},
+	{"ADDRESS", func(l *lumev1.Lume) string { return l.GetAddress() }},
+}
+
+// formatLumeTime formats a date of a Lume in the Lume's time zone
+func formatLumeTime(l *lumev1.Lume, ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	location, err := time.LoadLocation(l.GetTimeZone())
+	if err != nil {
+		location = time.Local
+	}
+	return ts.AsTime().In(location).Format("2006-01-02 15:04")
+}
+
+// lumeFields are the flags setting the fields of a Lume
+type lumeFields struct {
+	lumeType    string
+	name        string
+	start       string
+	end         string
+	latitude    float64
+	longitude   float64
+	address     string
+	description string
+	images      []string
+	tags        []string
+	bookingLink string
+	timeZone    string
+}
+
+// lumeFieldPaths maps the flags to the field mask paths of UpdateLumeRequest
+var lumeFieldPaths = map[string]string{
+	"type":         "type",
+	"name":         "name",
+	"start":        "date_start",
+	"end":          "date_end",
+	"latitude":     "latitude",
+	"longitude":    "longitude",
+	"address":      "address",
+	"description":  "description",
+	"image":        "images",
+	"tag":          "category_tags",
+	"booking-link": "booking_link",
+	"time-zone":    "time_zone",
+}
+
+// register adds the flags to a command
+func (f *lumeFields) register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&f.lumeType, "type", "", "type of the Lume, e.g. city, attraction or restaurant")
+	flags.StringVar(&f.name, "name", "", "name of the Lume")
+	flags.StringVar(&f.start, "start", "", "start date, as 2006-01-02, 2006-01-02T15:04 or RFC 3339")
+	flags.StringVar(&f.end, "end", "", "end date, as 2006-01-02, 2006-01-02T15:04 or RFC 3339")
+	flags.Float64Var(&f.latitude, "latitude", 0, "latitude in degrees")
+	flags.Float64Var(&f.longitude, "longitude", 0, "longitude in degrees")
+	flags.StringVar(&f.address, "address", "", "address")
+	flags.StringVar(&f.description, "description", "", "description")
+	flags.StringArrayVar(&f.images, "image", nil, "image URL, may be repeated")
+	flags.StringArrayVar(&f.tags, "tag", nil, "category tag, may be repeated")
+	flags.StringVar(&f.bookingLink, "booking-link", "", "booking URL")
+	flags.StringVar(&f.timeZone, "time-zone", "", "IANA time zone of the dates, defaults to yours")
+
+	_ = cmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions(
+		enumNames(lumev1.LumeType(0).Descriptor(), lumeTypePrefix), cobra.ShellCompDirectiveNoFileComp))
+}
+
+// parseType parses --type
+func (f *lumeFields) parseType() (lumev1.LumeType, error) {
+	number, err := parseEnum(lumev1.LumeType(0).Descriptor(), lumeTypePrefix, f.lumeType)
+	return lumev1.LumeType(number), err
+}
+
+// parseDates parses --start and --end, in --time-zone if given and otherwise
+// in UTC unless they carry an offset
+func (f *lumeFields) parseDates() (start, end *timestamppb.Timestamp, err error) {
+	location := time.UTC
+	if f.timeZone != "" {
+		if location, err = time.LoadLocation(f.timeZone); err != nil {
+			return nil, nil, fmt.Errorf("invalid time zone %q", f.timeZone)
+		}
+	}
+	if start, err = parseDate(f.start, location); err != nil {
+		return nil, nil, fmt.Errorf("invalid --start: %w", err)
+	}
+	if end, err = parseDate(f.end, location); err != nil {
+		return nil, nil, fmt.Errorf("invalid --end: %w", err)
+	}
+	return start, end, nil
+}
+
+// parseDate parses a date, with or without a time and offset
+func parseDate(s string, location *time.Location) (*timestamppb.Timestamp, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return timestamppb.New(t), nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, location); err == nil {
+			return timestamppb.New(t), nil
+		}
+	}
+	return nil, fmt.Errorf("%q is not a date", s)
+}
+
+// newLumeCommand builds the lume commands
+func newLumeCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "lume",
+		Aliases: []string{"lumes"},
+		Short:   "Manage the Lumes of a Lumo",
+	}
+	cmd.AddCommand(
+		newLumeListCommand(o),
+		newLumeGetCommand(o),
+		newLumeCreateCommand(o),
+		newLumeUpdateCommand(o),
+		newLumeDeleteCommand(o),
+	)
+	return cmd
+}
+
+// newLumeListCommand builds lume list
+func newLumeListCommand(o *options) *cobra.Command {
+	var lumoID, lumeType string
+	cmd := &cobra.Command{
+		Use:     "list --lumo LUMO_ID",
+		Aliases: []string{"ls"},
+		Short:   "List the Lumes of a Lumo",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			var filter lumev1.LumeType
+			if lumeType != "" {
+				fields := lumeFields{lumeType: lumeType}
+				if filter, err = fields.parseType(); err != nil {
+					return err
+				}
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				lumes, err := listLumes(ctx, client, lumoID, filter)
+				if err != nil {
+					return err
+				}
+				return printList(p, lumes, lumeColumns)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&lumoID, "lumo", "", "Lumo whose Lumes to list")
+	cmd.Flags().StringVar(&lumeType, "type", "", "only list Lumes of this type")
+	_ = cmd.MarkFlagRequired("lumo")
+	_ = cmd.RegisterFlagCompletionFunc("lumo", o.completeLumoIDs)
+	_ = cmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions(
+		enumNames(lumev1.LumeType(0).Descriptor(), lumeTypePrefix), cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+// newLumeGetCommand builds lume get
+func newLumeGetCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get LUME_ID",
+		Short: "Show a Lume",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				res, err := client.Lumes.GetLume(ctx, connect.NewRequest(&lumev1.GetLumeRequest{LumeId: args[0]}))
+				if err != nil {
+					return err
+				}
+				return printOne(p, res.Msg.GetLume(), lumeColumns)
+			})
+		},
+	}
+}
+
+// newLumeCreateCommand builds lume create
+func newLumeCreateCommand(o *options) *cobra.Command {
+	var fields lumeFields
+	var lumoID, idempotencyKey string
+	cmd := &cobra.Command{
+		Use:   "create --lumo LUMO_ID --type TYPE --name NAME",
+		Short: "Add a Lume to a Lumo",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			lumeType, err := fields.parseType()
+			if err != nil {
+				return err
+			}
+			start, end, err := fields.parseDates()
+			if err != nil {
+				return err
+			}
+			req := &lumev1.CreateLumeRequest{
+				LumoId:         lumoID,
+				Type:           lumeType,
+				Name:           fields.name,
+				DateStart:      start,
+				DateEnd:        end,
+				Latitude:       fields.latitude,
+				Longitude:      fields.longitude,
+				Address:        fields.address,
+				Description:    fields.description,
+				Images:         fields.images,
+				CategoryTags:   fields.tags,
+				BookingLink:    fields.bookingLink,
+				TimeZone:       fields.timeZone,
+				IdempotencyKey: idempotencyKey,
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				res, err := client.Lumes.CreateLume(ctx, connect.NewRequest(req))
+				if err != nil {
+					return err
+				}
+				return printOne(p, res.Msg.GetLume(), lumeColumns)
+			})
+		},
+	}
+	fields.register(cmd)
+	cmd.Flags().StringVar(&lumoID, "lumo", "", "Lumo to add the Lume to")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "key to safely retry the command with")
+	_ = cmd.MarkFlagRequired("lumo")
+	_ = cmd.MarkFlagRequired("type")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.RegisterFlagCompletionFunc("lumo", o.completeLumoIDs)
+	return cmd
+}
+
+// newLumeUpdateCommand builds lume update
+func newLumeUpdateCommand(o *options) *cobra.Command {
+	var fields lumeFields
+	var expectedVersion int64
+	cmd := &cobra.Command{
+		Use:   "update LUME_ID [flags]",
+		Short: "Change the fields of a Lume given as flags",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			req := &lumev1.UpdateLumeRequest{
+				LumeId:       args[0],
+				UpdateMask:   &fieldmaskpb.FieldMask{Paths: changedPaths(cmd.Flags(), lumeFieldPaths)},
+				Name:         fields.name,
+				Latitude:     fields.latitude,
+				Longitude:    fields.longitude,
+				Address:      fields.address,
+				Description:  fields.description,
+				Images:       fields.images,
+				CategoryTags: fields.tags,
+				BookingLink:  fields.bookingLink,
+				TimeZone:     fields.timeZone,
+			}
+			if len(req.UpdateMask.Paths) == 0 {
+				return errNothingToUpdate
+			}
+			if cmd.Flags().Changed("type") {
+				lumeType, err := fields.parseType()
+				if err != nil {
+					return err
+				}
+				req.Type = &lumeType
+			}
+			if req.DateStart, req.DateEnd, err = fields.parseDates(); err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("expected-version") {
+				req.ExpectedVersion = &expectedVersion
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				res, err := client.Lumes.UpdateLume(ctx, connect.NewRequest(req))
+				if err != nil {
+					return err
+				}
+				return printOne(p, res.Msg.GetLume(), lumeColumns)
+			})
+		},
+	}
+	fields.register(cmd)
+	cmd.Flags().Int64Var(&expectedVersion, "expected-version", 0, "fail if the Lume is no longer at this version")
+	return cmd
+}
+
+// newLumeDeleteCommand builds lume delete
+func newLumeDeleteCommand(o *options) *cobra.Command {
+	var expectedVersion int64
+	cmd := &cobra.Command{
+		Use:     "delete LUME_ID",
+		Aliases: []string{"rm"},
+		Short:   "Move a Lume and its Links to the trash",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &lumev1.DeleteLumeRequest{LumeId: args[0]}
+			if cmd.Flags().Changed("expected-version") {
+				req.ExpectedVersion = &expectedVersion
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				if _, err := client.Lumes.DeleteLume(ctx, connect.NewRequest(req)); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "deleted lume %s\n", args[0])
+				return nil
+			})
+		},
+	}
+	cmd.Flags().Int64Var(&expectedVersion, "expected-version", 0, "fail if the Lume is no longer at this version")
+	return cmd
+}
+
+// listLumes lists all Lumes of a Lumo, page by page
+func listLumes(ctx context.Context, client *Client, lumoID string, lumeType lumev1.LumeType) ([]*lumev1.Lume, error) {
+	var lumes []*lumev1.Lume
+	pageToken := ""
+	for {
+		res, err := client.Lumes.ListLumes(ctx, connect.NewRequest(&lumev1.ListLumesRequest{
+			// The field is named user_id but holds the Lumo
+			UserId:    lumoID,
+			Type:      lumeType,
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		lumes = append(lumes, res.Msg.GetLumes()...)
+		pageToken = res.Msg.GetNextPageToken()
+		if pageToken == "" {
+			return lumes, nil
+		}
+	}
+}
+
+// changedPaths returns the field mask paths of the flags that were set
+func changedPaths(flags *pflag.FlagSet, paths map[string]string) []string {
+	var changed []string
+	flags.Visit(func(flag *pflag.Flag) {
+		if path, ok := paths[flag.Name]; ok {
+			changed = append(changed, path)
+		}
+	})
+	return changed
+}
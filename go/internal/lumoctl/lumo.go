@@ -0,0 +1,218 @@
+package lumoctl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	lumov1 "github.com/mcdev12/lumo/go/internal/genproto/lumo/v1"
+)
+
+// pageSize is how many entities lumoctl asks for at a time
+const pageSize = 100
+
+// lumoColumns are the table columns of Lumos
+var lumoColumns = []column[*lumov1.Lumo]{
+	{"ID", func(l *lumov1.Lumo) string { return l.GetLumoId() }},
+	{"TITLE", func(l *lumov1.Lumo) string { return l.GetTitle() }},
+	{"VERSION", func(l *lumov1.Lumo) string { return strconv.FormatInt(l.GetVersion(), 10) }},
+	{"UPDATED", func(l *lumov1.Lumo) string { return formatTime(l.GetUpdatedAt()) }},
+}
+
+// newLumoCommand builds the lumo commands
+func newLumoCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "lumo",
+		Aliases: []string{"lumos"},
+		Short:   "Manage Lumos",
+	}
+	cmd.AddCommand(
+		newLumoListCommand(o),
+		newLumoGetCommand(o),
+		newLumoCreateCommand(o),
+		newLumoUpdateCommand(o),
+		newLumoDeleteCommand(o),
+	)
+	return cmd
+}
+
+// newLumoListCommand builds lumo list
+func newLumoListCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List your Lumos",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				lumos, err := listLumos(ctx, client)
+				if err != nil {
+					return err
+				}
+				return printList(p, lumos, lumoColumns)
+			})
+		},
+	}
+}
+
+// newLumoGetCommand builds lumo get
+func newLumoGetCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get LUMO_ID",
+		Short:             "Show a Lumo",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: o.completeLumoIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				res, err := client.Lumos.GetLumo(ctx, connect.NewRequest(&lumov1.GetLumoRequest{Uuid: args[0]}))
+				if err != nil {
+					return err
+				}
+				return printOne(p, res.Msg.GetLumo(), lumoColumns)
+			})
+		},
+	}
+}
+
+// newLumoCreateCommand builds lumo create
+func newLumoCreateCommand(o *options) *cobra.Command {
+	var title, idempotencyKey string
+	cmd := &cobra.Command{
+		Use:   "create --title TITLE",
+		Short: "Create a Lumo",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				res, err := client.Lumos.CreateLumo(ctx, connect.NewRequest(&lumov1.CreateLumoRequest{
+					Lumo:           &lumov1.Lumo{Title: title},
+					IdempotencyKey: idempotencyKey,
+				}))
+				if err != nil {
+					return err
+				}
+				return printOne(p, res.Msg.GetLumo(), lumoColumns)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&title, "title", "", "title of the Lumo")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "key to safely retry the command with")
+	_ = cmd.MarkFlagRequired("title")
+	return cmd
+}
+
+// newLumoUpdateCommand builds lumo update
+func newLumoUpdateCommand(o *options) *cobra.Command {
+	var title string
+	var expectedVersion int64
+	cmd := &cobra.Command{
+		Use:               "update LUMO_ID --title TITLE",
+		Short:             "Rename a Lumo",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: o.completeLumoIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			req := &lumov1.UpdateLumoRequest{
+				Lumo: &lumov1.Lumo{LumoId: args[0], Title: title},
+			}
+			if cmd.Flags().Changed("expected-version") {
+				req.ExpectedVersion = &expectedVersion
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				res, err := client.Lumos.UpdateLumo(ctx, connect.NewRequest(req))
+				if err != nil {
+					return err
+				}
+				return printOne(p, res.Msg.GetLumo(), lumoColumns)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&title, "title", "", "new title of the Lumo")
+	cmd.Flags().Int64Var(&expectedVersion, "expected-version", 0, "fail if the Lumo is no longer at this version")
+	_ = cmd.MarkFlagRequired("title")
+	return cmd
+}
+
+// newLumoDeleteCommand builds lumo delete
+func newLumoDeleteCommand(o *options) *cobra.Command {
+	var expectedVersion int64
+	cmd := &cobra.Command{
+		Use:               "delete LUMO_ID",
+		Aliases:           []string{"rm"},
+		Short:             "Move a Lumo with its Lumes and Links to the trash",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: o.completeLumoIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &lumov1.DeleteLumoRequest{Uuid: args[0]}
+			if cmd.Flags().Changed("expected-version") {
+				req.ExpectedVersion = &expectedVersion
+			}
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				if _, err := client.Lumos.DeleteLumo(ctx, connect.NewRequest(req)); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "deleted lumo %s\n", args[0])
+				return nil
+			})
+		},
+	}
+	cmd.Flags().Int64Var(&expectedVersion, "expected-version", 0, "fail if the Lumo is no longer at this version")
+	return cmd
+}
+
+// listLumos lists all of the caller's Lumos, page by page
+func listLumos(ctx context.Context, client *Client) ([]*lumov1.Lumo, error) {
+	var lumos []*lumov1.Lumo
+	pageToken := ""
+	for {
+		res, err := client.Lumos.ListLumos(ctx, connect.NewRequest(&lumov1.ListLumosRequest{
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		lumos = append(lumos, res.Msg.GetLumos()...)
+		pageToken = res.Msg.GetNextPageToken()
+		if pageToken == "" {
+			return lumos, nil
+		}
+	}
+}
+
+// completeLumoIDs completes the IDs of the caller's Lumos, described by their
+// titles
+func (o *options) completeLumoIDs(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	err := o.withClient(cmd, func(ctx context.Context, client *Client) error {
+		lumos, err := listLumos(ctx, client)
+		for _, lumo := range lumos {
+			completions = append(completions, lumo.GetLumoId()+"\t"+lumo.GetTitle())
+		}
+		return err
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
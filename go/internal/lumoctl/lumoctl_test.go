@@ -0,0 +1,293 @@
+package lumoctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	linkv1 "github.com/mcdev12/lumo/go/internal/genproto/link/v1"
+	lumev1 "github.com/mcdev12/lumo/go/internal/genproto/lume/v1"
+	lumov1 "github.com/mcdev12/lumo/go/internal/genproto/lumo/v1"
+)
+
+// CommandTestSuite runs lumoctl commands against a fake API
+type CommandTestSuite struct {
+	suite.Suite
+	api    *fakeAPI
+	server *httptest.Server
+	dir    string
+	env    map[string]string
+}
+
+// SetupTest is called before each test
+func (s *CommandTestSuite) SetupTest() {
+	s.api, s.server = newFakeAPI()
+	s.dir = s.T().TempDir()
+	s.env = map[string]string{
+		ConfigEnv: filepath.Join(s.dir, "config.yaml"),
+		ServerEnv: s.server.URL,
+		TokenEnv:  "secret",
+	}
+}
+
+// TearDownTest is called after each test
+func (s *CommandTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// TestCommandSuite runs the test suite
+func TestCommandSuite(t *testing.T) {
+	suite.Run(t, new(CommandTestSuite))
+}
+
+// run runs lumoctl and returns what it wrote to stdout
+func (s *CommandTestSuite) run(args ...string) (string, error) {
+	cmd := NewRootCommand(func(name string) (string, bool) {
+		value, ok := s.env[name]
+		return value, ok
+	}, strings.NewReader(""))
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+// seed stores a Lumo with two Lumes and a travel Link between them
+func (s *CommandTestSuite) seed() *lumov1.Lumo {
+	lumo := &lumov1.Lumo{LumoId: "lumo-1", Title: "Paris", Version: 3}
+	paris := &lumev1.Lume{
+		LumeId:       "lume-1",
+		LumoId:       "lumo-1",
+		Type:         lumev1.LumeType_LUME_TYPE_CITY,
+		Name:         "Paris",
+		DateStart:    timestamppb.New(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)),
+		CategoryTags: []string{"europe"},
+		TimeZone:     "Europe/Paris",
+	}
+	louvre := &lumev1.Lume{
+		LumeId:    "lume-2",
+		LumoId:    "lumo-1",
+		Type:      lumev1.LumeType_LUME_TYPE_ATTRACTION,
+		Name:      "Louvre",
+		Latitude:  48.8606,
+		Longitude: 2.3376,
+	}
+	link := &linkv1.Link{
+		LinkId:     "link-1",
+		FromLumeId: "lume-1",
+		ToLumeId:   "lume-2",
+		Type:       linkv1.LinkType_LINK_TYPE_RECOMMENDED,
+		Notes:      "go early",
+	}
+	s.api.lumos = append(s.api.lumos, lumo)
+	s.api.lumes = append(s.api.lumes, paris, louvre)
+	s.api.links = append(s.api.links, link)
+	return lumo
+}
+
+// Test an exported Lumo is recreated with new IDs, Links connecting the new
+// Lumes, and that running the import again doesn't create duplicates
+func (s *CommandTestSuite) TestExportImport() {
+	// Arrange
+	s.seed()
+	file := filepath.Join(s.dir, "paris.json")
+
+	// Act
+	_, exportErr := s.run("export", "lumo-1", "-f", file)
+	output, importErr := s.run("import", file, "--title", "Paris again", "-o", "json")
+	_, reimportErr := s.run("import", file, "--title", "Paris again")
+
+	// Assert
+	s.Require().NoError(exportErr)
+	s.Require().NoError(importErr)
+	s.Require().NoError(reimportErr)
+
+	var imported map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(output), &imported))
+	s.Equal("Paris again", imported["title"])
+
+	s.Len(s.api.lumos, 2)
+	s.Require().Len(s.api.lumes, 4)
+	s.Require().Len(s.api.links, 2)
+	newParis, newLouvre, newLink := s.api.lumes[2], s.api.lumes[3], s.api.links[1]
+	s.Equal(imported["lumo_id"], newParis.GetLumoId())
+	s.Equal("Paris", newParis.GetName())
+	s.Equal("Europe/Paris", newParis.GetTimeZone())
+	s.Equal([]string{"europe"}, newParis.GetCategoryTags())
+	s.True(newParis.GetDateStart().AsTime().Equal(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)))
+	s.Equal(48.8606, newLouvre.GetLatitude())
+	s.Equal(newParis.GetLumeId(), newLink.GetFromLumeId())
+	s.Equal(newLouvre.GetLumeId(), newLink.GetToLumeId())
+	s.Equal("go early", newLink.GetNotes())
+
+	for _, authorization := range s.api.authorization {
+		s.Equal("Bearer secret", authorization)
+	}
+}
+
+// Test rate limited creates are retried with the same idempotency key until
+// the import finishes
+func (s *CommandTestSuite) TestImportRetriesRateLimited() {
+	// Arrange
+	s.seed()
+	file := filepath.Join(s.dir, "paris.json")
+	_, err := s.run("export", "lumo-1", "-f", file)
+	s.Require().NoError(err)
+	s.api.rateLimited = 2
+
+	// Act
+	_, err = s.run("import", file)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Len(s.api.lumos, 2)
+	s.Len(s.api.lumes, 4)
+	s.Len(s.api.links, 2)
+	s.Require().Len(s.api.limitedKeys, 2)
+	for _, key := range s.api.limitedKeys {
+		s.Contains(s.api.responses, key)
+	}
+}
+
+// Test the export file is versioned JSON with the API's field names
+func (s *CommandTestSuite) TestExportFormat() {
+	// Arrange
+	s.seed()
+
+	// Act
+	output, err := s.run("export", "lumo-1")
+
+	// Assert
+	s.Require().NoError(err)
+	var file map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(output), &file))
+	s.Equal(float64(ExportVersion), file["version"])
+	s.Equal("lumo-1", file["lumo"].(map[string]any)["lumo_id"])
+	s.Len(file["lumes"], 2)
+	s.Equal("lume-1", file["links"].([]any)[0].(map[string]any)["from_lume_id"])
+}
+
+// Test Links to Lumes missing from the export are rejected before anything
+// is created
+func (s *CommandTestSuite) TestImportDanglingLink() {
+	// Arrange
+	file := filepath.Join(s.dir, "broken.json")
+	content, err := json.Marshal(&Export{
+		Version: ExportVersion,
+		Lumo:    &lumov1.Lumo{Title: "Broken"},
+		Links:   []*linkv1.Link{{LinkId: "link-1", FromLumeId: "lume-1", ToLumeId: "lume-2"}},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(os.WriteFile(file, content, 0o600))
+
+	// Act
+	_, err = s.run("import", file)
+
+	// Assert
+	s.ErrorContains(err, "link link-1 connects lumes that aren't in the export")
+	s.Empty(s.api.lumos)
+}
+
+// Test files of other versions are rejected
+func (s *CommandTestSuite) TestImportUnknownVersion() {
+	// Arrange
+	file := filepath.Join(s.dir, "future.json")
+	s.Require().NoError(os.WriteFile(file, []byte(`{"version": 2, "lumo": {}}`), 0o600))
+
+	// Act
+	_, err := s.run("import", file)
+
+	// Assert
+	s.ErrorContains(err, "unsupported export version 2")
+	s.Empty(s.api.lumos)
+}
+
+// Test lists are read page by page and printed in every format
+func (s *CommandTestSuite) TestListLumes() {
+	// Arrange
+	s.seed()
+	for i := 0; i < pageSize; i++ {
+		s.api.lumes = append(s.api.lumes, &lumev1.Lume{LumeId: "extra", LumoId: "lumo-1", Name: "Extra"})
+	}
+
+	// Act
+	table, tableErr := s.run("lume", "list", "--lumo", "lumo-1")
+	jsonOutput, jsonErr := s.run("lume", "list", "--lumo", "lumo-1", "-o", "json")
+	yamlOutput, yamlErr := s.run("lume", "list", "--lumo", "lumo-1", "-o", "yaml")
+
+	// Assert
+	s.Require().NoError(tableErr)
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+	s.Len(lines, pageSize+3)
+	s.Regexp(`^ID\s+TYPE\s+NAME\s+START\s+END\s+ADDRESS$`, lines[0])
+	s.Regexp(`^lume-1\s+city\s+Paris\s+2026-05-01 02:00\s*$`, lines[1])
+
+	s.Require().NoError(jsonErr)
+	var lumes []map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(jsonOutput), &lumes))
+	s.Len(lumes, pageSize+2)
+	s.Equal("LUME_TYPE_ATTRACTION", lumes[1]["type"])
+
+	s.Require().NoError(yamlErr)
+	s.True(strings.HasPrefix(yamlOutput, "- lume_id: lume-1\n  lumo_id: lumo-1\n  type: LUME_TYPE_CITY\n"), yamlOutput)
+}
+
+// Test creating a Lume parses its type and dates
+func (s *CommandTestSuite) TestCreateLume() {
+	// Arrange
+	s.seed()
+
+	// Act
+	_, err := s.run("lume", "create", "--lumo", "lumo-1", "--type", "restaurant", "--name", "Septime",
+		"--start", "2026-05-02T20:30", "--time-zone", "Europe/Paris", "--tag", "dinner", "--tag", "booked")
+	_, typeErr := s.run("lume", "create", "--lumo", "lumo-1", "--type", "castle", "--name", "Versailles")
+
+	// Assert
+	s.Require().NoError(err)
+	created := s.api.lumes[len(s.api.lumes)-1]
+	s.Equal(lumev1.LumeType_LUME_TYPE_RESTAURANT, created.GetType())
+	s.True(created.GetDateStart().AsTime().Equal(time.Date(2026, 5, 2, 18, 30, 0, 0, time.UTC)))
+	s.Equal([]string{"dinner", "booked"}, created.GetCategoryTags())
+	s.ErrorContains(typeErr, `unknown lume type "castle"`)
+}
+
+// Test profiles are saved, selected and take effect below flags and the
+// environment
+func (s *CommandTestSuite) TestProfiles() {
+	// Arrange
+	delete(s.env, ServerEnv)
+	delete(s.env, TokenEnv)
+	s.env["STAGING_TOKEN"] = "staging-secret"
+
+	// Act
+	_, err1 := s.run("profile", "set", "local", "--server", "http://localhost:1")
+	_, err2 := s.run("profile", "set", "staging", "--server", s.server.URL, "--token-env", "STAGING_TOKEN", "--use")
+	_, err3 := s.run("lumo", "list")
+	shown, err4 := s.run("profile", "show", "--profile", "local", "--token", "flag-secret")
+	_, err5 := s.run("profile", "show", "--profile", "missing")
+
+	// Assert
+	s.Require().NoError(err1)
+	s.Require().NoError(err2)
+	s.Require().NoError(err3)
+	s.Equal([]string{"Bearer staging-secret"}, s.api.authorization)
+	s.Require().NoError(err4)
+	s.Equal("server: http://localhost:1\ntoken: REDACTED\nprotocol: connect\n", shown)
+	s.ErrorContains(err5, `profile "missing" not found`)
+
+	profiles, err := LoadProfiles(s.env[ConfigEnv])
+	s.Require().NoError(err)
+	s.Equal("staging", profiles.Current)
+	s.Equal([]string{"local", "staging"}, profiles.Names())
+	s.Empty(profiles.Profiles["staging"].Token)
+}
@@ -0,0 +1,214 @@
+package lumoctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+)
+
+// formats lists the output formats, for validation and completion
+var formats = []string{FormatTable, FormatJSON, FormatYAML}
+
+// jsonOptions marshals messages with the field names of the .proto files,
+// like the rest of the API's JSON
+var jsonOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+// column is a table column and how to get its value from a message
+type column[T proto.Message] struct {
+	header string
+	value  func(T) string
+}
+
+// printer writes messages in the chosen format
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// newPrinter creates a printer, rejecting unknown formats
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	for _, known := range formats {
+		if format == known {
+			return &printer{w: w, format: format}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown output format %q, must be one of %s", format, strings.Join(formats, ", "))
+}
+
+// printOne writes a single message: a one-row table, a JSON object or a YAML
+// mapping
+func printOne[T proto.Message](p *printer, item T, columns []column[T]) error {
+	if p.format == FormatTable {
+		return writeTable(p.w, []T{item}, columns)
+	}
+	raw, err := jsonOptions.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return p.writeJSON(raw)
+}
+
+// printList writes messages as table rows, a JSON array or a YAML sequence
+func printList[T proto.Message](p *printer, items []T, columns []column[T]) error {
+	if p.format == FormatTable {
+		return writeTable(p.w, items, columns)
+	}
+	raw, err := marshalList(items)
+	if err != nil {
+		return err
+	}
+	return p.writeJSON(raw)
+}
+
+// marshalList marshals messages into a JSON array
+func marshalList[T proto.Message](items []T) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		raw, err := jsonOptions.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(raw)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// printValue writes a value that isn't a message as JSON or YAML
+func printValue(w io.Writer, format string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	p := &printer{w: w, format: format}
+	return p.writeJSON(raw)
+}
+
+// writeJSON writes compact JSON indented, or converted to YAML
+func (p *printer) writeJSON(raw []byte) error {
+	if p.format == FormatYAML {
+		return writeYAML(p.w, raw)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := p.w.Write(buf.Bytes())
+	return err
+}
+
+// writeYAML converts JSON to block-style YAML, keeping the order of the keys
+func writeYAML(w io.Writer, raw []byte) error {
+	// JSON is YAML in flow style
+	var node yaml.Node
+	if err := yaml.Unmarshal(raw, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// blockStyle drops the flow style and quotes of the JSON the node was parsed
+// from, quoting only what YAML needs quoted
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// writeTable writes a header and one row per message, aligned with tabs
+func writeTable[T proto.Message](w io.Writer, items []T, columns []column[T]) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.header
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, item := range items {
+		values := make([]string, len(columns))
+		for i, col := range columns {
+			// Tabs and newlines would break the alignment
+			values[i] = strings.Join(strings.Fields(col.value(item)), " ")
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+// formatTime formats a timestamp for tables, empty if it isn't set
+func formatTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Local().Format(time.RFC3339)
+}
+
+// enumName returns the short lowercase name of an enum value, e.g. city for
+// LUME_TYPE_CITY, or "" if it's unspecified
+func enumName(value protoreflect.Enum, prefix string) string {
+	if value.Number() == 0 {
+		return ""
+	}
+	desc := value.Descriptor().Values().ByNumber(value.Number())
+	if desc == nil {
+		return fmt.Sprint(value.Number())
+	}
+	return strings.ToLower(strings.TrimPrefix(string(desc.Name()), prefix))
+}
+
+// enumNames returns the short names of an enum's values, except the
+// unspecified one
+func enumNames(desc protoreflect.EnumDescriptor, prefix string) []string {
+	var names []string
+	values := desc.Values()
+	for i := 0; i < values.Len(); i++ {
+		if values.Get(i).Number() == 0 {
+			continue
+		}
+		names = append(names, strings.ToLower(strings.TrimPrefix(string(values.Get(i).Name()), prefix)))
+	}
+	return names
+}
+
+// parseEnum parses the short or full name of an enum value, in any case
+func parseEnum(desc protoreflect.EnumDescriptor, prefix, s string) (protoreflect.EnumNumber, error) {
+	name := strings.ToUpper(s)
+	if !strings.HasPrefix(name, prefix) {
+		name = prefix + name
+	}
+	value := desc.Values().ByName(protoreflect.Name(name))
+	if value == nil || value.Number() == 0 {
+		return 0, fmt.Errorf("unknown %s %q, must be one of %s", strings.ToLower(strings.ReplaceAll(strings.TrimSuffix(prefix, "_"), "_", " ")), s, strings.Join(enumNames(desc, prefix), ", "))
+	}
+	return value.Number(), nil
+}
@@ -0,0 +1,400 @@
+package lumoctl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables overriding the config file and the current profile
+const (
+	ConfigEnv  = "LUMOCTL_CONFIG"
+	ProfileEnv = "LUMOCTL_PROFILE"
+	ServerEnv  = "LUMOCTL_SERVER"
+	TokenEnv   = "LUMOCTL_TOKEN"
+)
+
+// DefaultServer is used when neither a flag, the environment nor the profile
+// names a server
+const DefaultServer = "http://localhost:8080"
+
+// DefaultProfile is the profile used when no other is selected
+const DefaultProfile = "default"
+
+// Protocols the API can be called with
+const (
+	ProtocolConnect = "connect"
+	ProtocolGRPC    = "grpc"
+	ProtocolGRPCWeb = "grpcweb"
+)
+
+// Profile is a server to call and how to authenticate with it
+type Profile struct {
+	// Server is the base URL of the API, e.g. https://lumo.example.com
+	Server string `yaml:"server,omitempty" json:"server,omitempty"`
+
+	// Token is a bearer token or API key, sent as Authorization: Bearer
+	Token string `yaml:"token,omitempty" json:"token,omitempty"`
+
+	// TokenEnv names an environment variable holding the token instead, to
+	// keep it out of the file
+	TokenEnv string `yaml:"token_env,omitempty" json:"token_env,omitempty"`
+
+	// Protocol is connect, the default, grpc or grpcweb
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+
+	// CAFile verifies the server's certificate instead of the system roots
+	CAFile string `yaml:"ca_file,omitempty" json:"ca_file,omitempty"`
+}
+
+// Profiles is the content of the config file
+type Profiles struct {
+	// Current is the profile used without --profile or $LUMOCTL_PROFILE
+	Current  string              `yaml:"current,omitempty" json:"current,omitempty"`
+	Profiles map[string]*Profile `yaml:"profiles" json:"profiles"`
+}
+
+// DefaultConfigPath returns $LUMOCTL_CONFIG or config.yaml in the user's
+// config directory, e.g. ~/.config/lumoctl/config.yaml
+func DefaultConfigPath(lookupEnv func(string) (string, bool)) (string, error) {
+	if path, ok := lookupEnv(ConfigEnv); ok && path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "lumoctl", "config.yaml"), nil
+}
+
+// LoadProfiles reads the config file, which may not exist yet
+func LoadProfiles(path string) (*Profiles, error) {
+	profiles := &Profiles{Profiles: map[string]*Profile{}}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(content, profiles); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if profiles.Profiles == nil {
+		profiles.Profiles = map[string]*Profile{}
+	}
+	return profiles, nil
+}
+
+// Save writes the config file, readable only by the user since it may hold tokens
+func (p *Profiles) Save(path string) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(p); err != nil {
+		return err
+	}
+	content := buf.Bytes()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// Names returns the names of the profiles in order
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the named profile, or the current one if name is empty. Only
+// a profile asked for by name has to exist; otherwise an empty profile is
+// returned so lumoctl works without a config file.
+func (p *Profiles) Get(name string) (string, *Profile, error) {
+	explicit := name != ""
+	if name == "" {
+		name = p.Current
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	profile, ok := p.Profiles[name]
+	if !ok {
+		if explicit {
+			return "", nil, fmt.Errorf("profile %q not found", name)
+		}
+		profile = &Profile{}
+	}
+	return name, profile, nil
+}
+
+// Connection is where and how to call the API, after applying flags and the
+// environment over the profile
+type Connection struct {
+	Server   string `yaml:"server" json:"server"`
+	Token    string `yaml:"token,omitempty" json:"token,omitempty"`
+	Protocol string `yaml:"protocol" json:"protocol"`
+	CAFile   string `yaml:"ca_file,omitempty" json:"ca_file,omitempty"`
+}
+
+// Resolve builds the Connection from the flags, which take precedence, the
+// environment and the profile
+func (p *Profile) Resolve(server, token string, lookupEnv func(string) (string, bool)) (*Connection, error) {
+	conn := &Connection{
+		Server:   first(server, env(lookupEnv, ServerEnv), p.Server, DefaultServer),
+		Token:    first(token, env(lookupEnv, TokenEnv), p.Token),
+		Protocol: first(p.Protocol, ProtocolConnect),
+		CAFile:   p.CAFile,
+	}
+	if conn.Token == "" && p.TokenEnv != "" {
+		conn.Token = env(lookupEnv, p.TokenEnv)
+	}
+
+	switch conn.Protocol {
+	case ProtocolConnect, ProtocolGRPC, ProtocolGRPCWeb:
+	default:
+		return nil, fmt.Errorf("unknown protocol %q, must be %s, %s or %s", conn.Protocol, ProtocolConnect, ProtocolGRPC, ProtocolGRPCWeb)
+	}
+	return conn, nil
+}
+
+// env returns the value of an environment variable, or "" if it isn't set
+func env(lookupEnv func(string) (string, bool), name string) string {
+	value, _ := lookupEnv(name)
+	return value
+}
+
+// first returns the first non-empty value
+func first(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// redactedToken replaces tokens in what lumoctl prints
+const redactedToken = "REDACTED"
+
+// redacted returns a copy of the profile without its token
+func (p *Profile) redacted() *Profile {
+	copied := *p
+	if copied.Token != "" {
+		copied.Token = redactedToken
+	}
+	return &copied
+}
+
+// newProfileCommand builds the profile commands
+func newProfileCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "profile",
+		Aliases: []string{"profiles"},
+		Short:   "Manage the servers and credentials lumoctl uses",
+	}
+	cmd.AddCommand(
+		newProfileListCommand(o),
+		newProfileShowCommand(o),
+		newProfileSetCommand(o),
+		newProfileUseCommand(o),
+		newProfileDeleteCommand(o),
+	)
+	return cmd
+}
+
+// newProfileListCommand builds profile list
+func newProfileListCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the profiles, marking the current one",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if _, err := o.printer(cmd); err != nil {
+				return err
+			}
+			profiles, _, err := o.loadProfiles()
+			if err != nil {
+				return err
+			}
+			if o.output != FormatTable {
+				redacted := &Profiles{Current: profiles.Current, Profiles: map[string]*Profile{}}
+				for name, profile := range profiles.Profiles {
+					redacted.Profiles[name] = profile.redacted()
+				}
+				return printValue(cmd.OutOrStdout(), o.output, redacted)
+			}
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(tw, "CURRENT\tNAME\tSERVER\tPROTOCOL")
+			for _, name := range profiles.Names() {
+				profile := profiles.Profiles[name]
+				current := ""
+				if name == profiles.Current {
+					current = "*"
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", current, name, profile.Server, first(profile.Protocol, ProtocolConnect))
+			}
+			return tw.Flush()
+		},
+	}
+}
+
+// newProfileShowCommand builds profile show
+func newProfileShowCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:               "show",
+		Short:             "Show the connection settings in effect, after flags and the environment",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if _, err := o.printer(cmd); err != nil {
+				return err
+			}
+			conn, err := o.connection()
+			if err != nil {
+				return err
+			}
+			shown := *conn
+			if shown.Token != "" {
+				shown.Token = redactedToken
+			}
+			format := o.output
+			if format == FormatTable {
+				format = FormatYAML
+			}
+			return printValue(cmd.OutOrStdout(), format, &shown)
+		},
+	}
+}
+
+// newProfileSetCommand builds profile set
+func newProfileSetCommand(o *options) *cobra.Command {
+	var tokenEnv, protocol, caFile string
+	var use bool
+	cmd := &cobra.Command{
+		Use:   "set NAME [--server URL] [--token TOKEN] [flags]",
+		Short: "Create a profile or change the settings given as flags",
+		Long: `set creates a profile or changes the settings given as flags, taking the
+server and token from the global --server and --token flags. The first profile
+becomes the current one.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: o.completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, path, err := o.loadProfiles()
+			if err != nil {
+				return err
+			}
+			profile, ok := profiles.Profiles[args[0]]
+			if !ok {
+				profile = &Profile{}
+				profiles.Profiles[args[0]] = profile
+			}
+
+			flags := cmd.Flags()
+			if flags.Changed("server") {
+				profile.Server = o.server
+			}
+			if flags.Changed("token") {
+				profile.Token = o.token
+			}
+			if flags.Changed("token-env") {
+				profile.TokenEnv = tokenEnv
+			}
+			if flags.Changed("protocol") {
+				profile.Protocol = protocol
+			}
+			if flags.Changed("ca-file") {
+				profile.CAFile = caFile
+			}
+			if _, err := profile.Resolve("", "", o.lookupEnv); err != nil {
+				return err
+			}
+			if use || profiles.Current == "" {
+				profiles.Current = args[0]
+			}
+			return profiles.Save(path)
+		},
+	}
+	cmd.Flags().StringVar(&tokenEnv, "token-env", "", "environment variable to read the token from instead")
+	cmd.Flags().StringVar(&protocol, "protocol", "", "protocol to call the API with: connect, grpc or grpcweb")
+	cmd.Flags().StringVar(&caFile, "ca-file", "", "CA certificates to verify the server with instead of the system's")
+	cmd.Flags().BoolVar(&use, "use", false, "make the profile the current one")
+	_ = cmd.RegisterFlagCompletionFunc("protocol", cobra.FixedCompletions(
+		[]string{ProtocolConnect, ProtocolGRPC, ProtocolGRPCWeb}, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+// newProfileUseCommand builds profile use
+func newProfileUseCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:               "use NAME",
+		Short:             "Make a profile the current one",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: o.completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, path, err := o.loadProfiles()
+			if err != nil {
+				return err
+			}
+			if _, ok := profiles.Profiles[args[0]]; !ok {
+				return fmt.Errorf("profile %q not found", args[0])
+			}
+			profiles.Current = args[0]
+			return profiles.Save(path)
+		},
+	}
+}
+
+// newProfileDeleteCommand builds profile delete
+func newProfileDeleteCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete NAME",
+		Aliases:           []string{"rm"},
+		Short:             "Delete a profile",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: o.completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, path, err := o.loadProfiles()
+			if err != nil {
+				return err
+			}
+			if _, ok := profiles.Profiles[args[0]]; !ok {
+				return fmt.Errorf("profile %q not found", args[0])
+			}
+			delete(profiles.Profiles, args[0])
+			if profiles.Current == args[0] {
+				profiles.Current = ""
+			}
+			return profiles.Save(path)
+		},
+	}
+}
+
+// completeProfiles completes the names of the profiles
+func (o *options) completeProfiles(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	profiles, _, err := o.loadProfiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return profiles.Names(), cobra.ShellCompDirectiveNoFileComp
+}
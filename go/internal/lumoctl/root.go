@@ -0,0 +1,134 @@
+// Package lumoctl implements lumoctl, the command-line client of the Lumo API
+package lumoctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// options are the global flags and what the commands share
+type options struct {
+	configPath string
+	profile    string
+	server     string
+	token      string
+	output     string
+	timeout    time.Duration
+
+	lookupEnv func(string) (string, bool)
+	stdin     io.Reader
+}
+
+// Execute runs lumoctl with the process's arguments and environment and
+// returns its exit code
+func Execute() int {
+	cmd := NewRootCommand(os.LookupEnv, os.Stdin)
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "lumoctl:", err)
+		return 1
+	}
+	return 0
+}
+
+// NewRootCommand builds the lumoctl command tree. lookupEnv is normally
+// os.LookupEnv and stdin os.Stdin.
+func NewRootCommand(lookupEnv func(string) (string, bool), stdin io.Reader) *cobra.Command {
+	o := &options{
+		lookupEnv: lookupEnv,
+		stdin:     stdin,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "lumoctl",
+		Short: "Command-line client of the Lumo API",
+		Long: `lumoctl manages Lumos, their Lumes and the Links between them through the
+Lumo API. The server and token come from a profile in the config file, which
+--server, --token, $LUMOCTL_SERVER and $LUMOCTL_TOKEN override.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&o.configPath, "config", "", "config file (default $"+ConfigEnv+" or ~/.config/lumoctl/config.yaml)")
+	flags.StringVarP(&o.profile, "profile", "p", "", "profile to use (default $"+ProfileEnv+" or the current profile)")
+	flags.StringVar(&o.server, "server", "", "server URL, overriding the profile")
+	flags.StringVar(&o.token, "token", "", "bearer token or API key, overriding the profile")
+	flags.StringVarP(&o.output, "output", "o", FormatTable, "output format: table, json or yaml")
+	flags.DurationVar(&o.timeout, "timeout", 30*time.Second, "how long a command may take")
+
+	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(formats, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("profile", o.completeProfiles)
+
+	cmd.AddCommand(
+		newLumoCommand(o),
+		newLumeCommand(o),
+		newLinkCommand(o),
+		newExportCommand(o),
+		newImportCommand(o),
+		newProfileCommand(o),
+	)
+	return cmd
+}
+
+// path returns the config file to use
+func (o *options) path() (string, error) {
+	if o.configPath != "" {
+		return o.configPath, nil
+	}
+	return DefaultConfigPath(o.lookupEnv)
+}
+
+// loadProfiles reads the config file
+func (o *options) loadProfiles() (*Profiles, string, error) {
+	path, err := o.path()
+	if err != nil {
+		return nil, "", err
+	}
+	profiles, err := LoadProfiles(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return profiles, path, nil
+}
+
+// connection resolves where and how to call the API
+func (o *options) connection() (*Connection, error) {
+	profiles, _, err := o.loadProfiles()
+	if err != nil {
+		return nil, err
+	}
+	name := o.profile
+	if name == "" {
+		name = env(o.lookupEnv, ProfileEnv)
+	}
+	_, profile, err := profiles.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return profile.Resolve(o.server, o.token, o.lookupEnv)
+}
+
+// withClient runs fn with the API client and a context bounded by --timeout
+func (o *options) withClient(cmd *cobra.Command, fn func(ctx context.Context, client *Client) error) error {
+	conn, err := o.connection()
+	if err != nil {
+		return err
+	}
+	client, err := NewClient(conn)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), o.timeout)
+	defer cancel()
+	return fn(ctx, client)
+}
+
+// printer creates the printer for --output
+func (o *options) printer(cmd *cobra.Command) (*printer, error) {
+	return newPrinter(cmd.OutOrStdout(), o.output)
+}
@@ -0,0 +1,345 @@
+package lumoctl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	linkv1 "github.com/mcdev12/lumo/go/internal/genproto/link/v1"
+	lumev1 "github.com/mcdev12/lumo/go/internal/genproto/lume/v1"
+	lumov1 "github.com/mcdev12/lumo/go/internal/genproto/lumo/v1"
+	"github.com/mcdev12/lumo/go/internal/ratelimit"
+)
+
+// ExportVersion is the version of the export format, bumped on changes
+// older versions of import can't read
+const ExportVersion = 1
+
+// Export is a Lumo with its Lumes and Links, as written by export and read by
+// import
+type Export struct {
+	Version    int
+	ExportedAt time.Time
+	Lumo       *lumov1.Lumo
+	Lumes      []*lumev1.Lume
+	Links      []*linkv1.Link
+}
+
+// exportFile is the JSON layout of an Export, with the entities in the JSON
+// mapping of the API
+type exportFile struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Lumo       json.RawMessage   `json:"lumo"`
+	Lumes      []json.RawMessage `json:"lumes"`
+	Links      []json.RawMessage `json:"links"`
+}
+
+// MarshalJSON writes the Export as an exportFile
+func (e *Export) MarshalJSON() ([]byte, error) {
+	file := exportFile{
+		Version:    e.Version,
+		ExportedAt: e.ExportedAt,
+		Lumes:      []json.RawMessage{},
+		Links:      []json.RawMessage{},
+	}
+	var err error
+	if file.Lumo, err = jsonOptions.Marshal(e.Lumo); err != nil {
+		return nil, err
+	}
+	for _, lume := range e.Lumes {
+		raw, err := jsonOptions.Marshal(lume)
+		if err != nil {
+			return nil, err
+		}
+		file.Lumes = append(file.Lumes, raw)
+	}
+	for _, link := range e.Links {
+		raw, err := jsonOptions.Marshal(link)
+		if err != nil {
+			return nil, err
+		}
+		file.Links = append(file.Links, raw)
+	}
+	return json.Marshal(file)
+}
+
+// UnmarshalJSON reads an exportFile, rejecting versions it doesn't know
+func (e *Export) UnmarshalJSON(data []byte) error {
+	var file exportFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	if file.Version != ExportVersion {
+		return fmt.Errorf("unsupported export version %d, expected %d", file.Version, ExportVersion)
+	}
+	if len(file.Lumo) == 0 {
+		return fmt.Errorf("export has no lumo")
+	}
+
+	e.Version = file.Version
+	e.ExportedAt = file.ExportedAt
+	e.Lumo = &lumov1.Lumo{}
+	if err := protojson.Unmarshal(file.Lumo, e.Lumo); err != nil {
+		return fmt.Errorf("invalid lumo: %w", err)
+	}
+	e.Lumes = make([]*lumev1.Lume, len(file.Lumes))
+	for i, raw := range file.Lumes {
+		e.Lumes[i] = &lumev1.Lume{}
+		if err := protojson.Unmarshal(raw, e.Lumes[i]); err != nil {
+			return fmt.Errorf("invalid lume %d: %w", i, err)
+		}
+	}
+	e.Links = make([]*linkv1.Link, len(file.Links))
+	for i, raw := range file.Links {
+		e.Links[i] = &linkv1.Link{}
+		if err := protojson.Unmarshal(raw, e.Links[i]); err != nil {
+			return fmt.Errorf("invalid link %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// exportLumo reads a Lumo with all of its Lumes and Links
+func exportLumo(ctx context.Context, client *Client, lumoID string) (*Export, error) {
+	res, err := client.Lumos.GetLumo(ctx, connect.NewRequest(&lumov1.GetLumoRequest{Uuid: lumoID}))
+	if err != nil {
+		return nil, err
+	}
+	lumes, err := listLumes(ctx, client, lumoID, lumev1.LumeType_LUME_TYPE_UNSPECIFIED)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lumes: %w", err)
+	}
+	links, err := listLinks(ctx, client, &linkv1.ListLinksRequest{LumoUuid: lumoID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+
+	return &Export{
+		Version:    ExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Lumo:       res.Msg.GetLumo(),
+		Lumes:      lumes,
+		Links:      links,
+	}, nil
+}
+
+// importLumo recreates an exported Lumo, its Lumes and the Links between
+// them, which get new IDs. Every call carries an idempotency key derived from
+// keyPrefix and the exported ID, so running the same import again after a
+// failure picks up where it stopped instead of creating duplicates.
+func importLumo(ctx context.Context, client *Client, export *Export, title, keyPrefix string) (*lumov1.Lumo, error) {
+	lumeIDs := make(map[string]string, len(export.Lumes))
+	for _, lume := range export.Lumes {
+		lumeIDs[lume.GetLumeId()] = ""
+	}
+	for _, link := range export.Links {
+		_, fromOK := lumeIDs[link.GetFromLumeId()]
+		_, toOK := lumeIDs[link.GetToLumeId()]
+		if !fromOK || !toOK {
+			return nil, fmt.Errorf("link %s connects lumes that aren't in the export", link.GetLinkId())
+		}
+	}
+
+	if title == "" {
+		title = export.Lumo.GetTitle()
+	}
+	lumoReq := connect.NewRequest(&lumov1.CreateLumoRequest{
+		Lumo:           &lumov1.Lumo{Title: title},
+		IdempotencyKey: keyPrefix + "-lumo",
+	})
+	lumoRes, err := retryRateLimited(ctx, func() (*connect.Response[lumov1.CreateLumoResponse], error) {
+		return client.Lumos.CreateLumo(ctx, lumoReq)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lumo: %w", err)
+	}
+	lumo := lumoRes.Msg.GetLumo()
+
+	for _, lume := range export.Lumes {
+		req := connect.NewRequest(&lumev1.CreateLumeRequest{
+			LumoId:         lumo.GetLumoId(),
+			Type:           lume.GetType(),
+			Name:           lume.GetName(),
+			DateStart:      lume.GetDateStart(),
+			DateEnd:        lume.GetDateEnd(),
+			Latitude:       lume.GetLatitude(),
+			Longitude:      lume.GetLongitude(),
+			Address:        lume.GetAddress(),
+			Description:    lume.GetDescription(),
+			Images:         lume.GetImages(),
+			CategoryTags:   lume.GetCategoryTags(),
+			BookingLink:    lume.GetBookingLink(),
+			TimeZone:       lume.GetTimeZone(),
+			IdempotencyKey: keyPrefix + "-lume-" + lume.GetLumeId(),
+		})
+		res, err := retryRateLimited(ctx, func() (*connect.Response[lumev1.CreateLumeResponse], error) {
+			return client.Lumes.CreateLume(ctx, req)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create lume %q: %w", lume.GetName(), err)
+		}
+		lumeIDs[lume.GetLumeId()] = res.Msg.GetLume().GetLumeId()
+	}
+
+	for _, link := range export.Links {
+		var travel *linkv1.TravelDetails
+		if link.GetTravel() != nil {
+			travel = proto.Clone(link.GetTravel()).(*linkv1.TravelDetails)
+		}
+		req := connect.NewRequest(&linkv1.CreateLinkRequest{
+			FromLumeId:     lumeIDs[link.GetFromLumeId()],
+			ToLumeId:       lumeIDs[link.GetToLumeId()],
+			Type:           link.GetType(),
+			Mode:           requestMode(travel),
+			Travel:         travel,
+			Notes:          link.GetNotes(),
+			SequenceIndex:  link.GetSequenceIndex(),
+			IdempotencyKey: keyPrefix + "-link-" + link.GetLinkId(),
+		})
+		_, err := retryRateLimited(ctx, func() (*connect.Response[linkv1.CreateLinkResponse], error) {
+			return client.Links.CreateLink(ctx, req)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create link %s: %w", link.GetLinkId(), err)
+		}
+	}
+
+	return lumo, nil
+}
+
+// maxRateLimitRetries is how many times a rate limited call is retried before
+// import gives up
+const maxRateLimitRetries = 5
+
+// retryRateLimited makes a call, and makes it again for as long as the server
+// asks to retry later, waiting for the Retry-After it sends. The request is
+// resent unchanged, so creates keep their idempotency key.
+func retryRateLimited[T any](ctx context.Context, call func() (T, error)) (T, error) {
+	for retries := 0; ; retries++ {
+		res, err := call()
+		var connectErr *connect.Error
+		if retries == maxRateLimitRetries || !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeResourceExhausted {
+			return res, err
+		}
+
+		// The server sends whole seconds, wait a second if it didn't say
+		wait := time.Second
+		if seconds, err := strconv.Atoi(connectErr.Meta().Get(ratelimit.RetryAfterKey)); err == nil && seconds >= 0 {
+			wait = time.Duration(seconds) * time.Second
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// importKeyPrefix derives the prefix of an import's idempotency keys from
+// the file and the title it is imported under
+func importKeyPrefix(content []byte, title string) string {
+	hash := sha256.New()
+	hash.Write(content)
+	hash.Write([]byte{0})
+	hash.Write([]byte(title))
+	return "lumoctl-import-" + hex.EncodeToString(hash.Sum(nil))[:32]
+}
+
+// newExportCommand builds export
+func newExportCommand(o *options) *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:               "export LUMO_ID [-f FILE]",
+		Short:             "Write a Lumo with its Lumes and Links to a JSON file",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: o.completeLumoIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				export, err := exportLumo(ctx, client, args[0])
+				if err != nil {
+					return err
+				}
+				content, err := json.MarshalIndent(export, "", "  ")
+				if err != nil {
+					return err
+				}
+				content = append(content, '\n')
+
+				if file == "" || file == "-" {
+					_, err = cmd.OutOrStdout().Write(content)
+					return err
+				}
+				if err := os.WriteFile(file, content, 0o644); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "exported lumo %s with %d lumes and %d links to %s\n",
+					args[0], len(export.Lumes), len(export.Links), file)
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file to write, - or nothing for stdout")
+	return cmd
+}
+
+// newImportCommand builds import
+func newImportCommand(o *options) *cobra.Command {
+	var title string
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Recreate an exported Lumo with its Lumes and Links",
+		Long: `import creates a new Lumo with the Lumes and Links of a file written by
+export, or read from stdin if FILE is -. Running the same import again after a
+failure continues it, as long as the server still remembers the idempotency
+keys of the calls that succeeded. Calls the server rate limits are retried
+after the wait it asks for.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := o.printer(cmd)
+			if err != nil {
+				return err
+			}
+			var content []byte
+			if args[0] == "-" {
+				content, err = io.ReadAll(o.stdin)
+			} else {
+				content, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return err
+			}
+			var export Export
+			if err := json.Unmarshal(content, &export); err != nil {
+				return fmt.Errorf("invalid export: %w", err)
+			}
+
+			return o.withClient(cmd, func(ctx context.Context, client *Client) error {
+				lumo, err := importLumo(ctx, client, &export, title, importKeyPrefix(content, title))
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "imported %d lumes and %d links into lumo %s\n",
+					len(export.Lumes), len(export.Links), lumo.GetLumoId())
+				return printOne(p, lumo, lumoColumns)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&title, "title", "", "title of the new Lumo, defaults to the exported one")
+	return cmd
+}
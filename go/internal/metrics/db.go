@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// QueryObserver records the duration and errors of queries by name. It's
+// meant for db.WithQueryObserver.
+type QueryObserver struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+// NewQueryObserver registers the query metrics and returns the QueryObserver
+// recording them
+func NewQueryObserver(registerer prometheus.Registerer) *QueryObserver {
+	factory := promauto.With(registerer)
+	return &QueryObserver{
+		duration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "lumo_db_query_duration_seconds",
+			Help:    "How long database queries took, by sqlc query name.",
+			Buckets: DefaultBuckets,
+		}, []string{"query"}),
+		errors: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "lumo_db_query_errors_total",
+			Help: "Database queries that failed, by sqlc query name.",
+		}, []string{"query"}),
+	}
+}
+
+// ObserveQuery records a query that ran
+func (o *QueryObserver) ObserveQuery(_ context.Context, name string, duration time.Duration, err error) {
+	o.duration.WithLabelValues(name).Observe(duration.Seconds())
+	if err != nil {
+		o.errors.WithLabelValues(name).Inc()
+	}
+}
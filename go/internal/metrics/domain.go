@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/mcdev12/lumo/go/internal/models/stats"
+)
+
+// DomainRepository is what DomainCollector needs from the database
+type DomainRepository interface {
+	GetDomainStats(ctx context.Context) (*stats.Domain, error)
+}
+
+// DomainCollector counts what is stored into gauges. Counting takes a few
+// queries over whole tables, so Collect runs on an interval with
+// worker.Every rather than on every scrape.
+type DomainCollector struct {
+	repo         DomainRepository
+	entities     *prometheus.GaugeVec
+	lumesPerLumo *prometheus.GaugeVec
+}
+
+// NewDomainCollector registers the domain gauges and returns the
+// DomainCollector keeping them up to date
+func NewDomainCollector(registerer prometheus.Registerer, repo DomainRepository) *DomainCollector {
+	factory := promauto.With(registerer)
+	return &DomainCollector{
+		repo: repo,
+		entities: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "lumo_entities",
+			Help: "Lumos, Lumes and Links outside the trash, by type.",
+		}, []string{"type"}),
+		lumesPerLumo: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "lumo_lumes_per_lumo",
+			Help: "Lumes per Lumo outside the trash: mean, p50, p90, p99 and max.",
+		}, []string{"stat"}),
+	}
+}
+
+// Collect refreshes the gauges, logging rather than failing so the next run
+// can retry. The gauges keep their previous values meanwhile.
+func (c *DomainCollector) Collect(ctx context.Context) {
+	domain, err := c.repo.GetDomainStats(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "collecting domain metrics failed", slog.Any("error", err))
+		return
+	}
+
+	c.entities.WithLabelValues("lumo").Set(float64(domain.Lumos))
+	c.entities.WithLabelValues("lume").Set(float64(domain.Lumes))
+	c.entities.WithLabelValues("link").Set(float64(domain.Links))
+
+	c.lumesPerLumo.WithLabelValues("mean").Set(domain.LumesPerLumo.Mean)
+	c.lumesPerLumo.WithLabelValues("p50").Set(domain.LumesPerLumo.P50)
+	c.lumesPerLumo.WithLabelValues("p90").Set(domain.LumesPerLumo.P90)
+	c.lumesPerLumo.WithLabelValues("p99").Set(domain.LumesPerLumo.P99)
+	c.lumesPerLumo.WithLabelValues("max").Set(domain.LumesPerLumo.Max)
+}
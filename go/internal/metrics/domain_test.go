@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mcdev12/lumo/go/internal/models/stats"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+)
+
+// stubDomainRepository returns its stats or its error
+type stubDomainRepository struct {
+	domain *stats.Domain
+	err    error
+}
+
+// GetDomainStats returns the stats or the error
+func (r *stubDomainRepository) GetDomainStats(context.Context) (*stats.Domain, error) {
+	return r.domain, r.err
+}
+
+// DomainCollectorTestSuite is a test suite for the DomainCollector
+type DomainCollectorTestSuite struct {
+	suite.Suite
+	registry  *prometheus.Registry
+	repo      *stubDomainRepository
+	collector *DomainCollector
+}
+
+// SetupTest is called before each test
+func (s *DomainCollectorTestSuite) SetupTest() {
+	s.registry = NewRegistry()
+	s.repo = &stubDomainRepository{}
+	s.collector = NewDomainCollector(s.registry, s.repo)
+}
+
+// TestDomainCollectorSuite runs the test suite
+func TestDomainCollectorSuite(t *testing.T) {
+	suite.Run(t, new(DomainCollectorTestSuite))
+}
+
+// scrape returns what the registry serves
+func (s *DomainCollectorTestSuite) scrape() string {
+	recorder := httptest.NewRecorder()
+	Handler(s.registry).ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	return recorder.Body.String()
+}
+
+// Test the gauges are set from the stats, and kept when collecting fails
+func (s *DomainCollectorTestSuite) TestCollect() {
+	// Arrange
+	s.repo.domain = &stats.Domain{
+		Lumos:        2,
+		Lumes:        7,
+		Links:        4,
+		LumesPerLumo: stats.Distribution{Mean: 3.5, P50: 3.5, P90: 5.5, P99: 5.95, Max: 6},
+	}
+
+	// Act
+	s.collector.Collect(context.Background())
+	s.repo.domain, s.repo.err = nil, errors.New("database error")
+	s.collector.Collect(context.Background())
+
+	// Assert
+	body := s.scrape()
+	s.Contains(body, `lumo_entities{type="lume"} 7`)
+	s.Contains(body, `lumo_entities{type="link"} 4`)
+	s.Contains(body, `lumo_lumes_per_lumo{stat="mean"} 3.5`)
+	s.Contains(body, `lumo_lumes_per_lumo{stat="max"} 6`)
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Interceptor counts requests by procedure and code and records how long
+// they took. It belongs in front of the logging interceptor, so panics are
+// counted as the CodeInternal errors they become.
+type Interceptor struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewInterceptor registers the RPC metrics and returns the Interceptor
+// recording them
+func NewInterceptor(registerer prometheus.Registerer) *Interceptor {
+	factory := promauto.With(registerer)
+	return &Interceptor{
+		requests: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "lumo_rpc_requests_total",
+			Help: "Requests handled, by procedure and code.",
+		}, []string{"procedure", "code"}),
+		duration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "lumo_rpc_request_duration_seconds",
+			Help:    "How long requests took to handle, by procedure.",
+			Buckets: DefaultBuckets,
+		}, []string{"procedure"}),
+	}
+}
+
+// WrapUnary records unary requests
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		start := time.Now()
+		res, err := next(ctx, req)
+		i.record(req.Spec().Procedure, err, start)
+		return res, err
+	}
+}
+
+// WrapStreamingClient leaves outgoing streams alone
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler records streaming requests once they end
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		start := time.Now()
+		err := next(ctx, conn)
+		i.record(conn.Spec().Procedure, err, start)
+		return err
+	}
+}
+
+// record counts a request and observes its duration
+func (i *Interceptor) record(procedure string, err error, start time.Time) {
+	code := "ok"
+	if err != nil {
+		code = connect.CodeOf(err).String()
+	}
+	i.requests.WithLabelValues(procedure, code).Inc()
+	i.duration.WithLabelValues(procedure).Observe(time.Since(start).Seconds())
+}
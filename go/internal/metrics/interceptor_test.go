@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const testProcedure = "/test.v1.TestService/Test"
+
+// InterceptorTestSuite is a test suite for the Interceptor
+type InterceptorTestSuite struct {
+	suite.Suite
+	registry *prometheus.Registry
+	server   *httptest.Server
+	handle   func() error
+}
+
+// SetupTest is called before each test
+func (s *InterceptorTestSuite) SetupTest() {
+	s.registry = NewRegistry()
+	s.handle = func() error { return nil }
+
+	handler := func(_ context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+		if err := s.handle(); err != nil {
+			return nil, err
+		}
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle(testProcedure, connect.NewUnaryHandler(testProcedure, handler, connect.WithInterceptors(NewInterceptor(s.registry))))
+	s.server = httptest.NewServer(mux)
+}
+
+// TearDownTest is called after each test
+func (s *InterceptorTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// TestInterceptorSuite runs the test suite
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorTestSuite))
+}
+
+// call makes a request
+func (s *InterceptorTestSuite) call() error {
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](s.server.Client(), s.server.URL+testProcedure)
+	_, err := client.CallUnary(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	return err
+}
+
+// Test requests are counted by procedure and code and timed
+func (s *InterceptorTestSuite) TestRecordsRequests() {
+	// Act
+	s.NoError(s.call())
+	s.NoError(s.call())
+	s.handle = func() error { return connect.NewError(connect.CodeNotFound, errors.New("no such lume")) }
+	s.Error(s.call())
+	s.handle = func() error { return errors.New("boom") }
+	s.Error(s.call())
+
+	// Assert
+	recorder := httptest.NewRecorder()
+	Handler(s.registry).ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+	s.Contains(body, `lumo_rpc_requests_total{code="ok",procedure="`+testProcedure+`"} 2`)
+	s.Contains(body, `lumo_rpc_requests_total{code="not_found",procedure="`+testProcedure+`"} 1`)
+	s.Contains(body, `lumo_rpc_requests_total{code="unknown",procedure="`+testProcedure+`"} 1`)
+	s.Contains(body, `lumo_rpc_request_duration_seconds_count{procedure="`+testProcedure+`"} 4`)
+}
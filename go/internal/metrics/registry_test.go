@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/stretchr/testify/suite"
+)
+
+// RegistryTestSuite is a test suite for the Registry
+type RegistryTestSuite struct {
+	suite.Suite
+	registry *prometheus.Registry
+}
+
+// SetupTest is called before each test
+func (s *RegistryTestSuite) SetupTest() {
+	s.registry = NewRegistry()
+}
+
+// TestRegistrySuite runs the test suite
+func TestRegistrySuite(t *testing.T) {
+	suite.Run(t, new(RegistryTestSuite))
+}
+
+// scrape returns what the handler serves
+func (s *RegistryTestSuite) scrape() string {
+	recorder := httptest.NewRecorder()
+	Handler(s.registry).ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	s.Contains(recorder.Header().Get("Content-Type"), "text/plain")
+	body, err := io.ReadAll(recorder.Body)
+	s.Require().NoError(err)
+	return string(body)
+}
+
+// Test the runtime metrics are served next to the server's own
+func (s *RegistryTestSuite) TestHandler() {
+	// Arrange
+	observer := NewQueryObserver(s.registry)
+	s.registry.MustRegister(collectors.NewDBStatsCollector(&sql.DB{}, "lumo"))
+
+	// Act
+	observer.ObserveQuery(context.Background(), "GetLumo", 0, sql.ErrNoRows)
+
+	// Assert
+	body := s.scrape()
+	s.Contains(body, "go_goroutines ")
+	s.Contains(body, `go_sql_open_connections{db_name="lumo"} 0`)
+	s.Contains(body, `lumo_db_query_duration_seconds_count{query="GetLumo"} 1`)
+	s.Contains(body, `lumo_db_query_errors_total{query="GetLumo"} 1`)
+}
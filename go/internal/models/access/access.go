@@ -0,0 +1,63 @@
+package access
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when an entity doesn't exist or belongs to
+	// someone else. Both look the same so callers can't probe for other
+	// users' Lumos.
+	ErrNotFound = errors.New("not found")
+
+	// ErrCrossLumoLink is returned when a Link would connect Lumes of two
+	// different Lumos
+	ErrCrossLumoLink = errors.New("linked lumes must belong to the same lumo")
+)
+
+// Role is what a member may do in a Lumo
+type Role string
+
+const (
+	RoleUnspecified Role = "ROLE_UNSPECIFIED"
+	RoleOwner       Role = "OWNER"
+	RoleEditor      Role = "EDITOR"
+	RoleCommenter   Role = "COMMENTER"
+	RoleViewer      Role = "VIEWER"
+)
+
+// roleRanks orders the roles, each role can do everything the ones ranked
+// below it can
+var roleRanks = map[Role]int{
+	RoleViewer:    1,
+	RoleCommenter: 2,
+	RoleEditor:    3,
+	RoleOwner:     4,
+}
+
+// IsValid reports whether r is a role a member can have
+func (r Role) IsValid() bool {
+	return roleRanks[r] > 0
+}
+
+// Allows reports whether a member with role r may do what requires the
+// given role
+func (r Role) Allows(required Role) bool {
+	return r.IsValid() && roleRanks[r] >= roleRanks[required]
+}
+
+// Owner identifies the Lumo an entity belongs to, the user who created it and
+// the role the caller has in it
+type Owner struct {
+	LumoID string
+	UserID string
+
+	// Set by authorization, RoleOwner when there is no authenticated caller
+	Role Role
+}
+
+// NewOwner creates a new Owner
+func NewOwner(lumoID, userID string) *Owner {
+	return &Owner{
+		LumoID: lumoID,
+		UserID: userID,
+	}
+}
@@ -0,0 +1,144 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrAPIKeyNotFound is returned for unknown keys as well as for keys that
+// were revoked, so a secret reveals nothing about why it stopped working
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// SecretPrefix starts every secret, telling API keys apart from bearer tokens
+const SecretPrefix = "lumo_"
+
+const (
+	// secretBytes is how much randomness goes into a secret
+	secretBytes = 32
+
+	// prefixLength is how much of a secret is kept to tell keys apart
+	prefixLength = len(SecretPrefix) + 8
+)
+
+// Scope is what a key may do on behalf of its user
+type Scope string
+
+const (
+	ScopeUnspecified Scope = ""
+	// Only procedures without side effects, in every Lumo the user can read
+	ScopeReadOnly Scope = "READ_ONLY"
+	// Anything the user may do within a single Lumo
+	ScopeLumo Scope = "LUMO"
+	// Anything the user may do
+	ScopeFull Scope = "FULL"
+)
+
+// IsValid reports whether the scope is one of the known scopes
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeReadOnly, ScopeLumo, ScopeFull:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIKey represents a key scripts and other services authenticate with
+type APIKey struct {
+	// Internal database ID (not exposed in API)
+	ID int64 `json:"-"`
+
+	// Unique identifier (UUID)
+	APIKeyID string `json:"api_key_id"`
+
+	// The user the key acts for (UUID)
+	UserID string `json:"user_id"`
+
+	// Free-form label
+	Name string `json:"name"`
+
+	// The first characters of the secret, to tell keys apart
+	Prefix string `json:"prefix"`
+
+	// The secret itself. Only known right after it was created or rotated,
+	// the database keeps its hash.
+	Secret     string `json:"-"`
+	SecretHash string `json:"-"`
+
+	// What the key may do, ScopeLumo keys are limited to LumoID
+	Scope  Scope  `json:"scope"`
+	LumoID string `json:"lumo_id,omitempty"`
+
+	// Usage tracking
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	// The key stops working once it's revoked
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+
+	// When the secret was last replaced
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+
+	// System timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewAPIKey creates a new APIKey with a generated UUID and secret
+func NewAPIKey(userID, name string, scope Scope, lumoID string) (*APIKey, error) {
+	now := time.Now()
+	key := &APIKey{
+		APIKeyID:  uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		Scope:     scope,
+		LumoID:    lumoID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := key.NewSecret(); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// NewSecret replaces the secret of the key with a freshly generated one
+func (k *APIKey) NewSecret() error {
+	secret, err := generateSecret()
+	if err != nil {
+		return err
+	}
+
+	k.Secret = secret
+	k.SecretHash = HashSecret(secret)
+	k.Prefix = secret[:prefixLength]
+	return nil
+}
+
+// HashSecret returns the hash a secret is stored and looked up by
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsSecret reports whether a credential looks like an API key secret rather
+// than a bearer token
+func IsSecret(credential string) bool {
+	return strings.HasPrefix(credential, SecretPrefix)
+}
+
+// generateSecret returns a random URL-safe secret starting with SecretPrefix
+func generateSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return SecretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
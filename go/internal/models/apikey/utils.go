@@ -0,0 +1,61 @@
+package apikey
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apikeypb "github.com/mcdev12/lumo/go/internal/genproto/apikey/v1"
+)
+
+// DomainToProto converts domain APIKey to protobuf ApiKey
+func DomainToProto(domainKey *APIKey) *apikeypb.ApiKey {
+	proto := &apikeypb.ApiKey{
+		ApiKeyId:  domainKey.APIKeyID,
+		UserId:    domainKey.UserID,
+		Name:      domainKey.Name,
+		Prefix:    domainKey.Prefix,
+		Scope:     DomainScopeToProto(domainKey.Scope),
+		LumoId:    domainKey.LumoID,
+		CreatedAt: timestamppb.New(domainKey.CreatedAt),
+	}
+
+	// Handle optional timestamps
+	if domainKey.LastUsedAt != nil {
+		proto.LastUsedAt = timestamppb.New(*domainKey.LastUsedAt)
+	}
+	if domainKey.RevokedAt != nil {
+		proto.RevokedAt = timestamppb.New(*domainKey.RevokedAt)
+	}
+	if domainKey.RotatedAt != nil {
+		proto.RotatedAt = timestamppb.New(*domainKey.RotatedAt)
+	}
+
+	return proto
+}
+
+// DomainScopeToProto converts domain Scope to protobuf Scope
+func DomainScopeToProto(scope Scope) apikeypb.Scope {
+	switch scope {
+	case ScopeReadOnly:
+		return apikeypb.Scope_SCOPE_READ_ONLY
+	case ScopeLumo:
+		return apikeypb.Scope_SCOPE_LUMO
+	case ScopeFull:
+		return apikeypb.Scope_SCOPE_FULL
+	default:
+		return apikeypb.Scope_SCOPE_UNSPECIFIED
+	}
+}
+
+// ProtoScopeToDomain converts protobuf Scope to domain Scope
+func ProtoScopeToDomain(scope apikeypb.Scope) Scope {
+	switch scope {
+	case apikeypb.Scope_SCOPE_READ_ONLY:
+		return ScopeReadOnly
+	case apikeypb.Scope_SCOPE_LUMO:
+		return ScopeLumo
+	case apikeypb.Scope_SCOPE_FULL:
+		return ScopeFull
+	default:
+		return ScopeUnspecified
+	}
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CodeOK is the code of calls that succeeded
+const CodeOK = "ok"
+
+// Entry represents a recorded call to a procedure that changes data
+type Entry struct {
+	// Internal database ID (not exposed in API)
+	ID int64 `json:"-"`
+
+	// User who made the call, empty if unknown
+	Actor string `json:"actor,omitempty"`
+
+	// API key the call was made with, empty for bearer tokens
+	APIKeyID string `json:"api_key_id,omitempty"`
+
+	// Full Connect procedure, e.g. /lume.v1.LumeService/UpdateLume
+	Procedure string `json:"procedure"`
+
+	// Lumo the call was about, empty if unknown
+	LumoID string `json:"lumo_id,omitempty"`
+
+	// IDs named in the request, and in the response if the call succeeded
+	TargetIDs []string `json:"target_ids"`
+
+	// Hex SHA-256 of the request message
+	RequestDigest string `json:"request_digest"`
+
+	// Connect code of the result, CodeOK on success
+	Code string `json:"code"`
+
+	// How long the call took
+	Latency time.Duration `json:"latency"`
+
+	// When the call was made
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Filter narrows down the entries to list. Empty fields match everything.
+type Filter struct {
+	LumoID string
+	Actor  string
+	// Entries recorded at or after Since and before Until
+	Since *time.Time
+	Until *time.Time
+}
+
+// Subject collects what a call turns out to be about while it runs, for
+// things the request alone doesn't tell, like the Lumo of a Lume
+type Subject struct {
+	mu     sync.Mutex
+	lumoID string
+}
+
+// subjectKey is the context key of the call's Subject
+type subjectKey struct{}
+
+// WithSubject returns a context carrying a new Subject for a call
+func WithSubject(ctx context.Context) (context.Context, *Subject) {
+	subject := &Subject{}
+	return context.WithValue(ctx, subjectKey{}, subject), subject
+}
+
+// NoteLumo records the Lumo the call of the context is about. The first Lumo
+// noted wins, and calls that aren't audited ignore it.
+func NoteLumo(ctx context.Context, lumoID string) {
+	subject, ok := ctx.Value(subjectKey{}).(*Subject)
+	if !ok {
+		return
+	}
+
+	subject.mu.Lock()
+	defer subject.mu.Unlock()
+	if subject.lumoID == "" {
+		subject.lumoID = lumoID
+	}
+}
+
+// LumoID returns the Lumo noted for the call, empty if none was
+func (s *Subject) LumoID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lumoID
+}
@@ -0,0 +1,23 @@
+package audit
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditpb "github.com/mcdev12/lumo/go/internal/genproto/audit/v1"
+)
+
+// DomainToProto converts domain Entry to protobuf AuditLogEntry
+func DomainToProto(domainEntry *Entry) *auditpb.AuditLogEntry {
+	return &auditpb.AuditLogEntry{
+		Actor:         domainEntry.Actor,
+		ApiKeyId:      domainEntry.APIKeyID,
+		Procedure:     domainEntry.Procedure,
+		LumoId:        domainEntry.LumoID,
+		TargetIds:     domainEntry.TargetIDs,
+		RequestDigest: domainEntry.RequestDigest,
+		Code:          domainEntry.Code,
+		Latency:       durationpb.New(domainEntry.Latency),
+		CreatedAt:     timestamppb.New(domainEntry.CreatedAt),
+	}
+}
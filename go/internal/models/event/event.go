@@ -0,0 +1,84 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidResumeToken is returned when a resume token can't be parsed
+var ErrInvalidResumeToken = errors.New("invalid resume token")
+
+// EntityType represents the kind of entity an event is about
+type EntityType string
+
+const (
+	EntityTypeUnspecified EntityType = "ENTITY_TYPE_UNSPECIFIED"
+	EntityTypeLume        EntityType = "LUME"
+	EntityTypeLink        EntityType = "LINK"
+)
+
+// Type represents what happened to the entity
+type Type string
+
+const (
+	TypeUnspecified Type = "EVENT_TYPE_UNSPECIFIED"
+	TypeCreated     Type = "CREATED"
+	TypeUpdated     Type = "UPDATED"
+	TypeDeleted     Type = "DELETED"
+)
+
+// Event represents a change to a Lume or Link inside a Lumo
+type Event struct {
+	// Internal database ID, also the position used to resume a stream
+	ID int64 `json:"-"`
+
+	// Lumo the changed entity belongs to
+	LumoID string `json:"lumo_id"`
+
+	// Kind and UUID of the changed entity
+	EntityType EntityType `json:"entity_type"`
+	EntityID   string     `json:"entity_id"`
+
+	// What happened to the entity
+	Type Type `json:"type"`
+
+	// JSON state of the entity after the change, or right before it was deleted
+	Payload json.RawMessage `json:"payload"`
+
+	// System timestamp
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewEvent creates a new Event carrying a JSON snapshot of the entity
+func NewEvent(lumoID string, entityType EntityType, entityID string, eventType Type, entity any) (*Event, error) {
+	payload, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		LumoID:     lumoID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Type:       eventType,
+		Payload:    payload,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// ResumeToken returns the opaque token a client passes back to resume
+// streaming right after this event
+func (e *Event) ResumeToken() string {
+	return strconv.FormatInt(e.ID, 10)
+}
+
+// ParseResumeToken returns the event ID encoded in a resume token
+func ParseResumeToken(token string) (int64, error) {
+	id, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || id < 0 {
+		return 0, ErrInvalidResumeToken
+	}
+	return id, nil
+}
@@ -0,0 +1,67 @@
+package event
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventpb "github.com/mcdev12/lumo/go/internal/genproto/event/v1"
+	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+)
+
+// DomainToProto converts domain Event to protobuf LumoEvent
+func DomainToProto(domainEvent *Event) (*eventpb.LumoEvent, error) {
+	proto := &eventpb.LumoEvent{
+		ResumeToken: domainEvent.ResumeToken(),
+		LumoId:      domainEvent.LumoID,
+		EntityType:  DomainEntityTypeToProto(domainEvent.EntityType),
+		EntityId:    domainEvent.EntityID,
+		Type:        DomainTypeToProto(domainEvent.Type),
+		CreatedAt:   timestamppb.New(domainEvent.CreatedAt),
+	}
+
+	// Decode the entity snapshot
+	switch domainEvent.EntityType {
+	case EntityTypeLume:
+		var domainLume lume.Lume
+		if err := json.Unmarshal(domainEvent.Payload, &domainLume); err != nil {
+			return nil, err
+		}
+		proto.Entity = &eventpb.LumoEvent_Lume{Lume: lume.DomainToProto(&domainLume)}
+	case EntityTypeLink:
+		var domainLink link.Link
+		if err := json.Unmarshal(domainEvent.Payload, &domainLink); err != nil {
+			return nil, err
+		}
+		proto.Entity = &eventpb.LumoEvent_Link{Link: link.DomainToProto(&domainLink)}
+	}
+
+	return proto, nil
+}
+
+// Domain EntityType to Proto EntityType conversion
+func DomainEntityTypeToProto(dt EntityType) eventpb.EntityType {
+	switch dt {
+	case EntityTypeLume:
+		return eventpb.EntityType_ENTITY_TYPE_LUME
+	case EntityTypeLink:
+		return eventpb.EntityType_ENTITY_TYPE_LINK
+	default:
+		return eventpb.EntityType_ENTITY_TYPE_UNSPECIFIED
+	}
+}
+
+// Domain Type to Proto EventType conversion
+func DomainTypeToProto(dt Type) eventpb.EventType {
+	switch dt {
+	case TypeCreated:
+		return eventpb.EventType_EVENT_TYPE_CREATED
+	case TypeUpdated:
+		return eventpb.EventType_EVENT_TYPE_UPDATED
+	case TypeDeleted:
+		return eventpb.EventType_EVENT_TYPE_DELETED
+	default:
+		return eventpb.EventType_EVENT_TYPE_UNSPECIFIED
+	}
+}
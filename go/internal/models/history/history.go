@@ -0,0 +1,104 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/lumo"
+)
+
+// ErrNotRestorable is returned when a Lumo can't be restored to the requested
+// time, because it didn't exist back then or no history reaches that far
+var ErrNotRestorable = errors.New("lumo can't be restored to the requested time")
+
+// EntityType represents the kind of entity a history entry is about
+type EntityType string
+
+const (
+	EntityTypeUnspecified EntityType = "ENTITY_TYPE_UNSPECIFIED"
+	EntityTypeLumo        EntityType = "LUMO"
+	EntityTypeLume        EntityType = "LUME"
+	EntityTypeLink        EntityType = "LINK"
+)
+
+// Operation represents what happened to the entity. The values match the
+// event types of the Lumo event log.
+type Operation string
+
+const (
+	OperationUnspecified Operation = "OPERATION_UNSPECIFIED"
+	OperationCreated     Operation = "CREATED"
+	OperationUpdated     Operation = "UPDATED"
+	OperationDeleted     Operation = "DELETED"
+)
+
+// Entry represents one change to a Lumo, Lume or Link
+type Entry struct {
+	// Internal database ID
+	ID int64 `json:"-"`
+
+	// Lumo the changed entity belongs to
+	LumoID string `json:"lumo_id"`
+
+	// Kind and UUID of the changed entity
+	EntityType EntityType `json:"entity_type"`
+	EntityID   string     `json:"entity_id"`
+
+	// What happened to the entity
+	Operation Operation `json:"operation"`
+
+	// Who made the change, empty if unknown
+	Actor string `json:"actor,omitempty"`
+
+	// JSON state of the entity after the change, or right before it was deleted
+	Snapshot json.RawMessage `json:"snapshot"`
+
+	// When the change happened
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewEntry creates a new Entry carrying a JSON snapshot of the entity. The
+// actor is taken from the context.
+func NewEntry(ctx context.Context, lumoID string, entityType EntityType, entityID string, operation Operation, entity any) (*Entry, error) {
+	snapshot, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	actor, _ := ActorFromContext(ctx)
+
+	return &Entry{
+		LumoID:     lumoID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Operation:  operation,
+		Actor:      actor,
+		Snapshot:   snapshot,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// Graph is a Lumo together with its Lumes and Links
+type Graph struct {
+	Lumo  *lumo.Lumo
+	Lumes []*lume.Lume
+	Links []*link.Link
+}
+
+// actorKey is the context key of the actor making a change
+type actorKey struct{}
+
+// WithActor returns a context recording who makes the changes done with it
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor
+func ActorFromContext(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(actorKey{}).(string)
+	return actor, ok && actor != ""
+}
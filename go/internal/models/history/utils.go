@@ -0,0 +1,91 @@
+package history
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	historypb "github.com/mcdev12/lumo/go/internal/genproto/history/v1"
+	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/lumo"
+)
+
+// DomainToProto converts domain Entry to protobuf HistoryEntry
+func DomainToProto(domainEntry *Entry) (*historypb.HistoryEntry, error) {
+	proto := &historypb.HistoryEntry{
+		Id:         domainEntry.ID,
+		LumoId:     domainEntry.LumoID,
+		EntityType: DomainEntityTypeToProto(domainEntry.EntityType),
+		EntityId:   domainEntry.EntityID,
+		Operation:  DomainOperationToProto(domainEntry.Operation),
+		Actor:      domainEntry.Actor,
+		CreatedAt:  timestamppb.New(domainEntry.CreatedAt),
+	}
+
+	// Decode the entity snapshot
+	switch domainEntry.EntityType {
+	case EntityTypeLumo:
+		var domainLumo lumo.Lumo
+		if err := json.Unmarshal(domainEntry.Snapshot, &domainLumo); err != nil {
+			return nil, err
+		}
+		proto.Snapshot = &historypb.HistoryEntry_Lumo{Lumo: lumo.DomainToProto(&domainLumo)}
+	case EntityTypeLume:
+		var domainLume lume.Lume
+		if err := json.Unmarshal(domainEntry.Snapshot, &domainLume); err != nil {
+			return nil, err
+		}
+		proto.Snapshot = &historypb.HistoryEntry_Lume{Lume: lume.DomainToProto(&domainLume)}
+	case EntityTypeLink:
+		var domainLink link.Link
+		if err := json.Unmarshal(domainEntry.Snapshot, &domainLink); err != nil {
+			return nil, err
+		}
+		proto.Snapshot = &historypb.HistoryEntry_Link{Link: link.DomainToProto(&domainLink)}
+	}
+
+	return proto, nil
+}
+
+// Domain EntityType to Proto EntityType conversion
+func DomainEntityTypeToProto(dt EntityType) historypb.EntityType {
+	switch dt {
+	case EntityTypeLumo:
+		return historypb.EntityType_ENTITY_TYPE_LUMO
+	case EntityTypeLume:
+		return historypb.EntityType_ENTITY_TYPE_LUME
+	case EntityTypeLink:
+		return historypb.EntityType_ENTITY_TYPE_LINK
+	default:
+		return historypb.EntityType_ENTITY_TYPE_UNSPECIFIED
+	}
+}
+
+// Proto EntityType to Domain EntityType conversion
+func ProtoEntityTypeToDomain(pt historypb.EntityType) EntityType {
+	switch pt {
+	case historypb.EntityType_ENTITY_TYPE_LUMO:
+		return EntityTypeLumo
+	case historypb.EntityType_ENTITY_TYPE_LUME:
+		return EntityTypeLume
+	case historypb.EntityType_ENTITY_TYPE_LINK:
+		return EntityTypeLink
+	default:
+		return EntityTypeUnspecified
+	}
+}
+
+// Domain Operation to Proto Operation conversion
+func DomainOperationToProto(op Operation) historypb.Operation {
+	switch op {
+	case OperationCreated:
+		return historypb.Operation_OPERATION_CREATED
+	case OperationUpdated:
+		return historypb.Operation_OPERATION_UPDATED
+	case OperationDeleted:
+		return historypb.Operation_OPERATION_DELETED
+	default:
+		return historypb.Operation_OPERATION_UNSPECIFIED
+	}
+}
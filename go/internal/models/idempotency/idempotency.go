@@ -0,0 +1,43 @@
+package idempotency
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrKeyNotFound is returned when a key was never used or has been purged
+var ErrKeyNotFound = errors.New("idempotency key not found")
+
+// Key represents an idempotency key chosen by a client and the call it was
+// first used for
+type Key struct {
+	// Internal database ID (not exposed in API)
+	ID int64 `json:"-"`
+
+	// The calling user the key belongs to, empty when authentication is disabled
+	Scope string `json:"scope"`
+
+	// The key chosen by the client
+	Key string `json:"key"`
+
+	// SHA-256 of the procedure and request, a retry must send the same request
+	RequestHash []byte `json:"request_hash"`
+
+	// The serialized response, nil while the first call is still running
+	Response []byte `json:"response,omitempty"`
+
+	// System timestamps
+	CreatedAt time.Time `json:"created_at"`
+
+	// How long the first call may run. A retry takes over a key still
+	// without a response after this, as its call must have crashed.
+	LockedUntil time.Time `json:"locked_until"`
+
+	// After this the key is free to be used again
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Completed reports whether the first call finished and its response was stored
+func (k *Key) Completed() bool {
+	return k.Response != nil
+}
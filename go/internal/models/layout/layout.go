@@ -0,0 +1,100 @@
+package layout
+
+import "time"
+
+// Algorithm represents a strategy for arranging the nodes of a canvas
+type Algorithm string
+
+const (
+	AlgorithmUnspecified Algorithm = "LAYOUT_ALGORITHM_UNSPECIFIED"
+	AlgorithmLayered     Algorithm = "LAYOUT_ALGORITHM_LAYERED"
+	AlgorithmGeographic  Algorithm = "LAYOUT_ALGORITHM_GEOGRAPHIC"
+	AlgorithmTimeline    Algorithm = "LAYOUT_ALGORITHM_TIMELINE"
+)
+
+// NodeLayout represents the canvas placement of a single Lume
+type NodeLayout struct {
+	// Internal database ID (not exposed in API)
+	ID int64 `json:"-"`
+
+	// The Lume this placement belongs to
+	LumeID string `json:"lume_id"`
+
+	// Lumo reference (parent container)
+	LumoID string `json:"lumo_id"`
+
+	// Canvas position of the node
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+
+	// Optional node size, nil lets the client size the node itself
+	Width  *float64 `json:"width,omitempty"`
+	Height *float64 `json:"height,omitempty"`
+
+	// Whether the node is rendered collapsed
+	Collapsed bool `json:"collapsed"`
+
+	// System timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Viewport represents the pan/zoom state of a Lumo's canvas
+type Viewport struct {
+	// Internal database ID (not exposed in API)
+	ID int64 `json:"-"`
+
+	// Lumo reference (parent container)
+	LumoID string `json:"lumo_id"`
+
+	// Canvas translation and zoom factor
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	Zoom float64 `json:"zoom"`
+
+	// System timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Layout represents the full canvas state of a Lumo
+type Layout struct {
+	LumoID string `json:"lumo_id"`
+
+	Nodes []*NodeLayout `json:"nodes"`
+
+	// Nil when the canvas has never been panned or zoomed
+	Viewport *Viewport `json:"viewport,omitempty"`
+}
+
+// NewNodeLayout creates a NodeLayout at the canvas origin
+func NewNodeLayout(lumoID, lumeID string) *NodeLayout {
+	now := time.Now()
+	return &NodeLayout{
+		LumeID:    lumeID,
+		LumoID:    lumoID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// NewViewport creates a Viewport with no translation at zoom 1
+func NewViewport(lumoID string) *Viewport {
+	now := time.Now()
+	return &Viewport{
+		LumoID:    lumoID,
+		Zoom:      1,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// NodeByLumeID returns the placement of the given Lume, or nil if it has none
+func (l *Layout) NodeByLumeID(lumeID string) *NodeLayout {
+	for _, node := range l.Nodes {
+		if node.LumeID == lumeID {
+			return node
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package layout
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	layoutpb "github.com/mcdev12/lumo/go/internal/genproto/layout/v1"
+)
+
+// DomainToProto converts domain Layout to protobuf Layout
+func DomainToProto(domainLayout *Layout) *layoutpb.Layout {
+	proto := &layoutpb.Layout{
+		LumoId: domainLayout.LumoID,
+		Nodes:  NodesToProto(domainLayout.Nodes),
+	}
+
+	if domainLayout.Viewport != nil {
+		proto.Viewport = ViewportToProto(domainLayout.Viewport)
+	}
+
+	return proto
+}
+
+// NodeToProto converts domain NodeLayout to protobuf NodeLayout
+func NodeToProto(domainNode *NodeLayout) *layoutpb.NodeLayout {
+	return &layoutpb.NodeLayout{
+		LumeId:    domainNode.LumeID,
+		X:         domainNode.X,
+		Y:         domainNode.Y,
+		Width:     domainNode.Width,
+		Height:    domainNode.Height,
+		Collapsed: domainNode.Collapsed,
+		UpdatedAt: timestamppb.New(domainNode.UpdatedAt),
+	}
+}
+
+// NodesToProto converts a list of domain NodeLayouts to protobuf NodeLayouts
+func NodesToProto(domainNodes []*NodeLayout) []*layoutpb.NodeLayout {
+	nodes := make([]*layoutpb.NodeLayout, len(domainNodes))
+	for i, domainNode := range domainNodes {
+		nodes[i] = NodeToProto(domainNode)
+	}
+	return nodes
+}
+
+// ViewportToProto converts domain Viewport to protobuf Viewport
+func ViewportToProto(domainViewport *Viewport) *layoutpb.Viewport {
+	return &layoutpb.Viewport{
+		X:         domainViewport.X,
+		Y:         domainViewport.Y,
+		Zoom:      domainViewport.Zoom,
+		UpdatedAt: timestamppb.New(domainViewport.UpdatedAt),
+	}
+}
+
+// Proto LayoutAlgorithm to Domain Algorithm conversion
+func ProtoAlgorithmToDomain(pa layoutpb.LayoutAlgorithm) Algorithm {
+	switch pa {
+	case layoutpb.LayoutAlgorithm_LAYOUT_ALGORITHM_LAYERED:
+		return AlgorithmLayered
+	case layoutpb.LayoutAlgorithm_LAYOUT_ALGORITHM_GEOGRAPHIC:
+		return AlgorithmGeographic
+	case layoutpb.LayoutAlgorithm_LAYOUT_ALGORITHM_TIMELINE:
+		return AlgorithmTimeline
+	default:
+		return AlgorithmUnspecified
+	}
+}
@@ -35,6 +35,7 @@ type TravelDetails struct {
 	DurationSec    int32      `json:"duration_sec"`
 	CostEstimate   float64    `json:"cost_estimate"`
 	DistanceMeters float64    `json:"distance_meters"`
+	Currency       string     `json:"currency,omitempty"` // ISO 4217 code of CostEstimate
 }
 
 // Link represents a connection between two Lumés in the domain
@@ -64,6 +65,12 @@ type Link struct {
 	// System timestamps
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Incremented on every update, used to detect concurrent edits
+	Version int64 `json:"version"`
+
+	// Set while the Link is in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // NewLink creates a new Link with generated UUID
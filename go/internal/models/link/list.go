@@ -0,0 +1,19 @@
+package link
+
+import (
+	"context"
+
+	"github.com/mcdev12/lumo/go/internal/models/paging"
+)
+
+// Lister lists the Links between the Lumes of a Lumo a page at a time
+type Lister interface {
+	ListLinksByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*Link, error)
+}
+
+// ListAll pages through every Link between the Lumes of a Lumo
+func ListAll(ctx context.Context, lister Lister, lumoID string) ([]*Link, error) {
+	return paging.All(ctx, func(ctx context.Context, limit, offset int32) ([]*Link, error) {
+		return lister.ListLinksByLumoID(ctx, lumoID, limit, offset)
+	})
+}
@@ -15,6 +15,7 @@ func DomainToProto(domainLink *Link) *linkpb.Link {
 		Type:       DomainLinkTypeToProto(domainLink.Type),
 		CreatedAt:  timestamppb.New(domainLink.CreatedAt),
 		UpdatedAt:  timestamppb.New(domainLink.UpdatedAt),
+		Version:    domainLink.Version,
 	}
 
 	// Handle optional notes
@@ -34,9 +35,15 @@ func DomainToProto(domainLink *Link) *linkpb.Link {
 			DurationSec:    domainLink.Travel.DurationSec,
 			CostEstimate:   domainLink.Travel.CostEstimate,
 			DistanceMeters: domainLink.Travel.DistanceMeters,
+			Currency:       domainLink.Travel.Currency,
 		}
 	}
 
+	// Handle trash timestamp
+	if domainLink.DeletedAt != nil {
+		proto.DeletedAt = timestamppb.New(*domainLink.DeletedAt)
+	}
+
 	return proto
 }
 
@@ -49,6 +56,7 @@ func ProtoToDomain(protoLink *linkpb.Link) *Link {
 		Type:       ProtoLinkTypeToDomain(protoLink.Type),
 		CreatedAt:  protoLink.CreatedAt.AsTime(),
 		UpdatedAt:  protoLink.UpdatedAt.AsTime(),
+		Version:    protoLink.Version,
 	}
 
 	// Handle optional notes
@@ -68,9 +76,16 @@ func ProtoToDomain(protoLink *linkpb.Link) *Link {
 			DurationSec:    protoLink.Travel.DurationSec,
 			CostEstimate:   protoLink.Travel.CostEstimate,
 			DistanceMeters: protoLink.Travel.DistanceMeters,
+			Currency:       protoLink.Travel.Currency,
 		}
 	}
 
+	// Handle trash timestamp
+	if protoLink.DeletedAt != nil {
+		deletedAt := protoLink.DeletedAt.AsTime()
+		domain.DeletedAt = &deletedAt
+	}
+
 	return domain
 }
 
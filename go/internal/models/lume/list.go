@@ -0,0 +1,19 @@
+package lume
+
+import (
+	"context"
+
+	"github.com/mcdev12/lumo/go/internal/models/paging"
+)
+
+// Lister lists the Lumes of a Lumo a page at a time
+type Lister interface {
+	ListLumesByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*Lume, error)
+}
+
+// ListAll pages through every Lume of a Lumo
+func ListAll(ctx context.Context, lister Lister, lumoID string) ([]*Lume, error) {
+	return paging.All(ctx, func(ctx context.Context, limit, offset int32) ([]*Lume, error) {
+		return lister.ListLumesByLumoID(ctx, lumoID, limit, offset)
+	})
+}
@@ -1,11 +1,15 @@
 package lume
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrTooManyLumes is returned when creating a Lume in a Lumo that is full
+var ErrTooManyLumes = errors.New("the lumo has reached its maximum number of lumes")
+
 // LumeType represents the type of travel node
 type LumeType string
 
@@ -45,6 +49,9 @@ type Lume struct {
 	// Optional end date/time
 	DateEnd *time.Time `json:"date_end,omitempty"`
 
+	// IANA time zone the dates are local to (e.g. "Europe/Paris")
+	TimeZone string `json:"time_zone"`
+
 	// Optional GPS coordinates
 	Latitude  *float64 `json:"latitude,omitempty"`
 	Longitude *float64 `json:"longitude,omitempty"`
@@ -67,6 +74,12 @@ type Lume struct {
 	// System timestamps
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Incremented on every update, used to detect concurrent edits
+	Version int64 `json:"version"`
+
+	// Set while the Lume is in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // NewLume creates a new Lume with generated UUID
@@ -1,9 +1,61 @@
 package lume
 
 import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	lumepb "github.com/mcdev12/lumo/go/internal/genproto/lume/v1"
 )
 
+// DomainToProto converts domain Lume to protobuf Lume
+func DomainToProto(domainLume *Lume) *lumepb.Lume {
+	proto := &lumepb.Lume{
+		LumeId:       domainLume.LumeID,
+		LumoId:       domainLume.LumoID,
+		Type:         DomainLumeTypeToProto(domainLume.Type),
+		Name:         domainLume.Name,
+		TimeZone:     domainLume.TimeZone,
+		Description:  domainLume.Description,
+		Images:       domainLume.Images,
+		CategoryTags: domainLume.CategoryTags,
+		CreatedAt:    timestamppb.New(domainLume.CreatedAt),
+		UpdatedAt:    timestamppb.New(domainLume.UpdatedAt),
+		Version:      domainLume.Version,
+	}
+
+	// Handle optional timestamps
+	if domainLume.DateStart != nil {
+		proto.DateStart = timestamppb.New(*domainLume.DateStart)
+	}
+	if domainLume.DateEnd != nil {
+		proto.DateEnd = timestamppb.New(*domainLume.DateEnd)
+	}
+
+	// Handle optional coordinates
+	if domainLume.Latitude != nil {
+		proto.Latitude = *domainLume.Latitude
+	}
+	if domainLume.Longitude != nil {
+		proto.Longitude = *domainLume.Longitude
+	}
+
+	// Handle optional address
+	if domainLume.Address != nil {
+		proto.Address = *domainLume.Address
+	}
+
+	// Handle optional booking link
+	if domainLume.BookingLink != nil {
+		proto.BookingLink = *domainLume.BookingLink
+	}
+
+	// Handle trash timestamp
+	if domainLume.DeletedAt != nil {
+		proto.DeletedAt = timestamppb.New(*domainLume.DeletedAt)
+	}
+
+	return proto
+}
+
 // ProtoToDomain converts protobuf Lume to domain Lume
 func ProtoToDomain(protoLume *lumepb.Lume) *Lume {
 	domain := &Lume{
@@ -11,11 +63,13 @@ func ProtoToDomain(protoLume *lumepb.Lume) *Lume {
 		LumoID:       protoLume.LumoId,
 		Type:         ProtoLumeTypeToDomain(protoLume.Type),
 		Name:         protoLume.Name,
+		TimeZone:     protoLume.TimeZone,
 		Description:  protoLume.Description,
 		Images:       protoLume.Images,
 		CategoryTags: protoLume.CategoryTags,
 		CreatedAt:    protoLume.CreatedAt.AsTime(),
 		UpdatedAt:    protoLume.UpdatedAt.AsTime(),
+		Version:      protoLume.Version,
 	}
 
 	// Handle optional timestamps
@@ -46,9 +100,41 @@ func ProtoToDomain(protoLume *lumepb.Lume) *Lume {
 		domain.BookingLink = &protoLume.BookingLink
 	}
 
+	// Handle trash timestamp
+	if protoLume.DeletedAt != nil {
+		deletedAt := protoLume.DeletedAt.AsTime()
+		domain.DeletedAt = &deletedAt
+	}
+
 	return domain
 }
 
+// Domain LumeType to Proto LumeType conversion
+func DomainLumeTypeToProto(dt LumeType) lumepb.LumeType {
+	switch dt {
+	case LumeTypeCity:
+		return lumepb.LumeType_LUME_TYPE_CITY
+	case LumeTypeAttraction:
+		return lumepb.LumeType_LUME_TYPE_ATTRACTION
+	case LumeTypeAccommodation:
+		return lumepb.LumeType_LUME_TYPE_ACCOMMODATION
+	case LumeTypeRestaurant:
+		return lumepb.LumeType_LUME_TYPE_RESTAURANT
+	case LumeTypeTransportHub:
+		return lumepb.LumeType_LUME_TYPE_TRANSPORT_HUB
+	case LumeTypeActivity:
+		return lumepb.LumeType_LUME_TYPE_ACTIVITY
+	case LumeTypeShopping:
+		return lumepb.LumeType_LUME_TYPE_SHOPPING
+	case LumeTypeEntertainment:
+		return lumepb.LumeType_LUME_TYPE_ENTERTAINMENT
+	case LumeTypeCustom:
+		return lumepb.LumeType_LUME_TYPE_CUSTOM
+	default:
+		return lumepb.LumeType_LUME_TYPE_UNSPECIFIED
+	}
+}
+
 // Proto LumeType to Domain LumeType conversion
 func ProtoLumeTypeToDomain(pt lumepb.LumeType) LumeType {
 	switch pt {
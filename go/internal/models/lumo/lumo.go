@@ -1,11 +1,16 @@
 package lumo
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrTooManyLumos is returned when a user who owns as many Lumos as they may
+// creates another one
+var ErrTooManyLumos = errors.New("the user has reached their maximum number of lumos")
+
 // Lumo represents a container for travel planning in the domain
 type Lumo struct {
 	// Internal database ID (not exposed in API)
@@ -23,6 +28,12 @@ type Lumo struct {
 	// System timestamps
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Incremented on every update, used to detect concurrent edits
+	Version int64 `json:"version"`
+
+	// Set while the Lumo is in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // NewLumo creates a new Lumo with generated UUID
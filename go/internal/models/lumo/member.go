@@ -0,0 +1,47 @@
+package lumo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/models/access"
+)
+
+var (
+	// ErrMemberNotFound is returned when a user isn't a member of the Lumo
+	ErrMemberNotFound = errors.New("member not found")
+
+	// ErrAlreadyMember is returned when sharing a Lumo with one of its members
+	ErrAlreadyMember = errors.New("user is already a member of the lumo")
+)
+
+// Member represents a user a Lumo is shared with
+type Member struct {
+	// Internal database ID (not exposed in API)
+	ID int64 `json:"-"`
+
+	// The shared Lumo (UUID)
+	LumoID string `json:"lumo_id"`
+
+	// The member (UUID)
+	UserID string `json:"user_id"`
+
+	// What the member may do in the Lumo
+	Role access.Role `json:"role"`
+
+	// System timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewMember creates a new Member
+func NewMember(lumoID, userID string, role access.Role) *Member {
+	now := time.Now()
+	return &Member{
+		LumoID:    lumoID,
+		UserID:    userID,
+		Role:      role,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
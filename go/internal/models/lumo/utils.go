@@ -6,6 +6,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	lumopb "github.com/mcdev12/lumo/go/internal/genproto/lumo/v1"
+	"github.com/mcdev12/lumo/go/internal/models/access"
 )
 
 // DomainToProto converts domain Lumo to protobuf Lumo
@@ -16,6 +17,12 @@ func DomainToProto(domainLumo *Lumo) *lumopb.Lumo {
 		Title:     domainLumo.Title,
 		CreatedAt: timestamppb.New(domainLumo.CreatedAt),
 		UpdatedAt: timestamppb.New(domainLumo.UpdatedAt),
+		Version:   domainLumo.Version,
+	}
+
+	// Handle trash timestamp
+	if domainLumo.DeletedAt != nil {
+		proto.DeletedAt = timestamppb.New(*domainLumo.DeletedAt)
 	}
 
 	return proto
@@ -29,11 +36,61 @@ func ProtoToDomain(protoLumo *lumopb.Lumo) *Lumo {
 		Title:     protoLumo.Title,
 		CreatedAt: protoLumo.CreatedAt.AsTime(),
 		UpdatedAt: protoLumo.UpdatedAt.AsTime(),
+		Version:   protoLumo.Version,
+	}
+
+	// Handle trash timestamp
+	if protoLumo.DeletedAt != nil {
+		deletedAt := protoLumo.DeletedAt.AsTime()
+		domain.DeletedAt = &deletedAt
 	}
 
 	return domain
 }
 
+// MemberToProto converts domain Member to protobuf LumoMember
+func MemberToProto(domainMember *Member) *lumopb.LumoMember {
+	return &lumopb.LumoMember{
+		LumoId:    domainMember.LumoID,
+		UserId:    domainMember.UserID,
+		Role:      DomainRoleToProto(domainMember.Role),
+		CreatedAt: timestamppb.New(domainMember.CreatedAt),
+		UpdatedAt: timestamppb.New(domainMember.UpdatedAt),
+	}
+}
+
+// Domain Role to Proto MemberRole conversion
+func DomainRoleToProto(role access.Role) lumopb.MemberRole {
+	switch role {
+	case access.RoleOwner:
+		return lumopb.MemberRole_MEMBER_ROLE_OWNER
+	case access.RoleEditor:
+		return lumopb.MemberRole_MEMBER_ROLE_EDITOR
+	case access.RoleCommenter:
+		return lumopb.MemberRole_MEMBER_ROLE_COMMENTER
+	case access.RoleViewer:
+		return lumopb.MemberRole_MEMBER_ROLE_VIEWER
+	default:
+		return lumopb.MemberRole_MEMBER_ROLE_UNSPECIFIED
+	}
+}
+
+// Proto MemberRole to Domain Role conversion
+func ProtoRoleToDomain(role lumopb.MemberRole) access.Role {
+	switch role {
+	case lumopb.MemberRole_MEMBER_ROLE_OWNER:
+		return access.RoleOwner
+	case lumopb.MemberRole_MEMBER_ROLE_EDITOR:
+		return access.RoleEditor
+	case lumopb.MemberRole_MEMBER_ROLE_COMMENTER:
+		return access.RoleCommenter
+	case lumopb.MemberRole_MEMBER_ROLE_VIEWER:
+		return access.RoleViewer
+	default:
+		return access.RoleUnspecified
+	}
+}
+
 // ValidateTimestamps ensures that timestamps are valid
 func ValidateTimestamps(created, updated time.Time) bool {
 	// Ensure timestamps are not zero values
@@ -0,0 +1,88 @@
+package outbox
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/history"
+)
+
+// Status represents how far the delivery of an event got
+type Status string
+
+const (
+	StatusPending   Status = "PENDING"
+	StatusDelivered Status = "DELIVERED"
+	StatusFailed    Status = "FAILED"
+)
+
+// Event represents a domain event such as LumeCreated, LinkUpdated or
+// LumoDeleted. Its JSON encoding is what sinks publish.
+type Event struct {
+	// Internal database ID, also the order events are published in
+	ID int64 `json:"-"`
+
+	// Public UUID, the same on every delivery of the event
+	EventID string `json:"id"`
+
+	// What happened, see TypeOf
+	Type string `json:"type"`
+
+	// Lumo the changed entity belongs to and the entity's UUID
+	LumoID   string `json:"lumo_id"`
+	EntityID string `json:"entity_id"`
+
+	// Who made the change, empty if unknown
+	Actor string `json:"actor,omitempty"`
+
+	// JSON state of the entity after the change, or right before it was deleted
+	Payload json.RawMessage `json:"data"`
+
+	// When the change happened
+	CreatedAt time.Time `json:"occurred_at"`
+
+	// Delivery tracking
+	Status      Status     `json:"-"`
+	Attempts    int32      `json:"-"`
+	LastError   string     `json:"-"`
+	DeliveredAt *time.Time `json:"-"`
+}
+
+// NewEvent creates the domain event announcing a change recorded in history
+func NewEvent(entry *history.Entry) *Event {
+	return &Event{
+		EventID:   uuid.New().String(),
+		Type:      TypeOf(entry.EntityType, entry.Operation),
+		LumoID:    entry.LumoID,
+		EntityID:  entry.EntityID,
+		Actor:     entry.Actor,
+		Payload:   entry.Snapshot,
+		CreatedAt: entry.CreatedAt,
+		Status:    StatusPending,
+	}
+}
+
+// TypeOf names the event of an operation on an entity, e.g. LumeCreated
+func TypeOf(entityType history.EntityType, operation history.Operation) string {
+	return titleCase(string(entityType)) + titleCase(string(operation))
+}
+
+// EntityType returns the kind of entity the event is about
+func (e *Event) EntityType() history.EntityType {
+	for _, entityType := range []history.EntityType{history.EntityTypeLumo, history.EntityTypeLume, history.EntityTypeLink} {
+		if strings.HasPrefix(e.Type, titleCase(string(entityType))) {
+			return entityType
+		}
+	}
+	return history.EntityTypeUnspecified
+}
+
+// titleCase turns an upper case constant like LUME into Lume
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return s[:1] + strings.ToLower(s[1:])
+}
@@ -0,0 +1,22 @@
+package paging
+
+import "context"
+
+// PageSize is the page size used to read whole lists, e.g. a Lumo's graph
+const PageSize = 500
+
+// All reads every item of a list by calling list for one page after the other,
+// until a page comes back short
+func All[T any](ctx context.Context, list func(ctx context.Context, limit, offset int32) ([]T, error)) ([]T, error) {
+	var all []T
+	for offset := int32(0); ; offset += PageSize {
+		page, err := list(ctx, PageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < PageSize {
+			return all, nil
+		}
+	}
+}
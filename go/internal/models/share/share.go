@@ -0,0 +1,110 @@
+package share
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/lumo"
+)
+
+// ErrShareLinkNotFound is returned for unknown tokens as well as for links
+// that were revoked or have expired, so a token reveals nothing about why it
+// stopped working
+var ErrShareLinkNotFound = errors.New("share link not found")
+
+// tokenBytes is how much randomness goes into a share token
+const tokenBytes = 32
+
+// Policy lists what a share link leaves out of the Lumo it shows
+type Policy struct {
+	// Clears the booking link of every Lume
+	HideBookingLinks bool `json:"hide_booking_links"`
+
+	// Clears the cost estimate of every Link
+	HideCosts bool `json:"hide_costs"`
+
+	// Clears Lume descriptions and Link notes
+	HideNotes bool `json:"hide_notes"`
+}
+
+// ShareLink represents a read-only link to a Lumo
+type ShareLink struct {
+	// Internal database ID (not exposed in API)
+	ID int64 `json:"-"`
+
+	// Unique identifier (UUID)
+	ShareLinkID string `json:"share_link_id"`
+
+	// The shared Lumo (UUID)
+	LumoID string `json:"lumo_id"`
+
+	// The secret part of the link. Only known right after creation, the
+	// database keeps its hash.
+	Token     string `json:"-"`
+	TokenHash string `json:"-"`
+
+	// What the link leaves out
+	Policy Policy `json:"policy"`
+
+	// The link stops working at ExpiresAt, if set, or once it's revoked
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+
+	// Usage tracking
+	AccessCount    int64      `json:"access_count"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+
+	// User who created the link, empty if unknown
+	CreatedBy string `json:"created_by,omitempty"`
+
+	// System timestamps
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SharedLumo is a Lumo with its Lumes and Links as seen through a share link
+type SharedLumo struct {
+	Lumo  *lumo.Lumo
+	Lumes []*lume.Lume
+	Links []*link.Link
+}
+
+// NewShareLink creates a new ShareLink with a generated UUID and token
+func NewShareLink(lumoID, createdBy string, policy Policy, expiresAt *time.Time) (*ShareLink, error) {
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShareLink{
+		ShareLinkID: uuid.New().String(),
+		LumoID:      lumoID,
+		Token:       token,
+		TokenHash:   HashToken(token),
+		Policy:      policy,
+		ExpiresAt:   expiresAt,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// HashToken returns the hash a token is stored and looked up by
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateToken returns a random URL-safe token
+func generateToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
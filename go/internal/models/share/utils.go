@@ -0,0 +1,51 @@
+package share
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	sharepb "github.com/mcdev12/lumo/go/internal/genproto/share/v1"
+)
+
+// DomainToProto converts domain ShareLink to protobuf ShareLink
+func DomainToProto(domainLink *ShareLink) *sharepb.ShareLink {
+	proto := &sharepb.ShareLink{
+		ShareLinkId: domainLink.ShareLinkID,
+		LumoId:      domainLink.LumoID,
+		Policy:      DomainPolicyToProto(domainLink.Policy),
+		AccessCount: domainLink.AccessCount,
+		CreatedBy:   domainLink.CreatedBy,
+		CreatedAt:   timestamppb.New(domainLink.CreatedAt),
+	}
+
+	// Handle optional timestamps
+	if domainLink.ExpiresAt != nil {
+		proto.ExpiresAt = timestamppb.New(*domainLink.ExpiresAt)
+	}
+	if domainLink.RevokedAt != nil {
+		proto.RevokedAt = timestamppb.New(*domainLink.RevokedAt)
+	}
+	if domainLink.LastAccessedAt != nil {
+		proto.LastAccessedAt = timestamppb.New(*domainLink.LastAccessedAt)
+	}
+
+	return proto
+}
+
+// DomainPolicyToProto converts domain Policy to protobuf RedactionPolicy
+func DomainPolicyToProto(policy Policy) *sharepb.RedactionPolicy {
+	return &sharepb.RedactionPolicy{
+		HideBookingLinks: policy.HideBookingLinks,
+		HideCosts:        policy.HideCosts,
+		HideNotes:        policy.HideNotes,
+	}
+}
+
+// ProtoPolicyToDomain converts protobuf RedactionPolicy to domain Policy. A
+// missing policy hides nothing.
+func ProtoPolicyToDomain(policy *sharepb.RedactionPolicy) Policy {
+	return Policy{
+		HideBookingLinks: policy.GetHideBookingLinks(),
+		HideCosts:        policy.GetHideCosts(),
+		HideNotes:        policy.GetHideNotes(),
+	}
+}
@@ -0,0 +1,21 @@
+package stats
+
+// Domain summarizes what is stored, leaving out everything in the trash
+type Domain struct {
+	// Number of Lumos, Lumes and Links
+	Lumos int64
+	Lumes int64
+	Links int64
+
+	// How many Lumes the Lumos have
+	LumesPerLumo Distribution
+}
+
+// Distribution summarizes a set of counts
+type Distribution struct {
+	Mean float64
+	P50  float64
+	P90  float64
+	P99  float64
+	Max  float64
+}
@@ -0,0 +1,85 @@
+package trash
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/lumo"
+)
+
+var (
+	// ErrNotInTrash is returned when restoring an entity that isn't in the trash
+	ErrNotInTrash = errors.New("entity is not in the trash")
+
+	// ErrParentInTrash is returned when restoring a Lume whose Lumo, or a Link
+	// one of whose Lumes, is still in the trash
+	ErrParentInTrash = errors.New("entity can't be restored while its parent is in the trash")
+
+	// ErrConflict is returned when restoring a Link whose connection has been
+	// made again since it was deleted
+	ErrConflict = errors.New("entity conflicts with one created since it was deleted")
+)
+
+// EntityType represents the kind of entity in the trash
+type EntityType string
+
+const (
+	EntityTypeUnspecified EntityType = "ENTITY_TYPE_UNSPECIFIED"
+	EntityTypeLumo        EntityType = "LUMO"
+	EntityTypeLume        EntityType = "LUME"
+	EntityTypeLink        EntityType = "LINK"
+)
+
+// Item represents a deleted Lumo, Lume or Link. Exactly one of Lumo, Lume and
+// Link is set, matching EntityType.
+type Item struct {
+	EntityType EntityType
+	EntityID   string
+
+	// When the entity was moved to the trash, zero once it's restored
+	DeletedAt time.Time
+
+	Lumo *lumo.Lumo
+	Lume *lume.Lume
+	Link *link.Link
+}
+
+// LumoItem wraps a Lumo as a trash Item
+func LumoItem(domainLumo *lumo.Lumo) *Item {
+	return &Item{
+		EntityType: EntityTypeLumo,
+		EntityID:   domainLumo.LumoID,
+		DeletedAt:  deletedAt(domainLumo.DeletedAt),
+		Lumo:       domainLumo,
+	}
+}
+
+// LumeItem wraps a Lume as a trash Item
+func LumeItem(domainLume *lume.Lume) *Item {
+	return &Item{
+		EntityType: EntityTypeLume,
+		EntityID:   domainLume.LumeID,
+		DeletedAt:  deletedAt(domainLume.DeletedAt),
+		Lume:       domainLume,
+	}
+}
+
+// LinkItem wraps a Link as a trash Item
+func LinkItem(domainLink *link.Link) *Item {
+	return &Item{
+		EntityType: EntityTypeLink,
+		EntityID:   domainLink.LinkID,
+		DeletedAt:  deletedAt(domainLink.DeletedAt),
+		Link:       domainLink,
+	}
+}
+
+// deletedAt dereferences an optional deletion time
+func deletedAt(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
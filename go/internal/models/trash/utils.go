@@ -0,0 +1,62 @@
+package trash
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	trashpb "github.com/mcdev12/lumo/go/internal/genproto/trash/v1"
+	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/lumo"
+)
+
+// DomainToProto converts domain Item to protobuf TrashItem
+func DomainToProto(domainItem *Item) *trashpb.TrashItem {
+	proto := &trashpb.TrashItem{
+		EntityType: DomainEntityTypeToProto(domainItem.EntityType),
+		EntityId:   domainItem.EntityID,
+	}
+
+	// Handle optional deletion time
+	if !domainItem.DeletedAt.IsZero() {
+		proto.DeletedAt = timestamppb.New(domainItem.DeletedAt)
+	}
+
+	switch {
+	case domainItem.Lumo != nil:
+		proto.Entity = &trashpb.TrashItem_Lumo{Lumo: lumo.DomainToProto(domainItem.Lumo)}
+	case domainItem.Lume != nil:
+		proto.Entity = &trashpb.TrashItem_Lume{Lume: lume.DomainToProto(domainItem.Lume)}
+	case domainItem.Link != nil:
+		proto.Entity = &trashpb.TrashItem_Link{Link: link.DomainToProto(domainItem.Link)}
+	}
+
+	return proto
+}
+
+// Domain EntityType to Proto EntityType conversion
+func DomainEntityTypeToProto(dt EntityType) trashpb.EntityType {
+	switch dt {
+	case EntityTypeLumo:
+		return trashpb.EntityType_ENTITY_TYPE_LUMO
+	case EntityTypeLume:
+		return trashpb.EntityType_ENTITY_TYPE_LUME
+	case EntityTypeLink:
+		return trashpb.EntityType_ENTITY_TYPE_LINK
+	default:
+		return trashpb.EntityType_ENTITY_TYPE_UNSPECIFIED
+	}
+}
+
+// Proto EntityType to Domain EntityType conversion
+func ProtoEntityTypeToDomain(pt trashpb.EntityType) EntityType {
+	switch pt {
+	case trashpb.EntityType_ENTITY_TYPE_LUMO:
+		return EntityTypeLumo
+	case trashpb.EntityType_ENTITY_TYPE_LUME:
+		return EntityTypeLume
+	case trashpb.EntityType_ENTITY_TYPE_LINK:
+		return EntityTypeLink
+	default:
+		return EntityTypeUnspecified
+	}
+}
@@ -0,0 +1,160 @@
+package user
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	// Time zones are validated against the embedded database so they work
+	// the same in containers without one
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+)
+
+// Domain errors
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrUserExists   = errors.New("user already exists")
+	ErrEmailTaken   = errors.New("email is already in use")
+)
+
+// DistanceUnit is the unit distances are shown in
+type DistanceUnit string
+
+const (
+	DistanceUnitUnspecified DistanceUnit = "DISTANCE_UNIT_UNSPECIFIED"
+	DistanceUnitKilometers  DistanceUnit = "KILOMETERS"
+	DistanceUnitMiles       DistanceUnit = "MILES"
+)
+
+// IsValid reports whether the unit is a known unit
+func (u DistanceUnit) IsValid() bool {
+	return u == DistanceUnitKilometers || u == DistanceUnitMiles
+}
+
+// Default preferences
+const (
+	DefaultHomeCurrency = "USD"
+	DefaultDistanceUnit = DistanceUnitKilometers
+	DefaultTimeZone     = "UTC"
+	DefaultLocale       = "en-US"
+)
+
+var (
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	localePattern   = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+)
+
+// Preferences is how a user likes to see their trips
+type Preferences struct {
+	// ISO 4217 code, e.g. "EUR"
+	HomeCurrency string `json:"home_currency"`
+
+	DistanceUnit DistanceUnit `json:"distance_unit"`
+
+	// IANA time zone, e.g. "Europe/Paris"
+	TimeZone string `json:"time_zone"`
+
+	// BCP 47 language tag, e.g. "fr-FR"
+	Locale string `json:"locale"`
+}
+
+// DefaultPreferences returns the preferences of users who haven't set any
+func DefaultPreferences() Preferences {
+	return Preferences{
+		HomeCurrency: DefaultHomeCurrency,
+		DistanceUnit: DefaultDistanceUnit,
+		TimeZone:     DefaultTimeZone,
+		Locale:       DefaultLocale,
+	}
+}
+
+// WithDefaults returns the preferences with blank fields set to their defaults
+func (p Preferences) WithDefaults() Preferences {
+	defaults := DefaultPreferences()
+	if p.HomeCurrency == "" {
+		p.HomeCurrency = defaults.HomeCurrency
+	}
+	if p.DistanceUnit == "" || p.DistanceUnit == DistanceUnitUnspecified {
+		p.DistanceUnit = defaults.DistanceUnit
+	}
+	if p.TimeZone == "" {
+		p.TimeZone = defaults.TimeZone
+	}
+	if p.Locale == "" {
+		p.Locale = defaults.Locale
+	}
+	return p
+}
+
+// User represents a person using Lumo
+type User struct {
+	// Internal database ID (not exposed in API)
+	ID int64 `json:"-"`
+
+	// Unique identifier (UUID), the subject of the user's bearer tokens
+	UserID string `json:"user_id"`
+
+	// Lowercased, empty for users that predate accounts
+	Email string `json:"email,omitempty"`
+
+	DisplayName string `json:"display_name"`
+
+	Preferences Preferences `json:"preferences"`
+
+	// System timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewUser creates a new User, generating a UUID if userID is empty
+func NewUser(userID, email, displayName string, preferences Preferences) *User {
+	if userID == "" {
+		userID = uuid.New().String()
+	}
+
+	now := time.Now()
+	return &User{
+		UserID:      userID,
+		Email:       NormalizeEmail(email),
+		DisplayName: displayName,
+		Preferences: preferences.WithDefaults(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Public returns what anyone signed in may see of the user: who they are,
+// without their email address or preferences
+func (u *User) Public() *User {
+	return &User{
+		UserID:      u.UserID,
+		DisplayName: u.DisplayName,
+	}
+}
+
+// NormalizeEmail returns the form emails are stored and looked up in
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// IsValidCurrency reports whether code looks like an ISO 4217 currency code
+func IsValidCurrency(code string) bool {
+	return currencyPattern.MatchString(code)
+}
+
+// IsValidTimeZone reports whether name is a known IANA time zone
+func IsValidTimeZone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// IsValidLocale reports whether tag looks like a BCP 47 language tag
+func IsValidLocale(tag string) bool {
+	return localePattern.MatchString(tag)
+}
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	userpb "github.com/mcdev12/lumo/go/internal/genproto/user/v1"
+)
+
+// DomainToProto converts domain User to protobuf User. The Public view of a
+// user leaves out their preferences and timestamps.
+func DomainToProto(domainUser *User) *userpb.User {
+	proto := &userpb.User{
+		UserId:      domainUser.UserID,
+		Email:       domainUser.Email,
+		DisplayName: domainUser.DisplayName,
+	}
+	if domainUser.Preferences != (Preferences{}) {
+		proto.Preferences = DomainPreferencesToProto(domainUser.Preferences)
+	}
+	if !domainUser.CreatedAt.IsZero() {
+		proto.CreatedAt = timestamppb.New(domainUser.CreatedAt)
+		proto.UpdatedAt = timestamppb.New(domainUser.UpdatedAt)
+	}
+	return proto
+}
+
+// DomainPreferencesToProto converts domain Preferences to protobuf Preferences
+func DomainPreferencesToProto(preferences Preferences) *userpb.Preferences {
+	return &userpb.Preferences{
+		HomeCurrency: preferences.HomeCurrency,
+		DistanceUnit: DomainDistanceUnitToProto(preferences.DistanceUnit),
+		TimeZone:     preferences.TimeZone,
+		Locale:       preferences.Locale,
+	}
+}
+
+// ProtoPreferencesToDomain converts protobuf Preferences to domain Preferences.
+// A nil message gives blank preferences.
+func ProtoPreferencesToDomain(preferences *userpb.Preferences) Preferences {
+	if preferences == nil {
+		return Preferences{}
+	}
+	return Preferences{
+		HomeCurrency: preferences.GetHomeCurrency(),
+		DistanceUnit: ProtoDistanceUnitToDomain(preferences.GetDistanceUnit()),
+		TimeZone:     preferences.GetTimeZone(),
+		Locale:       preferences.GetLocale(),
+	}
+}
+
+// Domain DistanceUnit to Proto DistanceUnit conversion
+func DomainDistanceUnitToProto(unit DistanceUnit) userpb.DistanceUnit {
+	switch unit {
+	case DistanceUnitKilometers:
+		return userpb.DistanceUnit_DISTANCE_UNIT_KILOMETERS
+	case DistanceUnitMiles:
+		return userpb.DistanceUnit_DISTANCE_UNIT_MILES
+	default:
+		return userpb.DistanceUnit_DISTANCE_UNIT_UNSPECIFIED
+	}
+}
+
+// Proto DistanceUnit to Domain DistanceUnit conversion
+func ProtoDistanceUnitToDomain(unit userpb.DistanceUnit) DistanceUnit {
+	switch unit {
+	case userpb.DistanceUnit_DISTANCE_UNIT_KILOMETERS:
+		return DistanceUnitKilometers
+	case userpb.DistanceUnit_DISTANCE_UNIT_MILES:
+		return DistanceUnitMiles
+	default:
+		return DistanceUnitUnspecified
+	}
+}
@@ -0,0 +1,57 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MetadataKey is the error metadata key carrying the current version of an
+// entity on a conflict
+const MetadataKey = "Current-Version"
+
+// ErrConflict is returned when an entity was changed by someone else since
+// the caller last read it
+var ErrConflict = errors.New("version conflict")
+
+// ConflictError carries the version the caller expected and the version the
+// entity is currently at, so clients can re-read and retry
+type ConflictError struct {
+	Expected int64
+	Current  int64
+}
+
+// NewConflictError creates a new ConflictError
+func NewConflictError(expected, current int64) *ConflictError {
+	return &ConflictError{
+		Expected: expected,
+		Current:  current,
+	}
+}
+
+// Error implements the error interface
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: expected version %d, current version %d", ErrConflict, e.Expected, e.Current)
+}
+
+// Unwrap lets errors.Is match ErrConflict
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// Current returns the current version carried by a conflict error
+func Current(err error) (int64, bool) {
+	var conflict *ConflictError
+	if errors.As(err, &conflict) {
+		return conflict.Current, true
+	}
+	return 0, false
+}
+
+// Check returns a *ConflictError if the caller expected a version other than
+// the current one. A nil expected version always passes.
+func Check(expected *int64, current int64) error {
+	if expected != nil && *expected != current {
+		return NewConflictError(*expected, current)
+	}
+	return nil
+}
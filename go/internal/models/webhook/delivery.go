@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/outbox"
+)
+
+// Status represents how far a delivery got
+type Status string
+
+const (
+	StatusPending   Status = "PENDING"
+	StatusDelivered Status = "DELIVERED"
+	StatusFailed    Status = "FAILED"
+)
+
+// Delivery represents one event sent, or to be sent, to a webhook
+type Delivery struct {
+	// Internal database ID (not exposed in API)
+	ID int64 `json:"-"`
+
+	// Unique identifier (UUID)
+	DeliveryID string `json:"delivery_id"`
+
+	WebhookID string `json:"webhook_id"`
+
+	// The outbox event being delivered
+	EventID   string `json:"event_id"`
+	EventType string `json:"event_type"`
+
+	// The JSON body that is POSTed
+	Payload []byte `json:"-"`
+
+	// The delivery this one was manually requested to repeat
+	RedeliveryOf string `json:"redelivery_of,omitempty"`
+
+	// Delivery tracking
+	Status        Status     `json:"status"`
+	Attempts      int32      `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	ResponseCode  int32      `json:"response_code,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+
+	// System timestamp
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewDelivery creates a pending delivery of an event to a webhook
+func NewDelivery(webhookID string, event *outbox.Event, payload []byte) *Delivery {
+	now := time.Now()
+	return &Delivery{
+		DeliveryID:    uuid.New().String(),
+		WebhookID:     webhookID,
+		EventID:       event.EventID,
+		EventType:     event.Type,
+		Payload:       payload,
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+// Redeliver creates a pending delivery repeating this one
+func (d *Delivery) Redeliver() *Delivery {
+	now := time.Now()
+	return &Delivery{
+		DeliveryID:    uuid.New().String(),
+		WebhookID:     d.WebhookID,
+		EventID:       d.EventID,
+		EventType:     d.EventType,
+		Payload:       d.Payload,
+		RedeliveryOf:  d.DeliveryID,
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
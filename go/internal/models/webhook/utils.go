@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	webhookpb "github.com/mcdev12/lumo/go/internal/genproto/webhook/v1"
+	"github.com/mcdev12/lumo/go/internal/models/history"
+	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+)
+
+// DomainToProto converts domain Webhook to protobuf Webhook
+func DomainToProto(domainWebhook *Webhook) *webhookpb.Webhook {
+	proto := &webhookpb.Webhook{
+		WebhookId:           domainWebhook.WebhookID,
+		UserId:              domainWebhook.UserID,
+		LumoId:              domainWebhook.LumoID,
+		Url:                 domainWebhook.URL,
+		Description:         domainWebhook.Description,
+		Filter:              DomainFilterToProto(domainWebhook.Filter),
+		ConsecutiveFailures: domainWebhook.ConsecutiveFailures,
+		CreatedAt:           timestamppb.New(domainWebhook.CreatedAt),
+		UpdatedAt:           timestamppb.New(domainWebhook.UpdatedAt),
+	}
+
+	// Handle optional timestamps
+	if domainWebhook.DisabledAt != nil {
+		proto.DisabledAt = timestamppb.New(*domainWebhook.DisabledAt)
+	}
+
+	return proto
+}
+
+// DomainFilterToProto converts domain Filter to protobuf WebhookFilter
+func DomainFilterToProto(filter Filter) *webhookpb.WebhookFilter {
+	proto := &webhookpb.WebhookFilter{}
+	for _, entityType := range filter.EntityTypes {
+		proto.EntityTypes = append(proto.EntityTypes, history.DomainEntityTypeToProto(entityType))
+	}
+	for _, lumeType := range filter.LumeTypes {
+		proto.LumeTypes = append(proto.LumeTypes, lume.DomainLumeTypeToProto(lumeType))
+	}
+	for _, linkType := range filter.LinkTypes {
+		proto.LinkTypes = append(proto.LinkTypes, link.DomainLinkTypeToProto(linkType))
+	}
+	return proto
+}
+
+// ProtoFilterToDomain converts protobuf WebhookFilter to domain Filter
+func ProtoFilterToDomain(proto *webhookpb.WebhookFilter) Filter {
+	var filter Filter
+	for _, entityType := range proto.GetEntityTypes() {
+		filter.EntityTypes = append(filter.EntityTypes, history.ProtoEntityTypeToDomain(entityType))
+	}
+	for _, lumeType := range proto.GetLumeTypes() {
+		filter.LumeTypes = append(filter.LumeTypes, lume.ProtoLumeTypeToDomain(lumeType))
+	}
+	for _, linkType := range proto.GetLinkTypes() {
+		filter.LinkTypes = append(filter.LinkTypes, link.ProtoLinkTypeToDomain(linkType))
+	}
+	return filter
+}
+
+// DeliveryToProto converts domain Delivery to protobuf WebhookDelivery
+func DeliveryToProto(delivery *Delivery) *webhookpb.WebhookDelivery {
+	proto := &webhookpb.WebhookDelivery{
+		DeliveryId:   delivery.DeliveryID,
+		WebhookId:    delivery.WebhookID,
+		EventId:      delivery.EventID,
+		EventType:    delivery.EventType,
+		RedeliveryOf: delivery.RedeliveryOf,
+		Status:       DomainStatusToProto(delivery.Status),
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode,
+		LastError:    delivery.LastError,
+		CreatedAt:    timestamppb.New(delivery.CreatedAt),
+	}
+
+	// Only pending deliveries have an attempt coming up
+	if delivery.Status == StatusPending {
+		proto.NextAttemptAt = timestamppb.New(delivery.NextAttemptAt)
+	}
+	if delivery.DeliveredAt != nil {
+		proto.DeliveredAt = timestamppb.New(*delivery.DeliveredAt)
+	}
+
+	return proto
+}
+
+// DomainStatusToProto converts domain Status to protobuf DeliveryStatus
+func DomainStatusToProto(status Status) webhookpb.DeliveryStatus {
+	switch status {
+	case StatusPending:
+		return webhookpb.DeliveryStatus_DELIVERY_STATUS_PENDING
+	case StatusDelivered:
+		return webhookpb.DeliveryStatus_DELIVERY_STATUS_DELIVERED
+	case StatusFailed:
+		return webhookpb.DeliveryStatus_DELIVERY_STATUS_FAILED
+	default:
+		return webhookpb.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
+	}
+}
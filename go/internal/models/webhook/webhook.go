@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/history"
+	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/outbox"
+)
+
+// Domain errors
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrDeliveryExists   = errors.New("event already queued for webhook")
+)
+
+const (
+	// SecretPrefix starts every signing secret
+	SecretPrefix = "whsec_"
+
+	// secretBytes is how much randomness goes into a secret
+	secretBytes = 32
+)
+
+// Filter narrows the events sent to a webhook. Every list that isn't empty
+// must contain the event's value. LumeTypes only applies to Lume events and
+// LinkTypes only to Link events.
+type Filter struct {
+	EntityTypes []history.EntityType `json:"entity_types,omitempty"`
+	LumeTypes   []lume.LumeType      `json:"lume_types,omitempty"`
+	LinkTypes   []link.LinkType      `json:"link_types,omitempty"`
+}
+
+// IsValid reports whether every value of the filter is specified
+func (f Filter) IsValid() bool {
+	return !slices.Contains(f.EntityTypes, history.EntityTypeUnspecified) &&
+		!slices.Contains(f.LumeTypes, lume.LumeTypeUnspecified) &&
+		!slices.Contains(f.LinkTypes, link.LinkTypeUnspecified)
+}
+
+// Matches reports whether an event passes the filter
+func (f Filter) Matches(event *outbox.Event) bool {
+	entityType := event.EntityType()
+	if len(f.EntityTypes) > 0 && !slices.Contains(f.EntityTypes, entityType) {
+		return false
+	}
+
+	switch {
+	case entityType == history.EntityTypeLume && len(f.LumeTypes) > 0:
+		var entity struct {
+			Type lume.LumeType `json:"type"`
+		}
+		if err := json.Unmarshal(event.Payload, &entity); err != nil {
+			return false
+		}
+		return slices.Contains(f.LumeTypes, entity.Type)
+	case entityType == history.EntityTypeLink && len(f.LinkTypes) > 0:
+		var entity struct {
+			Type link.LinkType `json:"type"`
+		}
+		if err := json.Unmarshal(event.Payload, &entity); err != nil {
+			return false
+		}
+		return slices.Contains(f.LinkTypes, entity.Type)
+	default:
+		return true
+	}
+}
+
+// Webhook represents an endpoint the domain events of a Lumo, or of every
+// Lumo of a user, are POSTed to
+type Webhook struct {
+	// Internal database ID (not exposed in API)
+	ID int64 `json:"-"`
+
+	// Unique identifier (UUID)
+	WebhookID string `json:"webhook_id"`
+
+	// The user who registered the endpoint
+	UserID string `json:"user_id"`
+
+	// The only Lumo whose events are sent, empty for all of the user's Lumos
+	LumoID string `json:"lumo_id,omitempty"`
+
+	// Where events are POSTed to
+	URL string `json:"url"`
+
+	// Free-form label
+	Description string `json:"description"`
+
+	// Key the payloads are signed with
+	Secret string `json:"-"`
+
+	// Which events are sent
+	Filter Filter `json:"filter"`
+
+	// Failed attempts since the last successful one
+	ConsecutiveFailures int32 `json:"consecutive_failures"`
+
+	// Set once the endpoint kept failing, nothing is sent until it's enabled again
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+
+	// System timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewWebhook creates a new Webhook with a generated UUID and signing secret
+func NewWebhook(userID, lumoID, url, description string, filter Filter) (*Webhook, error) {
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Webhook{
+		WebhookID:   uuid.New().String(),
+		UserID:      userID,
+		LumoID:      lumoID,
+		URL:         url,
+		Description: description,
+		Secret:      secret,
+		Filter:      filter,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Enabled reports whether events are sent to the webhook
+func (w *Webhook) Enabled() bool {
+	return w.DisabledAt == nil
+}
+
+// Enable has events sent to the webhook again, with a clean slate
+func (w *Webhook) Enable() {
+	w.DisabledAt = nil
+	w.ConsecutiveFailures = 0
+}
+
+// Disable stops events from being sent to the webhook
+func (w *Webhook) Disable() {
+	if w.DisabledAt == nil {
+		now := time.Now()
+		w.DisabledAt = &now
+	}
+}
+
+// IsValidURL reports whether events can be POSTed to a URL: an absolute
+// http or https URL with a host
+func IsValidURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// generateSecret returns a random URL-safe secret starting with SecretPrefix
+func generateSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return SecretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
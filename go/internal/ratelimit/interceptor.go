@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/mcdev12/lumo/go/internal/auth"
+)
+
+// RetryAfterKey is the metadata key, sent as a header or trailer, telling a
+// limited caller how many seconds to wait before trying again
+const RetryAfterKey = "Retry-After"
+
+// Class is a kind of procedure callers have a separate limit for
+type Class string
+
+const (
+	// ClassRead is procedures without side effects
+	ClassRead Class = "read"
+	// ClassWrite is every other procedure
+	ClassWrite Class = "write"
+	// ClassImport is the procedures that create many entities at once
+	ClassImport Class = "import"
+)
+
+// Config configures the limit of each Class
+type Config struct {
+	Read   Limit
+	Write  Limit
+	Import Limit
+
+	// ImportProcedures are the procedures of ClassImport, such as
+	// "/history.v1.HistoryService/RestoreLumo"
+	ImportProcedures []string
+}
+
+// Interceptor limits how fast each caller may call each Class of procedures.
+// Callers are told apart by API key, by user and, when authentication is
+// disabled or a procedure is public, by network address. Calls over the
+// limit fail with ResourceExhausted and a Retry-After.
+type Interceptor struct {
+	limiters map[Class]*Limiter
+	imports  map[string]bool
+}
+
+// NewInterceptor creates a new rate limiting Interceptor
+func NewInterceptor(cfg Config) *Interceptor {
+	imports := make(map[string]bool, len(cfg.ImportProcedures))
+	for _, procedure := range cfg.ImportProcedures {
+		imports[procedure] = true
+	}
+
+	return &Interceptor{
+		limiters: map[Class]*Limiter{
+			ClassRead:   NewLimiter(cfg.Read),
+			ClassWrite:  NewLimiter(cfg.Write),
+			ClassImport: NewLimiter(cfg.Import),
+		},
+		imports: imports,
+	}
+}
+
+// WrapUnary limits unary requests
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		if err := i.limit(ctx, req.Spec(), req.Peer()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient leaves outgoing streams alone
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler limits opening streams
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.limit(ctx, conn.Spec(), conn.Peer()); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// limit takes a token from the caller's bucket for the procedure's Class
+func (i *Interceptor) limit(ctx context.Context, spec connect.Spec, peer connect.Peer) error {
+	class := i.classify(spec)
+	allowed, wait := i.limiters[class].Allow(callerKey(ctx, peer))
+	if allowed {
+		return nil
+	}
+
+	err := connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("too many %s requests, retry in %s", class, wait.Round(time.Millisecond)))
+	err.Meta().Set(RetryAfterKey, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	return err
+}
+
+// classify returns the Class of a procedure
+func (i *Interceptor) classify(spec connect.Spec) Class {
+	switch {
+	case i.imports[spec.Procedure]:
+		return ClassImport
+	case spec.IdempotencyLevel == connect.IdempotencyNoSideEffects:
+		return ClassRead
+	default:
+		return ClassWrite
+	}
+}
+
+// callerKey names the bucket of the caller: their API key, their user or,
+// without an Identity, their address
+func callerKey(ctx context.Context, peer connect.Peer) string {
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		if identity.APIKeyID != "" {
+			return "api_key:" + identity.APIKeyID
+		}
+		return "user:" + identity.UserID
+	}
+
+	host, _, err := net.SplitHostPort(peer.Addr)
+	if err != nil {
+		host = peer.Addr
+	}
+	return "addr:" + host
+}
@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/mcdev12/lumo/go/internal/auth"
+)
+
+const (
+	readProcedure   = "/test.v1.TestService/Read"
+	writeProcedure  = "/test.v1.TestService/Write"
+	importProcedure = "/test.v1.TestService/Import"
+)
+
+// InterceptorTestSuite is a test suite for the Interceptor
+type InterceptorTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+}
+
+// SetupTest is called before each test
+func (s *InterceptorTestSuite) SetupTest() {
+	interceptor := NewInterceptor(Config{
+		Read:             Limit{PerMinute: 60, Burst: 2},
+		Write:            Limit{PerMinute: 60, Burst: 1},
+		Import:           Limit{PerMinute: 1, Burst: 1},
+		ImportProcedures: []string{importProcedure},
+	})
+
+	// identify stands in for the auth interceptor, taking the caller from
+	// the test headers
+	identify := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if userID := req.Header().Get("Test-User"); userID != "" {
+				ctx = auth.WithIdentity(ctx, &auth.Identity{UserID: userID, APIKeyID: req.Header().Get("Test-Api-Key")})
+			}
+			return next(ctx, req)
+		}
+	})
+
+	handle := func(_ context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+	interceptors := connect.WithInterceptors(identify, interceptor)
+	mux := http.NewServeMux()
+	mux.Handle(readProcedure, connect.NewUnaryHandler(readProcedure, handle, interceptors,
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects)))
+	mux.Handle(writeProcedure, connect.NewUnaryHandler(writeProcedure, handle, interceptors))
+	mux.Handle(importProcedure, connect.NewUnaryHandler(importProcedure, handle, interceptors))
+	s.server = httptest.NewServer(mux)
+}
+
+// TearDownTest is called after each test
+func (s *InterceptorTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// TestInterceptorSuite runs the test suite
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorTestSuite))
+}
+
+// call makes a request as the user and API key, if set
+func (s *InterceptorTestSuite) call(procedure, userID, apiKeyID string) error {
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](s.server.Client(), s.server.URL+procedure)
+	req := connect.NewRequest(&emptypb.Empty{})
+	if userID != "" {
+		req.Header().Set("Test-User", userID)
+		req.Header().Set("Test-Api-Key", apiKeyID)
+	}
+	_, err := client.CallUnary(context.Background(), req)
+	return err
+}
+
+// Test calls over the limit fail with ResourceExhausted and a Retry-After
+func (s *InterceptorTestSuite) TestLimited() {
+	// Act
+	first := s.call(writeProcedure, "user-a", "")
+	second := s.call(writeProcedure, "user-a", "")
+
+	// Assert
+	s.NoError(first)
+	s.Equal(connect.CodeResourceExhausted, connect.CodeOf(second))
+	var connectErr *connect.Error
+	s.Require().ErrorAs(second, &connectErr)
+	s.Equal("1", connectErr.Meta().Get(RetryAfterKey))
+	s.Contains(connectErr.Message(), "too many write requests")
+}
+
+// Test every class of procedures has its own limit
+func (s *InterceptorTestSuite) TestClasses() {
+	// Arrange
+	s.Require().NoError(s.call(writeProcedure, "user-a", ""))
+
+	// Act & Assert
+	s.NoError(s.call(readProcedure, "user-a", ""))
+	s.NoError(s.call(readProcedure, "user-a", ""))
+	s.Equal(connect.CodeResourceExhausted, connect.CodeOf(s.call(readProcedure, "user-a", "")))
+
+	s.NoError(s.call(importProcedure, "user-a", ""))
+	s.Equal(connect.CodeResourceExhausted, connect.CodeOf(s.call(importProcedure, "user-a", "")))
+}
+
+// Test users, their API keys and anonymous callers are limited separately
+func (s *InterceptorTestSuite) TestCallers() {
+	// Arrange
+	s.Require().NoError(s.call(writeProcedure, "user-a", ""))
+
+	// Act & Assert
+	s.NoError(s.call(writeProcedure, "user-b", ""))
+	s.NoError(s.call(writeProcedure, "user-a", "key-1"))
+	s.NoError(s.call(writeProcedure, "user-a", "key-2"))
+	s.Equal(connect.CodeResourceExhausted, connect.CodeOf(s.call(writeProcedure, "user-a", "key-1")))
+
+	s.NoError(s.call(writeProcedure, "", ""))
+	s.Equal(connect.CodeResourceExhausted, connect.CodeOf(s.call(writeProcedure, "", "")))
+}
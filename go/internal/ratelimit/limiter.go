@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often buckets that have filled up again are dropped,
+// which keeps callers that went away from piling up
+const sweepInterval = time.Minute
+
+// Limit is a token bucket: PerMinute requests a minute on average, in bursts
+// of up to Burst. A PerMinute of 0 means no limit.
+type Limit struct {
+	PerMinute int
+	Burst     int
+}
+
+// bucket holds the tokens a key has left as of last
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter keeps a token bucket with the same Limit for every key
+type Limiter struct {
+	limit Limit
+	// rate is the tokens added per second
+	rate float64
+	now  func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewLimiter creates a Limiter for limit
+func NewLimiter(limit Limit) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		rate:    float64(limit.PerMinute) / 60,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from the bucket of key. If there is none left, it
+// returns false and how long until there will be.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l.limit.PerMinute <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration(math.Ceil((1 - b.tokens) / l.rate * float64(time.Second)))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// refill returns the tokens of b at now. Callers must hold mu.
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	tokens := b.tokens + now.Sub(b.last).Seconds()*l.rate
+	return math.Min(tokens, float64(l.limit.Burst))
+}
+
+// sweep drops the buckets that are full again, as a new bucket would be the
+// same. Callers must hold mu.
+func (l *Limiter) sweep(now time.Time) {
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= float64(l.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// LimiterTestSuite is a test suite for the Limiter
+type LimiterTestSuite struct {
+	suite.Suite
+	now     time.Time
+	limiter *Limiter
+}
+
+// SetupTest is called before each test
+func (s *LimiterTestSuite) SetupTest() {
+	s.now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.limiter = NewLimiter(Limit{PerMinute: 60, Burst: 3})
+	s.limiter.now = func() time.Time { return s.now }
+}
+
+// TestLimiterSuite runs the test suite
+func TestLimiterSuite(t *testing.T) {
+	suite.Run(t, new(LimiterTestSuite))
+}
+
+// Test a burst is allowed, then a token per second
+func (s *LimiterTestSuite) TestAllow() {
+	// Act & Assert
+	for range 3 {
+		allowed, _ := s.limiter.Allow("user:a")
+		s.True(allowed)
+	}
+
+	allowed, wait := s.limiter.Allow("user:a")
+	s.False(allowed)
+	s.Equal(time.Second, wait)
+
+	s.now = s.now.Add(400 * time.Millisecond)
+	allowed, wait = s.limiter.Allow("user:a")
+	s.False(allowed)
+	s.Equal(600*time.Millisecond, wait)
+
+	s.now = s.now.Add(600 * time.Millisecond)
+	allowed, _ = s.limiter.Allow("user:a")
+	s.True(allowed)
+}
+
+// Test every key has its own bucket
+func (s *LimiterTestSuite) TestKeys() {
+	// Arrange
+	for range 3 {
+		s.limiter.Allow("user:a")
+	}
+
+	// Act
+	allowedA, _ := s.limiter.Allow("user:a")
+	allowedB, _ := s.limiter.Allow("user:b")
+
+	// Assert
+	s.False(allowedA)
+	s.True(allowedB)
+}
+
+// Test buckets never hold more than a burst
+func (s *LimiterTestSuite) TestBurstCap() {
+	// Arrange
+	s.limiter.Allow("user:a")
+	s.now = s.now.Add(time.Hour)
+
+	// Act
+	allowed := 0
+	for range 10 {
+		if ok, _ := s.limiter.Allow("user:a"); ok {
+			allowed++
+		}
+	}
+
+	// Assert
+	s.Equal(3, allowed)
+}
+
+// Test buckets that filled up again are dropped
+func (s *LimiterTestSuite) TestSweep() {
+	// Arrange
+	s.limiter.Allow("user:a")
+	s.limiter.Allow("user:b")
+	s.now = s.now.Add(sweepInterval)
+
+	// Act
+	s.limiter.Allow("user:c")
+
+	// Assert
+	s.Len(s.limiter.buckets, 1)
+	s.Contains(s.limiter.buckets, "user:c")
+}
+
+// Test a PerMinute of 0 allows everything
+func (s *LimiterTestSuite) TestUnlimited() {
+	// Arrange
+	limiter := NewLimiter(Limit{})
+
+	// Act & Assert
+	for range 100 {
+		allowed, _ := limiter.Allow("user:a")
+		s.True(allowed)
+	}
+	s.Empty(limiter.buckets)
+}
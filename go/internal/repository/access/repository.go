@@ -0,0 +1,123 @@
+package access
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/access"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+//go:generate mockery
+type AccessQuerier interface {
+	GetLumoOwner(ctx context.Context, lumoID uuid.UUID) (sqlc.GetLumoOwnerRow, error)
+	GetLumoOwnerByID(ctx context.Context, id int64) (sqlc.GetLumoOwnerByIDRow, error)
+	GetLumeOwner(ctx context.Context, lumeID uuid.UUID) (sqlc.GetLumeOwnerRow, error)
+	GetLumeOwnerByID(ctx context.Context, id int64) (sqlc.GetLumeOwnerByIDRow, error)
+	GetLinkOwner(ctx context.Context, linkID uuid.UUID) (sqlc.GetLinkOwnerRow, error)
+	GetLinkOwnerByID(ctx context.Context, id int64) (sqlc.GetLinkOwnerByIDRow, error)
+	GetLumoMemberRole(ctx context.Context, arg sqlc.GetLumoMemberRoleParams) (string, error)
+}
+
+// Repository is the concrete implementation for ownership lookups
+type Repository struct {
+	queries AccessQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(conn sqlc.DBTX) *Repository {
+	return &Repository{
+		queries: sqlc.New(conn),
+	}
+}
+
+// GetLumoOwner returns the owner of a Lumo by its UUID
+func (r *Repository) GetLumoOwner(ctx context.Context, lumoID string) (*access.Owner, error) {
+	parsedUUID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.GetLumoOwner(ctx, parsedUUID)
+	return toOwner(result.LumoID, result.UserID, err)
+}
+
+// GetLumoOwnerByID returns the owner of a Lumo by its internal ID
+func (r *Repository) GetLumoOwnerByID(ctx context.Context, id int64) (*access.Owner, error) {
+	result, err := r.queries.GetLumoOwnerByID(ctx, id)
+	return toOwner(result.LumoID, result.UserID, err)
+}
+
+// GetLumeOwner returns the Lumo and owner of a Lume by its UUID
+func (r *Repository) GetLumeOwner(ctx context.Context, lumeID string) (*access.Owner, error) {
+	parsedUUID, err := uuid.Parse(lumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.GetLumeOwner(ctx, parsedUUID)
+	return toOwner(result.LumoID, result.UserID, err)
+}
+
+// GetLumeOwnerByID returns the Lumo and owner of a Lume by its internal ID
+func (r *Repository) GetLumeOwnerByID(ctx context.Context, id int64) (*access.Owner, error) {
+	result, err := r.queries.GetLumeOwnerByID(ctx, id)
+	return toOwner(result.LumoID, result.UserID, err)
+}
+
+// GetLinkOwner returns the Lumo and owner of a Link by its UUID
+func (r *Repository) GetLinkOwner(ctx context.Context, linkID string) (*access.Owner, error) {
+	parsedUUID, err := uuid.Parse(linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.GetLinkOwner(ctx, parsedUUID)
+	return toOwner(result.LumoID, result.UserID, err)
+}
+
+// GetLinkOwnerByID returns the Lumo and owner of a Link by its internal ID
+func (r *Repository) GetLinkOwnerByID(ctx context.Context, id int64) (*access.Owner, error) {
+	result, err := r.queries.GetLinkOwnerByID(ctx, id)
+	return toOwner(result.LumoID, result.UserID, err)
+}
+
+// GetMemberRole returns the role a user has in a Lumo, or access.ErrNotFound
+// if the Lumo isn't shared with them
+func (r *Repository) GetMemberRole(ctx context.Context, lumoID, userID string) (access.Role, error) {
+	parsedLumoID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return access.RoleUnspecified, err
+	}
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return access.RoleUnspecified, err
+	}
+
+	role, err := r.queries.GetLumoMemberRole(ctx, sqlc.GetLumoMemberRoleParams{
+		LumoID: parsedLumoID,
+		UserID: parsedUserID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return access.RoleUnspecified, access.ErrNotFound
+	}
+	if err != nil {
+		return access.RoleUnspecified, err
+	}
+
+	return access.Role(role), nil
+}
+
+// toOwner converts the result of an ownership lookup, reporting a missing
+// entity as access.ErrNotFound
+func toOwner(lumoID, userID uuid.UUID, err error) (*access.Owner, error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, access.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return access.NewOwner(lumoID.String(), userID.String()), nil
+}
@@ -0,0 +1,225 @@
+package access
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/access"
+	"github.com/mcdev12/lumo/go/internal/repository/access/mocks"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockAccessQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockAccessQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// ownerLookup pairs a repository lookup with the querier call backing it
+type ownerLookup struct {
+	name   string
+	expect func(lumoID, userID uuid.UUID, err error)
+	lookup func(ctx context.Context) (*access.Owner, error)
+}
+
+// lookups returns every ownership lookup of the repository
+func (s *RepositoryTestSuite) lookups(ctx context.Context) []ownerLookup {
+	entityID := uuid.New()
+	var id int64 = 42
+
+	return []ownerLookup{
+		{
+			name: "GetLumoOwner",
+			expect: func(lumoID, userID uuid.UUID, err error) {
+				s.mockQuerier.On("GetLumoOwner", ctx, entityID).Return(sqlc.GetLumoOwnerRow{LumoID: lumoID, UserID: userID}, err)
+			},
+			lookup: func(ctx context.Context) (*access.Owner, error) {
+				return s.repository.GetLumoOwner(ctx, entityID.String())
+			},
+		},
+		{
+			name: "GetLumoOwnerByID",
+			expect: func(lumoID, userID uuid.UUID, err error) {
+				s.mockQuerier.On("GetLumoOwnerByID", ctx, id).Return(sqlc.GetLumoOwnerByIDRow{LumoID: lumoID, UserID: userID}, err)
+			},
+			lookup: func(ctx context.Context) (*access.Owner, error) {
+				return s.repository.GetLumoOwnerByID(ctx, id)
+			},
+		},
+		{
+			name: "GetLumeOwner",
+			expect: func(lumoID, userID uuid.UUID, err error) {
+				s.mockQuerier.On("GetLumeOwner", ctx, entityID).Return(sqlc.GetLumeOwnerRow{LumoID: lumoID, UserID: userID}, err)
+			},
+			lookup: func(ctx context.Context) (*access.Owner, error) {
+				return s.repository.GetLumeOwner(ctx, entityID.String())
+			},
+		},
+		{
+			name: "GetLumeOwnerByID",
+			expect: func(lumoID, userID uuid.UUID, err error) {
+				s.mockQuerier.On("GetLumeOwnerByID", ctx, id).Return(sqlc.GetLumeOwnerByIDRow{LumoID: lumoID, UserID: userID}, err)
+			},
+			lookup: func(ctx context.Context) (*access.Owner, error) {
+				return s.repository.GetLumeOwnerByID(ctx, id)
+			},
+		},
+		{
+			name: "GetLinkOwner",
+			expect: func(lumoID, userID uuid.UUID, err error) {
+				s.mockQuerier.On("GetLinkOwner", ctx, entityID).Return(sqlc.GetLinkOwnerRow{LumoID: lumoID, UserID: userID}, err)
+			},
+			lookup: func(ctx context.Context) (*access.Owner, error) {
+				return s.repository.GetLinkOwner(ctx, entityID.String())
+			},
+		},
+		{
+			name: "GetLinkOwnerByID",
+			expect: func(lumoID, userID uuid.UUID, err error) {
+				s.mockQuerier.On("GetLinkOwnerByID", ctx, id).Return(sqlc.GetLinkOwnerByIDRow{LumoID: lumoID, UserID: userID}, err)
+			},
+			lookup: func(ctx context.Context) (*access.Owner, error) {
+				return s.repository.GetLinkOwnerByID(ctx, id)
+			},
+		},
+	}
+}
+
+// Test every lookup returns the owning Lumo and user
+func (s *RepositoryTestSuite) TestGetOwner() {
+	ctx := context.Background()
+
+	for _, tt := range s.lookups(ctx) {
+		s.Run(tt.name, func() {
+			// Arrange
+			s.SetupTest()
+			lumoID := uuid.New()
+			userID := uuid.New()
+
+			// Set up expectations
+			tt.expect(lumoID, userID, nil)
+
+			// Act
+			owner, err := tt.lookup(ctx)
+
+			// Assert
+			s.NoError(err)
+			s.Equal(access.NewOwner(lumoID.String(), userID.String()), owner)
+			s.mockQuerier.AssertExpectations(s.T())
+		})
+	}
+}
+
+// Test every lookup reports a missing entity as access.ErrNotFound
+func (s *RepositoryTestSuite) TestGetOwnerNotFound() {
+	ctx := context.Background()
+
+	for _, tt := range s.lookups(ctx) {
+		s.Run(tt.name, func() {
+			// Arrange
+			s.SetupTest()
+
+			// Set up expectations
+			tt.expect(uuid.Nil, uuid.Nil, sql.ErrNoRows)
+
+			// Act
+			owner, err := tt.lookup(ctx)
+
+			// Assert
+			s.ErrorIs(err, access.ErrNotFound)
+			s.Nil(owner)
+		})
+	}
+}
+
+// Test every lookup passes database errors through
+func (s *RepositoryTestSuite) TestGetOwnerError() {
+	ctx := context.Background()
+	expectedErr := errors.New("database error")
+
+	for _, tt := range s.lookups(ctx) {
+		s.Run(tt.name, func() {
+			// Arrange
+			s.SetupTest()
+
+			// Set up expectations
+			tt.expect(uuid.Nil, uuid.Nil, expectedErr)
+
+			// Act
+			owner, err := tt.lookup(ctx)
+
+			// Assert
+			s.ErrorIs(err, expectedErr)
+			s.NotErrorIs(err, access.ErrNotFound)
+			s.Nil(owner)
+		})
+	}
+}
+
+// Test UUID lookups reject malformed IDs without querying
+func (s *RepositoryTestSuite) TestGetOwnerInvalidUUID() {
+	ctx := context.Background()
+
+	owner, err := s.repository.GetLumeOwner(ctx, "not-a-uuid")
+
+	s.Error(err)
+	s.Nil(owner)
+	s.mockQuerier.AssertNotCalled(s.T(), "GetLumeOwner")
+}
+
+// Test GetMemberRole returns the member's role
+func (s *RepositoryTestSuite) TestGetMemberRole() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	userID := uuid.New()
+	params := sqlc.GetLumoMemberRoleParams{LumoID: lumoID, UserID: userID}
+
+	// Set up expectations
+	s.mockQuerier.On("GetLumoMemberRole", ctx, params).Return("EDITOR", nil)
+
+	// Act
+	role, err := s.repository.GetMemberRole(ctx, lumoID.String(), userID.String())
+
+	// Assert
+	s.NoError(err)
+	s.Equal(access.RoleEditor, role)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test GetMemberRole reports non-members as access.ErrNotFound
+func (s *RepositoryTestSuite) TestGetMemberRoleNotMember() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	userID := uuid.New()
+	params := sqlc.GetLumoMemberRoleParams{LumoID: lumoID, UserID: userID}
+
+	// Set up expectations
+	s.mockQuerier.On("GetLumoMemberRole", ctx, params).Return("", sql.ErrNoRows)
+
+	// Act
+	role, err := s.repository.GetMemberRole(ctx, lumoID.String(), userID.String())
+
+	// Assert
+	s.ErrorIs(err, access.ErrNotFound)
+	s.Equal(access.RoleUnspecified, role)
+}
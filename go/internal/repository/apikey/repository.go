@@ -0,0 +1,241 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/access"
+	"github.com/mcdev12/lumo/go/internal/models/apikey"
+	"github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/mcdev12/lumo/go/internal/repository/db"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+// Foreign keys of the api_key table
+const (
+	userConstraint = "fk_api_key_user"
+	lumoConstraint = "fk_api_key_lumo"
+)
+
+//go:generate mockery
+type APIKeyQuerier interface {
+	CreateApiKey(ctx context.Context, arg sqlc.CreateApiKeyParams) (sqlc.ApiKey, error)
+	GetApiKeyByApiKeyID(ctx context.Context, apiKeyID uuid.UUID) (sqlc.ApiKey, error)
+	ListApiKeysByUserID(ctx context.Context, arg sqlc.ListApiKeysByUserIDParams) ([]sqlc.ApiKey, error)
+	RevokeApiKey(ctx context.Context, arg sqlc.RevokeApiKeyParams) (sqlc.ApiKey, error)
+	RotateApiKey(ctx context.Context, arg sqlc.RotateApiKeyParams) (sqlc.ApiKey, error)
+	UseApiKey(ctx context.Context, arg sqlc.UseApiKeyParams) (sqlc.ApiKey, error)
+}
+
+// Repository is the concrete implementation for API key data access
+type Repository struct {
+	queries APIKeyQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(conn sqlc.DBTX) *Repository {
+	return &Repository{
+		queries: sqlc.New(conn),
+	}
+}
+
+// CreateAPIKey creates a new API key record from domain model. It fails with
+// user.ErrUserNotFound if the key's user doesn't exist.
+func (r *Repository) CreateAPIKey(ctx context.Context, domainKey *apikey.APIKey) (*apikey.APIKey, error) {
+	params, err := r.domainToCreateParams(domainKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.CreateApiKey(ctx, params)
+	if db.IsForeignKeyViolationOf(err, userConstraint) {
+		return nil, user.ErrUserNotFound
+	}
+	if db.IsForeignKeyViolationOf(err, lumoConstraint) {
+		return nil, access.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	created := r.sqlcRowToDomainModel(result)
+	created.Secret = domainKey.Secret
+	return created, nil
+}
+
+// GetAPIKeyByAPIKeyID retrieves an API key by its UUID
+func (r *Repository) GetAPIKeyByAPIKeyID(ctx context.Context, apiKeyID string) (*apikey.APIKey, error) {
+	parsedUUID, err := uuid.Parse(apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.GetApiKeyByApiKeyID(ctx, parsedUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apikey.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// ListAPIKeysByUserID retrieves the API keys of a user, newest first
+func (r *Repository) ListAPIKeysByUserID(ctx context.Context, userID string, limit, offset int32) ([]*apikey.APIKey, error) {
+	parsedUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.ListApiKeysByUserIDParams{
+		UserID: parsedUUID,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	results, err := r.queries.ListApiKeysByUserID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*apikey.APIKey, len(results))
+	for i, result := range results {
+		keys[i] = r.sqlcRowToDomainModel(result)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey stops an API key from working
+func (r *Repository) RevokeAPIKey(ctx context.Context, apiKeyID string) (*apikey.APIKey, error) {
+	parsedUUID, err := uuid.Parse(apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.RevokeApiKey(ctx, sqlc.RevokeApiKeyParams{
+		RevokedAt: time.Now(),
+		ApiKeyID:  parsedUUID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apikey.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// RotateAPIKey stores the new secret of an API key. It fails with
+// apikey.ErrAPIKeyNotFound if the key doesn't exist or was revoked.
+func (r *Repository) RotateAPIKey(ctx context.Context, domainKey *apikey.APIKey) (*apikey.APIKey, error) {
+	parsedUUID, err := uuid.Parse(domainKey.APIKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.RotateApiKey(ctx, sqlc.RotateApiKeyParams{
+		Prefix:     domainKey.Prefix,
+		SecretHash: domainKey.SecretHash,
+		RotatedAt:  time.Now(),
+		ApiKeyID:   parsedUUID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apikey.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rotated := r.sqlcRowToDomainModel(result)
+	rotated.Secret = domainKey.Secret
+	return rotated, nil
+}
+
+// UseAPIKey looks up the working API key with the given secret and records
+// its use. It fails with apikey.ErrAPIKeyNotFound if there is no such key or
+// it was revoked.
+func (r *Repository) UseAPIKey(ctx context.Context, secret string) (*apikey.APIKey, error) {
+	result, err := r.queries.UseApiKey(ctx, sqlc.UseApiKeyParams{
+		UsedAt:     time.Now(),
+		SecretHash: apikey.HashSecret(secret),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apikey.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// Helper method to convert domain APIKey to SQLC CreateApiKeyParams
+func (r *Repository) domainToCreateParams(domainKey *apikey.APIKey) (sqlc.CreateApiKeyParams, error) {
+	apiKeyID, err := uuid.Parse(domainKey.APIKeyID)
+	if err != nil {
+		return sqlc.CreateApiKeyParams{}, err
+	}
+	userID, err := uuid.Parse(domainKey.UserID)
+	if err != nil {
+		return sqlc.CreateApiKeyParams{}, err
+	}
+
+	params := sqlc.CreateApiKeyParams{
+		ApiKeyID:   apiKeyID,
+		UserID:     userID,
+		Name:       domainKey.Name,
+		Prefix:     domainKey.Prefix,
+		SecretHash: domainKey.SecretHash,
+		Scope:      string(domainKey.Scope),
+		CreatedAt:  domainKey.CreatedAt,
+		UpdatedAt:  domainKey.UpdatedAt,
+	}
+
+	// Handle optional fields
+	if domainKey.LumoID != "" {
+		lumoID, err := uuid.Parse(domainKey.LumoID)
+		if err != nil {
+			return sqlc.CreateApiKeyParams{}, err
+		}
+		params.LumoID = uuid.NullUUID{UUID: lumoID, Valid: true}
+	}
+
+	return params, nil
+}
+
+// Helper method to convert SQLC results to domain model
+func (r *Repository) sqlcRowToDomainModel(row sqlc.ApiKey) *apikey.APIKey {
+	domainKey := &apikey.APIKey{
+		ID:         row.ID,
+		APIKeyID:   row.ApiKeyID.String(),
+		UserID:     row.UserID.String(),
+		Name:       row.Name,
+		Prefix:     row.Prefix,
+		SecretHash: row.SecretHash,
+		Scope:      apikey.Scope(row.Scope),
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+	}
+
+	// Handle optional fields
+	if row.LumoID.Valid {
+		domainKey.LumoID = row.LumoID.UUID.String()
+	}
+	if row.LastUsedAt.Valid {
+		domainKey.LastUsedAt = &row.LastUsedAt.Time
+	}
+	if row.RevokedAt.Valid {
+		domainKey.RevokedAt = &row.RevokedAt.Time
+	}
+	if row.RotatedAt.Valid {
+		domainKey.RotatedAt = &row.RotatedAt.Time
+	}
+
+	return domainKey
+}
@@ -0,0 +1,194 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mcdev12/lumo/go/internal/models/access"
+	"github.com/mcdev12/lumo/go/internal/models/apikey"
+	"github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/mcdev12/lumo/go/internal/repository/apikey/mocks"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockAPIKeyQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockAPIKeyQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Helper function to create a test sqlc ApiKey row
+func newAPIKeyRow() sqlc.ApiKey {
+	return sqlc.ApiKey{
+		ID:         1,
+		ApiKeyID:   uuid.New(),
+		UserID:     uuid.New(),
+		Name:       "nightly export",
+		Prefix:     "lumo_abcdefgh",
+		SecretHash: apikey.HashSecret("lumo_secret"),
+		Scope:      string(apikey.ScopeLumo),
+		LumoID:     uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// Test CreateAPIKey stores the secret hash and hands the secret back
+func (s *RepositoryTestSuite) TestCreateAPIKey() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	domainKey, err := apikey.NewAPIKey(uuid.New().String(), "nightly export", apikey.ScopeLumo, lumoID.String())
+	s.Require().NoError(err)
+
+	// Set up expectations
+	s.mockQuerier.On("CreateApiKey", ctx, mock.MatchedBy(func(params sqlc.CreateApiKeyParams) bool {
+		return params.ApiKeyID.String() == domainKey.APIKeyID &&
+			params.SecretHash == apikey.HashSecret(domainKey.Secret) &&
+			params.Prefix == domainKey.Secret[:len(params.Prefix)] &&
+			params.Scope == "LUMO" &&
+			params.LumoID == uuid.NullUUID{UUID: lumoID, Valid: true}
+	})).Return(sqlc.ApiKey{
+		ID:         1,
+		ApiKeyID:   uuid.MustParse(domainKey.APIKeyID),
+		UserID:     uuid.MustParse(domainKey.UserID),
+		Name:       domainKey.Name,
+		Prefix:     domainKey.Prefix,
+		SecretHash: domainKey.SecretHash,
+		Scope:      "LUMO",
+		LumoID:     uuid.NullUUID{UUID: lumoID, Valid: true},
+		CreatedAt:  domainKey.CreatedAt,
+		UpdatedAt:  domainKey.UpdatedAt,
+	}, nil)
+
+	// Act
+	created, err := s.repository.CreateAPIKey(ctx, domainKey)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(domainKey.Secret, created.Secret)
+	s.Equal(domainKey.APIKeyID, created.APIKeyID)
+	s.Equal(apikey.ScopeLumo, created.Scope)
+	s.Equal(lumoID.String(), created.LumoID)
+	s.Nil(created.LastUsedAt)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test CreateAPIKey tells a missing user apart from a missing Lumo
+func (s *RepositoryTestSuite) TestCreateAPIKeyMissingReference() {
+	cases := []struct {
+		constraint string
+		wantErr    error
+	}{
+		{"fk_api_key_user", user.ErrUserNotFound},
+		{"fk_api_key_lumo", access.ErrNotFound},
+	}
+
+	for _, tc := range cases {
+		s.Run(tc.constraint, func() {
+			// Arrange
+			s.SetupTest()
+			ctx := context.Background()
+			domainKey, err := apikey.NewAPIKey(uuid.New().String(), "ci", apikey.ScopeLumo, uuid.New().String())
+			s.Require().NoError(err)
+
+			// Set up expectations
+			s.mockQuerier.On("CreateApiKey", ctx, mock.Anything).
+				Return(sqlc.ApiKey{}, &pq.Error{Code: "23503", Constraint: tc.constraint})
+
+			// Act
+			created, err := s.repository.CreateAPIKey(ctx, domainKey)
+
+			// Assert
+			s.ErrorIs(err, tc.wantErr)
+			s.Nil(created)
+		})
+	}
+}
+
+// Test UseAPIKey looks the key up by the secret's hash
+func (s *RepositoryTestSuite) TestUseAPIKey() {
+	// Arrange
+	ctx := context.Background()
+	row := newAPIKeyRow()
+	row.LastUsedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	// Set up expectations
+	s.mockQuerier.On("UseApiKey", ctx, mock.MatchedBy(func(params sqlc.UseApiKeyParams) bool {
+		return params.SecretHash == apikey.HashSecret("lumo_secret") && !params.UsedAt.IsZero()
+	})).Return(row, nil)
+
+	// Act
+	key, err := s.repository.UseAPIKey(ctx, "lumo_secret")
+
+	// Assert
+	s.NoError(err)
+	s.Equal(row.ApiKeyID.String(), key.APIKeyID)
+	s.Equal(row.UserID.String(), key.UserID)
+	s.Equal(row.LumoID.UUID.String(), key.LumoID)
+	s.NotNil(key.LastUsedAt)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test UseAPIKey reports unknown and revoked keys alike
+func (s *RepositoryTestSuite) TestUseAPIKeyNotFound() {
+	// Arrange
+	ctx := context.Background()
+
+	// Set up expectations
+	s.mockQuerier.On("UseApiKey", ctx, mock.Anything).Return(sqlc.ApiKey{}, sql.ErrNoRows)
+
+	// Act
+	key, err := s.repository.UseAPIKey(ctx, "lumo_revoked")
+
+	// Assert
+	s.ErrorIs(err, apikey.ErrAPIKeyNotFound)
+	s.Nil(key)
+}
+
+// Test RotateAPIKey stores the new hash and hands the new secret back
+func (s *RepositoryTestSuite) TestRotateAPIKey() {
+	// Arrange
+	ctx := context.Background()
+	row := newAPIKeyRow()
+	domainKey := s.repository.sqlcRowToDomainModel(row)
+	s.Require().NoError(domainKey.NewSecret())
+	row.SecretHash = domainKey.SecretHash
+	row.Prefix = domainKey.Prefix
+	row.RotatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	// Set up expectations
+	s.mockQuerier.On("RotateApiKey", ctx, mock.MatchedBy(func(params sqlc.RotateApiKeyParams) bool {
+		return params.ApiKeyID == row.ApiKeyID && params.SecretHash == apikey.HashSecret(domainKey.Secret)
+	})).Return(row, nil)
+
+	// Act
+	rotated, err := s.repository.RotateAPIKey(ctx, domainKey)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(domainKey.Secret, rotated.Secret)
+	s.Equal(domainKey.Prefix, rotated.Prefix)
+	s.NotNil(rotated.RotatedAt)
+	s.mockQuerier.AssertExpectations(s.T())
+}
@@ -0,0 +1,151 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/audit"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+//go:generate mockery
+type AuditQuerier interface {
+	CreateAuditLogEntry(ctx context.Context, arg sqlc.CreateAuditLogEntryParams) (sqlc.AuditLog, error)
+	ListAuditLog(ctx context.Context, arg sqlc.ListAuditLogParams) ([]sqlc.AuditLog, error)
+	PurgeAuditLog(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Repository is the concrete implementation for audit log data access
+type Repository struct {
+	queries AuditQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(conn sqlc.DBTX) *Repository {
+	return &Repository{
+		queries: sqlc.New(conn),
+	}
+}
+
+// CreateEntry records a call in the audit log
+func (r *Repository) CreateEntry(ctx context.Context, domainEntry *audit.Entry) (*audit.Entry, error) {
+	params, err := r.domainToCreateParams(domainEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.CreateAuditLogEntry(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// ListEntries retrieves the entries matching the filter, newest first
+func (r *Repository) ListEntries(ctx context.Context, filter audit.Filter, limit, offset int32) ([]*audit.Entry, error) {
+	params := sqlc.ListAuditLogParams{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	// Handle optional filters
+	if filter.LumoID != "" {
+		lumoID, err := uuid.Parse(filter.LumoID)
+		if err != nil {
+			return nil, err
+		}
+		params.LumoID = uuid.NullUUID{UUID: lumoID, Valid: true}
+	}
+	if filter.Actor != "" {
+		params.Actor = sql.NullString{String: filter.Actor, Valid: true}
+	}
+	if filter.Since != nil {
+		params.Since = sql.NullTime{Time: *filter.Since, Valid: true}
+	}
+	if filter.Until != nil {
+		params.Until = sql.NullTime{Time: *filter.Until, Valid: true}
+	}
+
+	results, err := r.queries.ListAuditLog(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*audit.Entry, len(results))
+	for i, result := range results {
+		entries[i] = r.sqlcRowToDomainModel(result)
+	}
+
+	return entries, nil
+}
+
+// Purge permanently deletes the entries recorded before the cutoff and
+// returns how many there were
+func (r *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.PurgeAuditLog(ctx, before)
+}
+
+// Helper method to convert domain Entry to SQLC CreateAuditLogEntryParams
+func (r *Repository) domainToCreateParams(domainEntry *audit.Entry) (sqlc.CreateAuditLogEntryParams, error) {
+	params := sqlc.CreateAuditLogEntryParams{
+		Procedure:     domainEntry.Procedure,
+		TargetIds:     domainEntry.TargetIDs,
+		RequestDigest: domainEntry.RequestDigest,
+		Code:          domainEntry.Code,
+		LatencyUs:     domainEntry.Latency.Microseconds(),
+		CreatedAt:     domainEntry.CreatedAt,
+	}
+	if params.TargetIds == nil {
+		params.TargetIds = []string{}
+	}
+
+	// Handle optional fields
+	if domainEntry.Actor != "" {
+		params.Actor = sql.NullString{String: domainEntry.Actor, Valid: true}
+	}
+	if domainEntry.APIKeyID != "" {
+		apiKeyID, err := uuid.Parse(domainEntry.APIKeyID)
+		if err != nil {
+			return sqlc.CreateAuditLogEntryParams{}, err
+		}
+		params.ApiKeyID = uuid.NullUUID{UUID: apiKeyID, Valid: true}
+	}
+	if domainEntry.LumoID != "" {
+		lumoID, err := uuid.Parse(domainEntry.LumoID)
+		if err != nil {
+			return sqlc.CreateAuditLogEntryParams{}, err
+		}
+		params.LumoID = uuid.NullUUID{UUID: lumoID, Valid: true}
+	}
+
+	return params, nil
+}
+
+// Helper method to convert SQLC results to domain model
+func (r *Repository) sqlcRowToDomainModel(row sqlc.AuditLog) *audit.Entry {
+	domainEntry := &audit.Entry{
+		ID:            row.ID,
+		Procedure:     row.Procedure,
+		TargetIDs:     row.TargetIds,
+		RequestDigest: row.RequestDigest,
+		Code:          row.Code,
+		Latency:       time.Duration(row.LatencyUs) * time.Microsecond,
+		CreatedAt:     row.CreatedAt,
+	}
+
+	// Handle optional fields
+	if row.Actor.Valid {
+		domainEntry.Actor = row.Actor.String
+	}
+	if row.ApiKeyID.Valid {
+		domainEntry.APIKeyID = row.ApiKeyID.UUID.String()
+	}
+	if row.LumoID.Valid {
+		domainEntry.LumoID = row.LumoID.UUID.String()
+	}
+
+	return domainEntry
+}
@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/audit"
+	"github.com/mcdev12/lumo/go/internal/repository/audit/mocks"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockAuditQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockAuditQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Test CreateEntry stores optional fields as NULL when they are unknown
+func (s *RepositoryTestSuite) TestCreateEntry() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	domainEntry := &audit.Entry{
+		Actor:         uuid.New().String(),
+		Procedure:     "/lume.v1.LumeService/UpdateLume",
+		LumoID:        lumoID.String(),
+		RequestDigest: "digest",
+		Code:          audit.CodeOK,
+		Latency:       1500 * time.Microsecond,
+		CreatedAt:     time.Now(),
+	}
+
+	// Set up expectations
+	s.mockQuerier.On("CreateAuditLogEntry", ctx, mock.MatchedBy(func(params sqlc.CreateAuditLogEntryParams) bool {
+		return params.Actor == sql.NullString{String: domainEntry.Actor, Valid: true} &&
+			!params.ApiKeyID.Valid &&
+			params.LumoID == uuid.NullUUID{UUID: lumoID, Valid: true} &&
+			params.TargetIds != nil && len(params.TargetIds) == 0 &&
+			params.LatencyUs == 1500
+	})).Return(sqlc.AuditLog{
+		ID:            1,
+		Actor:         sql.NullString{String: domainEntry.Actor, Valid: true},
+		Procedure:     domainEntry.Procedure,
+		LumoID:        uuid.NullUUID{UUID: lumoID, Valid: true},
+		TargetIds:     []string{},
+		RequestDigest: domainEntry.RequestDigest,
+		Code:          domainEntry.Code,
+		LatencyUs:     1500,
+		CreatedAt:     domainEntry.CreatedAt,
+	}, nil)
+
+	// Act
+	created, err := s.repository.CreateEntry(ctx, domainEntry)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(domainEntry.Actor, created.Actor)
+	s.Empty(created.APIKeyID)
+	s.Equal(lumoID.String(), created.LumoID)
+	s.Equal(1500*time.Microsecond, created.Latency)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test ListEntries only sets the filters that were given
+func (s *RepositoryTestSuite) TestListEntries() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	since := time.Now().Add(-time.Hour)
+	row := sqlc.AuditLog{
+		ID:        1,
+		Procedure: "/lumo.v1.LumoService/DeleteLumo",
+		LumoID:    uuid.NullUUID{UUID: lumoID, Valid: true},
+		TargetIds: []string{lumoID.String()},
+		Code:      "permission_denied",
+		CreatedAt: time.Now(),
+	}
+
+	// Set up expectations
+	s.mockQuerier.On("ListAuditLog", ctx, sqlc.ListAuditLogParams{
+		LumoID: uuid.NullUUID{UUID: lumoID, Valid: true},
+		Since:  sql.NullTime{Time: since, Valid: true},
+		Limit:  50,
+		Offset: 0,
+	}).Return([]sqlc.AuditLog{row}, nil)
+
+	// Act
+	entries, err := s.repository.ListEntries(ctx, audit.Filter{LumoID: lumoID.String(), Since: &since}, 50, 0)
+
+	// Assert
+	s.NoError(err)
+	s.Require().Len(entries, 1)
+	s.Empty(entries[0].Actor)
+	s.Equal([]string{lumoID.String()}, entries[0].TargetIDs)
+	s.Equal("permission_denied", entries[0].Code)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test Purge passes the cutoff through
+func (s *RepositoryTestSuite) TestPurge() {
+	// Arrange
+	ctx := context.Background()
+	before := time.Now().Add(-90 * 24 * time.Hour)
+
+	// Set up expectations
+	s.mockQuerier.On("PurgeAuditLog", ctx, before).Return(int64(7), nil)
+
+	// Act
+	purged, err := s.repository.Purge(ctx, before)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(int64(7), purged)
+	s.mockQuerier.AssertExpectations(s.T())
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// Postgres error codes
+const (
+	// uniqueViolation is the code of a unique constraint violation
+	uniqueViolation = "23505"
+
+	// foreignKeyViolation is the code of a foreign key constraint violation
+	foreignKeyViolation = "23503"
+)
+
+// IsUniqueViolation reports whether err was caused by a unique constraint
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
+// IsForeignKeyViolation reports whether err was caused by a foreign key
+// constraint, such as a reference to a row that doesn't exist
+func IsForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation
+}
+
+// IsForeignKeyViolationOf reports whether err was caused by the named foreign
+// key constraint, for tables that reference more than one other table
+func IsForeignKeyViolationOf(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation && pqErr.Constraint == constraint
+}
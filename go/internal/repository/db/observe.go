@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+// QueryObserver is told about every query made on a connection wrapped by
+// WithQueryObserver
+type QueryObserver interface {
+	// ObserveQuery is called once a query has run. name is the sqlc name of
+	// the query, or "unknown".
+	ObserveQuery(ctx context.Context, name string, duration time.Duration, err error)
+}
+
+// observedConn reports the queries made on it to an observer
+type observedConn struct {
+	conn     sqlc.DBTX
+	observer QueryObserver
+}
+
+// WithQueryObserver wraps conn so observer learns the name, duration and
+// error of every query. The duration of QueryContext is the time until the
+// first rows are available, not until they have all been read. Transactions
+// begun by RunInTx are observed too.
+func WithQueryObserver(conn sqlc.DBTX, observer QueryObserver) sqlc.DBTX {
+	return &observedConn{conn: conn, observer: observer}
+}
+
+// unwrap returns the connection queries are made on
+func (c *observedConn) unwrap() sqlc.DBTX {
+	return c.conn
+}
+
+// wrap observes the queries of conn as well
+func (c *observedConn) wrap(conn sqlc.DBTX) sqlc.DBTX {
+	return &observedConn{conn: conn, observer: c.observer}
+}
+
+// ExecContext runs and observes a statement
+func (c *observedConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := c.conn.ExecContext(ctx, query, args...)
+	c.observer.ObserveQuery(ctx, QueryName(query), time.Since(start), err)
+	return result, err
+}
+
+// PrepareContext prepares a statement, which isn't observed
+func (c *observedConn) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.conn.PrepareContext(ctx, query)
+}
+
+// QueryContext runs and observes a query
+func (c *observedConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := c.conn.QueryContext(ctx, query, args...)
+	c.observer.ObserveQuery(ctx, QueryName(query), time.Since(start), err)
+	return rows, err
+}
+
+// QueryRowContext runs and observes a query returning at most one row
+func (c *observedConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := c.conn.QueryRowContext(ctx, query, args...)
+	var err error
+	if row != nil {
+		err = row.Err()
+	}
+	c.observer.ObserveQuery(ctx, QueryName(query), time.Since(start), err)
+	return row
+}
+
+// QueryName returns the name of a query generated by sqlc, which starts with
+// a "-- name: GetLume :one" line, or "unknown" for any other query
+func QueryName(query string) string {
+	// Skip a request tag or other comment in front of the name
+	if strings.HasPrefix(query, "/*") {
+		if end := strings.Index(query, "*/"); end >= 0 {
+			query = strings.TrimSpace(query[end+2:])
+		}
+	}
+	rest, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return "unknown"
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	if name == "" || strings.ContainsAny(name, "\n\r") {
+		return "unknown"
+	}
+	return name
+}
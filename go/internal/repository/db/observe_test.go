@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/logging"
+	"github.com/stretchr/testify/suite"
+)
+
+// recordingObserver remembers the names of the queries it was told about
+type recordingObserver struct {
+	names []string
+}
+
+// ObserveQuery records the query name
+func (o *recordingObserver) ObserveQuery(_ context.Context, name string, _ time.Duration, _ error) {
+	o.names = append(o.names, name)
+}
+
+// ObserveTestSuite is a test suite for query observation
+type ObserveTestSuite struct {
+	suite.Suite
+	observer *recordingObserver
+	recorder *recordingConn
+}
+
+// SetupTest is called before each test
+func (s *ObserveTestSuite) SetupTest() {
+	s.observer = &recordingObserver{}
+	s.recorder = &recordingConn{}
+}
+
+// TestObserveSuite runs the test suite
+func TestObserveSuite(t *testing.T) {
+	suite.Run(t, new(ObserveTestSuite))
+}
+
+// Test queries are observed by name, also behind a request tag
+func (s *ObserveTestSuite) TestObserve() {
+	// Arrange
+	conn := WithRequestTags(WithQueryObserver(s.recorder, s.observer))
+	ctx := logging.WithRequestID(context.Background(), "req-1")
+
+	// Act
+	_, _ = conn.ExecContext(ctx, "-- name: DeleteLume :exec\nDELETE FROM lume")
+	_, _ = conn.QueryContext(ctx, "SELECT 1")
+
+	// Assert
+	s.Equal([]string{"DeleteLume", "unknown"}, s.observer.names)
+	s.Equal("/* request_id=req-1 */ -- name: DeleteLume :exec\nDELETE FROM lume", s.recorder.queries[0])
+}
+
+// Test QueryName
+func (s *ObserveTestSuite) TestQueryName() {
+	s.Equal("GetLume", QueryName("-- name: GetLume :one\nSELECT 1"))
+	s.Equal("GetLume", QueryName("/* request_id=abc */ -- name: GetLume :one\nSELECT 1"))
+	s.Equal("unknown", QueryName("SELECT 1"))
+	s.Equal("unknown", QueryName("-- name: \nSELECT 1"))
+}
@@ -0,0 +1,37 @@
+-- Ownership lookups for authorization. They don't filter out trashed rows:
+-- who owns an entity doesn't change while it's in the trash.
+
+-- name: GetLumoOwner :one
+SELECT lumo_id, user_id FROM lumo WHERE lumo_id = $1;
+
+-- name: GetLumoOwnerByID :one
+SELECT lumo_id, user_id FROM lumo WHERE id = $1;
+
+-- name: GetLumeOwner :one
+SELECT lumo.lumo_id, lumo.user_id
+FROM lume
+JOIN lumo ON lumo.lumo_id = lume.lumo_id
+WHERE lume.lume_id = $1;
+
+-- name: GetLumeOwnerByID :one
+SELECT lumo.lumo_id, lumo.user_id
+FROM lume
+JOIN lumo ON lumo.lumo_id = lume.lumo_id
+WHERE lume.id = $1;
+
+-- name: GetLinkOwner :one
+SELECT lumo.lumo_id, lumo.user_id
+FROM link
+JOIN lume ON lume.lume_id = link.from_lume_id
+JOIN lumo ON lumo.lumo_id = lume.lumo_id
+WHERE link.link_id = $1;
+
+-- name: GetLinkOwnerByID :one
+SELECT lumo.lumo_id, lumo.user_id
+FROM link
+JOIN lume ON lume.lume_id = link.from_lume_id
+JOIN lumo ON lumo.lumo_id = lume.lumo_id
+WHERE link.id = $1;
+
+-- name: GetLumoMemberRole :one
+SELECT role FROM lumo_member WHERE lumo_id = $1 AND user_id = $2;
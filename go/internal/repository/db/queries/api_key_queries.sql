@@ -0,0 +1,48 @@
+-- name: CreateApiKey :one
+INSERT INTO api_key (
+    api_key_id, user_id, name, prefix, secret_hash, scope, lumo_id, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id, api_key_id, user_id, name, prefix, secret_hash, scope, lumo_id,
+    last_used_at, revoked_at, rotated_at, created_at, updated_at;
+
+-- name: GetApiKeyByApiKeyID :one
+SELECT id, api_key_id, user_id, name, prefix, secret_hash, scope, lumo_id,
+    last_used_at, revoked_at, rotated_at, created_at, updated_at
+FROM api_key WHERE api_key_id = $1;
+
+-- name: ListApiKeysByUserID :many
+SELECT id, api_key_id, user_id, name, prefix, secret_hash, scope, lumo_id,
+    last_used_at, revoked_at, rotated_at, created_at, updated_at
+FROM api_key
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: RevokeApiKey :one
+-- Revoking a key twice keeps the first revocation time
+UPDATE api_key SET
+    revoked_at = COALESCE(revoked_at, sqlc.arg(revoked_at)::TIMESTAMPTZ),
+    updated_at = sqlc.arg(revoked_at)::TIMESTAMPTZ
+WHERE api_key_id = sqlc.arg(api_key_id)
+RETURNING id, api_key_id, user_id, name, prefix, secret_hash, scope, lumo_id,
+    last_used_at, revoked_at, rotated_at, created_at, updated_at;
+
+-- name: RotateApiKey :one
+-- Replaces the secret of a key that hasn't been revoked
+UPDATE api_key SET
+    prefix = sqlc.arg(prefix),
+    secret_hash = sqlc.arg(secret_hash),
+    rotated_at = sqlc.arg(rotated_at)::TIMESTAMPTZ,
+    updated_at = sqlc.arg(rotated_at)::TIMESTAMPTZ
+WHERE api_key_id = sqlc.arg(api_key_id) AND revoked_at IS NULL
+RETURNING id, api_key_id, user_id, name, prefix, secret_hash, scope, lumo_id,
+    last_used_at, revoked_at, rotated_at, created_at, updated_at;
+
+-- name: UseApiKey :one
+-- Records the use of a key that hasn't been revoked
+UPDATE api_key SET last_used_at = sqlc.arg(used_at)::TIMESTAMPTZ
+WHERE secret_hash = sqlc.arg(secret_hash) AND revoked_at IS NULL
+RETURNING id, api_key_id, user_id, name, prefix, secret_hash, scope, lumo_id,
+    last_used_at, revoked_at, rotated_at, created_at, updated_at;
@@ -0,0 +1,22 @@
+-- name: CreateAuditLogEntry :one
+INSERT INTO audit_log (
+    actor, api_key_id, procedure, lumo_id, target_ids, request_digest, code, latency_us, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id, actor, api_key_id, procedure, lumo_id, target_ids, request_digest, code, latency_us, created_at;
+
+-- name: ListAuditLog :many
+-- Entries matching every filter that is set, newest first
+SELECT id, actor, api_key_id, procedure, lumo_id, target_ids, request_digest, code, latency_us, created_at
+FROM audit_log
+WHERE (sqlc.narg(lumo_id)::UUID IS NULL OR lumo_id = sqlc.narg(lumo_id))
+    AND (sqlc.narg(actor)::TEXT IS NULL OR actor = sqlc.narg(actor))
+    AND (sqlc.narg(since)::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg(since))
+    AND (sqlc.narg(until)::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg(until))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: PurgeAuditLog :execrows
+-- Permanently deletes the entries recorded before the cutoff
+DELETE FROM audit_log WHERE created_at < sqlc.arg(before)::TIMESTAMPTZ;
@@ -0,0 +1,23 @@
+-- name: LockLumoEvents :exec
+-- Serializes event writers of a Lumo until commit so ids become visible in order
+SELECT pg_advisory_xact_lock(hashtextextended(sqlc.arg(lumo_id)::text, 0));
+
+-- name: CreateLumoEvent :one
+INSERT INTO lumo_event (
+    lumo_id, entity_type, entity_id, event_type, payload, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, lumo_id, entity_type, entity_id, event_type, payload, created_at;
+
+-- name: NotifyLumoEvent :exec
+SELECT pg_notify('lumo_events', sqlc.arg(payload)::text);
+
+-- name: GetLatestLumoEventID :one
+SELECT COALESCE(MAX(id), 0)::BIGINT FROM lumo_event WHERE lumo_id = $1;
+
+-- name: ListLumoEventsAfter :many
+SELECT id, lumo_id, entity_type, entity_id, event_type, payload, created_at
+FROM lumo_event
+WHERE lumo_id = $1 AND id > $2
+ORDER BY id ASC
+LIMIT $3;
@@ -0,0 +1,27 @@
+-- name: CreateEntityHistory :one
+INSERT INTO entity_history (
+    lumo_id, entity_type, entity_id, operation, actor, snapshot, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, lumo_id, entity_type, entity_id, operation, actor, snapshot, created_at;
+
+-- name: ListEntityHistory :many
+SELECT id, lumo_id, entity_type, entity_id, operation, actor, snapshot, created_at
+FROM entity_history
+WHERE entity_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListLumoHistory :many
+SELECT id, lumo_id, entity_type, entity_id, operation, actor, snapshot, created_at
+FROM entity_history
+WHERE lumo_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListLumoHistoryAsOf :many
+-- Latest entry of every entity of a Lumo recorded at or before the given time
+SELECT DISTINCT ON (entity_id) id, lumo_id, entity_type, entity_id, operation, actor, snapshot, created_at
+FROM entity_history
+WHERE lumo_id = $1 AND created_at <= sqlc.arg(at)
+ORDER BY entity_id, id DESC;
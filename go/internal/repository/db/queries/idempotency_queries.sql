@@ -0,0 +1,39 @@
+-- name: ClaimIdempotencyKey :execrows
+-- Claims the key for a call, taking it over once it has expired, or once the
+-- lock of a call with the same request ran out without a response. No row is
+-- affected while the key is held by another call.
+INSERT INTO idempotency_key (
+    scope, key, request_hash, locked_until, expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (scope, key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    response = NULL,
+    created_at = NOW(),
+    locked_until = EXCLUDED.locked_until,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_key.expires_at <= NOW()
+    OR (idempotency_key.response IS NULL
+        AND idempotency_key.locked_until <= NOW()
+        AND idempotency_key.request_hash = EXCLUDED.request_hash);
+
+-- name: GetIdempotencyKey :one
+SELECT id, scope, key, request_hash, response, created_at, locked_until, expires_at
+FROM idempotency_key
+WHERE scope = $1 AND key = $2;
+
+-- name: CompleteIdempotencyKey :exec
+-- Stores the response of the call holding the key
+UPDATE idempotency_key
+SET response = $1
+WHERE scope = $2 AND key = $3 AND request_hash = $4 AND response IS NULL;
+
+-- name: ReleaseIdempotencyKey :exec
+-- Frees the key of a call that failed, so it can be retried
+DELETE FROM idempotency_key
+WHERE scope = $1 AND key = $2 AND request_hash = $3 AND response IS NULL;
+
+-- name: PurgeIdempotencyKeys :execrows
+-- Permanently deletes the keys that expired before the cutoff
+DELETE FROM idempotency_key WHERE expires_at < sqlc.arg(before)::TIMESTAMPTZ;
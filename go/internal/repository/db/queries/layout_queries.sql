@@ -0,0 +1,43 @@
+-- name: UpsertLumeLayout :one
+INSERT INTO lume_layout (
+    lume_id, lumo_id, position_x, position_y,
+    width, height, collapsed, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (lume_id) DO UPDATE SET
+    position_x = EXCLUDED.position_x,
+    position_y = EXCLUDED.position_y,
+    width = EXCLUDED.width,
+    height = EXCLUDED.height,
+    collapsed = EXCLUDED.collapsed,
+    updated_at = EXCLUDED.updated_at
+RETURNING id, lume_id, lumo_id, position_x, position_y,
+    width, height, collapsed, created_at, updated_at;
+
+-- name: ListLumeLayoutsByLumoID :many
+SELECT id, lume_id, lumo_id, position_x, position_y,
+    width, height, collapsed, created_at, updated_at
+FROM lume_layout
+WHERE lumo_id = $1
+ORDER BY id ASC;
+
+-- name: ListLumeIDsByLumoID :many
+SELECT lume_id FROM lume WHERE lumo_id = $1 AND deleted_at IS NULL;
+
+-- name: UpsertLumoViewport :one
+INSERT INTO lumo_viewport (
+    lumo_id, x, y, zoom, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (lumo_id) DO UPDATE SET
+    x = EXCLUDED.x,
+    y = EXCLUDED.y,
+    zoom = EXCLUDED.zoom,
+    updated_at = EXCLUDED.updated_at
+RETURNING id, lumo_id, x, y, zoom, created_at, updated_at;
+
+-- name: GetLumoViewportByLumoID :one
+SELECT id, lumo_id, x, y, zoom, created_at, updated_at
+FROM lumo_viewport WHERE lumo_id = $1;
@@ -1,62 +1,85 @@
 -- name: CreateLink :one
+-- Creating a Link with the ID of one in the trash brings it back
 INSERT INTO link (
     link_id, from_lume_id, to_lume_id, link_type,
     travel_details, notes, sequence_index, created_at, updated_at
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9
-) RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at;
+)
+ON CONFLICT (link_id) DO UPDATE SET
+    from_lume_id = EXCLUDED.from_lume_id,
+    to_lume_id = EXCLUDED.to_lume_id,
+    link_type = EXCLUDED.link_type,
+    travel_details = EXCLUDED.travel_details,
+    notes = EXCLUDED.notes,
+    sequence_index = EXCLUDED.sequence_index,
+    updated_at = EXCLUDED.updated_at,
+    version = link.version + 1,
+    deleted_at = NULL,
+    deleted_by = NULL
+WHERE link.deleted_at IS NOT NULL
+RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by;
 
 -- name: GetLinkByID :one
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
-FROM link WHERE id = $1;
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
+FROM link WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: GetLinkByLinkID :one
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
-FROM link WHERE link_id = $1;
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
+FROM link WHERE link_id = $1 AND deleted_at IS NULL;
 
 -- name: ListLinksByFromLumeID :many
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 FROM link 
-WHERE from_lume_id = $1
+WHERE from_lume_id = $1 AND deleted_at IS NULL
 ORDER BY sequence_index ASC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: ListLinksByToLumeID :many
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 FROM link 
-WHERE to_lume_id = $1
+WHERE to_lume_id = $1 AND deleted_at IS NULL
 ORDER BY sequence_index ASC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: ListLinksByEitherLumeID :many
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 FROM link 
-WHERE from_lume_id = $1 OR to_lume_id = $1
+WHERE (from_lume_id = $1 OR to_lume_id = $1) AND deleted_at IS NULL
 ORDER BY sequence_index ASC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: ListLinksByType :many
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 FROM link 
-WHERE link_type = $1
+WHERE link_type = $1 AND deleted_at IS NULL
 ORDER BY sequence_index ASC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: ListLinksByLumeIDAndType :many
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 FROM link 
-WHERE (from_lume_id = $1 OR to_lume_id = $1) AND link_type = $2
+WHERE (from_lume_id = $1 OR to_lume_id = $1) AND link_type = $2 AND deleted_at IS NULL
 ORDER BY sequence_index ASC NULLS LAST, created_at DESC
 LIMIT $3 OFFSET $4;
 
+-- name: ListLinksByLumoID :many
+SELECT link.id, link.link_id, link.from_lume_id, link.to_lume_id, link.link_type,
+    link.travel_details, link.notes, link.sequence_index, link.created_at, link.updated_at, link.version, link.deleted_at, link.deleted_by
+FROM link
+JOIN lume ON lume.lume_id = link.from_lume_id
+WHERE lume.lumo_id = $1 AND link.deleted_at IS NULL
+ORDER BY link.sequence_index ASC NULLS LAST, link.created_at DESC
+LIMIT $2 OFFSET $3;
+
 -- name: UpdateLink :one
 UPDATE link SET
     from_lume_id = $2,
@@ -65,22 +88,94 @@ UPDATE link SET
     travel_details = $5,
     notes = $6,
     sequence_index = $7,
-    updated_at = $8
-WHERE link_id = $1
+    updated_at = $8,
+    version = version + 1
+WHERE link_id = $1 AND version = $9 AND deleted_at IS NULL
 RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at;
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by;
 
--- name: DeleteLink :exec
-DELETE FROM link WHERE id = $1;
+-- name: DeleteLink :one
+-- Moves the Link to the trash
+UPDATE link SET deleted_at = sqlc.arg(deleted_at)::TIMESTAMPTZ
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL
+    AND (sqlc.narg(expected_version)::BIGINT IS NULL OR version = sqlc.narg(expected_version))
+RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by;
 
--- name: DeleteLinkByLinkID :exec
-DELETE FROM link WHERE link_id = $1;
+-- name: DeleteLinkByLinkID :one
+-- Moves the Link to the trash
+UPDATE link SET deleted_at = sqlc.arg(deleted_at)::TIMESTAMPTZ
+WHERE link_id = sqlc.arg(link_id) AND deleted_at IS NULL
+    AND (sqlc.narg(expected_version)::BIGINT IS NULL OR version = sqlc.narg(expected_version))
+RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by;
 
 -- name: CountLinksByLumeID :one
-SELECT COUNT(*) FROM link WHERE from_lume_id = $1 OR to_lume_id = $1;
+SELECT COUNT(*) FROM link WHERE (from_lume_id = $1 OR to_lume_id = $1) AND deleted_at IS NULL;
 
 -- name: CountLinksByFromLumeID :one
-SELECT COUNT(*) FROM link WHERE from_lume_id = $1;
+SELECT COUNT(*) FROM link WHERE from_lume_id = $1 AND deleted_at IS NULL;
 
 -- name: CountLinksByToLumeID :one
-SELECT COUNT(*) FROM link WHERE to_lume_id = $1;
\ No newline at end of file
+SELECT COUNT(*) FROM link WHERE to_lume_id = $1 AND deleted_at IS NULL;
+
+-- name: ListTrashedLinksByLumoID :many
+-- Links of a Lumo that were deleted directly, most recently deleted first
+SELECT link.id, link.link_id, link.from_lume_id, link.to_lume_id, link.link_type,
+    link.travel_details, link.notes, link.sequence_index, link.created_at, link.updated_at, link.version, link.deleted_at, link.deleted_by
+FROM link
+JOIN lume ON lume.lume_id = link.from_lume_id
+WHERE lume.lumo_id = $1 AND link.deleted_at IS NOT NULL AND link.deleted_by IS NULL
+ORDER BY link.deleted_at DESC, link.id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: TrashLinksByLumeID :exec
+-- Moves the Links of a Lume that is being trashed along with it
+UPDATE link SET deleted_at = sqlc.arg(deleted_at)::TIMESTAMPTZ, deleted_by = sqlc.arg(lume_id)::UUID
+WHERE (from_lume_id = sqlc.arg(lume_id) OR to_lume_id = sqlc.arg(lume_id)) AND deleted_at IS NULL;
+
+-- name: TrashLinksByLumoID :exec
+-- Moves the Links of a Lumo that is being trashed along with it
+UPDATE link SET deleted_at = sqlc.arg(deleted_at)::TIMESTAMPTZ, deleted_by = sqlc.arg(lumo_id)::UUID
+WHERE deleted_at IS NULL
+    AND from_lume_id IN (SELECT lume_id FROM lume WHERE lumo_id = sqlc.arg(lumo_id));
+
+-- name: RestoreLinkByLinkID :one
+-- Takes a Link out of the trash
+UPDATE link SET deleted_at = NULL, deleted_by = NULL
+WHERE link_id = $1 AND deleted_at IS NOT NULL
+RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by;
+
+-- name: RestoreLinksByLumeID :exec
+-- Brings back the Links trashed together with a Lume once both of their
+-- Lumes are out of the trash, unless the connection was made again since
+UPDATE link SET deleted_at = NULL, deleted_by = NULL
+WHERE (link.from_lume_id = $1 OR link.to_lume_id = $1)
+    AND link.deleted_by IS NOT NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM lume
+        WHERE lume.lume_id IN (link.from_lume_id, link.to_lume_id) AND lume.deleted_at IS NOT NULL
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM link AS live
+        WHERE live.from_lume_id = link.from_lume_id AND live.to_lume_id = link.to_lume_id
+            AND live.link_type = link.link_type AND live.deleted_at IS NULL
+    );
+
+-- name: RestoreLinksByLumoID :exec
+-- Brings back the Links trashed together with a Lumo or its Lumes once both
+-- of their Lumes are out of the trash, unless the connection was made again
+-- since
+UPDATE link SET deleted_at = NULL, deleted_by = NULL
+WHERE link.from_lume_id IN (SELECT lume_id FROM lume WHERE lume.lumo_id = $1)
+    AND link.deleted_by IS NOT NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM lume
+        WHERE lume.lume_id IN (link.from_lume_id, link.to_lume_id) AND lume.deleted_at IS NOT NULL
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM link AS live
+        WHERE live.from_lume_id = link.from_lume_id AND live.to_lume_id = link.to_lume_id
+            AND live.link_type = link.link_type AND live.deleted_at IS NULL
+    );
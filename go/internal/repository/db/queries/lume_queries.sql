@@ -1,37 +1,61 @@
 -- name: CreateLume :one
+-- Creating a Lume with the ID of one in the trash brings it back
 INSERT INTO lume (
     lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
+    booking_link, created_at, updated_at, time_zone
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
-) RETURNING id, lume_id, lumo_id, type, name,
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+)
+ON CONFLICT (lume_id) DO UPDATE SET
+    lumo_id = EXCLUDED.lumo_id,
+    type = EXCLUDED.type,
+    name = EXCLUDED.name,
+    date_start = EXCLUDED.date_start,
+    date_end = EXCLUDED.date_end,
+    latitude = EXCLUDED.latitude,
+    longitude = EXCLUDED.longitude,
+    address = EXCLUDED.address,
+    description = EXCLUDED.description,
+    images = EXCLUDED.images,
+    category_tags = EXCLUDED.category_tags,
+    booking_link = EXCLUDED.booking_link,
+    updated_at = EXCLUDED.updated_at,
+    time_zone = EXCLUDED.time_zone,
+    version = lume.version + 1,
+    deleted_at = NULL,
+    deleted_by = NULL
+WHERE lume.deleted_at IS NOT NULL
+RETURNING id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at;
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone;
 
 -- name: GetLumeByID :one
 SELECT id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
-FROM lume WHERE id = $1;
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
+FROM lume WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: GetLumeByLumeID :one
 SELECT id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
-FROM lume WHERE lume_id = $1;
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
+FROM lume WHERE lume_id = $1 AND deleted_at IS NULL;
+
+-- name: GetLumoIDByLumeID :one
+SELECT lumo_id FROM lume WHERE lume_id = $1 AND deleted_at IS NULL;
 
 -- name: ListLumesByLumoID :many
 SELECT id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
 FROM lume 
-WHERE lumo_id = $1
+WHERE lumo_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
@@ -39,9 +63,9 @@ LIMIT $2 OFFSET $3;
 SELECT id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
 FROM lume 
-WHERE lumo_id = $1 AND type = $2
+WHERE lumo_id = $1 AND type = $2 AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $3 OFFSET $4;
 
@@ -49,9 +73,10 @@ LIMIT $3 OFFSET $4;
 SELECT id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
 FROM lume 
-WHERE lumo_id = $1 
+WHERE lumo_id = $1
+    AND deleted_at IS NULL
     AND latitude IS NOT NULL 
     AND longitude IS NOT NULL
     AND latitude BETWEEN $2 AND $3
@@ -72,18 +97,69 @@ UPDATE lume SET
     images = $10,
     category_tags = $11,
     booking_link = $12,
-    updated_at = $13
-WHERE lume_id = $1
+    updated_at = $13,
+    time_zone = $15,
+    version = version + 1
+WHERE lume_id = $1 AND version = $14 AND deleted_at IS NULL
 RETURNING id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at;
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone;
 
--- name: DeleteLume :exec
-DELETE FROM lume WHERE id = $1;
+-- name: DeleteLume :one
+-- Moves the Lume to the trash
+UPDATE lume SET deleted_at = sqlc.arg(deleted_at)::TIMESTAMPTZ
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL
+    AND (sqlc.narg(expected_version)::BIGINT IS NULL OR version = sqlc.narg(expected_version))
+RETURNING id, lume_id, lumo_id, type, name,
+    date_start, date_end, latitude, longitude,
+    address, description, images, category_tags,
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone;
 
--- name: DeleteLumeByLumeID :exec
-DELETE FROM lume WHERE lume_id = $1;
+-- name: DeleteLumeByLumeID :one
+-- Moves the Lume to the trash
+UPDATE lume SET deleted_at = sqlc.arg(deleted_at)::TIMESTAMPTZ
+WHERE lume_id = sqlc.arg(lume_id) AND deleted_at IS NULL
+    AND (sqlc.narg(expected_version)::BIGINT IS NULL OR version = sqlc.narg(expected_version))
+RETURNING id, lume_id, lumo_id, type, name,
+    date_start, date_end, latitude, longitude,
+    address, description, images, category_tags,
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone;
 
 -- name: CountLumesByLumo :one
-SELECT COUNT(*) FROM lume WHERE lumo_id = $1;
\ No newline at end of file
+SELECT COUNT(*) FROM lume WHERE lumo_id = $1 AND deleted_at IS NULL;
+
+-- name: ListTrashedLumesByLumoID :many
+-- Lumes of a Lumo that were deleted directly, most recently deleted first
+SELECT id, lume_id, lumo_id, type, name,
+    date_start, date_end, latitude, longitude,
+    address, description, images, category_tags,
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
+FROM lume
+WHERE lumo_id = $1 AND deleted_at IS NOT NULL AND deleted_by IS NULL
+ORDER BY deleted_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: IsLumeTrashed :one
+SELECT EXISTS (
+    SELECT 1 FROM lume WHERE lume_id = $1 AND deleted_at IS NOT NULL
+);
+
+-- name: TrashLumesByLumoID :exec
+-- Moves the Lumes of a Lumo that is being trashed along with it
+UPDATE lume SET deleted_at = sqlc.arg(deleted_at)::TIMESTAMPTZ, deleted_by = sqlc.arg(lumo_id)::UUID
+WHERE lumo_id = sqlc.arg(lumo_id) AND deleted_at IS NULL;
+
+-- name: RestoreLumeByLumeID :one
+-- Takes a Lume out of the trash
+UPDATE lume SET deleted_at = NULL, deleted_by = NULL
+WHERE lume_id = $1 AND deleted_at IS NOT NULL
+RETURNING id, lume_id, lumo_id, type, name,
+    date_start, date_end, latitude, longitude,
+    address, description, images, category_tags,
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone;
+
+-- name: RestoreLumesByLumoID :exec
+-- Brings back the Lumes that were trashed together with their Lumo
+UPDATE lume SET deleted_at = NULL, deleted_by = NULL
+WHERE lumo_id = $1 AND deleted_by = $1;
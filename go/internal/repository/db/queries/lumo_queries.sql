@@ -1,37 +1,86 @@
 -- name: CreateLumo :one
+-- Creating a Lumo with the ID of one in the trash brings it back
 INSERT INTO lumo (
     lumo_id, user_id, title, created_at, updated_at
 ) VALUES (
     $1, $2, $3, $4, $5
-) RETURNING id, lumo_id, user_id, title, created_at, updated_at;
+)
+ON CONFLICT (lumo_id) DO UPDATE SET
+    user_id = EXCLUDED.user_id,
+    title = EXCLUDED.title,
+    updated_at = EXCLUDED.updated_at,
+    version = lumo.version + 1,
+    deleted_at = NULL
+WHERE lumo.deleted_at IS NOT NULL
+RETURNING id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at;
 
 -- name: GetLumoByID :one
-SELECT id, lumo_id, user_id, title, created_at, updated_at
-FROM lumo WHERE id = $1;
+SELECT id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
+FROM lumo WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: GetLumoByLumoID :one
-SELECT id, lumo_id, user_id, title, created_at, updated_at
-FROM lumo WHERE lumo_id = $1;
+SELECT id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
+FROM lumo WHERE lumo_id = $1 AND deleted_at IS NULL;
 
 -- name: ListLumosByUserID :many
-SELECT id, lumo_id, user_id, title, created_at, updated_at
+-- Lumos a user created or that are shared with them
+SELECT id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
 FROM lumo 
-WHERE user_id = $1
+WHERE (user_id = $1 OR lumo_id IN (SELECT lumo_id FROM lumo_member WHERE lumo_member.user_id = $1))
+    AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: UpdateLumo :one
 UPDATE lumo SET
     title = $2,
-    updated_at = $3
-WHERE lumo_id = $1
-RETURNING id, lumo_id, user_id, title, created_at, updated_at;
+    updated_at = $3,
+    version = version + 1
+WHERE lumo_id = $1 AND version = $4 AND deleted_at IS NULL
+RETURNING id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at;
 
--- name: DeleteLumo :exec
-DELETE FROM lumo WHERE id = $1;
+-- name: DeleteLumo :one
+-- Moves the Lumo to the trash
+UPDATE lumo SET deleted_at = sqlc.arg(deleted_at)::TIMESTAMPTZ
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL
+    AND (sqlc.narg(expected_version)::BIGINT IS NULL OR version = sqlc.narg(expected_version))
+RETURNING id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at;
 
--- name: DeleteLumoByLumoID :exec
-DELETE FROM lumo WHERE lumo_id = $1;
+-- name: DeleteLumoByLumoID :one
+-- Moves the Lumo to the trash
+UPDATE lumo SET deleted_at = sqlc.arg(deleted_at)::TIMESTAMPTZ
+WHERE lumo_id = sqlc.arg(lumo_id) AND deleted_at IS NULL
+    AND (sqlc.narg(expected_version)::BIGINT IS NULL OR version = sqlc.narg(expected_version))
+RETURNING id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at;
 
 -- name: CountLumosByUserID :one
-SELECT COUNT(*) FROM lumo WHERE user_id = $1;
\ No newline at end of file
+SELECT COUNT(*) FROM lumo
+WHERE (user_id = $1 OR lumo_id IN (SELECT lumo_id FROM lumo_member WHERE lumo_member.user_id = $1))
+    AND deleted_at IS NULL;
+
+-- name: CountLumosOwnedByUser :one
+-- Counts the Lumos a user created that are not in the trash
+SELECT COUNT(*) FROM lumo WHERE user_id = $1 AND deleted_at IS NULL;
+
+-- name: LockLumosOfUser :exec
+-- Serializes the creation of a user's Lumos until commit, for their quota
+SELECT pg_advisory_xact_lock(hashtextextended('lumos_of_user:' || sqlc.arg(user_id)::text, 0));
+
+-- name: ListTrashedLumosByUserID :many
+-- Lumos of a user in the trash, most recently deleted first
+SELECT id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
+FROM lumo
+WHERE user_id = $1 AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: IsLumoTrashed :one
+SELECT EXISTS (
+    SELECT 1 FROM lumo WHERE lumo_id = $1 AND deleted_at IS NOT NULL
+);
+
+-- name: RestoreLumoByLumoID :one
+-- Takes a Lumo out of the trash
+UPDATE lumo SET deleted_at = NULL
+WHERE lumo_id = $1 AND deleted_at IS NOT NULL
+RETURNING id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at;
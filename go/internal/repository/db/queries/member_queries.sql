@@ -0,0 +1,40 @@
+-- name: CreateLumoMember :one
+INSERT INTO lumo_member (
+    lumo_id, user_id, role, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING id, lumo_id, user_id, role, created_at, updated_at;
+
+-- name: UpsertLumoOwner :exec
+-- Makes the creator of a Lumo its owner, also when a Lumo is brought back
+-- from the trash by creating it again
+INSERT INTO lumo_member (
+    lumo_id, user_id, role, created_at, updated_at
+) VALUES (
+    $1, $2, 'OWNER', $3, $3
+)
+ON CONFLICT (lumo_id, user_id) DO UPDATE SET
+    role = 'OWNER',
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetLumoMember :one
+SELECT id, lumo_id, user_id, role, created_at, updated_at
+FROM lumo_member WHERE lumo_id = $1 AND user_id = $2;
+
+-- name: ListLumoMembers :many
+SELECT id, lumo_id, user_id, role, created_at, updated_at
+FROM lumo_member
+WHERE lumo_id = $1
+ORDER BY created_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateLumoMemberRole :one
+UPDATE lumo_member SET
+    role = $3,
+    updated_at = $4
+WHERE lumo_id = $1 AND user_id = $2
+RETURNING id, lumo_id, user_id, role, created_at, updated_at;
+
+-- name: DeleteLumoMember :execrows
+DELETE FROM lumo_member WHERE lumo_id = $1 AND user_id = $2;
@@ -0,0 +1,33 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox (
+    event_id, event_type, lumo_id, entity_id, actor, payload, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: ClaimOutboxEvents :many
+-- Leases the oldest events that are due to one relay until lease_until, so
+-- other relays skip them while they are being delivered
+UPDATE outbox
+SET next_attempt_at = sqlc.arg(lease_until)::TIMESTAMPTZ,
+    attempts = attempts + 1
+WHERE id IN (
+    SELECT o.id FROM outbox o
+    WHERE o.status = 'PENDING' AND o.next_attempt_at <= sqlc.arg(now)::TIMESTAMPTZ
+    ORDER BY o.id
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, event_id, event_type, lumo_id, entity_id, actor, payload, status, attempts, next_attempt_at, last_error, delivered_at, created_at;
+
+-- name: MarkOutboxEventDelivered :exec
+UPDATE outbox
+SET status = 'DELIVERED', delivered_at = $2, last_error = NULL
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+-- Records a failed attempt, either to retry at next_attempt_at (PENDING) or
+-- for good (FAILED)
+UPDATE outbox
+SET status = $2, next_attempt_at = $3, last_error = $4
+WHERE id = $1;
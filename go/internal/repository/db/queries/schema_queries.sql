@@ -0,0 +1,3 @@
+-- name: GetSchemaVersion :one
+-- Returns the number of the latest schema file applied, 0 if none recorded one
+SELECT COALESCE(MAX(version), 0)::INTEGER FROM schema_version;
@@ -0,0 +1,42 @@
+-- name: CreateShareLink :one
+INSERT INTO share_link (
+    share_link_id, lumo_id, token_hash, hide_booking_links, hide_costs, hide_notes,
+    expires_at, created_by, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id, share_link_id, lumo_id, token_hash, hide_booking_links, hide_costs, hide_notes,
+    expires_at, revoked_at, access_count, last_accessed_at, created_by, created_at;
+
+-- name: GetShareLinkByShareLinkID :one
+SELECT id, share_link_id, lumo_id, token_hash, hide_booking_links, hide_costs, hide_notes,
+    expires_at, revoked_at, access_count, last_accessed_at, created_by, created_at
+FROM share_link WHERE share_link_id = $1;
+
+-- name: ListShareLinksByLumoID :many
+SELECT id, share_link_id, lumo_id, token_hash, hide_booking_links, hide_costs, hide_notes,
+    expires_at, revoked_at, access_count, last_accessed_at, created_by, created_at
+FROM share_link
+WHERE lumo_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: RevokeShareLink :one
+-- Revoking a link twice keeps the first revocation time
+UPDATE share_link SET revoked_at = COALESCE(revoked_at, sqlc.arg(revoked_at)::TIMESTAMPTZ)
+WHERE share_link_id = sqlc.arg(share_link_id)
+RETURNING id, share_link_id, lumo_id, token_hash, hide_booking_links, hide_costs, hide_notes,
+    expires_at, revoked_at, access_count, last_accessed_at, created_by, created_at;
+
+-- name: UseShareLink :one
+-- Counts an access through a link that still works: neither revoked nor
+-- expired, and its Lumo isn't in the trash
+UPDATE share_link SET
+    access_count = access_count + 1,
+    last_accessed_at = sqlc.arg(accessed_at)::TIMESTAMPTZ
+WHERE token_hash = sqlc.arg(token_hash)
+    AND revoked_at IS NULL
+    AND (expires_at IS NULL OR expires_at > sqlc.arg(accessed_at)::TIMESTAMPTZ)
+    AND lumo_id IN (SELECT lumo_id FROM lumo WHERE deleted_at IS NULL)
+RETURNING id, share_link_id, lumo_id, token_hash, hide_booking_links, hide_costs, hide_notes,
+    expires_at, revoked_at, access_count, last_accessed_at, created_by, created_at;
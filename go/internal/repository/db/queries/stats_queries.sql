@@ -0,0 +1,22 @@
+-- name: CountLiveEntities :one
+-- Counts the Lumos, Lumes and Links that are not in the trash
+SELECT
+    (SELECT COUNT(*) FROM lumo WHERE lumo.deleted_at IS NULL)::BIGINT AS lumos,
+    (SELECT COUNT(*) FROM lume WHERE lume.deleted_at IS NULL)::BIGINT AS lumes,
+    (SELECT COUNT(*) FROM link WHERE link.deleted_at IS NULL)::BIGINT AS links;
+
+-- name: GetLumesPerLumoStats :one
+-- Summarizes how many Lumes the Lumos that are not in the trash have
+SELECT
+    COALESCE(AVG(per_lumo.lumes), 0)::DOUBLE PRECISION AS mean,
+    COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY per_lumo.lumes), 0)::DOUBLE PRECISION AS p50,
+    COALESCE(PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY per_lumo.lumes), 0)::DOUBLE PRECISION AS p90,
+    COALESCE(PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY per_lumo.lumes), 0)::DOUBLE PRECISION AS p99,
+    COALESCE(MAX(per_lumo.lumes), 0)::BIGINT AS max
+FROM (
+    SELECT COUNT(lume.id) AS lumes
+    FROM lumo
+    LEFT JOIN lume ON lume.lumo_id = lumo.lumo_id AND lume.deleted_at IS NULL
+    WHERE lumo.deleted_at IS NULL
+    GROUP BY lumo.id
+) per_lumo;
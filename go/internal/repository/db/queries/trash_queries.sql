@@ -0,0 +1,11 @@
+-- name: PurgeLinks :execrows
+-- Permanently deletes the Links that were trashed before the cutoff
+DELETE FROM link WHERE deleted_at < sqlc.arg(before)::TIMESTAMPTZ;
+
+-- name: PurgeLumes :execrows
+-- Permanently deletes the Lumes that were trashed before the cutoff
+DELETE FROM lume WHERE deleted_at < sqlc.arg(before)::TIMESTAMPTZ;
+
+-- name: PurgeLumos :execrows
+-- Permanently deletes the Lumos that were trashed before the cutoff
+DELETE FROM lumo WHERE deleted_at < sqlc.arg(before)::TIMESTAMPTZ;
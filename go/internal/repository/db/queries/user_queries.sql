@@ -0,0 +1,48 @@
+-- name: CreateUser :one
+-- Users backfilled without an email can be created once more to fill in
+-- their profile, anyone else already exists
+INSERT INTO "user" (
+    user_id, email, display_name,
+    home_currency, distance_unit, time_zone, locale,
+    created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    email = EXCLUDED.email,
+    display_name = EXCLUDED.display_name,
+    home_currency = EXCLUDED.home_currency,
+    distance_unit = EXCLUDED.distance_unit,
+    time_zone = EXCLUDED.time_zone,
+    locale = EXCLUDED.locale,
+    updated_at = EXCLUDED.updated_at
+WHERE "user".email IS NULL
+RETURNING id, user_id, email, display_name,
+    home_currency, distance_unit, time_zone, locale,
+    created_at, updated_at;
+
+-- name: GetUserByUserID :one
+SELECT id, user_id, email, display_name,
+    home_currency, distance_unit, time_zone, locale,
+    created_at, updated_at
+FROM "user" WHERE user_id = $1;
+
+-- name: GetUserByEmail :one
+SELECT id, user_id, email, display_name,
+    home_currency, distance_unit, time_zone, locale,
+    created_at, updated_at
+FROM "user" WHERE email = $1;
+
+-- name: UpdateUser :one
+UPDATE "user" SET
+    email = $2,
+    display_name = $3,
+    home_currency = $4,
+    distance_unit = $5,
+    time_zone = $6,
+    locale = $7,
+    updated_at = $8
+WHERE user_id = $1
+RETURNING id, user_id, email, display_name,
+    home_currency, distance_unit, time_zone, locale,
+    created_at, updated_at;
@@ -0,0 +1,106 @@
+-- name: CreateWebhook :one
+INSERT INTO webhook (
+    webhook_id, user_id, lumo_id, url, description, secret, entity_types, lume_types, link_types, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+)
+RETURNING id, webhook_id, user_id, lumo_id, url, description, secret, entity_types, lume_types, link_types, consecutive_failures, disabled_at, created_at, updated_at;
+
+-- name: GetWebhookByWebhookID :one
+SELECT id, webhook_id, user_id, lumo_id, url, description, secret, entity_types, lume_types, link_types, consecutive_failures, disabled_at, created_at, updated_at
+FROM webhook
+WHERE webhook_id = $1;
+
+-- name: ListWebhooksByUserID :many
+-- Webhooks of a user, only those of one Lumo if lumo_id is set, newest first
+SELECT id, webhook_id, user_id, lumo_id, url, description, secret, entity_types, lume_types, link_types, consecutive_failures, disabled_at, created_at, updated_at
+FROM webhook
+WHERE user_id = sqlc.arg(user_id)
+    AND (sqlc.narg(lumo_id)::UUID IS NULL OR lumo_id = sqlc.narg(lumo_id))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListWebhooksForEvent :many
+-- Enabled webhooks that should hear about the events of a Lumo: those of the
+-- Lumo itself and those of all Lumos of a user, as long as their user is
+-- still a member
+SELECT w.id, w.webhook_id, w.user_id, w.lumo_id, w.url, w.description, w.secret, w.entity_types, w.lume_types, w.link_types, w.consecutive_failures, w.disabled_at, w.created_at, w.updated_at
+FROM webhook w
+WHERE w.disabled_at IS NULL
+    AND (w.lumo_id IS NULL OR w.lumo_id = sqlc.arg(lumo_id))
+    AND EXISTS (
+        SELECT 1 FROM lumo_member m
+        WHERE m.lumo_id = sqlc.arg(lumo_id) AND m.user_id = w.user_id
+    );
+
+-- name: UpdateWebhook :one
+UPDATE webhook
+SET url = $2, description = $3, entity_types = $4, lume_types = $5, link_types = $6,
+    consecutive_failures = $7, disabled_at = $8, updated_at = $9
+WHERE webhook_id = $1
+RETURNING id, webhook_id, user_id, lumo_id, url, description, secret, entity_types, lume_types, link_types, consecutive_failures, disabled_at, created_at, updated_at;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhook WHERE webhook_id = $1;
+
+-- name: RecordWebhookSuccess :exec
+UPDATE webhook SET consecutive_failures = 0 WHERE webhook_id = $1;
+
+-- name: RecordWebhookFailure :one
+-- Counts a failed attempt, disabling the webhook once disable_after attempts
+-- in a row failed
+UPDATE webhook
+SET consecutive_failures = consecutive_failures + 1,
+    disabled_at = CASE
+        WHEN disabled_at IS NULL AND consecutive_failures + 1 >= sqlc.arg(disable_after)::INT THEN sqlc.arg(now)::TIMESTAMPTZ
+        ELSE disabled_at
+    END
+WHERE webhook_id = sqlc.arg(webhook_id)
+RETURNING id, webhook_id, user_id, lumo_id, url, description, secret, entity_types, lume_types, link_types, consecutive_failures, disabled_at, created_at, updated_at;
+
+-- name: CreateWebhookDelivery :one
+-- Deliveries of an event that is already queued for the webhook are dropped,
+-- unless they were requested manually
+INSERT INTO webhook_delivery (
+    delivery_id, webhook_id, event_id, event_type, payload, redelivery_of, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (webhook_id, event_id) WHERE redelivery_of IS NULL DO NOTHING
+RETURNING id, delivery_id, webhook_id, event_id, event_type, payload, redelivery_of, status, attempts, next_attempt_at, response_code, last_error, delivered_at, created_at;
+
+-- name: GetWebhookDeliveryByDeliveryID :one
+SELECT id, delivery_id, webhook_id, event_id, event_type, payload, redelivery_of, status, attempts, next_attempt_at, response_code, last_error, delivered_at, created_at
+FROM webhook_delivery
+WHERE delivery_id = $1;
+
+-- name: ListWebhookDeliveries :many
+-- Deliveries to a webhook, newest first
+SELECT id, delivery_id, webhook_id, event_id, event_type, payload, redelivery_of, status, attempts, next_attempt_at, response_code, last_error, delivered_at, created_at
+FROM webhook_delivery
+WHERE webhook_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ClaimWebhookDeliveries :many
+-- Leases the oldest deliveries that are due to enabled webhooks to one worker
+-- until lease_until, so other workers skip them while they are being sent
+UPDATE webhook_delivery
+SET next_attempt_at = sqlc.arg(lease_until)::TIMESTAMPTZ,
+    attempts = attempts + 1
+WHERE id IN (
+    SELECT d.id FROM webhook_delivery d
+    JOIN webhook w ON w.webhook_id = d.webhook_id
+    WHERE d.status = 'PENDING' AND d.next_attempt_at <= sqlc.arg(now)::TIMESTAMPTZ
+        AND w.disabled_at IS NULL
+    ORDER BY d.id
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE OF d SKIP LOCKED
+)
+RETURNING id, delivery_id, webhook_id, event_id, event_type, payload, redelivery_of, status, attempts, next_attempt_at, response_code, last_error, delivered_at, created_at;
+
+-- name: MarkWebhookDelivery :exec
+-- Records the outcome of an attempt
+UPDATE webhook_delivery
+SET status = $2, next_attempt_at = $3, response_code = $4, last_error = $5, delivered_at = $6
+WHERE id = $1;
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+// ErrSchemaOutdated means schema files the code relies on haven't been
+// applied to the database
+var ErrSchemaOutdated = errors.New("database schema is outdated")
+
+//go:embed schema/*.sql
+var schemaFiles embed.FS
+
+// SchemaVersion is the number of the latest schema file, which the database
+// must be at
+var SchemaVersion = latestSchemaVersion()
+
+// CheckSchema returns ErrSchemaOutdated if the database is behind
+// SchemaVersion
+func CheckSchema(ctx context.Context, conn sqlc.DBTX) error {
+	applied, err := sqlc.New(conn).GetSchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get schema version: %w", err)
+	}
+	if int(applied) < SchemaVersion {
+		return fmt.Errorf("%w: at %d, expected %d", ErrSchemaOutdated, applied, SchemaVersion)
+	}
+	return nil
+}
+
+// latestSchemaVersion returns the highest number schema files start with,
+// e.g. 16 for 016_schema_version_schema.sql
+func latestSchemaVersion() int {
+	entries, err := schemaFiles.ReadDir("schema")
+	if err != nil {
+		panic("db: reading embedded schema files: " + err.Error())
+	}
+	latest := 0
+	for _, entry := range entries {
+		prefix, _, _ := strings.Cut(path.Base(entry.Name()), "_")
+		if version, err := strconv.Atoi(prefix); err == nil && version > latest {
+			latest = version
+		}
+	}
+	return latest
+}
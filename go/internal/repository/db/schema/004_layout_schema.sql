@@ -0,0 +1,55 @@
+-- Enable extensions for UUIDs if needed
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- Table: lume_layout
+-- Canvas placement of a single Lume inside its Lumo
+CREATE TABLE IF NOT EXISTS lume_layout (
+    -- Internal database ID
+    id BIGSERIAL PRIMARY KEY,
+    -- The Lume this layout belongs to (one layout per Lume)
+    lume_id UUID NOT NULL UNIQUE,
+    -- Parent Lumo, so a whole canvas can be loaded with one query
+    lumo_id UUID NOT NULL,
+    -- Canvas position of the node
+    position_x DOUBLE PRECISION NOT NULL DEFAULT 0,
+    position_y DOUBLE PRECISION NOT NULL DEFAULT 0,
+    -- Optional node size, NULL lets the client size the node itself
+    width DOUBLE PRECISION NULL,
+    height DOUBLE PRECISION NULL,
+    -- Whether the node is rendered collapsed
+    collapsed BOOLEAN NOT NULL DEFAULT FALSE,
+    -- System timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Layout rows go away together with their Lume
+ALTER TABLE lume_layout
+    ADD CONSTRAINT fk_lume_layout_lume
+    FOREIGN KEY (lume_id)
+    REFERENCES lume(lume_id)
+    ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_lume_layout_lumo ON lume_layout (lumo_id);
+
+-- Table: lumo_viewport
+-- Last saved pan/zoom of a Lumo's canvas
+CREATE TABLE IF NOT EXISTS lumo_viewport (
+    -- Internal database ID
+    id BIGSERIAL PRIMARY KEY,
+    -- The Lumo this viewport belongs to (one viewport per Lumo)
+    lumo_id UUID NOT NULL UNIQUE,
+    -- Canvas translation and zoom factor
+    x DOUBLE PRECISION NOT NULL DEFAULT 0,
+    y DOUBLE PRECISION NOT NULL DEFAULT 0,
+    zoom DOUBLE PRECISION NOT NULL DEFAULT 1,
+    -- System timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE lumo_viewport
+    ADD CONSTRAINT fk_lumo_viewport_lumo
+    FOREIGN KEY (lumo_id)
+    REFERENCES lumo(lumo_id)
+    ON DELETE CASCADE;
@@ -0,0 +1,30 @@
+-- Table: lumo_event
+-- Append-only log of changes to the Lumes and Links of a Lumo. Watchers are
+-- woken up through NOTIFY on the lumo_events channel and read from here, so a
+-- reconnecting client can resume from the last event it saw.
+CREATE TABLE IF NOT EXISTS lumo_event (
+    -- Internal database ID, also the resume position of the event
+    id BIGSERIAL PRIMARY KEY,
+    -- Lumo the changed entity belongs to
+    lumo_id UUID NOT NULL,
+    -- Kind of entity that changed (LUME, LINK)
+    entity_type TEXT NOT NULL,
+    -- External UUID of the changed entity
+    entity_id UUID NOT NULL,
+    -- What happened to it (CREATED, UPDATED, DELETED)
+    event_type TEXT NOT NULL,
+    -- State of the entity after the change, or right before it was deleted
+    payload JSONB NOT NULL,
+    -- Audit timestamp
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Events go away together with their Lumo
+ALTER TABLE lumo_event
+    ADD CONSTRAINT fk_lumo_event_lumo
+    FOREIGN KEY (lumo_id)
+    REFERENCES lumo(lumo_id)
+    ON DELETE CASCADE;
+
+-- Watchers read the events of one Lumo in id order
+CREATE INDEX IF NOT EXISTS idx_lumo_event_lumo_id ON lumo_event (lumo_id, id);
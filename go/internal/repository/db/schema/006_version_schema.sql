@@ -0,0 +1,8 @@
+-- Optimistic concurrency
+-- Every Lumo, Lume and Link carries a version that is bumped on each update.
+-- Writers send the version they last read and the update only applies if it
+-- still matches, so concurrent edits are rejected instead of overwriting
+-- each other.
+ALTER TABLE lumo ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE lume ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE link ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
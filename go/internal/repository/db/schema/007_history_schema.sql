@@ -0,0 +1,29 @@
+-- Table: entity_history
+-- Append-only record of every change to a Lumo, its Lumes and its Links with
+-- a full snapshot of the entity, who made the change and when. It backs the
+-- history view and point-in-time restores, so it deliberately has no foreign
+-- key to lumo: the history of a deleted Lumo is kept.
+CREATE TABLE IF NOT EXISTS entity_history (
+    -- Internal database ID, orders changes made in the same instant
+    id BIGSERIAL PRIMARY KEY,
+    -- Lumo the changed entity belongs to (the Lumo itself for LUMO entries)
+    lumo_id UUID NOT NULL,
+    -- Kind of entity that changed (LUMO, LUME, LINK)
+    entity_type TEXT NOT NULL,
+    -- External UUID of the changed entity
+    entity_id UUID NOT NULL,
+    -- What happened to it (CREATED, UPDATED, DELETED)
+    operation TEXT NOT NULL,
+    -- Who made the change, if known
+    actor TEXT,
+    -- State of the entity after the change, or right before it was deleted
+    snapshot JSONB NOT NULL,
+    -- When the change happened
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- History of a single entity, newest first
+CREATE INDEX IF NOT EXISTS idx_entity_history_entity_id ON entity_history (entity_id, id);
+
+-- History of a Lumo and restores to a point in time
+CREATE INDEX IF NOT EXISTS idx_entity_history_lumo_id ON entity_history (lumo_id, created_at, id);
@@ -0,0 +1,23 @@
+-- Soft deletion
+-- Deleting a Lumo, Lume or Link moves it to the trash by setting deleted_at
+-- instead of removing the row. Reads filter trashed rows out, and the purger
+-- removes them for good once they are past the retention period.
+ALTER TABLE lumo ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ NULL;
+ALTER TABLE lume ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ NULL;
+ALTER TABLE link ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ NULL;
+
+-- Lumes and Links trashed because their Lumo or Lume was deleted remember
+-- who took them along, so they come back when it is restored. NULL when the
+-- entity was deleted directly.
+ALTER TABLE lume ADD COLUMN IF NOT EXISTS deleted_by UUID NULL;
+ALTER TABLE link ADD COLUMN IF NOT EXISTS deleted_by UUID NULL;
+
+-- A trashed Link shouldn't keep the same connection from being made again
+ALTER TABLE link DROP CONSTRAINT IF EXISTS link_from_lume_id_to_lume_id_link_type_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_link_connection ON link (from_lume_id, to_lume_id, link_type)
+    WHERE deleted_at IS NULL;
+
+-- Trash listings and the purger only look at trashed rows
+CREATE INDEX IF NOT EXISTS idx_lumo_deleted_at ON lumo (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_lume_deleted_at ON lume (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_link_deleted_at ON link (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,32 @@
+-- Table: lumo_member
+-- Users a Lumo is shared with and the role each of them has. The Lumo's
+-- creator (lumo.user_id) is a member too, always with the OWNER role.
+CREATE TABLE IF NOT EXISTS lumo_member (
+    -- Internal database ID
+    id BIGSERIAL PRIMARY KEY,
+    -- The shared Lumo
+    lumo_id UUID NOT NULL,
+    -- The user it is shared with
+    user_id UUID NOT NULL,
+    -- OWNER, EDITOR, COMMENTER or VIEWER
+    role TEXT NOT NULL CHECK (role IN ('OWNER', 'EDITOR', 'COMMENTER', 'VIEWER')),
+    -- System timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (lumo_id, user_id)
+);
+
+-- Members go away together with their Lumo
+ALTER TABLE lumo_member
+    ADD CONSTRAINT fk_lumo_member_lumo
+    FOREIGN KEY (lumo_id)
+    REFERENCES lumo(lumo_id)
+    ON DELETE CASCADE;
+
+-- ListLumos looks up the Lumos shared with a user
+CREATE INDEX IF NOT EXISTS idx_lumo_member_user_id ON lumo_member (user_id);
+
+-- Existing Lumos start out with their creator as the only member
+INSERT INTO lumo_member (lumo_id, user_id, role, created_at, updated_at)
+SELECT lumo_id, user_id, 'OWNER', created_at, created_at FROM lumo
+ON CONFLICT (lumo_id, user_id) DO NOTHING;
@@ -0,0 +1,35 @@
+-- Table: share_link
+-- Read-only links to a Lumo for people without an account
+CREATE TABLE IF NOT EXISTS share_link (
+    -- Internal database ID
+    id BIGSERIAL PRIMARY KEY,
+    -- Unique identifier (UUID)
+    share_link_id UUID NOT NULL UNIQUE,
+    -- The shared Lumo
+    lumo_id UUID NOT NULL,
+    -- SHA-256 of the token, the token itself is never stored
+    token_hash TEXT NOT NULL UNIQUE,
+    -- Redaction policy
+    hide_booking_links BOOLEAN NOT NULL DEFAULT FALSE,
+    hide_costs BOOLEAN NOT NULL DEFAULT FALSE,
+    hide_notes BOOLEAN NOT NULL DEFAULT FALSE,
+    -- The link stops working once it expires or is revoked
+    expires_at TIMESTAMPTZ NULL,
+    revoked_at TIMESTAMPTZ NULL,
+    -- Usage tracking
+    access_count BIGINT NOT NULL DEFAULT 0,
+    last_accessed_at TIMESTAMPTZ NULL,
+    -- User who created the link, NULL when authentication was disabled
+    created_by UUID NULL,
+    -- System timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Share links go away together with their Lumo
+ALTER TABLE share_link
+    ADD CONSTRAINT fk_share_link_lumo
+    FOREIGN KEY (lumo_id)
+    REFERENCES lumo(lumo_id)
+    ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_share_link_lumo_id ON share_link (lumo_id);
@@ -0,0 +1,37 @@
+-- Table: user
+-- People using Lumo. user_id is the subject of their bearer tokens.
+CREATE TABLE IF NOT EXISTS "user" (
+    -- Internal database ID
+    id BIGSERIAL PRIMARY KEY,
+    -- Unique identifier (UUID)
+    user_id UUID NOT NULL UNIQUE,
+    -- Stored lowercased. NULL for users backfilled below until they set one.
+    email TEXT NULL UNIQUE,
+    display_name TEXT NOT NULL DEFAULT '',
+    -- Preferences
+    home_currency TEXT NOT NULL DEFAULT 'USD',
+    distance_unit TEXT NOT NULL DEFAULT 'KILOMETERS' CHECK (distance_unit IN ('KILOMETERS', 'MILES')),
+    time_zone TEXT NOT NULL DEFAULT 'UTC',
+    locale TEXT NOT NULL DEFAULT 'en-US',
+    -- System timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Everyone who already created or was given a Lumo gets a user with the
+-- default preferences
+INSERT INTO "user" (user_id)
+SELECT user_id FROM lumo
+UNION
+SELECT user_id FROM lumo_member
+ON CONFLICT (user_id) DO NOTHING;
+
+-- A Lumo's creator must exist. Users are never deleted, so no cascade.
+ALTER TABLE lumo
+    ADD CONSTRAINT fk_lumo_user
+    FOREIGN KEY (user_id)
+    REFERENCES "user"(user_id);
+
+-- The time zone a Lume's dates are local to. Lumes created from now on get
+-- their creator's time zone unless they name one.
+ALTER TABLE lume ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC';
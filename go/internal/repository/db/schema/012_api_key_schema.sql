@@ -0,0 +1,44 @@
+-- Table: api_key
+-- Keys scripts and other services authenticate with on behalf of a user
+CREATE TABLE IF NOT EXISTS api_key (
+    -- Internal database ID
+    id BIGSERIAL PRIMARY KEY,
+    -- Unique identifier (UUID)
+    api_key_id UUID NOT NULL UNIQUE,
+    -- The user the key acts for
+    user_id UUID NOT NULL,
+    -- Free-form label
+    name TEXT NOT NULL,
+    -- First characters of the secret, to tell keys apart
+    prefix TEXT NOT NULL,
+    -- SHA-256 of the secret, the secret itself is never stored
+    secret_hash TEXT NOT NULL UNIQUE,
+    -- What the key may do, LUMO keys are limited to lumo_id
+    scope TEXT NOT NULL CHECK (scope IN ('READ_ONLY', 'LUMO', 'FULL')),
+    lumo_id UUID NULL,
+    -- Usage tracking
+    last_used_at TIMESTAMPTZ NULL,
+    -- The key stops working once it's revoked
+    revoked_at TIMESTAMPTZ NULL,
+    -- When the secret was last replaced
+    rotated_at TIMESTAMPTZ NULL,
+    -- System timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_api_key_lumo_scope CHECK ((scope = 'LUMO') = (lumo_id IS NOT NULL))
+);
+
+-- Keys go away together with their user or the Lumo they are limited to
+ALTER TABLE api_key
+    ADD CONSTRAINT fk_api_key_user
+    FOREIGN KEY (user_id)
+    REFERENCES "user"(user_id)
+    ON DELETE CASCADE;
+
+ALTER TABLE api_key
+    ADD CONSTRAINT fk_api_key_lumo
+    FOREIGN KEY (lumo_id)
+    REFERENCES lumo(lumo_id)
+    ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_api_key_user_id ON api_key (user_id);
@@ -0,0 +1,29 @@
+-- Table: audit_log
+-- Who called which mutating procedure, on what, and how it went
+CREATE TABLE IF NOT EXISTS audit_log (
+    -- Internal database ID
+    id BIGSERIAL PRIMARY KEY,
+    -- User who made the call, NULL when authentication was disabled
+    actor TEXT NULL,
+    -- API key the call was made with, NULL for bearer tokens
+    api_key_id UUID NULL,
+    -- Full Connect procedure, e.g. /lume.v1.LumeService/UpdateLume
+    procedure TEXT NOT NULL,
+    -- Lumo the call was about, NULL if it never got that far. Not a foreign
+    -- key, entries outlive their Lumo.
+    lumo_id UUID NULL,
+    -- IDs named in the request, and in the response if it succeeded
+    target_ids TEXT[] NOT NULL DEFAULT '{}',
+    -- SHA-256 of the request message
+    request_digest TEXT NOT NULL,
+    -- Connect code of the result, "ok" on success
+    code TEXT NOT NULL,
+    -- How long the call took, in microseconds
+    latency_us BIGINT NOT NULL,
+    -- When the call was made
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_lumo_id ON audit_log (lumo_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at);
@@ -0,0 +1,34 @@
+-- Table: outbox
+-- Domain events such as LumeCreated, written in the same transaction as the
+-- change they describe and published by the relay afterwards
+CREATE TABLE IF NOT EXISTS outbox (
+    -- Internal database ID, also the order events are published in
+    id BIGSERIAL PRIMARY KEY,
+    -- Public UUID of the event, so consumers can drop the ones delivered twice
+    event_id UUID NOT NULL UNIQUE,
+    -- What happened, e.g. LumeCreated, LinkUpdated or LumoDeleted
+    event_type TEXT NOT NULL,
+    -- Lumo the changed entity belongs to. Not a foreign key, events outlive
+    -- their Lumo.
+    lumo_id UUID NOT NULL,
+    -- UUID of the changed Lumo, Lume or Link
+    entity_id UUID NOT NULL,
+    -- Who made the change, NULL if unknown
+    actor TEXT NULL,
+    -- JSON state of the entity after the change, or right before it was deleted
+    payload JSONB NOT NULL,
+    -- PENDING until every sink accepted the event, FAILED once the relay gave up
+    status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'DELIVERED', 'FAILED')),
+    -- Delivery attempts so far
+    attempts INT NOT NULL DEFAULT 0,
+    -- When the relay may try (again), pushed out while a relay holds the event
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- Error of the last failed attempt
+    last_error TEXT NULL,
+    -- When the event was delivered
+    delivered_at TIMESTAMPTZ NULL,
+    -- When the change happened
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (next_attempt_at, id) WHERE status = 'PENDING';
@@ -0,0 +1,86 @@
+-- Table: webhook
+-- Endpoints the domain events of a Lumo, or of every Lumo of a user, are
+-- POSTed to
+CREATE TABLE IF NOT EXISTS webhook (
+    -- Internal database ID
+    id BIGSERIAL PRIMARY KEY,
+    -- Unique identifier (UUID)
+    webhook_id UUID NOT NULL UNIQUE,
+    -- The user who registered the endpoint, events are only sent for Lumos
+    -- they are a member of
+    user_id UUID NOT NULL,
+    -- The only Lumo whose events are sent, NULL for all of the user's Lumos
+    lumo_id UUID NULL,
+    url TEXT NOT NULL,
+    -- Free-form label
+    description TEXT NOT NULL DEFAULT '',
+    -- Key the payloads are signed with
+    secret TEXT NOT NULL,
+    -- Event filters, an empty list lets everything through
+    entity_types TEXT[] NOT NULL DEFAULT '{}',
+    lume_types TEXT[] NOT NULL DEFAULT '{}',
+    link_types TEXT[] NOT NULL DEFAULT '{}',
+    -- Failed attempts since the last successful one
+    consecutive_failures INT NOT NULL DEFAULT 0,
+    -- Set once the endpoint kept failing, nothing is sent until it's enabled again
+    disabled_at TIMESTAMPTZ NULL,
+    -- System timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Webhooks go away together with their user or Lumo
+ALTER TABLE webhook
+    ADD CONSTRAINT fk_webhook_user
+    FOREIGN KEY (user_id)
+    REFERENCES "user"(user_id)
+    ON DELETE CASCADE;
+
+ALTER TABLE webhook
+    ADD CONSTRAINT fk_webhook_lumo
+    FOREIGN KEY (lumo_id)
+    REFERENCES lumo(lumo_id)
+    ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_user_id ON webhook (user_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_lumo_id ON webhook (lumo_id);
+
+-- Table: webhook_delivery
+-- Every event sent, or to be sent, to a webhook
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    -- Internal database ID
+    id BIGSERIAL PRIMARY KEY,
+    -- Unique identifier (UUID)
+    delivery_id UUID NOT NULL UNIQUE,
+    webhook_id UUID NOT NULL,
+    -- The outbox event being delivered
+    event_id UUID NOT NULL,
+    event_type TEXT NOT NULL,
+    -- The JSON body that is POSTed
+    payload JSONB NOT NULL,
+    -- The delivery this one was manually requested to repeat
+    redelivery_of UUID NULL,
+    -- PENDING until the endpoint accepted it, FAILED once attempts ran out
+    status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'DELIVERED', 'FAILED')),
+    -- Delivery attempts so far
+    attempts INT NOT NULL DEFAULT 0,
+    -- When to try (again), pushed out while a worker holds the delivery
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- HTTP status of the last response, NULL if there was none
+    response_code INT NULL,
+    -- Error of the last failed attempt
+    last_error TEXT NULL,
+    delivered_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE webhook_delivery
+    ADD CONSTRAINT fk_webhook_delivery_webhook
+    FOREIGN KEY (webhook_id)
+    REFERENCES webhook(webhook_id)
+    ON DELETE CASCADE;
+
+-- An event is only queued once per webhook, however often it's published
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_delivery_event ON webhook_delivery (webhook_id, event_id) WHERE redelivery_of IS NULL;
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_webhook_id ON webhook_delivery (webhook_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_pending ON webhook_delivery (next_attempt_at, id) WHERE status = 'PENDING';
@@ -0,0 +1,10 @@
+-- Table: schema_version
+-- Records which schema files have been applied, so the server can tell
+-- whether the database is up to date before it reports itself ready. Every
+-- schema file from this one on ends by inserting its number.
+CREATE TABLE IF NOT EXISTS schema_version (
+    version INTEGER PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO schema_version (version) VALUES (16) ON CONFLICT DO NOTHING;
@@ -0,0 +1,28 @@
+-- Table: idempotency_key
+-- Responses of create calls made with an idempotency key, kept for a while so
+-- a retried call gets the same response instead of creating a duplicate
+CREATE TABLE IF NOT EXISTS idempotency_key (
+    -- Internal database ID
+    id BIGSERIAL PRIMARY KEY,
+    -- The calling user the key belongs to, '' when authentication is disabled
+    scope TEXT NOT NULL,
+    -- The key chosen by the client
+    key TEXT NOT NULL,
+    -- SHA-256 of the procedure and request, a retry must send the same request
+    request_hash BYTEA NOT NULL,
+    -- The serialized response, NULL while the first call is still running
+    response BYTEA NULL,
+    -- System timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- How long the call holding the key may run: a key still without a
+    -- response after this was left behind by a call that crashed, and a retry
+    -- can take it over
+    locked_until TIMESTAMPTZ NOT NULL,
+    -- After this the key is free to be used again and is purged
+    expires_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (scope, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_key_expires_at ON idempotency_key (expires_at);
+
+INSERT INTO schema_version (version) VALUES (17) ON CONFLICT DO NOTHING;
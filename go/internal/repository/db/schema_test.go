@@ -0,0 +1,32 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// SchemaTestSuite is a test suite for the schema version
+type SchemaTestSuite struct {
+	suite.Suite
+}
+
+// TestSchemaSuite runs the test suite
+func TestSchemaSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
+
+// Test the schema version is the number of the latest schema file, which
+// must record it
+func (s *SchemaTestSuite) TestSchemaVersion() {
+	entries, err := schemaFiles.ReadDir("schema")
+	s.Require().NoError(err)
+	latest := entries[len(entries)-1].Name()
+
+	content, err := schemaFiles.ReadFile("schema/" + latest)
+	s.Require().NoError(err)
+
+	s.GreaterOrEqual(SchemaVersion, 16)
+	s.Contains(string(content), fmt.Sprintf("INSERT INTO schema_version (version) VALUES (%d)", SchemaVersion))
+}
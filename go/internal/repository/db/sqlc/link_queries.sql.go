@@ -15,7 +15,7 @@ import (
 )
 
 const countLinksByFromLumeID = `-- name: CountLinksByFromLumeID :one
-SELECT COUNT(*) FROM link WHERE from_lume_id = $1
+SELECT COUNT(*) FROM link WHERE from_lume_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) CountLinksByFromLumeID(ctx context.Context, fromLumeID uuid.UUID) (int64, error) {
@@ -26,7 +26,7 @@ func (q *Queries) CountLinksByFromLumeID(ctx context.Context, fromLumeID uuid.UU
 }
 
 const countLinksByLumeID = `-- name: CountLinksByLumeID :one
-SELECT COUNT(*) FROM link WHERE from_lume_id = $1 OR to_lume_id = $1
+SELECT COUNT(*) FROM link WHERE (from_lume_id = $1 OR to_lume_id = $1) AND deleted_at IS NULL
 `
 
 func (q *Queries) CountLinksByLumeID(ctx context.Context, fromLumeID uuid.UUID) (int64, error) {
@@ -37,7 +37,7 @@ func (q *Queries) CountLinksByLumeID(ctx context.Context, fromLumeID uuid.UUID)
 }
 
 const countLinksByToLumeID = `-- name: CountLinksByToLumeID :one
-SELECT COUNT(*) FROM link WHERE to_lume_id = $1
+SELECT COUNT(*) FROM link WHERE to_lume_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) CountLinksByToLumeID(ctx context.Context, toLumeID uuid.UUID) (int64, error) {
@@ -53,8 +53,21 @@ INSERT INTO link (
     travel_details, notes, sequence_index, created_at, updated_at
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9
-) RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+)
+ON CONFLICT (link_id) DO UPDATE SET
+    from_lume_id = EXCLUDED.from_lume_id,
+    to_lume_id = EXCLUDED.to_lume_id,
+    link_type = EXCLUDED.link_type,
+    travel_details = EXCLUDED.travel_details,
+    notes = EXCLUDED.notes,
+    sequence_index = EXCLUDED.sequence_index,
+    updated_at = EXCLUDED.updated_at,
+    version = link.version + 1,
+    deleted_at = NULL,
+    deleted_by = NULL
+WHERE link.deleted_at IS NOT NULL
+RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 `
 
 type CreateLinkParams struct {
@@ -69,6 +82,7 @@ type CreateLinkParams struct {
 	UpdatedAt     time.Time             `json:"updated_at"`
 }
 
+// Creating a Link with the ID of one in the trash brings it back
 func (q *Queries) CreateLink(ctx context.Context, arg CreateLinkParams) (Link, error) {
 	row := q.db.QueryRowContext(ctx, createLink,
 		arg.LinkID,
@@ -93,32 +107,89 @@ func (q *Queries) CreateLink(ctx context.Context, arg CreateLinkParams) (Link, e
 		&i.SequenceIndex,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
-const deleteLink = `-- name: DeleteLink :exec
-DELETE FROM link WHERE id = $1
+const deleteLink = `-- name: DeleteLink :one
+UPDATE link SET deleted_at = $1::TIMESTAMPTZ
+WHERE id = $2 AND deleted_at IS NULL
+    AND ($3::BIGINT IS NULL OR version = $3)
+RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 `
 
-func (q *Queries) DeleteLink(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteLink, id)
-	return err
+type DeleteLinkParams struct {
+	DeletedAt       time.Time     `json:"deleted_at"`
+	ID              int64         `json:"id"`
+	ExpectedVersion sql.NullInt64 `json:"expected_version"`
+}
+
+// Moves the Link to the trash
+func (q *Queries) DeleteLink(ctx context.Context, arg DeleteLinkParams) (Link, error) {
+	row := q.db.QueryRowContext(ctx, deleteLink, arg.DeletedAt, arg.ID, arg.ExpectedVersion)
+	var i Link
+	err := row.Scan(
+		&i.ID,
+		&i.LinkID,
+		&i.FromLumeID,
+		&i.ToLumeID,
+		&i.LinkType,
+		&i.TravelDetails,
+		&i.Notes,
+		&i.SequenceIndex,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+	)
+	return i, err
 }
 
-const deleteLinkByLinkID = `-- name: DeleteLinkByLinkID :exec
-DELETE FROM link WHERE link_id = $1
+const deleteLinkByLinkID = `-- name: DeleteLinkByLinkID :one
+UPDATE link SET deleted_at = $1::TIMESTAMPTZ
+WHERE link_id = $2 AND deleted_at IS NULL
+    AND ($3::BIGINT IS NULL OR version = $3)
+RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 `
 
-func (q *Queries) DeleteLinkByLinkID(ctx context.Context, linkID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteLinkByLinkID, linkID)
-	return err
+type DeleteLinkByLinkIDParams struct {
+	DeletedAt       time.Time     `json:"deleted_at"`
+	LinkID          uuid.UUID     `json:"link_id"`
+	ExpectedVersion sql.NullInt64 `json:"expected_version"`
+}
+
+// Moves the Link to the trash
+func (q *Queries) DeleteLinkByLinkID(ctx context.Context, arg DeleteLinkByLinkIDParams) (Link, error) {
+	row := q.db.QueryRowContext(ctx, deleteLinkByLinkID, arg.DeletedAt, arg.LinkID, arg.ExpectedVersion)
+	var i Link
+	err := row.Scan(
+		&i.ID,
+		&i.LinkID,
+		&i.FromLumeID,
+		&i.ToLumeID,
+		&i.LinkType,
+		&i.TravelDetails,
+		&i.Notes,
+		&i.SequenceIndex,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+	)
+	return i, err
 }
 
 const getLinkByID = `-- name: GetLinkByID :one
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
-FROM link WHERE id = $1
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
+FROM link WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetLinkByID(ctx context.Context, id int64) (Link, error) {
@@ -135,14 +206,17 @@ func (q *Queries) GetLinkByID(ctx context.Context, id int64) (Link, error) {
 		&i.SequenceIndex,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
 const getLinkByLinkID = `-- name: GetLinkByLinkID :one
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
-FROM link WHERE link_id = $1
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
+FROM link WHERE link_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetLinkByLinkID(ctx context.Context, linkID uuid.UUID) (Link, error) {
@@ -159,15 +233,18 @@ func (q *Queries) GetLinkByLinkID(ctx context.Context, linkID uuid.UUID) (Link,
 		&i.SequenceIndex,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
 const listLinksByEitherLumeID = `-- name: ListLinksByEitherLumeID :many
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 FROM link 
-WHERE from_lume_id = $1 OR to_lume_id = $1
+WHERE (from_lume_id = $1 OR to_lume_id = $1) AND deleted_at IS NULL
 ORDER BY sequence_index ASC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -198,6 +275,9 @@ func (q *Queries) ListLinksByEitherLumeID(ctx context.Context, arg ListLinksByEi
 			&i.SequenceIndex,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -214,9 +294,9 @@ func (q *Queries) ListLinksByEitherLumeID(ctx context.Context, arg ListLinksByEi
 
 const listLinksByFromLumeID = `-- name: ListLinksByFromLumeID :many
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 FROM link 
-WHERE from_lume_id = $1
+WHERE from_lume_id = $1 AND deleted_at IS NULL
 ORDER BY sequence_index ASC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -247,6 +327,9 @@ func (q *Queries) ListLinksByFromLumeID(ctx context.Context, arg ListLinksByFrom
 			&i.SequenceIndex,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -263,9 +346,9 @@ func (q *Queries) ListLinksByFromLumeID(ctx context.Context, arg ListLinksByFrom
 
 const listLinksByLumeIDAndType = `-- name: ListLinksByLumeIDAndType :many
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 FROM link 
-WHERE (from_lume_id = $1 OR to_lume_id = $1) AND link_type = $2
+WHERE (from_lume_id = $1 OR to_lume_id = $1) AND link_type = $2 AND deleted_at IS NULL
 ORDER BY sequence_index ASC NULLS LAST, created_at DESC
 LIMIT $3 OFFSET $4
 `
@@ -302,6 +385,62 @@ func (q *Queries) ListLinksByLumeIDAndType(ctx context.Context, arg ListLinksByL
 			&i.SequenceIndex,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLinksByLumoID = `-- name: ListLinksByLumoID :many
+SELECT link.id, link.link_id, link.from_lume_id, link.to_lume_id, link.link_type,
+    link.travel_details, link.notes, link.sequence_index, link.created_at, link.updated_at, link.version, link.deleted_at, link.deleted_by
+FROM link
+JOIN lume ON lume.lume_id = link.from_lume_id
+WHERE lume.lumo_id = $1 AND link.deleted_at IS NULL
+ORDER BY link.sequence_index ASC NULLS LAST, link.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListLinksByLumoIDParams struct {
+	LumoID uuid.UUID `json:"lumo_id"`
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
+}
+
+func (q *Queries) ListLinksByLumoID(ctx context.Context, arg ListLinksByLumoIDParams) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listLinksByLumoID, arg.LumoID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Link
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkID,
+			&i.FromLumeID,
+			&i.ToLumeID,
+			&i.LinkType,
+			&i.TravelDetails,
+			&i.Notes,
+			&i.SequenceIndex,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -318,9 +457,9 @@ func (q *Queries) ListLinksByLumeIDAndType(ctx context.Context, arg ListLinksByL
 
 const listLinksByToLumeID = `-- name: ListLinksByToLumeID :many
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 FROM link 
-WHERE to_lume_id = $1
+WHERE to_lume_id = $1 AND deleted_at IS NULL
 ORDER BY sequence_index ASC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -351,6 +490,9 @@ func (q *Queries) ListLinksByToLumeID(ctx context.Context, arg ListLinksByToLume
 			&i.SequenceIndex,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -367,9 +509,9 @@ func (q *Queries) ListLinksByToLumeID(ctx context.Context, arg ListLinksByToLume
 
 const listLinksByType = `-- name: ListLinksByType :many
 SELECT id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 FROM link 
-WHERE link_type = $1
+WHERE link_type = $1 AND deleted_at IS NULL
 ORDER BY sequence_index ASC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -400,6 +542,9 @@ func (q *Queries) ListLinksByType(ctx context.Context, arg ListLinksByTypeParams
 			&i.SequenceIndex,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -414,6 +559,167 @@ func (q *Queries) ListLinksByType(ctx context.Context, arg ListLinksByTypeParams
 	return items, nil
 }
 
+const listTrashedLinksByLumoID = `-- name: ListTrashedLinksByLumoID :many
+SELECT link.id, link.link_id, link.from_lume_id, link.to_lume_id, link.link_type,
+    link.travel_details, link.notes, link.sequence_index, link.created_at, link.updated_at, link.version, link.deleted_at, link.deleted_by
+FROM link
+JOIN lume ON lume.lume_id = link.from_lume_id
+WHERE lume.lumo_id = $1 AND link.deleted_at IS NOT NULL AND link.deleted_by IS NULL
+ORDER BY link.deleted_at DESC, link.id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListTrashedLinksByLumoIDParams struct {
+	LumoID uuid.UUID `json:"lumo_id"`
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
+}
+
+// Links of a Lumo that were deleted directly, most recently deleted first
+func (q *Queries) ListTrashedLinksByLumoID(ctx context.Context, arg ListTrashedLinksByLumoIDParams) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, listTrashedLinksByLumoID, arg.LumoID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Link
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkID,
+			&i.FromLumeID,
+			&i.ToLumeID,
+			&i.LinkType,
+			&i.TravelDetails,
+			&i.Notes,
+			&i.SequenceIndex,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreLinkByLinkID = `-- name: RestoreLinkByLinkID :one
+UPDATE link SET deleted_at = NULL, deleted_by = NULL
+WHERE link_id = $1 AND deleted_at IS NOT NULL
+RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
+`
+
+// Takes a Link out of the trash
+func (q *Queries) RestoreLinkByLinkID(ctx context.Context, linkID uuid.UUID) (Link, error) {
+	row := q.db.QueryRowContext(ctx, restoreLinkByLinkID, linkID)
+	var i Link
+	err := row.Scan(
+		&i.ID,
+		&i.LinkID,
+		&i.FromLumeID,
+		&i.ToLumeID,
+		&i.LinkType,
+		&i.TravelDetails,
+		&i.Notes,
+		&i.SequenceIndex,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+	)
+	return i, err
+}
+
+const restoreLinksByLumeID = `-- name: RestoreLinksByLumeID :exec
+UPDATE link SET deleted_at = NULL, deleted_by = NULL
+WHERE (link.from_lume_id = $1 OR link.to_lume_id = $1)
+    AND link.deleted_by IS NOT NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM lume
+        WHERE lume.lume_id IN (link.from_lume_id, link.to_lume_id) AND lume.deleted_at IS NOT NULL
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM link AS live
+        WHERE live.from_lume_id = link.from_lume_id AND live.to_lume_id = link.to_lume_id
+            AND live.link_type = link.link_type AND live.deleted_at IS NULL
+    )
+`
+
+// Brings back the Links trashed together with a Lume once both of their
+// Lumes are out of the trash, unless the connection was made again since
+func (q *Queries) RestoreLinksByLumeID(ctx context.Context, fromLumeID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, restoreLinksByLumeID, fromLumeID)
+	return err
+}
+
+const restoreLinksByLumoID = `-- name: RestoreLinksByLumoID :exec
+UPDATE link SET deleted_at = NULL, deleted_by = NULL
+WHERE link.from_lume_id IN (SELECT lume_id FROM lume WHERE lume.lumo_id = $1)
+    AND link.deleted_by IS NOT NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM lume
+        WHERE lume.lume_id IN (link.from_lume_id, link.to_lume_id) AND lume.deleted_at IS NOT NULL
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM link AS live
+        WHERE live.from_lume_id = link.from_lume_id AND live.to_lume_id = link.to_lume_id
+            AND live.link_type = link.link_type AND live.deleted_at IS NULL
+    )
+`
+
+// Brings back the Links trashed together with a Lumo or its Lumes once both
+// of their Lumes are out of the trash, unless the connection was made again
+// since
+func (q *Queries) RestoreLinksByLumoID(ctx context.Context, lumoID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, restoreLinksByLumoID, lumoID)
+	return err
+}
+
+const trashLinksByLumeID = `-- name: TrashLinksByLumeID :exec
+UPDATE link SET deleted_at = $1::TIMESTAMPTZ, deleted_by = $2::UUID
+WHERE (from_lume_id = $2 OR to_lume_id = $2) AND deleted_at IS NULL
+`
+
+type TrashLinksByLumeIDParams struct {
+	DeletedAt time.Time `json:"deleted_at"`
+	LumeID    uuid.UUID `json:"lume_id"`
+}
+
+// Moves the Links of a Lume that is being trashed along with it
+func (q *Queries) TrashLinksByLumeID(ctx context.Context, arg TrashLinksByLumeIDParams) error {
+	_, err := q.db.ExecContext(ctx, trashLinksByLumeID, arg.DeletedAt, arg.LumeID)
+	return err
+}
+
+const trashLinksByLumoID = `-- name: TrashLinksByLumoID :exec
+UPDATE link SET deleted_at = $1::TIMESTAMPTZ, deleted_by = $2::UUID
+WHERE deleted_at IS NULL
+    AND from_lume_id IN (SELECT lume_id FROM lume WHERE lumo_id = $2)
+`
+
+type TrashLinksByLumoIDParams struct {
+	DeletedAt time.Time `json:"deleted_at"`
+	LumoID    uuid.UUID `json:"lumo_id"`
+}
+
+// Moves the Links of a Lumo that is being trashed along with it
+func (q *Queries) TrashLinksByLumoID(ctx context.Context, arg TrashLinksByLumoIDParams) error {
+	_, err := q.db.ExecContext(ctx, trashLinksByLumoID, arg.DeletedAt, arg.LumoID)
+	return err
+}
+
 const updateLink = `-- name: UpdateLink :one
 UPDATE link SET
     from_lume_id = $2,
@@ -422,10 +728,11 @@ UPDATE link SET
     travel_details = $5,
     notes = $6,
     sequence_index = $7,
-    updated_at = $8
-WHERE link_id = $1
+    updated_at = $8,
+    version = version + 1
+WHERE link_id = $1 AND version = $9 AND deleted_at IS NULL
 RETURNING id, link_id, from_lume_id, to_lume_id, link_type,
-    travel_details, notes, sequence_index, created_at, updated_at
+    travel_details, notes, sequence_index, created_at, updated_at, version, deleted_at, deleted_by
 `
 
 type UpdateLinkParams struct {
@@ -437,6 +744,7 @@ type UpdateLinkParams struct {
 	Notes         sql.NullString        `json:"notes"`
 	SequenceIndex sql.NullInt32         `json:"sequence_index"`
 	UpdatedAt     time.Time             `json:"updated_at"`
+	Version       int64                 `json:"version"`
 }
 
 func (q *Queries) UpdateLink(ctx context.Context, arg UpdateLinkParams) (Link, error) {
@@ -449,6 +757,7 @@ func (q *Queries) UpdateLink(ctx context.Context, arg UpdateLinkParams) (Link, e
 		arg.Notes,
 		arg.SequenceIndex,
 		arg.UpdatedAt,
+		arg.Version,
 	)
 	var i Link
 	err := row.Scan(
@@ -462,6 +771,9 @@ func (q *Queries) UpdateLink(ctx context.Context, arg UpdateLinkParams) (Link, e
 		&i.SequenceIndex,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
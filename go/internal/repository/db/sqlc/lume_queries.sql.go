@@ -15,7 +15,7 @@ import (
 )
 
 const countLumesByLumo = `-- name: CountLumesByLumo :one
-SELECT COUNT(*) FROM lume WHERE lumo_id = $1
+SELECT COUNT(*) FROM lume WHERE lumo_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) CountLumesByLumo(ctx context.Context, lumoID uuid.UUID) (int64, error) {
@@ -30,13 +30,33 @@ INSERT INTO lume (
     lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
+    booking_link, created_at, updated_at, time_zone
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
-) RETURNING id, lume_id, lumo_id, type, name,
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+)
+ON CONFLICT (lume_id) DO UPDATE SET
+    lumo_id = EXCLUDED.lumo_id,
+    type = EXCLUDED.type,
+    name = EXCLUDED.name,
+    date_start = EXCLUDED.date_start,
+    date_end = EXCLUDED.date_end,
+    latitude = EXCLUDED.latitude,
+    longitude = EXCLUDED.longitude,
+    address = EXCLUDED.address,
+    description = EXCLUDED.description,
+    images = EXCLUDED.images,
+    category_tags = EXCLUDED.category_tags,
+    booking_link = EXCLUDED.booking_link,
+    updated_at = EXCLUDED.updated_at,
+    time_zone = EXCLUDED.time_zone,
+    version = lume.version + 1,
+    deleted_at = NULL,
+    deleted_by = NULL
+WHERE lume.deleted_at IS NOT NULL
+RETURNING id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
 `
 
 type CreateLumeParams struct {
@@ -55,8 +75,10 @@ type CreateLumeParams struct {
 	BookingLink  sql.NullString  `json:"booking_link"`
 	CreatedAt    time.Time       `json:"created_at"`
 	UpdatedAt    time.Time       `json:"updated_at"`
+	TimeZone     string          `json:"time_zone"`
 }
 
+// Creating a Lume with the ID of one in the trash brings it back
 func (q *Queries) CreateLume(ctx context.Context, arg CreateLumeParams) (Lume, error) {
 	row := q.db.QueryRowContext(ctx, createLume,
 		arg.LumeID,
@@ -74,6 +96,7 @@ func (q *Queries) CreateLume(ctx context.Context, arg CreateLumeParams) (Lume, e
 		arg.BookingLink,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.TimeZone,
 	)
 	var i Lume
 	err := row.Scan(
@@ -93,34 +116,110 @@ func (q *Queries) CreateLume(ctx context.Context, arg CreateLumeParams) (Lume, e
 		&i.BookingLink,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.TimeZone,
 	)
 	return i, err
 }
 
-const deleteLume = `-- name: DeleteLume :exec
-DELETE FROM lume WHERE id = $1
+const deleteLume = `-- name: DeleteLume :one
+UPDATE lume SET deleted_at = $1::TIMESTAMPTZ
+WHERE id = $2 AND deleted_at IS NULL
+    AND ($3::BIGINT IS NULL OR version = $3)
+RETURNING id, lume_id, lumo_id, type, name,
+    date_start, date_end, latitude, longitude,
+    address, description, images, category_tags,
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
 `
 
-func (q *Queries) DeleteLume(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteLume, id)
-	return err
+type DeleteLumeParams struct {
+	DeletedAt       time.Time     `json:"deleted_at"`
+	ID              int64         `json:"id"`
+	ExpectedVersion sql.NullInt64 `json:"expected_version"`
 }
 
-const deleteLumeByLumeID = `-- name: DeleteLumeByLumeID :exec
-DELETE FROM lume WHERE lume_id = $1
+// Moves the Lume to the trash
+func (q *Queries) DeleteLume(ctx context.Context, arg DeleteLumeParams) (Lume, error) {
+	row := q.db.QueryRowContext(ctx, deleteLume, arg.DeletedAt, arg.ID, arg.ExpectedVersion)
+	var i Lume
+	err := row.Scan(
+		&i.ID,
+		&i.LumeID,
+		&i.LumoID,
+		&i.Type,
+		&i.Name,
+		&i.DateStart,
+		&i.DateEnd,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Address,
+		&i.Description,
+		&i.Images,
+		&i.CategoryTags,
+		&i.BookingLink,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
+const deleteLumeByLumeID = `-- name: DeleteLumeByLumeID :one
+UPDATE lume SET deleted_at = $1::TIMESTAMPTZ
+WHERE lume_id = $2 AND deleted_at IS NULL
+    AND ($3::BIGINT IS NULL OR version = $3)
+RETURNING id, lume_id, lumo_id, type, name,
+    date_start, date_end, latitude, longitude,
+    address, description, images, category_tags,
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
 `
 
-func (q *Queries) DeleteLumeByLumeID(ctx context.Context, lumeID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteLumeByLumeID, lumeID)
-	return err
+type DeleteLumeByLumeIDParams struct {
+	DeletedAt       time.Time     `json:"deleted_at"`
+	LumeID          uuid.UUID     `json:"lume_id"`
+	ExpectedVersion sql.NullInt64 `json:"expected_version"`
+}
+
+// Moves the Lume to the trash
+func (q *Queries) DeleteLumeByLumeID(ctx context.Context, arg DeleteLumeByLumeIDParams) (Lume, error) {
+	row := q.db.QueryRowContext(ctx, deleteLumeByLumeID, arg.DeletedAt, arg.LumeID, arg.ExpectedVersion)
+	var i Lume
+	err := row.Scan(
+		&i.ID,
+		&i.LumeID,
+		&i.LumoID,
+		&i.Type,
+		&i.Name,
+		&i.DateStart,
+		&i.DateEnd,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Address,
+		&i.Description,
+		&i.Images,
+		&i.CategoryTags,
+		&i.BookingLink,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.TimeZone,
+	)
+	return i, err
 }
 
 const getLumeByID = `-- name: GetLumeByID :one
 SELECT id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
-FROM lume WHERE id = $1
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
+FROM lume WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetLumeByID(ctx context.Context, id int64) (Lume, error) {
@@ -143,6 +242,10 @@ func (q *Queries) GetLumeByID(ctx context.Context, id int64) (Lume, error) {
 		&i.BookingLink,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.TimeZone,
 	)
 	return i, err
 }
@@ -151,8 +254,8 @@ const getLumeByLumeID = `-- name: GetLumeByLumeID :one
 SELECT id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
-FROM lume WHERE lume_id = $1
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
+FROM lume WHERE lume_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetLumeByLumeID(ctx context.Context, lumeID uuid.UUID) (Lume, error) {
@@ -175,17 +278,45 @@ func (q *Queries) GetLumeByLumeID(ctx context.Context, lumeID uuid.UUID) (Lume,
 		&i.BookingLink,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.TimeZone,
 	)
 	return i, err
 }
 
+const getLumoIDByLumeID = `-- name: GetLumoIDByLumeID :one
+SELECT lumo_id FROM lume WHERE lume_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetLumoIDByLumeID(ctx context.Context, lumeID uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, getLumoIDByLumeID, lumeID)
+	var lumo_id uuid.UUID
+	err := row.Scan(&lumo_id)
+	return lumo_id, err
+}
+
+const isLumeTrashed = `-- name: IsLumeTrashed :one
+SELECT EXISTS (
+    SELECT 1 FROM lume WHERE lume_id = $1 AND deleted_at IS NOT NULL
+)
+`
+
+func (q *Queries) IsLumeTrashed(ctx context.Context, lumeID uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isLumeTrashed, lumeID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const listLumesByLumoID = `-- name: ListLumesByLumoID :many
 SELECT id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
 FROM lume 
-WHERE lumo_id = $1
+WHERE lumo_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -222,6 +353,10 @@ func (q *Queries) ListLumesByLumoID(ctx context.Context, arg ListLumesByLumoIDPa
 			&i.BookingLink,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.TimeZone,
 		); err != nil {
 			return nil, err
 		}
@@ -240,9 +375,9 @@ const listLumesByType = `-- name: ListLumesByType :many
 SELECT id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
 FROM lume 
-WHERE lumo_id = $1 AND type = $2
+WHERE lumo_id = $1 AND type = $2 AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $3 OFFSET $4
 `
@@ -285,6 +420,10 @@ func (q *Queries) ListLumesByType(ctx context.Context, arg ListLumesByTypeParams
 			&i.BookingLink,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.TimeZone,
 		); err != nil {
 			return nil, err
 		}
@@ -299,13 +438,125 @@ func (q *Queries) ListLumesByType(ctx context.Context, arg ListLumesByTypeParams
 	return items, nil
 }
 
+const listTrashedLumesByLumoID = `-- name: ListTrashedLumesByLumoID :many
+SELECT id, lume_id, lumo_id, type, name,
+    date_start, date_end, latitude, longitude,
+    address, description, images, category_tags,
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
+FROM lume
+WHERE lumo_id = $1 AND deleted_at IS NOT NULL AND deleted_by IS NULL
+ORDER BY deleted_at DESC, id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListTrashedLumesByLumoIDParams struct {
+	LumoID uuid.UUID `json:"lumo_id"`
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
+}
+
+// Lumes of a Lumo that were deleted directly, most recently deleted first
+func (q *Queries) ListTrashedLumesByLumoID(ctx context.Context, arg ListTrashedLumesByLumoIDParams) ([]Lume, error) {
+	rows, err := q.db.QueryContext(ctx, listTrashedLumesByLumoID, arg.LumoID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Lume
+	for rows.Next() {
+		var i Lume
+		if err := rows.Scan(
+			&i.ID,
+			&i.LumeID,
+			&i.LumoID,
+			&i.Type,
+			&i.Name,
+			&i.DateStart,
+			&i.DateEnd,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Address,
+			&i.Description,
+			&i.Images,
+			&i.CategoryTags,
+			&i.BookingLink,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.TimeZone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreLumeByLumeID = `-- name: RestoreLumeByLumeID :one
+UPDATE lume SET deleted_at = NULL, deleted_by = NULL
+WHERE lume_id = $1 AND deleted_at IS NOT NULL
+RETURNING id, lume_id, lumo_id, type, name,
+    date_start, date_end, latitude, longitude,
+    address, description, images, category_tags,
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
+`
+
+// Takes a Lume out of the trash
+func (q *Queries) RestoreLumeByLumeID(ctx context.Context, lumeID uuid.UUID) (Lume, error) {
+	row := q.db.QueryRowContext(ctx, restoreLumeByLumeID, lumeID)
+	var i Lume
+	err := row.Scan(
+		&i.ID,
+		&i.LumeID,
+		&i.LumoID,
+		&i.Type,
+		&i.Name,
+		&i.DateStart,
+		&i.DateEnd,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Address,
+		&i.Description,
+		&i.Images,
+		&i.CategoryTags,
+		&i.BookingLink,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
+const restoreLumesByLumoID = `-- name: RestoreLumesByLumoID :exec
+UPDATE lume SET deleted_at = NULL, deleted_by = NULL
+WHERE lumo_id = $1 AND deleted_by = $1
+`
+
+// Brings back the Lumes that were trashed together with their Lumo
+func (q *Queries) RestoreLumesByLumoID(ctx context.Context, lumoID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, restoreLumesByLumoID, lumoID)
+	return err
+}
+
 const searchLumesByLocation = `-- name: SearchLumesByLocation :many
 SELECT id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
 FROM lume 
-WHERE lumo_id = $1 
+WHERE lumo_id = $1
+    AND deleted_at IS NULL
     AND latitude IS NOT NULL 
     AND longitude IS NOT NULL
     AND latitude BETWEEN $2 AND $3
@@ -358,6 +609,10 @@ func (q *Queries) SearchLumesByLocation(ctx context.Context, arg SearchLumesByLo
 			&i.BookingLink,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.TimeZone,
 		); err != nil {
 			return nil, err
 		}
@@ -372,6 +627,22 @@ func (q *Queries) SearchLumesByLocation(ctx context.Context, arg SearchLumesByLo
 	return items, nil
 }
 
+const trashLumesByLumoID = `-- name: TrashLumesByLumoID :exec
+UPDATE lume SET deleted_at = $1::TIMESTAMPTZ, deleted_by = $2::UUID
+WHERE lumo_id = $2 AND deleted_at IS NULL
+`
+
+type TrashLumesByLumoIDParams struct {
+	DeletedAt time.Time `json:"deleted_at"`
+	LumoID    uuid.UUID `json:"lumo_id"`
+}
+
+// Moves the Lumes of a Lumo that is being trashed along with it
+func (q *Queries) TrashLumesByLumoID(ctx context.Context, arg TrashLumesByLumoIDParams) error {
+	_, err := q.db.ExecContext(ctx, trashLumesByLumoID, arg.DeletedAt, arg.LumoID)
+	return err
+}
+
 const updateLume = `-- name: UpdateLume :one
 UPDATE lume SET
     name = $2,
@@ -385,12 +656,14 @@ UPDATE lume SET
     images = $10,
     category_tags = $11,
     booking_link = $12,
-    updated_at = $13
-WHERE lume_id = $1
+    updated_at = $13,
+    time_zone = $15,
+    version = version + 1
+WHERE lume_id = $1 AND version = $14 AND deleted_at IS NULL
 RETURNING id, lume_id, lumo_id, type, name,
     date_start, date_end, latitude, longitude,
     address, description, images, category_tags,
-    booking_link, created_at, updated_at
+    booking_link, created_at, updated_at, version, deleted_at, deleted_by, time_zone
 `
 
 type UpdateLumeParams struct {
@@ -407,6 +680,8 @@ type UpdateLumeParams struct {
 	CategoryTags []string        `json:"category_tags"`
 	BookingLink  sql.NullString  `json:"booking_link"`
 	UpdatedAt    time.Time       `json:"updated_at"`
+	Version      int64           `json:"version"`
+	TimeZone     string          `json:"time_zone"`
 }
 
 func (q *Queries) UpdateLume(ctx context.Context, arg UpdateLumeParams) (Lume, error) {
@@ -424,6 +699,8 @@ func (q *Queries) UpdateLume(ctx context.Context, arg UpdateLumeParams) (Lume, e
 		pq.Array(arg.CategoryTags),
 		arg.BookingLink,
 		arg.UpdatedAt,
+		arg.Version,
+		arg.TimeZone,
 	)
 	var i Lume
 	err := row.Scan(
@@ -443,6 +720,10 @@ func (q *Queries) UpdateLume(ctx context.Context, arg UpdateLumeParams) (Lume, e
 		&i.BookingLink,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.TimeZone,
 	)
 	return i, err
 }
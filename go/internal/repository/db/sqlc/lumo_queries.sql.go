@@ -7,13 +7,16 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 const countLumosByUserID = `-- name: CountLumosByUserID :one
-SELECT COUNT(*) FROM lumo WHERE user_id = $1
+SELECT COUNT(*) FROM lumo
+WHERE (user_id = $1 OR lumo_id IN (SELECT lumo_id FROM lumo_member WHERE lumo_member.user_id = $1))
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) CountLumosByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
@@ -23,12 +26,32 @@ func (q *Queries) CountLumosByUserID(ctx context.Context, userID uuid.UUID) (int
 	return count, err
 }
 
+const countLumosOwnedByUser = `-- name: CountLumosOwnedByUser :one
+SELECT COUNT(*) FROM lumo WHERE user_id = $1 AND deleted_at IS NULL
+`
+
+// Counts the Lumos a user created that are not in the trash
+func (q *Queries) CountLumosOwnedByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countLumosOwnedByUser, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createLumo = `-- name: CreateLumo :one
 INSERT INTO lumo (
     lumo_id, user_id, title, created_at, updated_at
 ) VALUES (
     $1, $2, $3, $4, $5
-) RETURNING id, lumo_id, user_id, title, created_at, updated_at
+)
+ON CONFLICT (lumo_id) DO UPDATE SET
+    user_id = EXCLUDED.user_id,
+    title = EXCLUDED.title,
+    updated_at = EXCLUDED.updated_at,
+    version = lumo.version + 1,
+    deleted_at = NULL
+WHERE lumo.deleted_at IS NOT NULL
+RETURNING id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
 `
 
 type CreateLumoParams struct {
@@ -39,6 +62,7 @@ type CreateLumoParams struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// Creating a Lumo with the ID of one in the trash brings it back
 func (q *Queries) CreateLumo(ctx context.Context, arg CreateLumoParams) (Lumo, error) {
 	row := q.db.QueryRowContext(ctx, createLumo,
 		arg.LumoID,
@@ -55,31 +79,75 @@ func (q *Queries) CreateLumo(ctx context.Context, arg CreateLumoParams) (Lumo, e
 		&i.Title,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const deleteLumo = `-- name: DeleteLumo :exec
-DELETE FROM lumo WHERE id = $1
+const deleteLumo = `-- name: DeleteLumo :one
+UPDATE lumo SET deleted_at = $1::TIMESTAMPTZ
+WHERE id = $2 AND deleted_at IS NULL
+    AND ($3::BIGINT IS NULL OR version = $3)
+RETURNING id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
 `
 
-func (q *Queries) DeleteLumo(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteLumo, id)
-	return err
+type DeleteLumoParams struct {
+	DeletedAt       time.Time     `json:"deleted_at"`
+	ID              int64         `json:"id"`
+	ExpectedVersion sql.NullInt64 `json:"expected_version"`
 }
 
-const deleteLumoByLumoID = `-- name: DeleteLumoByLumoID :exec
-DELETE FROM lumo WHERE lumo_id = $1
+// Moves the Lumo to the trash
+func (q *Queries) DeleteLumo(ctx context.Context, arg DeleteLumoParams) (Lumo, error) {
+	row := q.db.QueryRowContext(ctx, deleteLumo, arg.DeletedAt, arg.ID, arg.ExpectedVersion)
+	var i Lumo
+	err := row.Scan(
+		&i.ID,
+		&i.LumoID,
+		&i.UserID,
+		&i.Title,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const deleteLumoByLumoID = `-- name: DeleteLumoByLumoID :one
+UPDATE lumo SET deleted_at = $1::TIMESTAMPTZ
+WHERE lumo_id = $2 AND deleted_at IS NULL
+    AND ($3::BIGINT IS NULL OR version = $3)
+RETURNING id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
 `
 
-func (q *Queries) DeleteLumoByLumoID(ctx context.Context, lumoID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteLumoByLumoID, lumoID)
-	return err
+type DeleteLumoByLumoIDParams struct {
+	DeletedAt       time.Time     `json:"deleted_at"`
+	LumoID          uuid.UUID     `json:"lumo_id"`
+	ExpectedVersion sql.NullInt64 `json:"expected_version"`
+}
+
+// Moves the Lumo to the trash
+func (q *Queries) DeleteLumoByLumoID(ctx context.Context, arg DeleteLumoByLumoIDParams) (Lumo, error) {
+	row := q.db.QueryRowContext(ctx, deleteLumoByLumoID, arg.DeletedAt, arg.LumoID, arg.ExpectedVersion)
+	var i Lumo
+	err := row.Scan(
+		&i.ID,
+		&i.LumoID,
+		&i.UserID,
+		&i.Title,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+	)
+	return i, err
 }
 
 const getLumoByID = `-- name: GetLumoByID :one
-SELECT id, lumo_id, user_id, title, created_at, updated_at
-FROM lumo WHERE id = $1
+SELECT id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
+FROM lumo WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetLumoByID(ctx context.Context, id int64) (Lumo, error) {
@@ -92,13 +160,15 @@ func (q *Queries) GetLumoByID(ctx context.Context, id int64) (Lumo, error) {
 		&i.Title,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getLumoByLumoID = `-- name: GetLumoByLumoID :one
-SELECT id, lumo_id, user_id, title, created_at, updated_at
-FROM lumo WHERE lumo_id = $1
+SELECT id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
+FROM lumo WHERE lumo_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetLumoByLumoID(ctx context.Context, lumoID uuid.UUID) (Lumo, error) {
@@ -111,14 +181,30 @@ func (q *Queries) GetLumoByLumoID(ctx context.Context, lumoID uuid.UUID) (Lumo,
 		&i.Title,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
+const isLumoTrashed = `-- name: IsLumoTrashed :one
+SELECT EXISTS (
+    SELECT 1 FROM lumo WHERE lumo_id = $1 AND deleted_at IS NOT NULL
+)
+`
+
+func (q *Queries) IsLumoTrashed(ctx context.Context, lumoID uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isLumoTrashed, lumoID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const listLumosByUserID = `-- name: ListLumosByUserID :many
-SELECT id, lumo_id, user_id, title, created_at, updated_at
+SELECT id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
 FROM lumo 
-WHERE user_id = $1
+WHERE (user_id = $1 OR lumo_id IN (SELECT lumo_id FROM lumo_member WHERE lumo_member.user_id = $1))
+    AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -129,6 +215,7 @@ type ListLumosByUserIDParams struct {
 	Offset int32     `json:"offset"`
 }
 
+// Lumos a user created or that are shared with them
 func (q *Queries) ListLumosByUserID(ctx context.Context, arg ListLumosByUserIDParams) ([]Lumo, error) {
 	rows, err := q.db.QueryContext(ctx, listLumosByUserID, arg.UserID, arg.Limit, arg.Offset)
 	if err != nil {
@@ -145,6 +232,55 @@ func (q *Queries) ListLumosByUserID(ctx context.Context, arg ListLumosByUserIDPa
 			&i.Title,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrashedLumosByUserID = `-- name: ListTrashedLumosByUserID :many
+SELECT id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
+FROM lumo
+WHERE user_id = $1 AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListTrashedLumosByUserIDParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
+}
+
+// Lumos of a user in the trash, most recently deleted first
+func (q *Queries) ListTrashedLumosByUserID(ctx context.Context, arg ListTrashedLumosByUserIDParams) ([]Lumo, error) {
+	rows, err := q.db.QueryContext(ctx, listTrashedLumosByUserID, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Lumo
+	for rows.Next() {
+		var i Lumo
+		if err := rows.Scan(
+			&i.ID,
+			&i.LumoID,
+			&i.UserID,
+			&i.Title,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -159,22 +295,62 @@ func (q *Queries) ListLumosByUserID(ctx context.Context, arg ListLumosByUserIDPa
 	return items, nil
 }
 
+const lockLumosOfUser = `-- name: LockLumosOfUser :exec
+SELECT pg_advisory_xact_lock(hashtextextended('lumos_of_user:' || $1::text, 0))
+`
+
+// Serializes the creation of a user's Lumos until commit, for their quota
+func (q *Queries) LockLumosOfUser(ctx context.Context, userID string) error {
+	_, err := q.db.ExecContext(ctx, lockLumosOfUser, userID)
+	return err
+}
+
+const restoreLumoByLumoID = `-- name: RestoreLumoByLumoID :one
+UPDATE lumo SET deleted_at = NULL
+WHERE lumo_id = $1 AND deleted_at IS NOT NULL
+RETURNING id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
+`
+
+// Takes a Lumo out of the trash
+func (q *Queries) RestoreLumoByLumoID(ctx context.Context, lumoID uuid.UUID) (Lumo, error) {
+	row := q.db.QueryRowContext(ctx, restoreLumoByLumoID, lumoID)
+	var i Lumo
+	err := row.Scan(
+		&i.ID,
+		&i.LumoID,
+		&i.UserID,
+		&i.Title,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
 const updateLumo = `-- name: UpdateLumo :one
 UPDATE lumo SET
     title = $2,
-    updated_at = $3
-WHERE lumo_id = $1
-RETURNING id, lumo_id, user_id, title, created_at, updated_at
+    updated_at = $3,
+    version = version + 1
+WHERE lumo_id = $1 AND version = $4 AND deleted_at IS NULL
+RETURNING id, lumo_id, user_id, title, created_at, updated_at, version, deleted_at
 `
 
 type UpdateLumoParams struct {
 	LumoID    uuid.UUID `json:"lumo_id"`
 	Title     string    `json:"title"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Version   int64     `json:"version"`
 }
 
 func (q *Queries) UpdateLumo(ctx context.Context, arg UpdateLumoParams) (Lumo, error) {
-	row := q.db.QueryRowContext(ctx, updateLumo, arg.LumoID, arg.Title, arg.UpdatedAt)
+	row := q.db.QueryRowContext(ctx, updateLumo,
+		arg.LumoID,
+		arg.Title,
+		arg.UpdatedAt,
+		arg.Version,
+	)
 	var i Lumo
 	err := row.Scan(
 		&i.ID,
@@ -183,6 +359,8 @@ func (q *Queries) UpdateLumo(ctx context.Context, arg UpdateLumoParams) (Lumo, e
 		&i.Title,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
 	)
 	return i, err
 }
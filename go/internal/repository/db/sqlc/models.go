@@ -6,12 +6,64 @@ package sqlc
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
 
+type ApiKey struct {
+	ID         int64         `json:"id"`
+	ApiKeyID   uuid.UUID     `json:"api_key_id"`
+	UserID     uuid.UUID     `json:"user_id"`
+	Name       string        `json:"name"`
+	Prefix     string        `json:"prefix"`
+	SecretHash string        `json:"secret_hash"`
+	Scope      string        `json:"scope"`
+	LumoID     uuid.NullUUID `json:"lumo_id"`
+	LastUsedAt sql.NullTime  `json:"last_used_at"`
+	RevokedAt  sql.NullTime  `json:"revoked_at"`
+	RotatedAt  sql.NullTime  `json:"rotated_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+type AuditLog struct {
+	ID            int64          `json:"id"`
+	Actor         sql.NullString `json:"actor"`
+	ApiKeyID      uuid.NullUUID  `json:"api_key_id"`
+	Procedure     string         `json:"procedure"`
+	LumoID        uuid.NullUUID  `json:"lumo_id"`
+	TargetIds     []string       `json:"target_ids"`
+	RequestDigest string         `json:"request_digest"`
+	Code          string         `json:"code"`
+	LatencyUs     int64          `json:"latency_us"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type EntityHistory struct {
+	ID         int64           `json:"id"`
+	LumoID     uuid.UUID       `json:"lumo_id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uuid.UUID       `json:"entity_id"`
+	Operation  string          `json:"operation"`
+	Actor      sql.NullString  `json:"actor"`
+	Snapshot   json.RawMessage `json:"snapshot"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	ID          int64     `json:"id"`
+	Scope       string    `json:"scope"`
+	Key         string    `json:"key"`
+	RequestHash []byte    `json:"request_hash"`
+	Response    []byte    `json:"response"`
+	CreatedAt   time.Time `json:"created_at"`
+	LockedUntil time.Time `json:"locked_until"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
 type Link struct {
 	ID            int64                 `json:"id"`
 	LinkID        uuid.UUID             `json:"link_id"`
@@ -23,6 +75,9 @@ type Link struct {
 	SequenceIndex sql.NullInt32         `json:"sequence_index"`
 	CreatedAt     time.Time             `json:"created_at"`
 	UpdatedAt     time.Time             `json:"updated_at"`
+	Version       int64                 `json:"version"`
+	DeletedAt     sql.NullTime          `json:"deleted_at"`
+	DeletedBy     uuid.NullUUID         `json:"deleted_by"`
 }
 
 type Lume struct {
@@ -42,13 +97,145 @@ type Lume struct {
 	BookingLink  sql.NullString  `json:"booking_link"`
 	CreatedAt    time.Time       `json:"created_at"`
 	UpdatedAt    time.Time       `json:"updated_at"`
+	Version      int64           `json:"version"`
+	DeletedAt    sql.NullTime    `json:"deleted_at"`
+	DeletedBy    uuid.NullUUID   `json:"deleted_by"`
+	TimeZone     string          `json:"time_zone"`
+}
+
+type LumeLayout struct {
+	ID        int64           `json:"id"`
+	LumeID    uuid.UUID       `json:"lume_id"`
+	LumoID    uuid.UUID       `json:"lumo_id"`
+	PositionX float64         `json:"position_x"`
+	PositionY float64         `json:"position_y"`
+	Width     sql.NullFloat64 `json:"width"`
+	Height    sql.NullFloat64 `json:"height"`
+	Collapsed bool            `json:"collapsed"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type Outbox struct {
+	ID            int64           `json:"id"`
+	EventID       uuid.UUID       `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	LumoID        uuid.UUID       `json:"lumo_id"`
+	EntityID      uuid.UUID       `json:"entity_id"`
+	Actor         sql.NullString  `json:"actor"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int32           `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     sql.NullString  `json:"last_error"`
+	DeliveredAt   sql.NullTime    `json:"delivered_at"`
+	CreatedAt     time.Time       `json:"created_at"`
 }
 
 type Lumo struct {
+	ID        int64        `json:"id"`
+	LumoID    uuid.UUID    `json:"lumo_id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Title     string       `json:"title"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	Version   int64        `json:"version"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+type LumoEvent struct {
+	ID         int64           `json:"id"`
+	LumoID     uuid.UUID       `json:"lumo_id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uuid.UUID       `json:"entity_id"`
+	EventType  string          `json:"event_type"`
+	Payload    json.RawMessage `json:"payload"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type LumoMember struct {
 	ID        int64     `json:"id"`
 	LumoID    uuid.UUID `json:"lumo_id"`
 	UserID    uuid.UUID `json:"user_id"`
-	Title     string    `json:"title"`
+	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+type LumoViewport struct {
+	ID        int64     `json:"id"`
+	LumoID    uuid.UUID `json:"lumo_id"`
+	X         float64   `json:"x"`
+	Y         float64   `json:"y"`
+	Zoom      float64   `json:"zoom"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SchemaVersion struct {
+	Version   int32     `json:"version"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+type ShareLink struct {
+	ID               int64         `json:"id"`
+	ShareLinkID      uuid.UUID     `json:"share_link_id"`
+	LumoID           uuid.UUID     `json:"lumo_id"`
+	TokenHash        string        `json:"token_hash"`
+	HideBookingLinks bool          `json:"hide_booking_links"`
+	HideCosts        bool          `json:"hide_costs"`
+	HideNotes        bool          `json:"hide_notes"`
+	ExpiresAt        sql.NullTime  `json:"expires_at"`
+	RevokedAt        sql.NullTime  `json:"revoked_at"`
+	AccessCount      int64         `json:"access_count"`
+	LastAccessedAt   sql.NullTime  `json:"last_accessed_at"`
+	CreatedBy        uuid.NullUUID `json:"created_by"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+type User struct {
+	ID           int64          `json:"id"`
+	UserID       uuid.UUID      `json:"user_id"`
+	Email        sql.NullString `json:"email"`
+	DisplayName  string         `json:"display_name"`
+	HomeCurrency string         `json:"home_currency"`
+	DistanceUnit string         `json:"distance_unit"`
+	TimeZone     string         `json:"time_zone"`
+	Locale       string         `json:"locale"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+type Webhook struct {
+	ID                  int64         `json:"id"`
+	WebhookID           uuid.UUID     `json:"webhook_id"`
+	UserID              uuid.UUID     `json:"user_id"`
+	LumoID              uuid.NullUUID `json:"lumo_id"`
+	Url                 string        `json:"url"`
+	Description         string        `json:"description"`
+	Secret              string        `json:"secret"`
+	EntityTypes         []string      `json:"entity_types"`
+	LumeTypes           []string      `json:"lume_types"`
+	LinkTypes           []string      `json:"link_types"`
+	ConsecutiveFailures int32         `json:"consecutive_failures"`
+	DisabledAt          sql.NullTime  `json:"disabled_at"`
+	CreatedAt           time.Time     `json:"created_at"`
+	UpdatedAt           time.Time     `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID            int64           `json:"id"`
+	DeliveryID    uuid.UUID       `json:"delivery_id"`
+	WebhookID     uuid.UUID       `json:"webhook_id"`
+	EventID       uuid.UUID       `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	RedeliveryOf  uuid.NullUUID   `json:"redelivery_of"`
+	Status        string          `json:"status"`
+	Attempts      int32           `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	ResponseCode  sql.NullInt32   `json:"response_code"`
+	LastError     sql.NullString  `json:"last_error"`
+	DeliveredAt   sql.NullTime    `json:"delivered_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
@@ -6,43 +6,204 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	// Claims the key for a call, taking it over once it has expired. No row is
+	// affected while the key is held by another call.
+	ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (int64, error)
+	// Leases the oldest events that are due to one relay until lease_until, so
+	// other relays skip them while they are being delivered
+	ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]Outbox, error)
+	// Leases the oldest deliveries that are due to enabled webhooks to one worker
+	// until lease_until, so other workers skip them while they are being sent
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// Stores the response of the call holding the key
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error
 	CountLinksByFromLumeID(ctx context.Context, fromLumeID uuid.UUID) (int64, error)
 	CountLinksByLumeID(ctx context.Context, fromLumeID uuid.UUID) (int64, error)
 	CountLinksByToLumeID(ctx context.Context, toLumeID uuid.UUID) (int64, error)
+	// Counts the Lumos, Lumes and Links that are not in the trash
+	CountLiveEntities(ctx context.Context) (CountLiveEntitiesRow, error)
 	CountLumesByLumo(ctx context.Context, lumoID uuid.UUID) (int64, error)
 	CountLumosByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	// Counts the Lumos a user created that are not in the trash
+	CountLumosOwnedByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
+	CreateAuditLogEntry(ctx context.Context, arg CreateAuditLogEntryParams) (AuditLog, error)
+	CreateEntityHistory(ctx context.Context, arg CreateEntityHistoryParams) (EntityHistory, error)
+	// Creating a Link with the ID of one in the trash brings it back
 	CreateLink(ctx context.Context, arg CreateLinkParams) (Link, error)
+	// Creating a Lume with the ID of one in the trash brings it back
 	CreateLume(ctx context.Context, arg CreateLumeParams) (Lume, error)
+	// Creating a Lumo with the ID of one in the trash brings it back
 	CreateLumo(ctx context.Context, arg CreateLumoParams) (Lumo, error)
-	DeleteLink(ctx context.Context, id int64) error
-	DeleteLinkByLinkID(ctx context.Context, linkID uuid.UUID) error
-	DeleteLume(ctx context.Context, id int64) error
-	DeleteLumeByLumeID(ctx context.Context, lumeID uuid.UUID) error
-	DeleteLumo(ctx context.Context, id int64) error
-	DeleteLumoByLumoID(ctx context.Context, lumoID uuid.UUID) error
+	CreateLumoEvent(ctx context.Context, arg CreateLumoEventParams) (LumoEvent, error)
+	CreateLumoMember(ctx context.Context, arg CreateLumoMemberParams) (LumoMember, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error
+	CreateShareLink(ctx context.Context, arg CreateShareLinkParams) (ShareLink, error)
+	// Users backfilled without an email can be created once more to fill in
+	// their profile, anyone else already exists
+	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	// Deliveries of an event that is already queued for the webhook are dropped,
+	// unless they were requested manually
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	// Moves the Link to the trash
+	DeleteLink(ctx context.Context, arg DeleteLinkParams) (Link, error)
+	// Moves the Link to the trash
+	DeleteLinkByLinkID(ctx context.Context, arg DeleteLinkByLinkIDParams) (Link, error)
+	// Moves the Lume to the trash
+	DeleteLume(ctx context.Context, arg DeleteLumeParams) (Lume, error)
+	// Moves the Lume to the trash
+	DeleteLumeByLumeID(ctx context.Context, arg DeleteLumeByLumeIDParams) (Lume, error)
+	// Moves the Lumo to the trash
+	DeleteLumo(ctx context.Context, arg DeleteLumoParams) (Lumo, error)
+	// Moves the Lumo to the trash
+	DeleteLumoByLumoID(ctx context.Context, arg DeleteLumoByLumoIDParams) (Lumo, error)
+	DeleteLumoMember(ctx context.Context, arg DeleteLumoMemberParams) (int64, error)
+	DeleteWebhook(ctx context.Context, webhookID uuid.UUID) (int64, error)
+	GetApiKeyByApiKeyID(ctx context.Context, apiKeyID uuid.UUID) (ApiKey, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetLatestLumoEventID(ctx context.Context, lumoID uuid.UUID) (int64, error)
 	GetLinkByID(ctx context.Context, id int64) (Link, error)
 	GetLinkByLinkID(ctx context.Context, linkID uuid.UUID) (Link, error)
+	GetLinkOwner(ctx context.Context, linkID uuid.UUID) (GetLinkOwnerRow, error)
+	GetLinkOwnerByID(ctx context.Context, id int64) (GetLinkOwnerByIDRow, error)
 	GetLumeByID(ctx context.Context, id int64) (Lume, error)
 	GetLumeByLumeID(ctx context.Context, lumeID uuid.UUID) (Lume, error)
+	GetLumeOwner(ctx context.Context, lumeID uuid.UUID) (GetLumeOwnerRow, error)
+	GetLumeOwnerByID(ctx context.Context, id int64) (GetLumeOwnerByIDRow, error)
+	// Summarizes how many Lumes the Lumos that are not in the trash have
+	GetLumesPerLumoStats(ctx context.Context) (GetLumesPerLumoStatsRow, error)
 	GetLumoByID(ctx context.Context, id int64) (Lumo, error)
 	GetLumoByLumoID(ctx context.Context, lumoID uuid.UUID) (Lumo, error)
+	GetLumoIDByLumeID(ctx context.Context, lumeID uuid.UUID) (uuid.UUID, error)
+	GetLumoMember(ctx context.Context, arg GetLumoMemberParams) (LumoMember, error)
+	GetLumoMemberRole(ctx context.Context, arg GetLumoMemberRoleParams) (string, error)
+	GetLumoOwner(ctx context.Context, lumoID uuid.UUID) (GetLumoOwnerRow, error)
+	GetLumoOwnerByID(ctx context.Context, id int64) (GetLumoOwnerByIDRow, error)
+	GetLumoViewportByLumoID(ctx context.Context, lumoID uuid.UUID) (LumoViewport, error)
+	// Returns the number of the latest schema file applied, 0 if none recorded one
+	GetSchemaVersion(ctx context.Context) (int32, error)
+	GetShareLinkByShareLinkID(ctx context.Context, shareLinkID uuid.UUID) (ShareLink, error)
+	GetUserByEmail(ctx context.Context, email sql.NullString) (User, error)
+	GetUserByUserID(ctx context.Context, userID uuid.UUID) (User, error)
+	GetWebhookByWebhookID(ctx context.Context, webhookID uuid.UUID) (Webhook, error)
+	GetWebhookDeliveryByDeliveryID(ctx context.Context, deliveryID uuid.UUID) (WebhookDelivery, error)
+	IsLumeTrashed(ctx context.Context, lumeID uuid.UUID) (bool, error)
+	IsLumoTrashed(ctx context.Context, lumoID uuid.UUID) (bool, error)
+	ListApiKeysByUserID(ctx context.Context, arg ListApiKeysByUserIDParams) ([]ApiKey, error)
+	// Entries matching every filter that is set, newest first
+	ListAuditLog(ctx context.Context, arg ListAuditLogParams) ([]AuditLog, error)
+	ListEntityHistory(ctx context.Context, arg ListEntityHistoryParams) ([]EntityHistory, error)
 	ListLinksByEitherLumeID(ctx context.Context, arg ListLinksByEitherLumeIDParams) ([]Link, error)
 	ListLinksByFromLumeID(ctx context.Context, arg ListLinksByFromLumeIDParams) ([]Link, error)
 	ListLinksByLumeIDAndType(ctx context.Context, arg ListLinksByLumeIDAndTypeParams) ([]Link, error)
+	ListLinksByLumoID(ctx context.Context, arg ListLinksByLumoIDParams) ([]Link, error)
 	ListLinksByToLumeID(ctx context.Context, arg ListLinksByToLumeIDParams) ([]Link, error)
 	ListLinksByType(ctx context.Context, arg ListLinksByTypeParams) ([]Link, error)
+	ListLumeIDsByLumoID(ctx context.Context, lumoID uuid.UUID) ([]uuid.UUID, error)
+	ListLumeLayoutsByLumoID(ctx context.Context, lumoID uuid.UUID) ([]LumeLayout, error)
 	ListLumesByLumoID(ctx context.Context, arg ListLumesByLumoIDParams) ([]Lume, error)
 	ListLumesByType(ctx context.Context, arg ListLumesByTypeParams) ([]Lume, error)
+	ListLumoEventsAfter(ctx context.Context, arg ListLumoEventsAfterParams) ([]LumoEvent, error)
+	ListLumoHistory(ctx context.Context, arg ListLumoHistoryParams) ([]EntityHistory, error)
+	// Latest entry of every entity of a Lumo recorded at or before the given time
+	ListLumoHistoryAsOf(ctx context.Context, arg ListLumoHistoryAsOfParams) ([]EntityHistory, error)
+	ListLumoMembers(ctx context.Context, arg ListLumoMembersParams) ([]LumoMember, error)
+	// Lumos a user created or that are shared with them
 	ListLumosByUserID(ctx context.Context, arg ListLumosByUserIDParams) ([]Lumo, error)
+	ListShareLinksByLumoID(ctx context.Context, arg ListShareLinksByLumoIDParams) ([]ShareLink, error)
+	// Links of a Lumo that were deleted directly, most recently deleted first
+	ListTrashedLinksByLumoID(ctx context.Context, arg ListTrashedLinksByLumoIDParams) ([]Link, error)
+	// Lumes of a Lumo that were deleted directly, most recently deleted first
+	ListTrashedLumesByLumoID(ctx context.Context, arg ListTrashedLumesByLumoIDParams) ([]Lume, error)
+	// Lumos of a user in the trash, most recently deleted first
+	ListTrashedLumosByUserID(ctx context.Context, arg ListTrashedLumosByUserIDParams) ([]Lumo, error)
+	// Deliveries to a webhook, newest first
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// Webhooks of a user, only those of one Lumo if lumo_id is set, newest first
+	ListWebhooksByUserID(ctx context.Context, arg ListWebhooksByUserIDParams) ([]Webhook, error)
+	// Enabled webhooks that should hear about the events of a Lumo: those of the
+	// Lumo itself and those of all Lumos of a user, as long as their user is
+	// still a member
+	ListWebhooksForEvent(ctx context.Context, lumoID uuid.UUID) ([]Webhook, error)
+	// Serializes event writers of a Lumo until commit so ids become visible in order
+	LockLumoEvents(ctx context.Context, lumoID string) error
+	// Serializes the creation of a user's Lumos until commit, for their quota
+	LockLumosOfUser(ctx context.Context, userID string) error
+	MarkOutboxEventDelivered(ctx context.Context, arg MarkOutboxEventDeliveredParams) error
+	// Records a failed attempt, either to retry at next_attempt_at (PENDING) or
+	// for good (FAILED)
+	MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error
+	// Records the outcome of an attempt
+	MarkWebhookDelivery(ctx context.Context, arg MarkWebhookDeliveryParams) error
+	NotifyLumoEvent(ctx context.Context, payload string) error
+	// Permanently deletes the entries recorded before the cutoff
+	PurgeAuditLog(ctx context.Context, before time.Time) (int64, error)
+	// Permanently deletes the keys that expired before the cutoff
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+	// Permanently deletes the Links that were trashed before the cutoff
+	PurgeLinks(ctx context.Context, before time.Time) (int64, error)
+	// Permanently deletes the Lumes that were trashed before the cutoff
+	PurgeLumes(ctx context.Context, before time.Time) (int64, error)
+	// Permanently deletes the Lumos that were trashed before the cutoff
+	PurgeLumos(ctx context.Context, before time.Time) (int64, error)
+	// Counts a failed attempt, disabling the webhook once disable_after attempts
+	// in a row failed
+	RecordWebhookFailure(ctx context.Context, arg RecordWebhookFailureParams) (Webhook, error)
+	RecordWebhookSuccess(ctx context.Context, webhookID uuid.UUID) error
+	// Frees the key of a call that failed, so it can be retried
+	ReleaseIdempotencyKey(ctx context.Context, arg ReleaseIdempotencyKeyParams) error
+	// Takes a Link out of the trash
+	RestoreLinkByLinkID(ctx context.Context, linkID uuid.UUID) (Link, error)
+	// Brings back the Links trashed together with a Lume once both of their
+	// Lumes are out of the trash, unless the connection was made again since
+	RestoreLinksByLumeID(ctx context.Context, fromLumeID uuid.UUID) error
+	// Brings back the Links trashed together with a Lumo or its Lumes once both
+	// of their Lumes are out of the trash, unless the connection was made again
+	// since
+	RestoreLinksByLumoID(ctx context.Context, lumoID uuid.UUID) error
+	// Takes a Lume out of the trash
+	RestoreLumeByLumeID(ctx context.Context, lumeID uuid.UUID) (Lume, error)
+	// Brings back the Lumes that were trashed together with their Lumo
+	RestoreLumesByLumoID(ctx context.Context, lumoID uuid.UUID) error
+	// Takes a Lumo out of the trash
+	RestoreLumoByLumoID(ctx context.Context, lumoID uuid.UUID) (Lumo, error)
+	// Revoking a key twice keeps the first revocation time
+	RevokeApiKey(ctx context.Context, arg RevokeApiKeyParams) (ApiKey, error)
+	// Revoking a link twice keeps the first revocation time
+	RevokeShareLink(ctx context.Context, arg RevokeShareLinkParams) (ShareLink, error)
+	// Replaces the secret of a key that hasn't been revoked
+	RotateApiKey(ctx context.Context, arg RotateApiKeyParams) (ApiKey, error)
 	SearchLumesByLocation(ctx context.Context, arg SearchLumesByLocationParams) ([]Lume, error)
+	// Moves the Links of a Lume that is being trashed along with it
+	TrashLinksByLumeID(ctx context.Context, arg TrashLinksByLumeIDParams) error
+	// Moves the Links of a Lumo that is being trashed along with it
+	TrashLinksByLumoID(ctx context.Context, arg TrashLinksByLumoIDParams) error
+	// Moves the Lumes of a Lumo that is being trashed along with it
+	TrashLumesByLumoID(ctx context.Context, arg TrashLumesByLumoIDParams) error
 	UpdateLink(ctx context.Context, arg UpdateLinkParams) (Link, error)
 	UpdateLume(ctx context.Context, arg UpdateLumeParams) (Lume, error)
 	UpdateLumo(ctx context.Context, arg UpdateLumoParams) (Lumo, error)
+	UpdateLumoMemberRole(ctx context.Context, arg UpdateLumoMemberRoleParams) (LumoMember, error)
+	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (Webhook, error)
+	UpsertLumeLayout(ctx context.Context, arg UpsertLumeLayoutParams) (LumeLayout, error)
+	// Makes the creator of a Lumo its owner, also when a Lumo is brought back
+	// from the trash by creating it again
+	UpsertLumoOwner(ctx context.Context, arg UpsertLumoOwnerParams) error
+	UpsertLumoViewport(ctx context.Context, arg UpsertLumoViewportParams) (LumoViewport, error)
+	// Records the use of a key that hasn't been revoked
+	UseApiKey(ctx context.Context, arg UseApiKeyParams) (ApiKey, error)
+	// Counts an access through a link that still works: neither revoked nor
+	// expired, and its Lumo isn't in the trash
+	UseShareLink(ctx context.Context, arg UseShareLinkParams) (ShareLink, error)
 }
 
 var _ Querier = (*Queries)(nil)
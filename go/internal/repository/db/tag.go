@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mcdev12/lumo/go/internal/logging"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+// taggedConn prefixes every query with a comment naming the request it is
+// made for
+type taggedConn struct {
+	conn sqlc.DBTX
+}
+
+// WithRequestTags wraps conn so every query made with a context carrying a
+// request ID starts with a /* request_id=... */ comment. Postgres keeps the
+// comment in pg_stat_activity and its logs, so a slow or failing query can be
+// traced back to the request. Transactions begun by RunInTx are tagged too.
+func WithRequestTags(conn sqlc.DBTX) sqlc.DBTX {
+	return &taggedConn{conn: conn}
+}
+
+// unwrap returns the connection queries are made on
+func (c *taggedConn) unwrap() sqlc.DBTX {
+	return c.conn
+}
+
+// wrap tags the queries of conn as well
+func (c *taggedConn) wrap(conn sqlc.DBTX) sqlc.DBTX {
+	return &taggedConn{conn: conn}
+}
+
+// ExecContext runs a tagged statement
+func (c *taggedConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.conn.ExecContext(ctx, tag(ctx, query), args...)
+}
+
+// PrepareContext prepares a tagged statement
+func (c *taggedConn) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.conn.PrepareContext(ctx, tag(ctx, query))
+}
+
+// QueryContext runs a tagged query
+func (c *taggedConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.conn.QueryContext(ctx, tag(ctx, query), args...)
+}
+
+// QueryRowContext runs a tagged query returning at most one row
+func (c *taggedConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.conn.QueryRowContext(ctx, tag(ctx, query), args...)
+}
+
+// tag prefixes query with the request ID of ctx, if any. Request IDs are
+// limited to characters that can't end the comment.
+func tag(ctx context.Context, query string) string {
+	requestID, ok := logging.RequestIDFromContext(ctx)
+	if !ok {
+		return query
+	}
+	return "/* request_id=" + requestID + " */ " + query
+}
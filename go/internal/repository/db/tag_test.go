@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/mcdev12/lumo/go/internal/logging"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/stretchr/testify/suite"
+)
+
+// recordingConn remembers the statements run on it
+type recordingConn struct {
+	queries []string
+}
+
+// ExecContext records the statement
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ ...interface{}) (sql.Result, error) {
+	c.queries = append(c.queries, query)
+	return nil, nil
+}
+
+// PrepareContext records the statement
+func (c *recordingConn) PrepareContext(_ context.Context, query string) (*sql.Stmt, error) {
+	c.queries = append(c.queries, query)
+	return nil, nil
+}
+
+// QueryContext records the query
+func (c *recordingConn) QueryContext(_ context.Context, query string, _ ...interface{}) (*sql.Rows, error) {
+	c.queries = append(c.queries, query)
+	return nil, nil
+}
+
+// QueryRowContext records the query
+func (c *recordingConn) QueryRowContext(_ context.Context, query string, _ ...interface{}) *sql.Row {
+	c.queries = append(c.queries, query)
+	return nil
+}
+
+// TagTestSuite is a test suite for request tagging
+type TagTestSuite struct {
+	suite.Suite
+	recorder *recordingConn
+	conn     sqlc.DBTX
+}
+
+// SetupTest is called before each test
+func (s *TagTestSuite) SetupTest() {
+	s.recorder = &recordingConn{}
+	s.conn = WithRequestTags(s.recorder)
+}
+
+// TestTagSuite runs the test suite
+func TestTagSuite(t *testing.T) {
+	suite.Run(t, new(TagTestSuite))
+}
+
+// Test queries are tagged with the request ID of their context only
+func (s *TagTestSuite) TestTag() {
+	// Arrange
+	ctx := logging.WithRequestID(context.Background(), "req-1")
+
+	// Act
+	_, _ = s.conn.ExecContext(ctx, "DELETE FROM lumo")
+	_, _ = s.conn.QueryContext(context.Background(), "SELECT 1")
+
+	// Assert
+	s.Equal([]string{"/* request_id=req-1 */ DELETE FROM lumo", "SELECT 1"}, s.recorder.queries)
+}
+
+// Test RunInTx keeps tagging when the connection can't begin a transaction
+func (s *TagTestSuite) TestRunInTxTagged() {
+	// Arrange
+	ctx := logging.WithRequestID(context.Background(), "req-2")
+
+	// Act
+	err := RunInTx(ctx, s.conn, func(tx sqlc.DBTX) error {
+		s.Same(s.conn, tx)
+		_ = tx.QueryRowContext(ctx, "SELECT 2")
+		return nil
+	})
+
+	// Assert
+	s.NoError(err)
+	s.Equal([]string{"/* request_id=req-2 */ SELECT 2"}, s.recorder.queries)
+}
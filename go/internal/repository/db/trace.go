@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/mcdev12/lumo/go/internal/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedConn makes a span of every statement run on it
+type tracedConn struct {
+	conn sqlc.DBTX
+}
+
+// WithTracing wraps conn so every statement made for a traced request gets a
+// client span named after its sqlc query, carrying the statement. As with
+// WithQueryObserver, the span of QueryContext ends once the first rows are
+// available. Transactions begun by RunInTx are traced too.
+func WithTracing(conn sqlc.DBTX) sqlc.DBTX {
+	return &tracedConn{conn: conn}
+}
+
+// unwrap returns the connection queries are made on
+func (c *tracedConn) unwrap() sqlc.DBTX {
+	return c.conn
+}
+
+// wrap traces the queries of conn as well
+func (c *tracedConn) wrap(conn sqlc.DBTX) sqlc.DBTX {
+	return &tracedConn{conn: conn}
+}
+
+// ExecContext runs a traced statement
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args ...interface{}) (_ sql.Result, err error) {
+	ctx, span := startQuery(ctx, query)
+	defer tracing.End(span, &err)
+	return c.conn.ExecContext(ctx, query, args...)
+}
+
+// PrepareContext prepares a statement, which isn't traced
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.conn.PrepareContext(ctx, query)
+}
+
+// QueryContext runs a traced query
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args ...interface{}) (_ *sql.Rows, err error) {
+	ctx, span := startQuery(ctx, query)
+	defer tracing.End(span, &err)
+	return c.conn.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs a traced query returning at most one row
+func (c *tracedConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := startQuery(ctx, query)
+	row := c.conn.QueryRowContext(ctx, query, args...)
+	var err error
+	if row != nil {
+		err = row.Err()
+	}
+	tracing.End(span, &err)
+	return row
+}
+
+// startQuery starts the span of a query
+func startQuery(ctx context.Context, query string) (context.Context, trace.Span) {
+	name := QueryName(query)
+	return tracing.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(name),
+			semconv.DBQueryText(query),
+		),
+	)
+}
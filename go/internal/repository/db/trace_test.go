@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceTestSuite is a test suite for query tracing
+type TraceTestSuite struct {
+	suite.Suite
+	spans    *tracetest.SpanRecorder
+	provider *sdktrace.TracerProvider
+	previous trace.TracerProvider
+	recorder *recordingConn
+}
+
+// SetupTest is called before each test
+func (s *TraceTestSuite) SetupTest() {
+	s.spans = tracetest.NewSpanRecorder()
+	s.provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.spans))
+	s.previous = otel.GetTracerProvider()
+	otel.SetTracerProvider(s.provider)
+	s.recorder = &recordingConn{}
+}
+
+// TearDownTest is called after each test
+func (s *TraceTestSuite) TearDownTest() {
+	otel.SetTracerProvider(s.previous)
+}
+
+// TestTraceSuite runs the test suite
+func TestTraceSuite(t *testing.T) {
+	suite.Run(t, new(TraceTestSuite))
+}
+
+// Test statements of traced requests get a span named after their query
+func (s *TraceTestSuite) TestTrace() {
+	// Arrange
+	conn := WithTracing(s.recorder)
+	ctx, parent := s.provider.Tracer("test").Start(context.Background(), "rpc")
+
+	// Act
+	_, _ = conn.ExecContext(ctx, "-- name: DeleteLume :exec\nDELETE FROM lume")
+	_, _ = conn.ExecContext(context.Background(), "-- name: PurgeLumes :execrows\nDELETE FROM lume")
+	parent.End()
+
+	// Assert
+	ended := s.spans.Ended()
+	s.Require().Len(ended, 2)
+	s.Equal("DeleteLume", ended[0].Name())
+	s.Equal(trace.SpanKindClient, ended[0].SpanKind())
+	s.Equal(parent.SpanContext().SpanID(), ended[0].Parent().SpanID())
+	s.Equal("rpc", ended[1].Name())
+	s.Len(s.recorder.queries, 2)
+}
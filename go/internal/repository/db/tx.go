@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+// txBeginner is implemented by *sql.DB
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// wrapper is implemented by connections decorating another one, such as the
+// ones returned by WithRequestTags and WithQueryObserver
+type wrapper interface {
+	// unwrap returns the decorated connection
+	unwrap() sqlc.DBTX
+	// wrap decorates conn the same way
+	wrap(conn sqlc.DBTX) sqlc.DBTX
+}
+
+// RunInTx runs fn inside a transaction when conn is able to start one.
+// fn receives the handle its queries must be issued on. If conn is already a
+// transaction (or cannot begin one) fn simply runs against conn. The
+// transaction of a wrapped conn, e.g. by WithRequestTags, is wrapped the same
+// way.
+func RunInTx(ctx context.Context, conn sqlc.DBTX, fn func(tx sqlc.DBTX) error) error {
+	inner := conn
+	var wrappers []wrapper
+	for {
+		w, ok := inner.(wrapper)
+		if !ok {
+			break
+		}
+		wrappers = append(wrappers, w)
+		inner = w.unwrap()
+	}
+
+	beginner, ok := inner.(txBeginner)
+	if !ok {
+		return fn(conn)
+	}
+
+	tx, err := beginner.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	var txConn sqlc.DBTX = tx
+	for i := len(wrappers) - 1; i >= 0; i-- {
+		txConn = wrappers[i].wrap(txConn)
+	}
+
+	if err := fn(txConn); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/mcdev12/lumo/go/internal/models/version"
+)
+
+// VersionConflict explains why a versioned write matched no rows. current
+// reads the version the row is at now: if the row is gone err is returned
+// unchanged, otherwise it was changed concurrently and a
+// *version.ConflictError is returned.
+func VersionConflict(expected int64, err error, current func() (int64, error)) error {
+	currentVersion, getErr := current()
+	if errors.Is(getErr, sql.ErrNoRows) {
+		return err
+	}
+	if getErr != nil {
+		return getErr
+	}
+	return version.NewConflictError(expected, currentVersion)
+}
+
+// NullVersion converts an optional expected version to sql.NullInt64
+func NullVersion(expectedVersion *int64) sql.NullInt64 {
+	if expectedVersion == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *expectedVersion, Valid: true}
+}
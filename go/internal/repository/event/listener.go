@@ -0,0 +1,148 @@
+package event
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// Backoff bounds used by pq when the LISTEN connection drops
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+
+	// How often the LISTEN connection is checked when no notification arrives
+	pingInterval = 90 * time.Second
+)
+
+// Listener holds a dedicated LISTEN connection on Channel and wakes up the
+// watchers of the Lumo each notification is about. Watchers only get a
+// signal, they read the events themselves from the lumo_event table.
+type Listener struct {
+	listener *pq.Listener
+	done     chan struct{}
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+}
+
+// NewListener opens a LISTEN connection with the given DSN
+func NewListener(dsn string) (*Listener, error) {
+	l := &Listener{
+		done:        make(chan struct{}),
+		subscribers: make(map[string]map[chan struct{}]struct{}),
+	}
+
+	l.listener = pq.NewListener(dsn, minReconnectInterval, maxReconnectInterval, l.logEvent)
+	if err := l.listener.Listen(Channel); err != nil {
+		_ = l.listener.Close()
+		return nil, fmt.Errorf("error listening on %s: %w", Channel, err)
+	}
+
+	go l.run()
+
+	return l, nil
+}
+
+// Subscribe registers interest in a Lumo. The returned channel receives a
+// signal whenever new events may be available; signals are coalesced, so a
+// slow reader sees one pending wake-up rather than one per event. The
+// returned function must be called to unsubscribe.
+func (l *Listener) Subscribe(lumoID string) (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+
+	l.mu.Lock()
+	if l.subscribers[lumoID] == nil {
+		l.subscribers[lumoID] = make(map[chan struct{}]struct{})
+	}
+	l.subscribers[lumoID][wake] = struct{}{}
+	l.mu.Unlock()
+
+	return wake, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.subscribers[lumoID], wake)
+		if len(l.subscribers[lumoID]) == 0 {
+			delete(l.subscribers, lumoID)
+		}
+	}
+}
+
+// Close stops listening and releases the connection
+func (l *Listener) Close() error {
+	close(l.done)
+	return l.listener.Close()
+}
+
+// run dispatches notifications until the listener is closed
+func (l *Listener) run() {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case n, ok := <-l.listener.NotificationChannel():
+			if !ok {
+				return
+			}
+			if n == nil {
+				// The connection was re-established and notifications sent
+				// in the meantime are lost, so everyone has to catch up
+				l.wakeAll()
+				continue
+			}
+			lumoID, _, err := ParseNotification(n.Extra)
+			if err != nil {
+				slog.Warn("event listener got a malformed notification", slog.Any("error", err))
+				continue
+			}
+			l.wake(lumoID)
+		case <-ticker.C:
+			go func() {
+				if err := l.listener.Ping(); err != nil {
+					slog.Warn("event listener ping failed", slog.Any("error", err))
+				}
+			}()
+		}
+	}
+}
+
+// wake signals every subscriber of a Lumo
+func (l *Listener) wake(lumoID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for wake := range l.subscribers[lumoID] {
+		signal(wake)
+	}
+}
+
+// wakeAll signals every subscriber
+func (l *Listener) wakeAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, subscribers := range l.subscribers {
+		for wake := range subscribers {
+			signal(wake)
+		}
+	}
+}
+
+// logEvent reports connection state changes of the LISTEN connection
+func (l *Listener) logEvent(ev pq.ListenerEventType, err error) {
+	if err != nil {
+		slog.Warn("event listener connection event", slog.Int("event", int(ev)), slog.Any("error", err))
+	}
+}
+
+// signal performs a non-blocking send, dropping the signal if one is pending
+func signal(wake chan struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
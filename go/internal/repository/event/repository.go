@@ -0,0 +1,143 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/event"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+// Channel is the Postgres NOTIFY channel events are announced on
+const Channel = "lumo_events"
+
+//go:generate mockery
+type EventQuerier interface {
+	CreateLumoEvent(ctx context.Context, arg sqlc.CreateLumoEventParams) (sqlc.LumoEvent, error)
+	GetLatestLumoEventID(ctx context.Context, lumoID uuid.UUID) (int64, error)
+	ListLumoEventsAfter(ctx context.Context, arg sqlc.ListLumoEventsAfterParams) ([]sqlc.LumoEvent, error)
+	LockLumoEvents(ctx context.Context, lumoID string) error
+	NotifyLumoEvent(ctx context.Context, payload string) error
+}
+
+// Recorder is the subset of queries write paths need to record an event
+type Recorder interface {
+	CreateLumoEvent(ctx context.Context, arg sqlc.CreateLumoEventParams) (sqlc.LumoEvent, error)
+	LockLumoEvents(ctx context.Context, lumoID string) error
+	NotifyLumoEvent(ctx context.Context, payload string) error
+}
+
+// Repository is the concrete implementation for Event data access
+type Repository struct {
+	queries EventQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(db sqlc.DBTX) *Repository {
+	return &Repository{
+		queries: sqlc.New(db),
+	}
+}
+
+// GetLatestEventID returns the ID of the newest event of a Lumo, or 0 if it has none
+func (r *Repository) GetLatestEventID(ctx context.Context, lumoID string) (int64, error) {
+	parsedLumoID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return 0, err
+	}
+	return r.queries.GetLatestLumoEventID(ctx, parsedLumoID)
+}
+
+// ListEventsAfter retrieves the events of a Lumo that come after the given event ID
+func (r *Repository) ListEventsAfter(ctx context.Context, lumoID string, afterID int64, limit int32) ([]*event.Event, error) {
+	parsedLumoID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.ListLumoEventsAfterParams{
+		LumoID: parsedLumoID,
+		ID:     afterID,
+		Limit:  limit,
+	}
+
+	results, err := r.queries.ListLumoEventsAfter(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*event.Event, len(results))
+	for i, result := range results {
+		events[i] = r.sqlcRowToDomainModel(result)
+	}
+
+	return events, nil
+}
+
+// Record stores the event and announces it on Channel. It must run on the
+// same transaction as the write it describes: the NOTIFY is only delivered
+// on commit and the advisory lock keeps concurrent writers of the Lumo from
+// committing events out of ID order, which would let watchers skip them.
+func Record(ctx context.Context, q Recorder, domainEvent *event.Event) error {
+	parsedLumoID, err := uuid.Parse(domainEvent.LumoID)
+	if err != nil {
+		return err
+	}
+	parsedEntityID, err := uuid.Parse(domainEvent.EntityID)
+	if err != nil {
+		return err
+	}
+
+	if err := q.LockLumoEvents(ctx, domainEvent.LumoID); err != nil {
+		return err
+	}
+
+	result, err := q.CreateLumoEvent(ctx, sqlc.CreateLumoEventParams{
+		LumoID:     parsedLumoID,
+		EntityType: string(domainEvent.EntityType),
+		EntityID:   parsedEntityID,
+		EventType:  string(domainEvent.Type),
+		Payload:    domainEvent.Payload,
+		CreatedAt:  domainEvent.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	domainEvent.ID = result.ID
+
+	return q.NotifyLumoEvent(ctx, FormatNotification(domainEvent.LumoID, result.ID))
+}
+
+// FormatNotification builds the NOTIFY payload announcing an event
+func FormatNotification(lumoID string, eventID int64) string {
+	return lumoID + ":" + strconv.FormatInt(eventID, 10)
+}
+
+// ParseNotification extracts the Lumo ID and event ID from a NOTIFY payload
+func ParseNotification(payload string) (string, int64, error) {
+	lumoID, rawID, ok := strings.Cut(payload, ":")
+	if !ok {
+		return "", 0, fmt.Errorf("malformed event notification %q", payload)
+	}
+	eventID, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("malformed event notification %q: %w", payload, err)
+	}
+	return lumoID, eventID, nil
+}
+
+// sqlcRowToDomainModel converts a sqlc LumoEvent row to a domain Event
+func (r *Repository) sqlcRowToDomainModel(row sqlc.LumoEvent) *event.Event {
+	return &event.Event{
+		ID:         row.ID,
+		LumoID:     row.LumoID.String(),
+		EntityType: event.EntityType(row.EntityType),
+		EntityID:   row.EntityID.String(),
+		Type:       event.Type(row.EventType),
+		Payload:    row.Payload,
+		CreatedAt:  row.CreatedAt,
+	}
+}
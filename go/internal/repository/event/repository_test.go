@@ -0,0 +1,173 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/event"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/mcdev12/lumo/go/internal/repository/event/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockEventQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockEventQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Helper function to create a test LumoEvent sqlc model
+func createTestEventSqlc(id int64, lumoID uuid.UUID) sqlc.LumoEvent {
+	return sqlc.LumoEvent{
+		ID:         id,
+		LumoID:     lumoID,
+		EntityType: string(event.EntityTypeLume),
+		EntityID:   uuid.New(),
+		EventType:  string(event.TypeCreated),
+		Payload:    json.RawMessage(`{"name":"Paris"}`),
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Test GetLatestEventID
+func (s *RepositoryTestSuite) TestGetLatestEventID() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+
+	// Set up expectations
+	s.mockQuerier.On("GetLatestLumoEventID", mock.Anything, lumoID).Return(int64(42), nil)
+
+	// Act
+	result, err := s.repository.GetLatestEventID(ctx, lumoID.String())
+
+	// Assert
+	s.NoError(err)
+	s.Equal(int64(42), result)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test ListEventsAfter
+func (s *RepositoryTestSuite) TestListEventsAfter() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	sqlcEvents := []sqlc.LumoEvent{
+		createTestEventSqlc(11, lumoID),
+		createTestEventSqlc(12, lumoID),
+	}
+
+	// Set up expectations
+	s.mockQuerier.On("ListLumoEventsAfter", mock.Anything, mock.MatchedBy(func(params sqlc.ListLumoEventsAfterParams) bool {
+		return params.LumoID == lumoID && params.ID == 10 && params.Limit == 100
+	})).Return(sqlcEvents, nil)
+
+	// Act
+	results, err := s.repository.ListEventsAfter(ctx, lumoID.String(), 10, 100)
+
+	// Assert
+	s.NoError(err)
+	s.Len(results, 2)
+	s.Equal(int64(11), results[0].ID)
+	s.Equal(lumoID.String(), results[0].LumoID)
+	s.Equal(event.EntityTypeLume, results[0].EntityType)
+	s.Equal(event.TypeCreated, results[0].Type)
+	s.Equal(sqlcEvents[0].EntityID.String(), results[0].EntityID)
+	s.JSONEq(`{"name":"Paris"}`, string(results[0].Payload))
+	s.Equal(int64(12), results[1].ID)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test ListEventsAfter with invalid UUID
+func (s *RepositoryTestSuite) TestListEventsAfterInvalidUUID() {
+	// Act
+	results, err := s.repository.ListEventsAfter(context.Background(), "invalid-uuid", 0, 100)
+
+	// Assert
+	s.Error(err)
+	s.Nil(results)
+}
+
+// Test Record
+func (s *RepositoryTestSuite) TestRecord() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	entityID := uuid.New()
+	domainEvent, err := event.NewEvent(lumoID.String(), event.EntityTypeLink, entityID.String(), event.TypeUpdated, map[string]string{"notes": "by train"})
+	s.Require().NoError(err)
+
+	// Set up expectations
+	lock := s.mockQuerier.On("LockLumoEvents", mock.Anything, lumoID.String()).Return(nil)
+	create := s.mockQuerier.On("CreateLumoEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateLumoEventParams) bool {
+		return params.LumoID == lumoID &&
+			params.EntityType == string(event.EntityTypeLink) &&
+			params.EntityID == entityID &&
+			params.EventType == string(event.TypeUpdated)
+	})).Return(createTestEventSqlc(7, lumoID), nil).NotBefore(lock)
+	s.mockQuerier.On("NotifyLumoEvent", mock.Anything, lumoID.String()+":7").Return(nil).NotBefore(create)
+
+	// Act
+	err = Record(ctx, s.mockQuerier, domainEvent)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(int64(7), domainEvent.ID)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test Record with a database error
+func (s *RepositoryTestSuite) TestRecordError() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	domainEvent, err := event.NewEvent(lumoID.String(), event.EntityTypeLume, uuid.NewString(), event.TypeCreated, struct{}{})
+	s.Require().NoError(err)
+	expectedErr := errors.New("database error")
+
+	// Set up expectations
+	s.mockQuerier.On("LockLumoEvents", mock.Anything, lumoID.String()).Return(nil)
+	s.mockQuerier.On("CreateLumoEvent", mock.Anything, mock.AnythingOfType("sqlc.CreateLumoEventParams")).Return(sqlc.LumoEvent{}, expectedErr)
+
+	// Act
+	err = Record(ctx, s.mockQuerier, domainEvent)
+
+	// Assert
+	s.Equal(expectedErr, err)
+	s.mockQuerier.AssertNotCalled(s.T(), "NotifyLumoEvent", mock.Anything, mock.Anything)
+}
+
+// Test ParseNotification
+func (s *RepositoryTestSuite) TestParseNotification() {
+	lumoID := uuid.NewString()
+
+	parsedLumoID, eventID, err := ParseNotification(FormatNotification(lumoID, 99))
+	s.NoError(err)
+	s.Equal(lumoID, parsedLumoID)
+	s.Equal(int64(99), eventID)
+
+	_, _, err = ParseNotification("garbage")
+	s.Error(err)
+
+	_, _, err = ParseNotification(lumoID + ":abc")
+	s.Error(err)
+}
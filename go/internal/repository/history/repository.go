@@ -0,0 +1,153 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/history"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+//go:generate mockery
+type HistoryQuerier interface {
+	CreateEntityHistory(ctx context.Context, arg sqlc.CreateEntityHistoryParams) (sqlc.EntityHistory, error)
+	ListEntityHistory(ctx context.Context, arg sqlc.ListEntityHistoryParams) ([]sqlc.EntityHistory, error)
+	ListLumoHistory(ctx context.Context, arg sqlc.ListLumoHistoryParams) ([]sqlc.EntityHistory, error)
+	ListLumoHistoryAsOf(ctx context.Context, arg sqlc.ListLumoHistoryAsOfParams) ([]sqlc.EntityHistory, error)
+	LockLumoEvents(ctx context.Context, lumoID string) error
+}
+
+// Recorder is the subset of queries write paths need to record history
+type Recorder interface {
+	CreateEntityHistory(ctx context.Context, arg sqlc.CreateEntityHistoryParams) (sqlc.EntityHistory, error)
+}
+
+// Repository is the concrete implementation for History data access
+type Repository struct {
+	db      sqlc.DBTX
+	queries HistoryQuerier
+	writers WritersFunc
+}
+
+// NewRepository creates a new Repository instance. writers binds the Lumo,
+// Lume and Link repositories to the transaction of a restore.
+func NewRepository(conn sqlc.DBTX, writers WritersFunc) *Repository {
+	return &Repository{
+		db:      conn,
+		queries: sqlc.New(conn),
+		writers: writers,
+	}
+}
+
+// ListEntityHistory retrieves the history of a single Lumo, Lume or Link, newest first
+func (r *Repository) ListEntityHistory(ctx context.Context, entityID string, limit, offset int32) ([]*history.Entry, error) {
+	parsedEntityID, err := uuid.Parse(entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.ListEntityHistoryParams{
+		EntityID: parsedEntityID,
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	results, err := r.queries.ListEntityHistory(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowsToDomainModels(results), nil
+}
+
+// ListLumoHistory retrieves the history of a Lumo and everything in it, newest first
+func (r *Repository) ListLumoHistory(ctx context.Context, lumoID string, limit, offset int32) ([]*history.Entry, error) {
+	parsedLumoID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.ListLumoHistoryParams{
+		LumoID: parsedLumoID,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	results, err := r.queries.ListLumoHistory(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowsToDomainModels(results), nil
+}
+
+// Record appends a history entry. It must run on the same transaction as the
+// write it describes.
+func Record(ctx context.Context, q Recorder, domainEntry *history.Entry) error {
+	parsedLumoID, err := uuid.Parse(domainEntry.LumoID)
+	if err != nil {
+		return err
+	}
+	parsedEntityID, err := uuid.Parse(domainEntry.EntityID)
+	if err != nil {
+		return err
+	}
+
+	var actor sql.NullString
+	if domainEntry.Actor != "" {
+		actor = sql.NullString{String: domainEntry.Actor, Valid: true}
+	}
+
+	result, err := q.CreateEntityHistory(ctx, sqlc.CreateEntityHistoryParams{
+		LumoID:     parsedLumoID,
+		EntityType: string(domainEntry.EntityType),
+		EntityID:   parsedEntityID,
+		Operation:  string(domainEntry.Operation),
+		Actor:      actor,
+		Snapshot:   domainEntry.Snapshot,
+		CreatedAt:  domainEntry.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	domainEntry.ID = result.ID
+
+	return nil
+}
+
+// querierFor returns the querier to use on the given connection
+func (r *Repository) querierFor(conn sqlc.DBTX) HistoryQuerier {
+	if conn == nil || conn == r.db {
+		return r.queries
+	}
+	return sqlc.New(conn)
+}
+
+// sqlcRowsToDomainModels converts sqlc EntityHistory rows to domain Entries
+func (r *Repository) sqlcRowsToDomainModels(rows []sqlc.EntityHistory) []*history.Entry {
+	entries := make([]*history.Entry, len(rows))
+	for i, row := range rows {
+		entries[i] = r.sqlcRowToDomainModel(row)
+	}
+	return entries
+}
+
+// sqlcRowToDomainModel converts a sqlc EntityHistory row to a domain Entry
+func (r *Repository) sqlcRowToDomainModel(row sqlc.EntityHistory) *history.Entry {
+	domainEntry := &history.Entry{
+		ID:         row.ID,
+		LumoID:     row.LumoID.String(),
+		EntityType: history.EntityType(row.EntityType),
+		EntityID:   row.EntityID.String(),
+		Operation:  history.Operation(row.Operation),
+		Snapshot:   row.Snapshot,
+		CreatedAt:  row.CreatedAt,
+	}
+
+	if row.Actor.Valid {
+		domainEntry.Actor = row.Actor.String
+	}
+
+	return domainEntry
+}
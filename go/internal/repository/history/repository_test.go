@@ -0,0 +1,164 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/history"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/mcdev12/lumo/go/internal/repository/history/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockHistoryQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockHistoryQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Helper function to create a test EntityHistory sqlc model
+func createTestHistorySqlc(id int64, lumoID, entityID uuid.UUID) sqlc.EntityHistory {
+	return sqlc.EntityHistory{
+		ID:         id,
+		LumoID:     lumoID,
+		EntityType: string(history.EntityTypeLume),
+		EntityID:   entityID,
+		Operation:  string(history.OperationUpdated),
+		Actor:      sql.NullString{String: "user-1", Valid: true},
+		Snapshot:   json.RawMessage(`{"name":"Paris"}`),
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Test ListEntityHistory
+func (s *RepositoryTestSuite) TestListEntityHistory() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	entityID := uuid.New()
+	sqlcEntries := []sqlc.EntityHistory{
+		createTestHistorySqlc(2, lumoID, entityID),
+		createTestHistorySqlc(1, lumoID, entityID),
+	}
+	sqlcEntries[1].Actor = sql.NullString{}
+
+	// Set up expectations
+	params := sqlc.ListEntityHistoryParams{EntityID: entityID, Limit: 10, Offset: 0}
+	s.mockQuerier.On("ListEntityHistory", mock.Anything, params).Return(sqlcEntries, nil)
+
+	// Act
+	results, err := s.repository.ListEntityHistory(ctx, entityID.String(), 10, 0)
+
+	// Assert
+	s.NoError(err)
+	s.Len(results, 2)
+	s.Equal(int64(2), results[0].ID)
+	s.Equal(lumoID.String(), results[0].LumoID)
+	s.Equal(entityID.String(), results[0].EntityID)
+	s.Equal(history.EntityTypeLume, results[0].EntityType)
+	s.Equal(history.OperationUpdated, results[0].Operation)
+	s.Equal("user-1", results[0].Actor)
+	s.JSONEq(`{"name":"Paris"}`, string(results[0].Snapshot))
+	s.Empty(results[1].Actor)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test ListEntityHistory with invalid UUID
+func (s *RepositoryTestSuite) TestListEntityHistoryInvalidUUID() {
+	// Act
+	results, err := s.repository.ListEntityHistory(context.Background(), "invalid-uuid", 10, 0)
+
+	// Assert
+	s.Error(err)
+	s.Nil(results)
+}
+
+// Test ListLumoHistory
+func (s *RepositoryTestSuite) TestListLumoHistory() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	sqlcEntries := []sqlc.EntityHistory{
+		createTestHistorySqlc(5, lumoID, uuid.New()),
+	}
+
+	// Set up expectations
+	params := sqlc.ListLumoHistoryParams{LumoID: lumoID, Limit: 50, Offset: 50}
+	s.mockQuerier.On("ListLumoHistory", mock.Anything, params).Return(sqlcEntries, nil)
+
+	// Act
+	results, err := s.repository.ListLumoHistory(ctx, lumoID.String(), 50, 50)
+
+	// Assert
+	s.NoError(err)
+	s.Len(results, 1)
+	s.Equal(int64(5), results[0].ID)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test Record
+func (s *RepositoryTestSuite) TestRecord() {
+	// Arrange
+	ctx := history.WithActor(context.Background(), "user-1")
+	lumoID := uuid.New()
+	entityID := uuid.New()
+	domainEntry, err := history.NewEntry(ctx, lumoID.String(), history.EntityTypeLink, entityID.String(), history.OperationDeleted, map[string]string{"notes": "by train"})
+	s.Require().NoError(err)
+
+	// Set up expectations
+	s.mockQuerier.On("CreateEntityHistory", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEntityHistoryParams) bool {
+		return params.LumoID == lumoID &&
+			params.EntityType == string(history.EntityTypeLink) &&
+			params.EntityID == entityID &&
+			params.Operation == string(history.OperationDeleted) &&
+			params.Actor == sql.NullString{String: "user-1", Valid: true}
+	})).Return(createTestHistorySqlc(9, lumoID, entityID), nil)
+
+	// Act
+	err = Record(ctx, s.mockQuerier, domainEntry)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(int64(9), domainEntry.ID)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test Record without an actor in the context
+func (s *RepositoryTestSuite) TestRecordWithoutActor() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	domainEntry, err := history.NewEntry(ctx, lumoID.String(), history.EntityTypeLumo, lumoID.String(), history.OperationCreated, map[string]string{"title": "Japan"})
+	s.Require().NoError(err)
+
+	// Set up expectations
+	s.mockQuerier.On("CreateEntityHistory", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEntityHistoryParams) bool {
+		return !params.Actor.Valid
+	})).Return(createTestHistorySqlc(1, lumoID, lumoID), nil)
+
+	// Act
+	err = Record(ctx, s.mockQuerier, domainEntry)
+
+	// Assert
+	s.NoError(err)
+	s.mockQuerier.AssertExpectations(s.T())
+}
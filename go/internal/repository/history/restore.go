@@ -0,0 +1,376 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/history"
+	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/lumo"
+	"github.com/mcdev12/lumo/go/internal/repository/db"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+// LumoWriter is what a restore needs from the Lumo repository
+type LumoWriter interface {
+	GetLumoByLumoID(ctx context.Context, lumoID string) (*lumo.Lumo, error)
+	CreateLumo(ctx context.Context, domainLumo *lumo.Lumo) (*lumo.Lumo, error)
+	UpdateLumo(ctx context.Context, domainLumo *lumo.Lumo) (*lumo.Lumo, error)
+}
+
+// LumeWriter is what a restore needs from the Lume repository
+type LumeWriter interface {
+	ListLumesByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*lume.Lume, error)
+	CreateLume(ctx context.Context, domainLume *lume.Lume) (*lume.Lume, error)
+	UpdateLume(ctx context.Context, domainLume *lume.Lume) (*lume.Lume, error)
+	DeleteLumeByLumeID(ctx context.Context, lumeID string, expectedVersion *int64) error
+}
+
+// LinkWriter is what a restore needs from the Link repository
+type LinkWriter interface {
+	ListLinksByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*link.Link, error)
+	CreateLink(ctx context.Context, domainLink *link.Link) (*link.Link, error)
+	UpdateLink(ctx context.Context, domainLink *link.Link) (*link.Link, error)
+	DeleteLinkByLinkID(ctx context.Context, linkID string, expectedVersion *int64) error
+}
+
+// Writers are the repositories a restore writes through. Going through them
+// keeps versions, events and history of the restored entities up to date.
+type Writers struct {
+	Lumos LumoWriter
+	Lumes LumeWriter
+	Links LinkWriter
+}
+
+// WritersFunc returns Writers bound to the given transaction
+type WritersFunc func(tx sqlc.DBTX) Writers
+
+// snapshotState is the state of a Lumo's entities at a point in time, as
+// recorded in its history
+type snapshotState struct {
+	lumo        *lumo.Lumo
+	lumoDeleted bool
+	lumes       map[string]*lume.Lume
+	links       map[string]*link.Link
+	// Entities whose latest entry at that time is a deletion
+	deleted map[string]bool
+}
+
+// restorePlan lists the writes that turn the current graph into the past one
+type restorePlan struct {
+	deleteLinks []*link.Link
+	deleteLumes []*lume.Lume
+	createLumes []*lume.Lume
+	updateLumes []*lume.Lume
+	createLinks []*link.Link
+	updateLinks []*link.Link
+	// Resulting graph, minus the Lumo
+	lumes []*lume.Lume
+	links []*link.Link
+}
+
+// RestoreLumo rebuilds a Lumo, its Lumes and Links as they were at the given
+// time inside a single transaction and returns the restored graph.
+// Entities that predate the history are left alone unless they were created
+// after at.
+func (r *Repository) RestoreLumo(ctx context.Context, lumoID string, at time.Time) (*history.Graph, error) {
+	parsedLumoID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored *history.Graph
+	err = db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+		writers := r.writers(tx)
+
+		// Keep other writers of the Lumo out until the restore commits
+		if err := queries.LockLumoEvents(ctx, lumoID); err != nil {
+			return err
+		}
+
+		rows, err := queries.ListLumoHistoryAsOf(ctx, sqlc.ListLumoHistoryAsOfParams{
+			LumoID: parsedLumoID,
+			At:     at,
+		})
+		if err != nil {
+			return err
+		}
+		state, err := stateFromEntries(r.sqlcRowsToDomainModels(rows))
+		if err != nil {
+			return err
+		}
+
+		restoredLumo, err := r.restoreLumo(ctx, writers.Lumos, lumoID, state, at)
+		if err != nil {
+			return err
+		}
+
+		currentLumes, err := lume.ListAll(ctx, writers.Lumes, lumoID)
+		if err != nil {
+			return err
+		}
+		currentLinks, err := link.ListAll(ctx, writers.Links, lumoID)
+		if err != nil {
+			return err
+		}
+
+		plan := planRestore(state, at, currentLumes, currentLinks)
+		if err := r.applyPlan(ctx, writers, plan); err != nil {
+			return err
+		}
+
+		restored = &history.Graph{
+			Lumo:  restoredLumo,
+			Lumes: plan.lumes,
+			Links: plan.links,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+// restoreLumo brings the Lumo itself back to its past state, recreating it if
+// it has been deleted since
+func (r *Repository) restoreLumo(ctx context.Context, lumos LumoWriter, lumoID string, state *snapshotState, at time.Time) (*lumo.Lumo, error) {
+	current, err := lumos.GetLumoByLumoID(ctx, lumoID)
+	if errors.Is(err, sql.ErrNoRows) {
+		current = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case state.lumoDeleted:
+		return nil, history.ErrNotRestorable
+	case state.lumo == nil && (current == nil || current.CreatedAt.After(at)):
+		// No record of the Lumo existing at that time
+		return nil, history.ErrNotRestorable
+	case state.lumo == nil:
+		// The Lumo hasn't changed since before its history starts
+		return current, nil
+	case current == nil:
+		return lumos.CreateLumo(ctx, state.lumo)
+	case current.Title == state.lumo.Title:
+		return current, nil
+	default:
+		current.Title = state.lumo.Title
+		return lumos.UpdateLumo(ctx, current)
+	}
+}
+
+// applyPlan performs the writes of a restore plan. Links go first so that
+// deleted Lumes don't take restored Links with them, and are recreated last
+// so that the Lumes they connect exist.
+func (r *Repository) applyPlan(ctx context.Context, writers Writers, plan *restorePlan) error {
+	for _, domainLink := range plan.deleteLinks {
+		if err := writers.Links.DeleteLinkByLinkID(ctx, domainLink.LinkID, nil); err != nil {
+			return err
+		}
+	}
+	for _, domainLume := range plan.deleteLumes {
+		if err := writers.Lumes.DeleteLumeByLumeID(ctx, domainLume.LumeID, nil); err != nil {
+			return err
+		}
+	}
+	for _, domainLume := range plan.createLumes {
+		if _, err := writers.Lumes.CreateLume(ctx, domainLume); err != nil {
+			return err
+		}
+	}
+	for _, domainLume := range plan.updateLumes {
+		if _, err := writers.Lumes.UpdateLume(ctx, domainLume); err != nil {
+			return err
+		}
+	}
+	for _, domainLink := range plan.createLinks {
+		if _, err := writers.Links.CreateLink(ctx, domainLink); err != nil {
+			return err
+		}
+	}
+	for _, domainLink := range plan.updateLinks {
+		if _, err := writers.Links.UpdateLink(ctx, domainLink); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stateFromEntries decodes the latest history entry of every entity
+func stateFromEntries(entries []*history.Entry) (*snapshotState, error) {
+	state := &snapshotState{
+		lumes:   make(map[string]*lume.Lume),
+		links:   make(map[string]*link.Link),
+		deleted: make(map[string]bool),
+	}
+
+	for _, entry := range entries {
+		if entry.Operation == history.OperationDeleted {
+			state.deleted[entry.EntityID] = true
+			if entry.EntityType == history.EntityTypeLumo {
+				state.lumoDeleted = true
+			}
+			continue
+		}
+
+		switch entry.EntityType {
+		case history.EntityTypeLumo:
+			var domainLumo lumo.Lumo
+			if err := json.Unmarshal(entry.Snapshot, &domainLumo); err != nil {
+				return nil, err
+			}
+			state.lumo = &domainLumo
+		case history.EntityTypeLume:
+			var domainLume lume.Lume
+			if err := json.Unmarshal(entry.Snapshot, &domainLume); err != nil {
+				return nil, err
+			}
+			state.lumes[entry.EntityID] = &domainLume
+		case history.EntityTypeLink:
+			var domainLink link.Link
+			if err := json.Unmarshal(entry.Snapshot, &domainLink); err != nil {
+				return nil, err
+			}
+			state.links[entry.EntityID] = &domainLink
+		}
+	}
+
+	return state, nil
+}
+
+// planRestore works out which Lumes and Links to delete, create and update to
+// go from the current graph back to state. Current entities without history
+// before at are kept if they already existed back then.
+func planRestore(state *snapshotState, at time.Time, currentLumes []*lume.Lume, currentLinks []*link.Link) *restorePlan {
+	plan := &restorePlan{}
+
+	// Lumes
+	currentLumeIDs := make(map[string]bool, len(currentLumes))
+	keptLumeIDs := make(map[string]bool)
+	for _, current := range currentLumes {
+		currentLumeIDs[current.LumeID] = true
+
+		past, ok := state.lumes[current.LumeID]
+		switch {
+		case ok:
+			keptLumeIDs[current.LumeID] = true
+			if lumeChanged(current, past) {
+				restored := *past
+				restored.ID = current.ID
+				restored.LumoID = current.LumoID
+				restored.Version = current.Version
+				plan.updateLumes = append(plan.updateLumes, &restored)
+				plan.lumes = append(plan.lumes, &restored)
+			} else {
+				plan.lumes = append(plan.lumes, current)
+			}
+		case state.deleted[current.LumeID] || current.CreatedAt.After(at):
+			plan.deleteLumes = append(plan.deleteLumes, current)
+		default:
+			keptLumeIDs[current.LumeID] = true
+			plan.lumes = append(plan.lumes, current)
+		}
+	}
+	for _, lumeID := range sortedKeys(state.lumes) {
+		if currentLumeIDs[lumeID] {
+			continue
+		}
+		past := state.lumes[lumeID]
+		plan.createLumes = append(plan.createLumes, past)
+		plan.lumes = append(plan.lumes, past)
+		keptLumeIDs[lumeID] = true
+	}
+
+	// Links, which only survive if both of their Lumes do
+	connects := func(l *link.Link) bool {
+		return keptLumeIDs[l.FromLumeID] && keptLumeIDs[l.ToLumeID]
+	}
+	currentLinkIDs := make(map[string]bool, len(currentLinks))
+	for _, current := range currentLinks {
+		currentLinkIDs[current.LinkID] = true
+
+		past, ok := state.links[current.LinkID]
+		switch {
+		case ok && connects(past):
+			if linkChanged(current, past) {
+				restored := *past
+				restored.ID = current.ID
+				restored.Version = current.Version
+				plan.updateLinks = append(plan.updateLinks, &restored)
+				plan.links = append(plan.links, &restored)
+			} else {
+				plan.links = append(plan.links, current)
+			}
+		case ok || state.deleted[current.LinkID] || current.CreatedAt.After(at) || !connects(current):
+			plan.deleteLinks = append(plan.deleteLinks, current)
+		default:
+			plan.links = append(plan.links, current)
+		}
+	}
+	for _, linkID := range sortedKeys(state.links) {
+		past := state.links[linkID]
+		if currentLinkIDs[linkID] || !connects(past) {
+			continue
+		}
+		plan.createLinks = append(plan.createLinks, past)
+		plan.links = append(plan.links, past)
+	}
+
+	return plan
+}
+
+// lumeChanged reports whether the user visible fields of two Lumes differ
+func lumeChanged(current, past *lume.Lume) bool {
+	return !sameJSON(comparableLume(*current), comparableLume(*past))
+}
+
+// linkChanged reports whether the user visible fields of two Links differ
+func linkChanged(current, past *link.Link) bool {
+	return !sameJSON(comparableLink(*current), comparableLink(*past))
+}
+
+// comparableLume clears the fields a restore doesn't bring back
+func comparableLume(l lume.Lume) lume.Lume {
+	l.ID = 0
+	l.CreatedAt = time.Time{}
+	l.UpdatedAt = time.Time{}
+	l.Version = 0
+	return l
+}
+
+// comparableLink clears the fields a restore doesn't bring back
+func comparableLink(l link.Link) link.Link {
+	l.ID = 0
+	l.CreatedAt = time.Time{}
+	l.UpdatedAt = time.Time{}
+	l.Version = 0
+	return l
+}
+
+// sameJSON compares two values by their JSON encoding
+func sameJSON(a, b any) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+// sortedKeys returns the keys of m in order, so restores write in a stable order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,157 @@
+package history
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/models/history"
+	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/lumo"
+	"github.com/stretchr/testify/suite"
+)
+
+// RestoreTestSuite is a test suite for working out restores
+type RestoreTestSuite struct {
+	suite.Suite
+	lumoID string
+	at     time.Time
+}
+
+// SetupTest is called before each test
+func (s *RestoreTestSuite) SetupTest() {
+	s.lumoID = "11111111-1111-1111-1111-111111111111"
+	s.at = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+// TestRestoreSuite runs the test suite
+func TestRestoreSuite(t *testing.T) {
+	suite.Run(t, new(RestoreTestSuite))
+}
+
+// Helper function to create a Lume created at the given time
+func (s *RestoreTestSuite) newLume(name string, createdAt time.Time) *lume.Lume {
+	l := lume.NewLume(s.lumoID, lume.LumeTypeCity, name)
+	l.CreatedAt = createdAt
+	l.Version = 3
+	return l
+}
+
+// Helper function to create a Link created at the given time
+func newLink(from, to *lume.Lume, createdAt time.Time) *link.Link {
+	l := link.NewLink(from.LumeID, to.LumeID, link.LinkTypeTravel)
+	l.CreatedAt = createdAt
+	return l
+}
+
+// Helper function to build a history entry for an entity
+func (s *RestoreTestSuite) entry(entityType history.EntityType, entityID string, operation history.Operation, entity any) *history.Entry {
+	snapshot, err := json.Marshal(entity)
+	s.Require().NoError(err)
+	return &history.Entry{
+		LumoID:     s.lumoID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Operation:  operation,
+		Snapshot:   snapshot,
+	}
+}
+
+// Test stateFromEntries decodes snapshots and tracks deletions
+func (s *RestoreTestSuite) TestStateFromEntries() {
+	// Arrange
+	paris := s.newLume("Paris", s.at)
+	gone := s.newLume("Gone", s.at)
+	entries := []*history.Entry{
+		s.entry(history.EntityTypeLumo, s.lumoID, history.OperationUpdated, lumo.Lumo{LumoID: s.lumoID, Title: "France"}),
+		s.entry(history.EntityTypeLume, paris.LumeID, history.OperationUpdated, paris),
+		s.entry(history.EntityTypeLume, gone.LumeID, history.OperationDeleted, gone),
+	}
+
+	// Act
+	state, err := stateFromEntries(entries)
+
+	// Assert
+	s.NoError(err)
+	s.Equal("France", state.lumo.Title)
+	s.False(state.lumoDeleted)
+	s.Len(state.lumes, 1)
+	s.Equal("Paris", state.lumes[paris.LumeID].Name)
+	s.True(state.deleted[gone.LumeID])
+}
+
+// Test planRestore brings back, reverts and removes Lumes
+func (s *RestoreTestSuite) TestPlanRestoreLumes() {
+	// Arrange
+	before := s.at.Add(-time.Hour)
+	after := s.at.Add(time.Hour)
+
+	renamed := s.newLume("Paris", before)
+	renamedPast := *renamed
+	renamedPast.Name = "Lyon"
+	unchanged := s.newLume("Nice", before)
+	untracked := s.newLume("Marseille", before)
+	added := s.newLume("Bordeaux", after)
+	deleted := s.newLume("Lille", before)
+
+	state := &snapshotState{
+		lumes: map[string]*lume.Lume{
+			renamed.LumeID:   &renamedPast,
+			unchanged.LumeID: unchanged,
+			deleted.LumeID:   deleted,
+		},
+		links:   map[string]*link.Link{},
+		deleted: map[string]bool{},
+	}
+
+	// Act
+	plan := planRestore(state, s.at, []*lume.Lume{renamed, unchanged, untracked, added}, nil)
+
+	// Assert
+	s.Len(plan.updateLumes, 1)
+	s.Equal("Lyon", plan.updateLumes[0].Name)
+	s.Equal(renamed.Version, plan.updateLumes[0].Version)
+	s.Len(plan.deleteLumes, 1)
+	s.Equal(added.LumeID, plan.deleteLumes[0].LumeID)
+	s.Len(plan.createLumes, 1)
+	s.Equal(deleted.LumeID, plan.createLumes[0].LumeID)
+	// Lumes without history that already existed are kept
+	s.Len(plan.lumes, 4)
+}
+
+// Test planRestore drops Links whose Lumes don't come back
+func (s *RestoreTestSuite) TestPlanRestoreLinks() {
+	// Arrange
+	before := s.at.Add(-time.Hour)
+	after := s.at.Add(time.Hour)
+
+	paris := s.newLume("Paris", before)
+	lyon := s.newLume("Lyon", before)
+	nice := s.newLume("Nice", after)
+
+	kept := newLink(paris, lyon, before)
+	toNice := newLink(lyon, nice, after)
+	removed := newLink(lyon, paris, before)
+	orphan := newLink(paris, s.newLume("Lille", before), before)
+
+	state := &snapshotState{
+		lumes: map[string]*lume.Lume{},
+		links: map[string]*link.Link{
+			removed.LinkID: removed,
+			orphan.LinkID:  orphan,
+		},
+		deleted: map[string]bool{nice.LumeID: true},
+	}
+
+	// Act
+	plan := planRestore(state, s.at, []*lume.Lume{paris, lyon, nice}, []*link.Link{kept, toNice})
+
+	// Assert
+	s.Len(plan.deleteLumes, 1)
+	s.Len(plan.deleteLinks, 1)
+	s.Equal(toNice.LinkID, plan.deleteLinks[0].LinkID)
+	s.Len(plan.createLinks, 1)
+	s.Equal(removed.LinkID, plan.createLinks[0].LinkID)
+	s.Len(plan.links, 2)
+}
@@ -0,0 +1,109 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/models/idempotency"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+//go:generate mockery
+type IdempotencyQuerier interface {
+	ClaimIdempotencyKey(ctx context.Context, arg sqlc.ClaimIdempotencyKeyParams) (int64, error)
+	CompleteIdempotencyKey(ctx context.Context, arg sqlc.CompleteIdempotencyKeyParams) error
+	GetIdempotencyKey(ctx context.Context, arg sqlc.GetIdempotencyKeyParams) (sqlc.IdempotencyKey, error)
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+	ReleaseIdempotencyKey(ctx context.Context, arg sqlc.ReleaseIdempotencyKeyParams) error
+}
+
+// Repository is the concrete implementation for idempotency key data access
+type Repository struct {
+	queries IdempotencyQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(conn sqlc.DBTX) *Repository {
+	return &Repository{
+		queries: sqlc.New(conn),
+	}
+}
+
+// Claim records the key for a call that is about to run. It reports false,
+// without changing anything, when the key is held by another call, i.e.
+// hasn't expired yet and either has a response or is still locked.
+func (r *Repository) Claim(ctx context.Context, key *idempotency.Key) (bool, error) {
+	claimed, err := r.queries.ClaimIdempotencyKey(ctx, sqlc.ClaimIdempotencyKeyParams{
+		Scope:       key.Scope,
+		Key:         key.Key,
+		RequestHash: key.RequestHash,
+		LockedUntil: key.LockedUntil,
+		ExpiresAt:   key.ExpiresAt,
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed > 0, nil
+}
+
+// Get retrieves a key by the scope it belongs to
+func (r *Repository) Get(ctx context.Context, scope, key string) (*idempotency.Key, error) {
+	result, err := r.queries.GetIdempotencyKey(ctx, sqlc.GetIdempotencyKeyParams{
+		Scope: scope,
+		Key:   key,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, idempotency.ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// Complete stores the response of the call holding the key
+func (r *Repository) Complete(ctx context.Context, key *idempotency.Key) error {
+	response := key.Response
+	if response == nil {
+		// NULL marks the call as still running
+		response = []byte{}
+	}
+	return r.queries.CompleteIdempotencyKey(ctx, sqlc.CompleteIdempotencyKeyParams{
+		Response:    response,
+		Scope:       key.Scope,
+		Key:         key.Key,
+		RequestHash: key.RequestHash,
+	})
+}
+
+// Release frees the key of a call that failed, so it can be retried
+func (r *Repository) Release(ctx context.Context, key *idempotency.Key) error {
+	return r.queries.ReleaseIdempotencyKey(ctx, sqlc.ReleaseIdempotencyKeyParams{
+		Scope:       key.Scope,
+		Key:         key.Key,
+		RequestHash: key.RequestHash,
+	})
+}
+
+// Purge permanently deletes the keys that expired before the cutoff and
+// returns how many there were
+func (r *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.PurgeIdempotencyKeys(ctx, before)
+}
+
+// Helper method to convert SQLC results to domain model
+func (r *Repository) sqlcRowToDomainModel(row sqlc.IdempotencyKey) *idempotency.Key {
+	return &idempotency.Key{
+		ID:          row.ID,
+		Scope:       row.Scope,
+		Key:         row.Key,
+		RequestHash: row.RequestHash,
+		Response:    row.Response,
+		CreatedAt:   row.CreatedAt,
+		LockedUntil: row.LockedUntil,
+		ExpiresAt:   row.ExpiresAt,
+	}
+}
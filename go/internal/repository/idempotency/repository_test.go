@@ -0,0 +1,143 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/models/idempotency"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/mcdev12/lumo/go/internal/repository/idempotency/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockIdempotencyQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockIdempotencyQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Test Claim reports whether the key was free
+func (s *RepositoryTestSuite) TestClaim() {
+	// Arrange
+	ctx := context.Background()
+	key := &idempotency.Key{
+		Scope:       "user-1",
+		Key:         "key-1",
+		RequestHash: []byte{1, 2, 3},
+		LockedUntil: time.Now().Add(time.Minute),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	params := sqlc.ClaimIdempotencyKeyParams{
+		Scope:       key.Scope,
+		Key:         key.Key,
+		RequestHash: key.RequestHash,
+		LockedUntil: key.LockedUntil,
+		ExpiresAt:   key.ExpiresAt,
+	}
+
+	// Set up expectations
+	s.mockQuerier.On("ClaimIdempotencyKey", ctx, params).Return(int64(1), nil).Once()
+	s.mockQuerier.On("ClaimIdempotencyKey", ctx, params).Return(int64(0), nil).Once()
+
+	// Act
+	first, err1 := s.repository.Claim(ctx, key)
+	second, err2 := s.repository.Claim(ctx, key)
+
+	// Assert
+	s.NoError(err1)
+	s.True(first)
+	s.NoError(err2)
+	s.False(second)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test Get maps rows to keys, telling pending keys from completed ones
+func (s *RepositoryTestSuite) TestGet() {
+	// Arrange
+	ctx := context.Background()
+	params := sqlc.GetIdempotencyKeyParams{Scope: "user-1", Key: "key-1"}
+
+	// Set up expectations
+	s.mockQuerier.On("GetIdempotencyKey", ctx, params).Return(sqlc.IdempotencyKey{
+		ID:          1,
+		Scope:       "user-1",
+		Key:         "key-1",
+		RequestHash: []byte{1, 2, 3},
+	}, nil).Once()
+	s.mockQuerier.On("GetIdempotencyKey", ctx, params).Return(sqlc.IdempotencyKey{
+		ID:          1,
+		Scope:       "user-1",
+		Key:         "key-1",
+		RequestHash: []byte{1, 2, 3},
+		Response:    []byte{4, 5},
+	}, nil).Once()
+
+	// Act
+	pending, err1 := s.repository.Get(ctx, "user-1", "key-1")
+	completed, err2 := s.repository.Get(ctx, "user-1", "key-1")
+
+	// Assert
+	s.NoError(err1)
+	s.False(pending.Completed())
+	s.NoError(err2)
+	s.True(completed.Completed())
+	s.Equal([]byte{4, 5}, completed.Response)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test Get returns ErrKeyNotFound for unknown keys
+func (s *RepositoryTestSuite) TestGetNotFound() {
+	// Arrange
+	ctx := context.Background()
+
+	// Set up expectations
+	s.mockQuerier.On("GetIdempotencyKey", ctx, sqlc.GetIdempotencyKeyParams{Scope: "", Key: "key-1"}).
+		Return(sqlc.IdempotencyKey{}, sql.ErrNoRows)
+
+	// Act
+	key, err := s.repository.Get(ctx, "", "key-1")
+
+	// Assert
+	s.ErrorIs(err, idempotency.ErrKeyNotFound)
+	s.Nil(key)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test Complete never stores an empty response as NULL, which would leave the
+// key pending
+func (s *RepositoryTestSuite) TestCompleteEmptyResponse() {
+	// Arrange
+	ctx := context.Background()
+	key := &idempotency.Key{Scope: "user-1", Key: "key-1", RequestHash: []byte{1}}
+
+	// Set up expectations
+	s.mockQuerier.On("CompleteIdempotencyKey", ctx, sqlc.CompleteIdempotencyKeyParams{
+		Response:    []byte{},
+		Scope:       "user-1",
+		Key:         "key-1",
+		RequestHash: []byte{1},
+	}).Return(nil)
+
+	// Act
+	err := s.repository.Complete(ctx, key)
+
+	// Assert
+	s.NoError(err)
+	s.mockQuerier.AssertExpectations(s.T())
+}
@@ -0,0 +1,202 @@
+package layout
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/layout"
+	"github.com/mcdev12/lumo/go/internal/repository/db"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+//go:generate mockery
+type LayoutQuerier interface {
+	GetLumoViewportByLumoID(ctx context.Context, lumoID uuid.UUID) (sqlc.LumoViewport, error)
+	ListLumeIDsByLumoID(ctx context.Context, lumoID uuid.UUID) ([]uuid.UUID, error)
+	ListLumeLayoutsByLumoID(ctx context.Context, lumoID uuid.UUID) ([]sqlc.LumeLayout, error)
+	UpsertLumeLayout(ctx context.Context, arg sqlc.UpsertLumeLayoutParams) (sqlc.LumeLayout, error)
+	UpsertLumoViewport(ctx context.Context, arg sqlc.UpsertLumoViewportParams) (sqlc.LumoViewport, error)
+}
+
+// Repository is the concrete implementation for Layout data access
+type Repository struct {
+	db      sqlc.DBTX
+	queries LayoutQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(conn sqlc.DBTX) *Repository {
+	return &Repository{
+		db:      conn,
+		queries: sqlc.New(conn),
+	}
+}
+
+// GetLayoutByLumoID retrieves all node placements and the viewport of a Lumo
+func (r *Repository) GetLayoutByLumoID(ctx context.Context, lumoID string) (*layout.Layout, error) {
+	parsedLumoID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := r.queries.ListLumeLayoutsByLumoID(ctx, parsedLumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	domainLayout := &layout.Layout{
+		LumoID: lumoID,
+		Nodes:  make([]*layout.NodeLayout, len(results)),
+	}
+	for i, result := range results {
+		domainLayout.Nodes[i] = r.sqlcNodeToDomainModel(result)
+	}
+
+	viewport, err := r.queries.GetLumoViewportByLumoID(ctx, parsedLumoID)
+	switch {
+	case err == nil:
+		domainLayout.Viewport = r.sqlcViewportToDomainModel(viewport)
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	return domainLayout, nil
+}
+
+// ListLumeIDsByLumoID returns the UUIDs of every Lume in a Lumo
+func (r *Repository) ListLumeIDsByLumoID(ctx context.Context, lumoID string) ([]string, error) {
+	parsedLumoID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := r.queries.ListLumeIDsByLumoID(ctx, parsedLumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	lumeIDs := make([]string, len(results))
+	for i, result := range results {
+		lumeIDs[i] = result.String()
+	}
+
+	return lumeIDs, nil
+}
+
+// SaveLayout upserts a batch of node placements and, if given, the viewport
+// of a Lumo in a single transaction
+func (r *Repository) SaveLayout(ctx context.Context, lumoID string, nodes []*layout.NodeLayout, viewport *layout.Viewport) (*layout.Layout, error) {
+	saved := &layout.Layout{
+		LumoID: lumoID,
+		Nodes:  make([]*layout.NodeLayout, 0, len(nodes)),
+	}
+
+	err := db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		for _, node := range nodes {
+			result, err := queries.UpsertLumeLayout(ctx, r.domainNodeToUpsertParams(node))
+			if err != nil {
+				return err
+			}
+			saved.Nodes = append(saved.Nodes, r.sqlcNodeToDomainModel(result))
+		}
+
+		if viewport != nil {
+			result, err := queries.UpsertLumoViewport(ctx, r.domainViewportToUpsertParams(viewport))
+			if err != nil {
+				return err
+			}
+			saved.Viewport = r.sqlcViewportToDomainModel(result)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// querierFor returns the querier to use on the given connection
+func (r *Repository) querierFor(conn sqlc.DBTX) LayoutQuerier {
+	if conn == nil || conn == r.db {
+		return r.queries
+	}
+	return sqlc.New(conn)
+}
+
+// Helper method to convert domain NodeLayout to SQLC UpsertLumeLayoutParams
+func (r *Repository) domainNodeToUpsertParams(node *layout.NodeLayout) sqlc.UpsertLumeLayoutParams {
+	params := sqlc.UpsertLumeLayoutParams{
+		LumeID:    uuid.MustParse(node.LumeID),
+		LumoID:    uuid.MustParse(node.LumoID),
+		PositionX: node.X,
+		PositionY: node.Y,
+		Collapsed: node.Collapsed,
+		CreatedAt: node.CreatedAt,
+		UpdatedAt: node.UpdatedAt,
+	}
+
+	// Handle optional size
+	if node.Width != nil {
+		params.Width = sql.NullFloat64{Float64: *node.Width, Valid: true}
+	}
+	if node.Height != nil {
+		params.Height = sql.NullFloat64{Float64: *node.Height, Valid: true}
+	}
+
+	return params
+}
+
+// Helper method to convert domain Viewport to SQLC UpsertLumoViewportParams
+func (r *Repository) domainViewportToUpsertParams(viewport *layout.Viewport) sqlc.UpsertLumoViewportParams {
+	return sqlc.UpsertLumoViewportParams{
+		LumoID:    uuid.MustParse(viewport.LumoID),
+		X:         viewport.X,
+		Y:         viewport.Y,
+		Zoom:      viewport.Zoom,
+		CreatedAt: viewport.CreatedAt,
+		UpdatedAt: viewport.UpdatedAt,
+	}
+}
+
+// Helper method to convert SQLC layout rows to domain model
+func (r *Repository) sqlcNodeToDomainModel(row sqlc.LumeLayout) *layout.NodeLayout {
+	domainNode := &layout.NodeLayout{
+		ID:        row.ID,
+		LumeID:    row.LumeID.String(),
+		LumoID:    row.LumoID.String(),
+		X:         row.PositionX,
+		Y:         row.PositionY,
+		Collapsed: row.Collapsed,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+
+	// Handle optional size
+	if row.Width.Valid {
+		domainNode.Width = &row.Width.Float64
+	}
+	if row.Height.Valid {
+		domainNode.Height = &row.Height.Float64
+	}
+
+	return domainNode
+}
+
+// Helper method to convert SQLC viewport rows to domain model
+func (r *Repository) sqlcViewportToDomainModel(row sqlc.LumoViewport) *layout.Viewport {
+	return &layout.Viewport{
+		ID:        row.ID,
+		LumoID:    row.LumoID.String(),
+		X:         row.X,
+		Y:         row.Y,
+		Zoom:      row.Zoom,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
@@ -0,0 +1,207 @@
+package layout
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/layout"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/mcdev12/lumo/go/internal/repository/layout/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockLayoutQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockLayoutQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Helper function to create a test sqlc.LumeLayout database model
+func createTestLumeLayoutSqlc(lumoID uuid.UUID) sqlc.LumeLayout {
+	now := time.Now()
+	return sqlc.LumeLayout{
+		ID:        1,
+		LumeID:    uuid.New(),
+		LumoID:    lumoID,
+		PositionX: 120,
+		PositionY: -45.5,
+		Width:     sql.NullFloat64{Float64: 240, Valid: true},
+		Collapsed: true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Helper function to create a test sqlc.LumoViewport database model
+func createTestLumoViewportSqlc(lumoID uuid.UUID) sqlc.LumoViewport {
+	now := time.Now()
+	return sqlc.LumoViewport{
+		ID:        1,
+		LumoID:    lumoID,
+		X:         10,
+		Y:         20,
+		Zoom:      1.5,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Test GetLayoutByLumoID
+func (s *RepositoryTestSuite) TestGetLayoutByLumoID() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	sqlcLayout := createTestLumeLayoutSqlc(lumoID)
+	sqlcViewport := createTestLumoViewportSqlc(lumoID)
+
+	// Set up expectations
+	s.mockQuerier.On("ListLumeLayoutsByLumoID", mock.Anything, lumoID).Return([]sqlc.LumeLayout{sqlcLayout}, nil)
+	s.mockQuerier.On("GetLumoViewportByLumoID", mock.Anything, lumoID).Return(sqlcViewport, nil)
+
+	// Act
+	result, err := s.repository.GetLayoutByLumoID(ctx, lumoID.String())
+
+	// Assert
+	s.NoError(err)
+	s.Equal(lumoID.String(), result.LumoID)
+	s.Len(result.Nodes, 1)
+	s.Equal(sqlcLayout.LumeID.String(), result.Nodes[0].LumeID)
+	s.Equal(sqlcLayout.PositionX, result.Nodes[0].X)
+	s.Equal(sqlcLayout.PositionY, result.Nodes[0].Y)
+	s.Equal(sqlcLayout.Width.Float64, *result.Nodes[0].Width)
+	s.Nil(result.Nodes[0].Height)
+	s.True(result.Nodes[0].Collapsed)
+	s.NotNil(result.Viewport)
+	s.Equal(sqlcViewport.Zoom, result.Viewport.Zoom)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test GetLayoutByLumoID when no viewport has been saved yet
+func (s *RepositoryTestSuite) TestGetLayoutByLumoIDWithoutViewport() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+
+	// Set up expectations
+	s.mockQuerier.On("ListLumeLayoutsByLumoID", mock.Anything, lumoID).Return([]sqlc.LumeLayout{}, nil)
+	s.mockQuerier.On("GetLumoViewportByLumoID", mock.Anything, lumoID).Return(sqlc.LumoViewport{}, sql.ErrNoRows)
+
+	// Act
+	result, err := s.repository.GetLayoutByLumoID(ctx, lumoID.String())
+
+	// Assert
+	s.NoError(err)
+	s.Empty(result.Nodes)
+	s.Nil(result.Viewport)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test GetLayoutByLumoID with invalid UUID
+func (s *RepositoryTestSuite) TestGetLayoutByLumoIDInvalidUUID() {
+	// Act
+	result, err := s.repository.GetLayoutByLumoID(context.Background(), "invalid-uuid")
+
+	// Assert
+	s.Error(err)
+	s.Nil(result)
+}
+
+// Test ListLumeIDsByLumoID
+func (s *RepositoryTestSuite) TestListLumeIDsByLumoID() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	lumeIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	// Set up expectations
+	s.mockQuerier.On("ListLumeIDsByLumoID", mock.Anything, lumoID).Return(lumeIDs, nil)
+
+	// Act
+	result, err := s.repository.ListLumeIDsByLumoID(ctx, lumoID.String())
+
+	// Assert
+	s.NoError(err)
+	s.Equal([]string{lumeIDs[0].String(), lumeIDs[1].String()}, result)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test SaveLayout
+func (s *RepositoryTestSuite) TestSaveLayout() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	sqlcLayout := createTestLumeLayoutSqlc(lumoID)
+	sqlcViewport := createTestLumoViewportSqlc(lumoID)
+
+	node := layout.NewNodeLayout(lumoID.String(), sqlcLayout.LumeID.String())
+	node.X = sqlcLayout.PositionX
+	node.Y = sqlcLayout.PositionY
+	node.Width = &sqlcLayout.Width.Float64
+	node.Collapsed = true
+
+	viewport := layout.NewViewport(lumoID.String())
+	viewport.Zoom = sqlcViewport.Zoom
+
+	// Set up expectations
+	s.mockQuerier.On("UpsertLumeLayout", mock.Anything, mock.MatchedBy(func(params sqlc.UpsertLumeLayoutParams) bool {
+		return params.LumeID == sqlcLayout.LumeID &&
+			params.PositionX == sqlcLayout.PositionX &&
+			params.Width.Valid && !params.Height.Valid &&
+			params.Collapsed
+	})).Return(sqlcLayout, nil)
+	s.mockQuerier.On("UpsertLumoViewport", mock.Anything, mock.AnythingOfType("sqlc.UpsertLumoViewportParams")).Return(sqlcViewport, nil)
+
+	// Act
+	result, err := s.repository.SaveLayout(ctx, lumoID.String(), []*layout.NodeLayout{node}, viewport)
+
+	// Assert
+	s.NoError(err)
+	s.Len(result.Nodes, 1)
+	s.Equal(node.LumeID, result.Nodes[0].LumeID)
+	s.NotNil(result.Viewport)
+	s.Equal(viewport.Zoom, result.Viewport.Zoom)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test SaveLayout stops at the first failing upsert
+func (s *RepositoryTestSuite) TestSaveLayoutError() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New().String()
+	nodes := []*layout.NodeLayout{
+		layout.NewNodeLayout(lumoID, uuid.New().String()),
+		layout.NewNodeLayout(lumoID, uuid.New().String()),
+	}
+	expectedErr := errors.New("database error")
+
+	// Set up expectations
+	s.mockQuerier.On("UpsertLumeLayout", mock.Anything, mock.AnythingOfType("sqlc.UpsertLumeLayoutParams")).Return(sqlc.LumeLayout{}, expectedErr).Once()
+
+	// Act
+	result, err := s.repository.SaveLayout(ctx, lumoID, nodes, nil)
+
+	// Assert
+	s.Error(err)
+	s.Nil(result)
+	s.Equal(expectedErr, err)
+	s.mockQuerier.AssertExpectations(s.T())
+}
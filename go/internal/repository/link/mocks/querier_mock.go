@@ -237,6 +237,72 @@ func (_c *MockLinkQuerier_CountLinksByToLumeID_Call) RunAndReturn(run func(ctx c
 	return _c
 }
 
+// CreateEntityHistory provides a mock function for the type MockLinkQuerier
+func (_mock *MockLinkQuerier) CreateEntityHistory(ctx context.Context, arg sqlc.CreateEntityHistoryParams) (sqlc.EntityHistory, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEntityHistory")
+	}
+
+	var r0 sqlc.EntityHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.CreateEntityHistoryParams) (sqlc.EntityHistory, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.CreateEntityHistoryParams) sqlc.EntityHistory); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(sqlc.EntityHistory)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sqlc.CreateEntityHistoryParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLinkQuerier_CreateEntityHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEntityHistory'
+type MockLinkQuerier_CreateEntityHistory_Call struct {
+	*mock.Call
+}
+
+// CreateEntityHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg sqlc.CreateEntityHistoryParams
+func (_e *MockLinkQuerier_Expecter) CreateEntityHistory(ctx interface{}, arg interface{}) *MockLinkQuerier_CreateEntityHistory_Call {
+	return &MockLinkQuerier_CreateEntityHistory_Call{Call: _e.mock.On("CreateEntityHistory", ctx, arg)}
+}
+
+func (_c *MockLinkQuerier_CreateEntityHistory_Call) Run(run func(ctx context.Context, arg sqlc.CreateEntityHistoryParams)) *MockLinkQuerier_CreateEntityHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sqlc.CreateEntityHistoryParams
+		if args[1] != nil {
+			arg1 = args[1].(sqlc.CreateEntityHistoryParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkQuerier_CreateEntityHistory_Call) Return(entityHistory sqlc.EntityHistory, err error) *MockLinkQuerier_CreateEntityHistory_Call {
+	_c.Call.Return(entityHistory, err)
+	return _c
+}
+
+func (_c *MockLinkQuerier_CreateEntityHistory_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.CreateEntityHistoryParams) (sqlc.EntityHistory, error)) *MockLinkQuerier_CreateEntityHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateLink provides a mock function for the type MockLinkQuerier
 func (_mock *MockLinkQuerier) CreateLink(ctx context.Context, arg sqlc.CreateLinkParams) (sqlc.Link, error) {
 	ret := _mock.Called(ctx, arg)
@@ -303,23 +369,155 @@ func (_c *MockLinkQuerier_CreateLink_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
-// DeleteLink provides a mock function for the type MockLinkQuerier
-func (_mock *MockLinkQuerier) DeleteLink(ctx context.Context, id int64) error {
-	ret := _mock.Called(ctx, id)
+// CreateLumoEvent provides a mock function for the type MockLinkQuerier
+func (_mock *MockLinkQuerier) CreateLumoEvent(ctx context.Context, arg sqlc.CreateLumoEventParams) (sqlc.LumoEvent, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteLink")
+		panic("no return value specified for CreateLumoEvent")
+	}
+
+	var r0 sqlc.LumoEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.CreateLumoEventParams) (sqlc.LumoEvent, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.CreateLumoEventParams) sqlc.LumoEvent); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(sqlc.LumoEvent)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sqlc.CreateLumoEventParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLinkQuerier_CreateLumoEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLumoEvent'
+type MockLinkQuerier_CreateLumoEvent_Call struct {
+	*mock.Call
+}
+
+// CreateLumoEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg sqlc.CreateLumoEventParams
+func (_e *MockLinkQuerier_Expecter) CreateLumoEvent(ctx interface{}, arg interface{}) *MockLinkQuerier_CreateLumoEvent_Call {
+	return &MockLinkQuerier_CreateLumoEvent_Call{Call: _e.mock.On("CreateLumoEvent", ctx, arg)}
+}
+
+func (_c *MockLinkQuerier_CreateLumoEvent_Call) Run(run func(ctx context.Context, arg sqlc.CreateLumoEventParams)) *MockLinkQuerier_CreateLumoEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sqlc.CreateLumoEventParams
+		if args[1] != nil {
+			arg1 = args[1].(sqlc.CreateLumoEventParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkQuerier_CreateLumoEvent_Call) Return(lumoEvent sqlc.LumoEvent, err error) *MockLinkQuerier_CreateLumoEvent_Call {
+	_c.Call.Return(lumoEvent, err)
+	return _c
+}
+
+func (_c *MockLinkQuerier_CreateLumoEvent_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.CreateLumoEventParams) (sqlc.LumoEvent, error)) *MockLinkQuerier_CreateLumoEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOutboxEvent provides a mock function for the type MockLinkQuerier
+func (_mock *MockLinkQuerier) CreateOutboxEvent(ctx context.Context, arg sqlc.CreateOutboxEventParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOutboxEvent")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.CreateOutboxEventParams) error); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
+// MockLinkQuerier_CreateOutboxEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOutboxEvent'
+type MockLinkQuerier_CreateOutboxEvent_Call struct {
+	*mock.Call
+}
+
+// CreateOutboxEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg sqlc.CreateOutboxEventParams
+func (_e *MockLinkQuerier_Expecter) CreateOutboxEvent(ctx interface{}, arg interface{}) *MockLinkQuerier_CreateOutboxEvent_Call {
+	return &MockLinkQuerier_CreateOutboxEvent_Call{Call: _e.mock.On("CreateOutboxEvent", ctx, arg)}
+}
+
+func (_c *MockLinkQuerier_CreateOutboxEvent_Call) Run(run func(ctx context.Context, arg sqlc.CreateOutboxEventParams)) *MockLinkQuerier_CreateOutboxEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sqlc.CreateOutboxEventParams
+		if args[1] != nil {
+			arg1 = args[1].(sqlc.CreateOutboxEventParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkQuerier_CreateOutboxEvent_Call) Return(err error) *MockLinkQuerier_CreateOutboxEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLinkQuerier_CreateOutboxEvent_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.CreateOutboxEventParams) error) *MockLinkQuerier_CreateOutboxEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteLink provides a mock function for the type MockLinkQuerier
+func (_mock *MockLinkQuerier) DeleteLink(ctx context.Context, arg sqlc.DeleteLinkParams) (sqlc.Link, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLink")
+	}
+
+	var r0 sqlc.Link
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.DeleteLinkParams) (sqlc.Link, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.DeleteLinkParams) sqlc.Link); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(sqlc.Link)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sqlc.DeleteLinkParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
 // MockLinkQuerier_DeleteLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLink'
 type MockLinkQuerier_DeleteLink_Call struct {
 	*mock.Call
@@ -327,20 +525,20 @@ type MockLinkQuerier_DeleteLink_Call struct {
 
 // DeleteLink is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
-func (_e *MockLinkQuerier_Expecter) DeleteLink(ctx interface{}, id interface{}) *MockLinkQuerier_DeleteLink_Call {
-	return &MockLinkQuerier_DeleteLink_Call{Call: _e.mock.On("DeleteLink", ctx, id)}
+//   - arg sqlc.DeleteLinkParams
+func (_e *MockLinkQuerier_Expecter) DeleteLink(ctx interface{}, arg interface{}) *MockLinkQuerier_DeleteLink_Call {
+	return &MockLinkQuerier_DeleteLink_Call{Call: _e.mock.On("DeleteLink", ctx, arg)}
 }
 
-func (_c *MockLinkQuerier_DeleteLink_Call) Run(run func(ctx context.Context, id int64)) *MockLinkQuerier_DeleteLink_Call {
+func (_c *MockLinkQuerier_DeleteLink_Call) Run(run func(ctx context.Context, arg sqlc.DeleteLinkParams)) *MockLinkQuerier_DeleteLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int64
+		var arg1 sqlc.DeleteLinkParams
 		if args[1] != nil {
-			arg1 = args[1].(int64)
+			arg1 = args[1].(sqlc.DeleteLinkParams)
 		}
 		run(
 			arg0,
@@ -350,31 +548,40 @@ func (_c *MockLinkQuerier_DeleteLink_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockLinkQuerier_DeleteLink_Call) Return(err error) *MockLinkQuerier_DeleteLink_Call {
-	_c.Call.Return(err)
+func (_c *MockLinkQuerier_DeleteLink_Call) Return(link sqlc.Link, err error) *MockLinkQuerier_DeleteLink_Call {
+	_c.Call.Return(link, err)
 	return _c
 }
 
-func (_c *MockLinkQuerier_DeleteLink_Call) RunAndReturn(run func(ctx context.Context, id int64) error) *MockLinkQuerier_DeleteLink_Call {
+func (_c *MockLinkQuerier_DeleteLink_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.DeleteLinkParams) (sqlc.Link, error)) *MockLinkQuerier_DeleteLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteLinkByLinkID provides a mock function for the type MockLinkQuerier
-func (_mock *MockLinkQuerier) DeleteLinkByLinkID(ctx context.Context, linkID uuid.UUID) error {
-	ret := _mock.Called(ctx, linkID)
+func (_mock *MockLinkQuerier) DeleteLinkByLinkID(ctx context.Context, arg sqlc.DeleteLinkByLinkIDParams) (sqlc.Link, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteLinkByLinkID")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = returnFunc(ctx, linkID)
+	var r0 sqlc.Link
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.DeleteLinkByLinkIDParams) (sqlc.Link, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.DeleteLinkByLinkIDParams) sqlc.Link); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(sqlc.Link)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sqlc.DeleteLinkByLinkIDParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockLinkQuerier_DeleteLinkByLinkID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLinkByLinkID'
@@ -384,20 +591,20 @@ type MockLinkQuerier_DeleteLinkByLinkID_Call struct {
 
 // DeleteLinkByLinkID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - linkID uuid.UUID
-func (_e *MockLinkQuerier_Expecter) DeleteLinkByLinkID(ctx interface{}, linkID interface{}) *MockLinkQuerier_DeleteLinkByLinkID_Call {
-	return &MockLinkQuerier_DeleteLinkByLinkID_Call{Call: _e.mock.On("DeleteLinkByLinkID", ctx, linkID)}
+//   - arg sqlc.DeleteLinkByLinkIDParams
+func (_e *MockLinkQuerier_Expecter) DeleteLinkByLinkID(ctx interface{}, arg interface{}) *MockLinkQuerier_DeleteLinkByLinkID_Call {
+	return &MockLinkQuerier_DeleteLinkByLinkID_Call{Call: _e.mock.On("DeleteLinkByLinkID", ctx, arg)}
 }
 
-func (_c *MockLinkQuerier_DeleteLinkByLinkID_Call) Run(run func(ctx context.Context, linkID uuid.UUID)) *MockLinkQuerier_DeleteLinkByLinkID_Call {
+func (_c *MockLinkQuerier_DeleteLinkByLinkID_Call) Run(run func(ctx context.Context, arg sqlc.DeleteLinkByLinkIDParams)) *MockLinkQuerier_DeleteLinkByLinkID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uuid.UUID
+		var arg1 sqlc.DeleteLinkByLinkIDParams
 		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
+			arg1 = args[1].(sqlc.DeleteLinkByLinkIDParams)
 		}
 		run(
 			arg0,
@@ -407,12 +614,12 @@ func (_c *MockLinkQuerier_DeleteLinkByLinkID_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockLinkQuerier_DeleteLinkByLinkID_Call) Return(err error) *MockLinkQuerier_DeleteLinkByLinkID_Call {
-	_c.Call.Return(err)
+func (_c *MockLinkQuerier_DeleteLinkByLinkID_Call) Return(link sqlc.Link, err error) *MockLinkQuerier_DeleteLinkByLinkID_Call {
+	_c.Call.Return(link, err)
 	return _c
 }
 
-func (_c *MockLinkQuerier_DeleteLinkByLinkID_Call) RunAndReturn(run func(ctx context.Context, linkID uuid.UUID) error) *MockLinkQuerier_DeleteLinkByLinkID_Call {
+func (_c *MockLinkQuerier_DeleteLinkByLinkID_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.DeleteLinkByLinkIDParams) (sqlc.Link, error)) *MockLinkQuerier_DeleteLinkByLinkID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -549,6 +756,138 @@ func (_c *MockLinkQuerier_GetLinkByLinkID_Call) RunAndReturn(run func(ctx contex
 	return _c
 }
 
+// GetLumoIDByLumeID provides a mock function for the type MockLinkQuerier
+func (_mock *MockLinkQuerier) GetLumoIDByLumeID(ctx context.Context, lumeID uuid.UUID) (uuid.UUID, error) {
+	ret := _mock.Called(ctx, lumeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLumoIDByLumeID")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (uuid.UUID, error)); ok {
+		return returnFunc(ctx, lumeID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) uuid.UUID); ok {
+		r0 = returnFunc(ctx, lumeID)
+	} else {
+		r0 = ret.Get(0).(uuid.UUID)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, lumeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLinkQuerier_GetLumoIDByLumeID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLumoIDByLumeID'
+type MockLinkQuerier_GetLumoIDByLumeID_Call struct {
+	*mock.Call
+}
+
+// GetLumoIDByLumeID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lumeID uuid.UUID
+func (_e *MockLinkQuerier_Expecter) GetLumoIDByLumeID(ctx interface{}, lumeID interface{}) *MockLinkQuerier_GetLumoIDByLumeID_Call {
+	return &MockLinkQuerier_GetLumoIDByLumeID_Call{Call: _e.mock.On("GetLumoIDByLumeID", ctx, lumeID)}
+}
+
+func (_c *MockLinkQuerier_GetLumoIDByLumeID_Call) Run(run func(ctx context.Context, lumeID uuid.UUID)) *MockLinkQuerier_GetLumoIDByLumeID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkQuerier_GetLumoIDByLumeID_Call) Return(uUID uuid.UUID, err error) *MockLinkQuerier_GetLumoIDByLumeID_Call {
+	_c.Call.Return(uUID, err)
+	return _c
+}
+
+func (_c *MockLinkQuerier_GetLumoIDByLumeID_Call) RunAndReturn(run func(ctx context.Context, lumeID uuid.UUID) (uuid.UUID, error)) *MockLinkQuerier_GetLumoIDByLumeID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsLumeTrashed provides a mock function for the type MockLinkQuerier
+func (_mock *MockLinkQuerier) IsLumeTrashed(ctx context.Context, lumeID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, lumeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLumeTrashed")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, lumeID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, lumeID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, lumeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLinkQuerier_IsLumeTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLumeTrashed'
+type MockLinkQuerier_IsLumeTrashed_Call struct {
+	*mock.Call
+}
+
+// IsLumeTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lumeID uuid.UUID
+func (_e *MockLinkQuerier_Expecter) IsLumeTrashed(ctx interface{}, lumeID interface{}) *MockLinkQuerier_IsLumeTrashed_Call {
+	return &MockLinkQuerier_IsLumeTrashed_Call{Call: _e.mock.On("IsLumeTrashed", ctx, lumeID)}
+}
+
+func (_c *MockLinkQuerier_IsLumeTrashed_Call) Run(run func(ctx context.Context, lumeID uuid.UUID)) *MockLinkQuerier_IsLumeTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkQuerier_IsLumeTrashed_Call) Return(b bool, err error) *MockLinkQuerier_IsLumeTrashed_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockLinkQuerier_IsLumeTrashed_Call) RunAndReturn(run func(ctx context.Context, lumeID uuid.UUID) (bool, error)) *MockLinkQuerier_IsLumeTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListLinksByEitherLumeID provides a mock function for the type MockLinkQuerier
 func (_mock *MockLinkQuerier) ListLinksByEitherLumeID(ctx context.Context, arg sqlc.ListLinksByEitherLumeIDParams) ([]sqlc.Link, error) {
 	ret := _mock.Called(ctx, arg)
@@ -753,6 +1092,74 @@ func (_c *MockLinkQuerier_ListLinksByLumeIDAndType_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// ListLinksByLumoID provides a mock function for the type MockLinkQuerier
+func (_mock *MockLinkQuerier) ListLinksByLumoID(ctx context.Context, arg sqlc.ListLinksByLumoIDParams) ([]sqlc.Link, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLinksByLumoID")
+	}
+
+	var r0 []sqlc.Link
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.ListLinksByLumoIDParams) ([]sqlc.Link, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.ListLinksByLumoIDParams) []sqlc.Link); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlc.Link)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sqlc.ListLinksByLumoIDParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLinkQuerier_ListLinksByLumoID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLinksByLumoID'
+type MockLinkQuerier_ListLinksByLumoID_Call struct {
+	*mock.Call
+}
+
+// ListLinksByLumoID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg sqlc.ListLinksByLumoIDParams
+func (_e *MockLinkQuerier_Expecter) ListLinksByLumoID(ctx interface{}, arg interface{}) *MockLinkQuerier_ListLinksByLumoID_Call {
+	return &MockLinkQuerier_ListLinksByLumoID_Call{Call: _e.mock.On("ListLinksByLumoID", ctx, arg)}
+}
+
+func (_c *MockLinkQuerier_ListLinksByLumoID_Call) Run(run func(ctx context.Context, arg sqlc.ListLinksByLumoIDParams)) *MockLinkQuerier_ListLinksByLumoID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sqlc.ListLinksByLumoIDParams
+		if args[1] != nil {
+			arg1 = args[1].(sqlc.ListLinksByLumoIDParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkQuerier_ListLinksByLumoID_Call) Return(links []sqlc.Link, err error) *MockLinkQuerier_ListLinksByLumoID_Call {
+	_c.Call.Return(links, err)
+	return _c
+}
+
+func (_c *MockLinkQuerier_ListLinksByLumoID_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.ListLinksByLumoIDParams) ([]sqlc.Link, error)) *MockLinkQuerier_ListLinksByLumoID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListLinksByToLumeID provides a mock function for the type MockLinkQuerier
 func (_mock *MockLinkQuerier) ListLinksByToLumeID(ctx context.Context, arg sqlc.ListLinksByToLumeIDParams) ([]sqlc.Link, error) {
 	ret := _mock.Called(ctx, arg)
@@ -889,6 +1296,254 @@ func (_c *MockLinkQuerier_ListLinksByType_Call) RunAndReturn(run func(ctx contex
 	return _c
 }
 
+// ListTrashedLinksByLumoID provides a mock function for the type MockLinkQuerier
+func (_mock *MockLinkQuerier) ListTrashedLinksByLumoID(ctx context.Context, arg sqlc.ListTrashedLinksByLumoIDParams) ([]sqlc.Link, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTrashedLinksByLumoID")
+	}
+
+	var r0 []sqlc.Link
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.ListTrashedLinksByLumoIDParams) ([]sqlc.Link, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.ListTrashedLinksByLumoIDParams) []sqlc.Link); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlc.Link)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sqlc.ListTrashedLinksByLumoIDParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLinkQuerier_ListTrashedLinksByLumoID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTrashedLinksByLumoID'
+type MockLinkQuerier_ListTrashedLinksByLumoID_Call struct {
+	*mock.Call
+}
+
+// ListTrashedLinksByLumoID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg sqlc.ListTrashedLinksByLumoIDParams
+func (_e *MockLinkQuerier_Expecter) ListTrashedLinksByLumoID(ctx interface{}, arg interface{}) *MockLinkQuerier_ListTrashedLinksByLumoID_Call {
+	return &MockLinkQuerier_ListTrashedLinksByLumoID_Call{Call: _e.mock.On("ListTrashedLinksByLumoID", ctx, arg)}
+}
+
+func (_c *MockLinkQuerier_ListTrashedLinksByLumoID_Call) Run(run func(ctx context.Context, arg sqlc.ListTrashedLinksByLumoIDParams)) *MockLinkQuerier_ListTrashedLinksByLumoID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sqlc.ListTrashedLinksByLumoIDParams
+		if args[1] != nil {
+			arg1 = args[1].(sqlc.ListTrashedLinksByLumoIDParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkQuerier_ListTrashedLinksByLumoID_Call) Return(links []sqlc.Link, err error) *MockLinkQuerier_ListTrashedLinksByLumoID_Call {
+	_c.Call.Return(links, err)
+	return _c
+}
+
+func (_c *MockLinkQuerier_ListTrashedLinksByLumoID_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.ListTrashedLinksByLumoIDParams) ([]sqlc.Link, error)) *MockLinkQuerier_ListTrashedLinksByLumoID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockLumoEvents provides a mock function for the type MockLinkQuerier
+func (_mock *MockLinkQuerier) LockLumoEvents(ctx context.Context, lumoID string) error {
+	ret := _mock.Called(ctx, lumoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockLumoEvents")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, lumoID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLinkQuerier_LockLumoEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockLumoEvents'
+type MockLinkQuerier_LockLumoEvents_Call struct {
+	*mock.Call
+}
+
+// LockLumoEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lumoID string
+func (_e *MockLinkQuerier_Expecter) LockLumoEvents(ctx interface{}, lumoID interface{}) *MockLinkQuerier_LockLumoEvents_Call {
+	return &MockLinkQuerier_LockLumoEvents_Call{Call: _e.mock.On("LockLumoEvents", ctx, lumoID)}
+}
+
+func (_c *MockLinkQuerier_LockLumoEvents_Call) Run(run func(ctx context.Context, lumoID string)) *MockLinkQuerier_LockLumoEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkQuerier_LockLumoEvents_Call) Return(err error) *MockLinkQuerier_LockLumoEvents_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLinkQuerier_LockLumoEvents_Call) RunAndReturn(run func(ctx context.Context, lumoID string) error) *MockLinkQuerier_LockLumoEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotifyLumoEvent provides a mock function for the type MockLinkQuerier
+func (_mock *MockLinkQuerier) NotifyLumoEvent(ctx context.Context, payload string) error {
+	ret := _mock.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyLumoEvent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLinkQuerier_NotifyLumoEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifyLumoEvent'
+type MockLinkQuerier_NotifyLumoEvent_Call struct {
+	*mock.Call
+}
+
+// NotifyLumoEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payload string
+func (_e *MockLinkQuerier_Expecter) NotifyLumoEvent(ctx interface{}, payload interface{}) *MockLinkQuerier_NotifyLumoEvent_Call {
+	return &MockLinkQuerier_NotifyLumoEvent_Call{Call: _e.mock.On("NotifyLumoEvent", ctx, payload)}
+}
+
+func (_c *MockLinkQuerier_NotifyLumoEvent_Call) Run(run func(ctx context.Context, payload string)) *MockLinkQuerier_NotifyLumoEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkQuerier_NotifyLumoEvent_Call) Return(err error) *MockLinkQuerier_NotifyLumoEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLinkQuerier_NotifyLumoEvent_Call) RunAndReturn(run func(ctx context.Context, payload string) error) *MockLinkQuerier_NotifyLumoEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreLinkByLinkID provides a mock function for the type MockLinkQuerier
+func (_mock *MockLinkQuerier) RestoreLinkByLinkID(ctx context.Context, linkID uuid.UUID) (sqlc.Link, error) {
+	ret := _mock.Called(ctx, linkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreLinkByLinkID")
+	}
+
+	var r0 sqlc.Link
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (sqlc.Link, error)); ok {
+		return returnFunc(ctx, linkID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) sqlc.Link); ok {
+		r0 = returnFunc(ctx, linkID)
+	} else {
+		r0 = ret.Get(0).(sqlc.Link)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLinkQuerier_RestoreLinkByLinkID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreLinkByLinkID'
+type MockLinkQuerier_RestoreLinkByLinkID_Call struct {
+	*mock.Call
+}
+
+// RestoreLinkByLinkID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID uuid.UUID
+func (_e *MockLinkQuerier_Expecter) RestoreLinkByLinkID(ctx interface{}, linkID interface{}) *MockLinkQuerier_RestoreLinkByLinkID_Call {
+	return &MockLinkQuerier_RestoreLinkByLinkID_Call{Call: _e.mock.On("RestoreLinkByLinkID", ctx, linkID)}
+}
+
+func (_c *MockLinkQuerier_RestoreLinkByLinkID_Call) Run(run func(ctx context.Context, linkID uuid.UUID)) *MockLinkQuerier_RestoreLinkByLinkID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLinkQuerier_RestoreLinkByLinkID_Call) Return(link sqlc.Link, err error) *MockLinkQuerier_RestoreLinkByLinkID_Call {
+	_c.Call.Return(link, err)
+	return _c
+}
+
+func (_c *MockLinkQuerier_RestoreLinkByLinkID_Call) RunAndReturn(run func(ctx context.Context, linkID uuid.UUID) (sqlc.Link, error)) *MockLinkQuerier_RestoreLinkByLinkID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateLink provides a mock function for the type MockLinkQuerier
 func (_mock *MockLinkQuerier) UpdateLink(ctx context.Context, arg sqlc.UpdateLinkParams) (sqlc.Link, error) {
 	ret := _mock.Called(ctx, arg)
@@ -4,11 +4,20 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/event"
+	"github.com/mcdev12/lumo/go/internal/models/history"
 	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/outbox"
+	"github.com/mcdev12/lumo/go/internal/models/trash"
+	"github.com/mcdev12/lumo/go/internal/repository/db"
 	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	eventRepo "github.com/mcdev12/lumo/go/internal/repository/event"
+	historyRepo "github.com/mcdev12/lumo/go/internal/repository/history"
+	outboxRepo "github.com/mcdev12/lumo/go/internal/repository/outbox"
 	"github.com/sqlc-dev/pqtype"
 )
 
@@ -17,28 +26,48 @@ type LinkQuerier interface {
 	CountLinksByFromLumeID(ctx context.Context, fromLumeID uuid.UUID) (int64, error)
 	CountLinksByLumeID(ctx context.Context, fromLumeID uuid.UUID) (int64, error)
 	CountLinksByToLumeID(ctx context.Context, toLumeID uuid.UUID) (int64, error)
+	CreateEntityHistory(ctx context.Context, arg sqlc.CreateEntityHistoryParams) (sqlc.EntityHistory, error)
 	CreateLink(ctx context.Context, arg sqlc.CreateLinkParams) (sqlc.Link, error)
-	DeleteLink(ctx context.Context, id int64) error
-	DeleteLinkByLinkID(ctx context.Context, linkID uuid.UUID) error
+	CreateLumoEvent(ctx context.Context, arg sqlc.CreateLumoEventParams) (sqlc.LumoEvent, error)
+	CreateOutboxEvent(ctx context.Context, arg sqlc.CreateOutboxEventParams) error
+	DeleteLink(ctx context.Context, arg sqlc.DeleteLinkParams) (sqlc.Link, error)
+	DeleteLinkByLinkID(ctx context.Context, arg sqlc.DeleteLinkByLinkIDParams) (sqlc.Link, error)
 	GetLinkByID(ctx context.Context, id int64) (sqlc.Link, error)
 	GetLinkByLinkID(ctx context.Context, linkID uuid.UUID) (sqlc.Link, error)
+	GetLumoIDByLumeID(ctx context.Context, lumeID uuid.UUID) (uuid.UUID, error)
+	IsLumeTrashed(ctx context.Context, lumeID uuid.UUID) (bool, error)
 	ListLinksByEitherLumeID(ctx context.Context, arg sqlc.ListLinksByEitherLumeIDParams) ([]sqlc.Link, error)
 	ListLinksByFromLumeID(ctx context.Context, arg sqlc.ListLinksByFromLumeIDParams) ([]sqlc.Link, error)
 	ListLinksByLumeIDAndType(ctx context.Context, arg sqlc.ListLinksByLumeIDAndTypeParams) ([]sqlc.Link, error)
+	ListLinksByLumoID(ctx context.Context, arg sqlc.ListLinksByLumoIDParams) ([]sqlc.Link, error)
 	ListLinksByToLumeID(ctx context.Context, arg sqlc.ListLinksByToLumeIDParams) ([]sqlc.Link, error)
 	ListLinksByType(ctx context.Context, arg sqlc.ListLinksByTypeParams) ([]sqlc.Link, error)
+	ListTrashedLinksByLumoID(ctx context.Context, arg sqlc.ListTrashedLinksByLumoIDParams) ([]sqlc.Link, error)
+	LockLumoEvents(ctx context.Context, lumoID string) error
+	NotifyLumoEvent(ctx context.Context, payload string) error
+	RestoreLinkByLinkID(ctx context.Context, linkID uuid.UUID) (sqlc.Link, error)
 	UpdateLink(ctx context.Context, arg sqlc.UpdateLinkParams) (sqlc.Link, error)
 }
 
 // Repository is the concrete implementation for Link data access
 type Repository struct {
+	db      sqlc.DBTX
 	queries LinkQuerier
 }
 
 // NewRepository creates a new Repository instance
-func NewRepository(db sqlc.DBTX) *Repository {
+func NewRepository(conn sqlc.DBTX) *Repository {
 	return &Repository{
-		queries: sqlc.New(db),
+		db:      conn,
+		queries: sqlc.New(conn),
+	}
+}
+
+// WithTx returns a Repository that runs its queries on the given transaction
+func (r *Repository) WithTx(tx sqlc.DBTX) *Repository {
+	return &Repository{
+		db:      tx,
+		queries: sqlc.New(tx),
 	}
 }
 
@@ -46,12 +75,23 @@ func NewRepository(db sqlc.DBTX) *Repository {
 func (r *Repository) CreateLink(ctx context.Context, domainLink *link.Link) (*link.Link, error) {
 	params := r.domainToCreateParams(domainLink)
 
-	result, err := r.queries.CreateLink(ctx, params)
+	var created *link.Link
+	err := db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.CreateLink(ctx, params)
+		if err != nil {
+			return err
+		}
+		created = r.sqlcRowToDomainModel(result)
+
+		return r.recordChange(ctx, queries, event.TypeCreated, created)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return r.sqlcRowToDomainModel(result), nil
+	return created, nil
 }
 
 // GetLinkByID retrieves a Link by its internal ID
@@ -205,30 +245,196 @@ func (r *Repository) ListLinksByLumeIDAndType(ctx context.Context, lumeID string
 	return links, nil
 }
 
-// UpdateLink updates an existing Link record
+// ListLinksByLumoID retrieves all Links between the Lumes of a Lumo
+func (r *Repository) ListLinksByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*link.Link, error) {
+	parsedLumoID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.ListLinksByLumoIDParams{
+		LumoID: parsedLumoID,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	results, err := r.queries.ListLinksByLumoID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*link.Link, len(results))
+	for i, result := range results {
+		links[i] = r.sqlcRowToDomainModel(result)
+	}
+
+	return links, nil
+}
+
+// UpdateLink updates an existing Link record. The update only applies if the
+// stored version still matches domainLink.Version, otherwise a
+// *version.ConflictError is returned.
 func (r *Repository) UpdateLink(ctx context.Context, domainLink *link.Link) (*link.Link, error) {
 	params := r.domainToUpdateParams(domainLink)
 
-	result, err := r.queries.UpdateLink(ctx, params)
+	var updated *link.Link
+	err := db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.UpdateLink(ctx, params)
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.VersionConflict(domainLink.Version, err, func() (int64, error) {
+				row, err := queries.GetLinkByLinkID(ctx, params.LinkID)
+				return row.Version, err
+			})
+		}
+		if err != nil {
+			return err
+		}
+		updated = r.sqlcRowToDomainModel(result)
+
+		return r.recordChange(ctx, queries, event.TypeUpdated, updated)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return r.sqlcRowToDomainModel(result), nil
+	return updated, nil
 }
 
-// DeleteLink deletes a Link by its internal ID
-func (r *Repository) DeleteLink(ctx context.Context, id int64) error {
-	return r.queries.DeleteLink(ctx, id)
+// DeleteLink moves a Link to the trash by its internal ID. When expectedVersion is set
+// the delete only applies if the stored version still matches.
+func (r *Repository) DeleteLink(ctx context.Context, id int64, expectedVersion *int64) error {
+	return db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.DeleteLink(ctx, sqlc.DeleteLinkParams{
+			DeletedAt:       time.Now(),
+			ID:              id,
+			ExpectedVersion: db.NullVersion(expectedVersion),
+		})
+		if errors.Is(err, sql.ErrNoRows) && expectedVersion != nil {
+			err = db.VersionConflict(*expectedVersion, err, func() (int64, error) {
+				row, err := queries.GetLinkByID(ctx, id)
+				return row.Version, err
+			})
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			// Nothing to delete
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return r.recordChange(ctx, queries, event.TypeDeleted, r.sqlcRowToDomainModel(result))
+	})
 }
 
-// DeleteLinkByLinkID deletes a Link by its UUID
-func (r *Repository) DeleteLinkByLinkID(ctx context.Context, linkID string) error {
+// DeleteLinkByLinkID moves a Link to the trash by its UUID. When expectedVersion is set
+// the delete only applies if the stored version still matches.
+func (r *Repository) DeleteLinkByLinkID(ctx context.Context, linkID string, expectedVersion *int64) error {
 	parsedUUID, err := uuid.Parse(linkID)
 	if err != nil {
 		return err
 	}
-	return r.queries.DeleteLinkByLinkID(ctx, parsedUUID)
+
+	return db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.DeleteLinkByLinkID(ctx, sqlc.DeleteLinkByLinkIDParams{
+			DeletedAt:       time.Now(),
+			LinkID:          parsedUUID,
+			ExpectedVersion: db.NullVersion(expectedVersion),
+		})
+		if errors.Is(err, sql.ErrNoRows) && expectedVersion != nil {
+			err = db.VersionConflict(*expectedVersion, err, func() (int64, error) {
+				row, err := queries.GetLinkByLinkID(ctx, parsedUUID)
+				return row.Version, err
+			})
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			// Nothing to delete
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return r.recordChange(ctx, queries, event.TypeDeleted, r.sqlcRowToDomainModel(result))
+	})
+}
+
+// ListTrashedLinksByLumoID retrieves the Links of a Lumo that were deleted
+// directly, most recently deleted first
+func (r *Repository) ListTrashedLinksByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*link.Link, error) {
+	parsedLumoID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.ListTrashedLinksByLumoIDParams{
+		LumoID: parsedLumoID,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	results, err := r.queries.ListTrashedLinksByLumoID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*link.Link, len(results))
+	for i, result := range results {
+		links[i] = r.sqlcRowToDomainModel(result)
+	}
+
+	return links, nil
+}
+
+// RestoreLinkByLinkID takes a Link out of the trash. It fails with
+// trash.ErrNotInTrash if the Link isn't in the trash, trash.ErrParentInTrash
+// while one of its Lumes is and trash.ErrConflict if the same connection has
+// been made again since.
+func (r *Repository) RestoreLinkByLinkID(ctx context.Context, linkID string) (*link.Link, error) {
+	parsedUUID, err := uuid.Parse(linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored *link.Link
+	err = db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.RestoreLinkByLinkID(ctx, parsedUUID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return trash.ErrNotInTrash
+		}
+		if db.IsUniqueViolation(err) {
+			return trash.ErrConflict
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, lumeID := range []uuid.UUID{result.FromLumeID, result.ToLumeID} {
+			lumeTrashed, err := queries.IsLumeTrashed(ctx, lumeID)
+			if err != nil {
+				return err
+			}
+			if lumeTrashed {
+				return trash.ErrParentInTrash
+			}
+		}
+		restored = r.sqlcRowToDomainModel(result)
+
+		return r.recordChange(ctx, queries, event.TypeCreated, restored)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
 }
 
 // CountLinksByLumeID returns the total count of Links connected to a Lume
@@ -258,6 +464,45 @@ func (r *Repository) CountLinksByToLumeID(ctx context.Context, toLumeID string)
 	return r.queries.CountLinksByToLumeID(ctx, parsedLumeID)
 }
 
+// recordChange records a change to a Link in the event log and history of
+// the Lumo its Lumes belong to, and in the outbox. A restore is recorded as a creation.
+func (r *Repository) recordChange(ctx context.Context, queries LinkQuerier, eventType event.Type, domainLink *link.Link) error {
+	fromLumeID, err := uuid.Parse(domainLink.FromLumeID)
+	if err != nil {
+		return err
+	}
+
+	lumoID, err := queries.GetLumoIDByLumeID(ctx, fromLumeID)
+	if err != nil {
+		return err
+	}
+
+	domainEntry, err := history.NewEntry(ctx, lumoID.String(), history.EntityTypeLink, domainLink.LinkID, history.Operation(eventType), domainLink)
+	if err != nil {
+		return err
+	}
+	if err := historyRepo.Record(ctx, queries, domainEntry); err != nil {
+		return err
+	}
+	if err := outboxRepo.Record(ctx, queries, outbox.NewEvent(domainEntry)); err != nil {
+		return err
+	}
+
+	domainEvent, err := event.NewEvent(lumoID.String(), event.EntityTypeLink, domainLink.LinkID, eventType, domainLink)
+	if err != nil {
+		return err
+	}
+	return eventRepo.Record(ctx, queries, domainEvent)
+}
+
+// querierFor returns the querier to use on the given connection
+func (r *Repository) querierFor(conn sqlc.DBTX) LinkQuerier {
+	if conn == nil || conn == r.db {
+		return r.queries
+	}
+	return sqlc.New(conn)
+}
+
 // Helper method to convert domain Link to SQLC CreateLinkParams
 func (r *Repository) domainToCreateParams(domainLink *link.Link) sqlc.CreateLinkParams {
 	now := time.Now()
@@ -297,6 +542,7 @@ func (r *Repository) domainToUpdateParams(domainLink *link.Link) sqlc.UpdateLink
 		ToLumeID:   uuid.MustParse(domainLink.ToLumeID),
 		LinkType:   string(domainLink.Type),
 		UpdatedAt:  time.Now(),
+		Version:    domainLink.Version,
 	}
 
 	// Handle optional notes
@@ -328,6 +574,7 @@ func (r *Repository) sqlcRowToDomainModel(row sqlc.Link) *link.Link {
 		Type:       link.LinkType(row.LinkType),
 		CreatedAt:  row.CreatedAt,
 		UpdatedAt:  row.UpdatedAt,
+		Version:    row.Version,
 	}
 
 	// Handle optional notes
@@ -350,5 +597,10 @@ func (r *Repository) sqlcRowToDomainModel(row sqlc.Link) *link.Link {
 		}
 	}
 
+	// Handle trash timestamp
+	if row.DeletedAt.Valid {
+		domainLink.DeletedAt = &row.DeletedAt.Time
+	}
+
 	return domainLink
 }
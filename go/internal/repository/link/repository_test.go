@@ -9,7 +9,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mcdev12/lumo/go/internal/models/event"
+	"github.com/mcdev12/lumo/go/internal/models/history"
 	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/outbox"
+	"github.com/mcdev12/lumo/go/internal/models/trash"
+	"github.com/mcdev12/lumo/go/internal/models/version"
 	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
 	"github.com/mcdev12/lumo/go/internal/repository/link/mocks"
 	"github.com/sqlc-dev/pqtype"
@@ -98,6 +104,29 @@ func createTestLinkSqlc() sqlc.Link {
 	}
 }
 
+// Helper function to create a test sqlc.Link database model that is in the trash
+func createTrashedLinkSqlc() sqlc.Link {
+	row := createTestLinkSqlc()
+	row.DeletedAt = sql.NullTime{Time: row.CreatedAt.Add(time.Hour), Valid: true}
+	return row
+}
+
+// Helper function to expect a Link event, history entry and outbox event being recorded in the same write
+func (s *RepositoryTestSuite) expectEvent(eventType event.Type) {
+	s.mockQuerier.On("CreateEntityHistory", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEntityHistoryParams) bool {
+		return params.EntityType == string(history.EntityTypeLink) && params.Operation == string(eventType)
+	})).Return(sqlc.EntityHistory{ID: 1}, nil)
+	s.mockQuerier.On("GetLumoIDByLumeID", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(uuid.New(), nil)
+	s.mockQuerier.On("LockLumoEvents", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+	s.mockQuerier.On("CreateLumoEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateLumoEventParams) bool {
+		return params.EntityType == string(event.EntityTypeLink) && params.EventType == string(eventType)
+	})).Return(sqlc.LumoEvent{ID: 1}, nil)
+	s.mockQuerier.On("NotifyLumoEvent", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+	s.mockQuerier.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+		return params.EventType == outbox.TypeOf(history.EntityTypeLink, history.Operation(eventType))
+	})).Return(nil)
+}
+
 // Test CreateLink
 func (s *RepositoryTestSuite) TestCreateLink() {
 	// Arrange
@@ -107,6 +136,7 @@ func (s *RepositoryTestSuite) TestCreateLink() {
 
 	// Set up expectations
 	s.mockQuerier.On("CreateLink", mock.Anything, mock.AnythingOfType("sqlc.CreateLinkParams")).Return(sqlcLink, nil)
+	s.expectEvent(event.TypeCreated)
 
 	// Act
 	result, err := s.repository.CreateLink(ctx, domainLink)
@@ -335,6 +365,45 @@ func (s *RepositoryTestSuite) TestListLinksByToLumeID() {
 	s.mockQuerier.AssertExpectations(s.T())
 }
 
+// Test ListLinksByLumoID
+func (s *RepositoryTestSuite) TestListLinksByLumoID() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	lumoIDStr := lumoID.String()
+	limit := int32(500)
+	offset := int32(0)
+	sqlcLink1 := createTestLinkSqlc()
+	sqlcLink2 := createTestLinkSqlc()
+	sqlcLink2.ID = 2
+	sqlcLinks := []sqlc.Link{sqlcLink1, sqlcLink2}
+
+	// Set up expectations
+	s.mockQuerier.On("ListLinksByLumoID", mock.Anything, mock.MatchedBy(func(params sqlc.ListLinksByLumoIDParams) bool {
+		return params.LumoID == lumoID && params.Limit == limit && params.Offset == offset
+	})).Return(sqlcLinks, nil)
+
+	// Act
+	results, err := s.repository.ListLinksByLumoID(ctx, lumoIDStr, limit, offset)
+
+	// Assert
+	s.NoError(err)
+	s.Len(results, 2)
+	s.Equal(sqlcLink1.ID, results[0].ID)
+	s.Equal(sqlcLink2.ID, results[1].ID)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test ListLinksByLumoID with invalid UUID
+func (s *RepositoryTestSuite) TestListLinksByLumoIDInvalidUUID() {
+	// Act
+	results, err := s.repository.ListLinksByLumoID(context.Background(), "invalid-uuid", 10, 0)
+
+	// Assert
+	s.Error(err)
+	s.Nil(results)
+}
+
 // Test UpdateLink
 func (s *RepositoryTestSuite) TestUpdateLink() {
 	// Arrange
@@ -344,6 +413,7 @@ func (s *RepositoryTestSuite) TestUpdateLink() {
 
 	// Set up expectations
 	s.mockQuerier.On("UpdateLink", mock.Anything, mock.AnythingOfType("sqlc.UpdateLinkParams")).Return(sqlcLink, nil)
+	s.expectEvent(event.TypeUpdated)
 
 	// Act
 	result, err := s.repository.UpdateLink(ctx, domainLink)
@@ -367,6 +437,35 @@ func (s *RepositoryTestSuite) TestUpdateLink() {
 	s.mockQuerier.AssertExpectations(s.T())
 }
 
+// Test UpdateLink when the Link was changed concurrently
+func (s *RepositoryTestSuite) TestUpdateLinkVersionConflict() {
+	// Arrange
+	ctx := context.Background()
+	domainLink := createTestLinkDomain()
+	domainLink.Version = 2
+	current := createTestLinkSqlc()
+	current.Version = 3
+
+	// Set up expectations
+	s.mockQuerier.On("UpdateLink", mock.Anything, mock.MatchedBy(func(params sqlc.UpdateLinkParams) bool {
+		return params.Version == 2
+	})).Return(sqlc.Link{}, sql.ErrNoRows)
+	s.mockQuerier.On("GetLinkByLinkID", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(current, nil)
+
+	// Act
+	result, err := s.repository.UpdateLink(ctx, domainLink)
+
+	// Assert
+	s.Error(err)
+	s.Nil(result)
+	s.ErrorIs(err, version.ErrConflict)
+	currentVersion, ok := version.Current(err)
+	s.True(ok)
+	s.Equal(int64(3), currentVersion)
+	s.mockQuerier.AssertNotCalled(s.T(), "CreateLumoEvent", mock.Anything, mock.Anything)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
 // Test DeleteLink
 func (s *RepositoryTestSuite) TestDeleteLink() {
 	// Arrange
@@ -374,10 +473,13 @@ func (s *RepositoryTestSuite) TestDeleteLink() {
 	id := int64(1)
 
 	// Set up expectations
-	s.mockQuerier.On("DeleteLink", mock.Anything, id).Return(nil)
+	s.mockQuerier.On("DeleteLink", mock.Anything, mock.MatchedBy(func(params sqlc.DeleteLinkParams) bool {
+		return params.ID == id && !params.DeletedAt.IsZero()
+	})).Return(createTrashedLinkSqlc(), nil)
+	s.expectEvent(event.TypeDeleted)
 
 	// Act
-	err := s.repository.DeleteLink(ctx, id)
+	err := s.repository.DeleteLink(ctx, id, nil)
 
 	// Assert
 	s.NoError(err)
@@ -391,15 +493,148 @@ func (s *RepositoryTestSuite) TestDeleteLinkByLinkID() {
 	domainLink := createTestLinkDomain()
 	linkID := uuid.MustParse(domainLink.LinkID)
 	linkIDStr := linkID.String()
+	expectedVersion := int64(4)
+
+	// Set up expectations
+	s.mockQuerier.On("DeleteLinkByLinkID", mock.Anything, mock.MatchedBy(func(params sqlc.DeleteLinkByLinkIDParams) bool {
+		return params.LinkID == linkID && !params.DeletedAt.IsZero() &&
+			params.ExpectedVersion == sql.NullInt64{Int64: expectedVersion, Valid: true}
+	})).Return(createTrashedLinkSqlc(), nil)
+	s.expectEvent(event.TypeDeleted)
+
+	// Act
+	err := s.repository.DeleteLinkByLinkID(ctx, linkIDStr, &expectedVersion)
+
+	// Assert
+	s.NoError(err)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test DeleteLink when the Link doesn't exist
+func (s *RepositoryTestSuite) TestDeleteLinkNotFound() {
+	// Arrange
+	ctx := context.Background()
+	id := int64(1)
+
+	// Set up expectations
+	s.mockQuerier.On("DeleteLink", mock.Anything, mock.AnythingOfType("sqlc.DeleteLinkParams")).Return(sqlc.Link{}, sql.ErrNoRows)
+
+	// Act
+	err := s.repository.DeleteLink(ctx, id, nil)
+
+	// Assert
+	s.NoError(err)
+	s.mockQuerier.AssertNotCalled(s.T(), "CreateLumoEvent", mock.Anything, mock.Anything)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test DeleteLink when the Link was changed concurrently
+func (s *RepositoryTestSuite) TestDeleteLinkVersionConflict() {
+	// Arrange
+	ctx := context.Background()
+	id := int64(1)
+	expectedVersion := int64(1)
+	current := createTestLinkSqlc()
+	current.Version = 2
+
+	// Set up expectations
+	s.mockQuerier.On("DeleteLink", mock.Anything, mock.MatchedBy(func(params sqlc.DeleteLinkParams) bool {
+		return params.ID == id && params.ExpectedVersion == sql.NullInt64{Int64: expectedVersion, Valid: true}
+	})).Return(sqlc.Link{}, sql.ErrNoRows)
+	s.mockQuerier.On("GetLinkByID", mock.Anything, id).Return(current, nil)
+
+	// Act
+	err := s.repository.DeleteLink(ctx, id, &expectedVersion)
+
+	// Assert
+	s.ErrorIs(err, version.ErrConflict)
+	s.mockQuerier.AssertNotCalled(s.T(), "CreateLumoEvent", mock.Anything, mock.Anything)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test ListTrashedLinksByLumoID
+func (s *RepositoryTestSuite) TestListTrashedLinksByLumoID() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New()
+	trashed := createTrashedLinkSqlc()
+
+	// Set up expectations
+	params := sqlc.ListTrashedLinksByLumoIDParams{
+		LumoID: lumoID,
+		Limit:  10,
+		Offset: 0,
+	}
+	s.mockQuerier.On("ListTrashedLinksByLumoID", mock.Anything, params).Return([]sqlc.Link{trashed}, nil)
+
+	// Act
+	results, err := s.repository.ListTrashedLinksByLumoID(ctx, lumoID.String(), 10, 0)
+
+	// Assert
+	s.NoError(err)
+	s.Len(results, 1)
+	s.Require().NotNil(results[0].DeletedAt)
+	s.Equal(trashed.DeletedAt.Time, *results[0].DeletedAt)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test RestoreLinkByLinkID
+func (s *RepositoryTestSuite) TestRestoreLinkByLinkID() {
+	// Arrange
+	ctx := context.Background()
+	restoredRow := createTestLinkSqlc()
 
 	// Set up expectations
-	s.mockQuerier.On("DeleteLinkByLinkID", mock.Anything, linkID).Return(nil)
+	s.mockQuerier.On("RestoreLinkByLinkID", mock.Anything, restoredRow.LinkID).Return(restoredRow, nil)
+	s.mockQuerier.On("IsLumeTrashed", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(false, nil)
+	s.expectEvent(event.TypeCreated)
 
 	// Act
-	err := s.repository.DeleteLinkByLinkID(ctx, linkIDStr)
+	result, err := s.repository.RestoreLinkByLinkID(ctx, restoredRow.LinkID.String())
 
 	// Assert
 	s.NoError(err)
+	s.Equal(restoredRow.LinkID.String(), result.LinkID)
+	s.Nil(result.DeletedAt)
+	s.mockQuerier.AssertNumberOfCalls(s.T(), "IsLumeTrashed", 2)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test RestoreLinkByLinkID while one of its Lumes is in the trash
+func (s *RepositoryTestSuite) TestRestoreLinkByLinkIDParentInTrash() {
+	// Arrange
+	ctx := context.Background()
+	restoredRow := createTestLinkSqlc()
+
+	// Set up expectations
+	s.mockQuerier.On("RestoreLinkByLinkID", mock.Anything, restoredRow.LinkID).Return(restoredRow, nil)
+	s.mockQuerier.On("IsLumeTrashed", mock.Anything, restoredRow.FromLumeID).Return(true, nil)
+
+	// Act
+	result, err := s.repository.RestoreLinkByLinkID(ctx, restoredRow.LinkID.String())
+
+	// Assert
+	s.ErrorIs(err, trash.ErrParentInTrash)
+	s.Nil(result)
+	s.mockQuerier.AssertNotCalled(s.T(), "CreateLumoEvent", mock.Anything, mock.Anything)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test RestoreLinkByLinkID when the same connection has been made again
+func (s *RepositoryTestSuite) TestRestoreLinkByLinkIDConflict() {
+	// Arrange
+	ctx := context.Background()
+	linkID := uuid.New()
+
+	// Set up expectations
+	s.mockQuerier.On("RestoreLinkByLinkID", mock.Anything, linkID).Return(sqlc.Link{}, &pq.Error{Code: "23505"})
+
+	// Act
+	result, err := s.repository.RestoreLinkByLinkID(ctx, linkID.String())
+
+	// Assert
+	s.ErrorIs(err, trash.ErrConflict)
+	s.Nil(result)
 	s.mockQuerier.AssertExpectations(s.T())
 }
 
@@ -105,6 +105,72 @@ func (_c *MockLumeQuerier_CountLumesByLumo_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// CreateEntityHistory provides a mock function for the type MockLumeQuerier
+func (_mock *MockLumeQuerier) CreateEntityHistory(ctx context.Context, arg sqlc.CreateEntityHistoryParams) (sqlc.EntityHistory, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEntityHistory")
+	}
+
+	var r0 sqlc.EntityHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.CreateEntityHistoryParams) (sqlc.EntityHistory, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.CreateEntityHistoryParams) sqlc.EntityHistory); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(sqlc.EntityHistory)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sqlc.CreateEntityHistoryParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLumeQuerier_CreateEntityHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEntityHistory'
+type MockLumeQuerier_CreateEntityHistory_Call struct {
+	*mock.Call
+}
+
+// CreateEntityHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg sqlc.CreateEntityHistoryParams
+func (_e *MockLumeQuerier_Expecter) CreateEntityHistory(ctx interface{}, arg interface{}) *MockLumeQuerier_CreateEntityHistory_Call {
+	return &MockLumeQuerier_CreateEntityHistory_Call{Call: _e.mock.On("CreateEntityHistory", ctx, arg)}
+}
+
+func (_c *MockLumeQuerier_CreateEntityHistory_Call) Run(run func(ctx context.Context, arg sqlc.CreateEntityHistoryParams)) *MockLumeQuerier_CreateEntityHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sqlc.CreateEntityHistoryParams
+		if args[1] != nil {
+			arg1 = args[1].(sqlc.CreateEntityHistoryParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLumeQuerier_CreateEntityHistory_Call) Return(entityHistory sqlc.EntityHistory, err error) *MockLumeQuerier_CreateEntityHistory_Call {
+	_c.Call.Return(entityHistory, err)
+	return _c
+}
+
+func (_c *MockLumeQuerier_CreateEntityHistory_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.CreateEntityHistoryParams) (sqlc.EntityHistory, error)) *MockLumeQuerier_CreateEntityHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateLume provides a mock function for the type MockLumeQuerier
 func (_mock *MockLumeQuerier) CreateLume(ctx context.Context, arg sqlc.CreateLumeParams) (sqlc.Lume, error) {
 	ret := _mock.Called(ctx, arg)
@@ -171,23 +237,155 @@ func (_c *MockLumeQuerier_CreateLume_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
-// DeleteLume provides a mock function for the type MockLumeQuerier
-func (_mock *MockLumeQuerier) DeleteLume(ctx context.Context, id int64) error {
-	ret := _mock.Called(ctx, id)
+// CreateLumoEvent provides a mock function for the type MockLumeQuerier
+func (_mock *MockLumeQuerier) CreateLumoEvent(ctx context.Context, arg sqlc.CreateLumoEventParams) (sqlc.LumoEvent, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteLume")
+		panic("no return value specified for CreateLumoEvent")
+	}
+
+	var r0 sqlc.LumoEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.CreateLumoEventParams) (sqlc.LumoEvent, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.CreateLumoEventParams) sqlc.LumoEvent); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(sqlc.LumoEvent)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sqlc.CreateLumoEventParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLumeQuerier_CreateLumoEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLumoEvent'
+type MockLumeQuerier_CreateLumoEvent_Call struct {
+	*mock.Call
+}
+
+// CreateLumoEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg sqlc.CreateLumoEventParams
+func (_e *MockLumeQuerier_Expecter) CreateLumoEvent(ctx interface{}, arg interface{}) *MockLumeQuerier_CreateLumoEvent_Call {
+	return &MockLumeQuerier_CreateLumoEvent_Call{Call: _e.mock.On("CreateLumoEvent", ctx, arg)}
+}
+
+func (_c *MockLumeQuerier_CreateLumoEvent_Call) Run(run func(ctx context.Context, arg sqlc.CreateLumoEventParams)) *MockLumeQuerier_CreateLumoEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sqlc.CreateLumoEventParams
+		if args[1] != nil {
+			arg1 = args[1].(sqlc.CreateLumoEventParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLumeQuerier_CreateLumoEvent_Call) Return(lumoEvent sqlc.LumoEvent, err error) *MockLumeQuerier_CreateLumoEvent_Call {
+	_c.Call.Return(lumoEvent, err)
+	return _c
+}
+
+func (_c *MockLumeQuerier_CreateLumoEvent_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.CreateLumoEventParams) (sqlc.LumoEvent, error)) *MockLumeQuerier_CreateLumoEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOutboxEvent provides a mock function for the type MockLumeQuerier
+func (_mock *MockLumeQuerier) CreateOutboxEvent(ctx context.Context, arg sqlc.CreateOutboxEventParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOutboxEvent")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.CreateOutboxEventParams) error); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
+// MockLumeQuerier_CreateOutboxEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOutboxEvent'
+type MockLumeQuerier_CreateOutboxEvent_Call struct {
+	*mock.Call
+}
+
+// CreateOutboxEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg sqlc.CreateOutboxEventParams
+func (_e *MockLumeQuerier_Expecter) CreateOutboxEvent(ctx interface{}, arg interface{}) *MockLumeQuerier_CreateOutboxEvent_Call {
+	return &MockLumeQuerier_CreateOutboxEvent_Call{Call: _e.mock.On("CreateOutboxEvent", ctx, arg)}
+}
+
+func (_c *MockLumeQuerier_CreateOutboxEvent_Call) Run(run func(ctx context.Context, arg sqlc.CreateOutboxEventParams)) *MockLumeQuerier_CreateOutboxEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sqlc.CreateOutboxEventParams
+		if args[1] != nil {
+			arg1 = args[1].(sqlc.CreateOutboxEventParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLumeQuerier_CreateOutboxEvent_Call) Return(err error) *MockLumeQuerier_CreateOutboxEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLumeQuerier_CreateOutboxEvent_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.CreateOutboxEventParams) error) *MockLumeQuerier_CreateOutboxEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteLume provides a mock function for the type MockLumeQuerier
+func (_mock *MockLumeQuerier) DeleteLume(ctx context.Context, arg sqlc.DeleteLumeParams) (sqlc.Lume, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLume")
+	}
+
+	var r0 sqlc.Lume
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.DeleteLumeParams) (sqlc.Lume, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.DeleteLumeParams) sqlc.Lume); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(sqlc.Lume)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sqlc.DeleteLumeParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
 // MockLumeQuerier_DeleteLume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLume'
 type MockLumeQuerier_DeleteLume_Call struct {
 	*mock.Call
@@ -195,20 +393,20 @@ type MockLumeQuerier_DeleteLume_Call struct {
 
 // DeleteLume is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
-func (_e *MockLumeQuerier_Expecter) DeleteLume(ctx interface{}, id interface{}) *MockLumeQuerier_DeleteLume_Call {
-	return &MockLumeQuerier_DeleteLume_Call{Call: _e.mock.On("DeleteLume", ctx, id)}
+//   - arg sqlc.DeleteLumeParams
+func (_e *MockLumeQuerier_Expecter) DeleteLume(ctx interface{}, arg interface{}) *MockLumeQuerier_DeleteLume_Call {
+	return &MockLumeQuerier_DeleteLume_Call{Call: _e.mock.On("DeleteLume", ctx, arg)}
 }
 
-func (_c *MockLumeQuerier_DeleteLume_Call) Run(run func(ctx context.Context, id int64)) *MockLumeQuerier_DeleteLume_Call {
+func (_c *MockLumeQuerier_DeleteLume_Call) Run(run func(ctx context.Context, arg sqlc.DeleteLumeParams)) *MockLumeQuerier_DeleteLume_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int64
+		var arg1 sqlc.DeleteLumeParams
 		if args[1] != nil {
-			arg1 = args[1].(int64)
+			arg1 = args[1].(sqlc.DeleteLumeParams)
 		}
 		run(
 			arg0,
@@ -218,31 +416,40 @@ func (_c *MockLumeQuerier_DeleteLume_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockLumeQuerier_DeleteLume_Call) Return(err error) *MockLumeQuerier_DeleteLume_Call {
-	_c.Call.Return(err)
+func (_c *MockLumeQuerier_DeleteLume_Call) Return(lume sqlc.Lume, err error) *MockLumeQuerier_DeleteLume_Call {
+	_c.Call.Return(lume, err)
 	return _c
 }
 
-func (_c *MockLumeQuerier_DeleteLume_Call) RunAndReturn(run func(ctx context.Context, id int64) error) *MockLumeQuerier_DeleteLume_Call {
+func (_c *MockLumeQuerier_DeleteLume_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.DeleteLumeParams) (sqlc.Lume, error)) *MockLumeQuerier_DeleteLume_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteLumeByLumeID provides a mock function for the type MockLumeQuerier
-func (_mock *MockLumeQuerier) DeleteLumeByLumeID(ctx context.Context, lumeID uuid.UUID) error {
-	ret := _mock.Called(ctx, lumeID)
+func (_mock *MockLumeQuerier) DeleteLumeByLumeID(ctx context.Context, arg sqlc.DeleteLumeByLumeIDParams) (sqlc.Lume, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteLumeByLumeID")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = returnFunc(ctx, lumeID)
+	var r0 sqlc.Lume
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.DeleteLumeByLumeIDParams) (sqlc.Lume, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.DeleteLumeByLumeIDParams) sqlc.Lume); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(sqlc.Lume)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sqlc.DeleteLumeByLumeIDParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockLumeQuerier_DeleteLumeByLumeID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLumeByLumeID'
@@ -252,20 +459,20 @@ type MockLumeQuerier_DeleteLumeByLumeID_Call struct {
 
 // DeleteLumeByLumeID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - lumeID uuid.UUID
-func (_e *MockLumeQuerier_Expecter) DeleteLumeByLumeID(ctx interface{}, lumeID interface{}) *MockLumeQuerier_DeleteLumeByLumeID_Call {
-	return &MockLumeQuerier_DeleteLumeByLumeID_Call{Call: _e.mock.On("DeleteLumeByLumeID", ctx, lumeID)}
+//   - arg sqlc.DeleteLumeByLumeIDParams
+func (_e *MockLumeQuerier_Expecter) DeleteLumeByLumeID(ctx interface{}, arg interface{}) *MockLumeQuerier_DeleteLumeByLumeID_Call {
+	return &MockLumeQuerier_DeleteLumeByLumeID_Call{Call: _e.mock.On("DeleteLumeByLumeID", ctx, arg)}
 }
 
-func (_c *MockLumeQuerier_DeleteLumeByLumeID_Call) Run(run func(ctx context.Context, lumeID uuid.UUID)) *MockLumeQuerier_DeleteLumeByLumeID_Call {
+func (_c *MockLumeQuerier_DeleteLumeByLumeID_Call) Run(run func(ctx context.Context, arg sqlc.DeleteLumeByLumeIDParams)) *MockLumeQuerier_DeleteLumeByLumeID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uuid.UUID
+		var arg1 sqlc.DeleteLumeByLumeIDParams
 		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
+			arg1 = args[1].(sqlc.DeleteLumeByLumeIDParams)
 		}
 		run(
 			arg0,
@@ -275,12 +482,12 @@ func (_c *MockLumeQuerier_DeleteLumeByLumeID_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockLumeQuerier_DeleteLumeByLumeID_Call) Return(err error) *MockLumeQuerier_DeleteLumeByLumeID_Call {
-	_c.Call.Return(err)
+func (_c *MockLumeQuerier_DeleteLumeByLumeID_Call) Return(lume sqlc.Lume, err error) *MockLumeQuerier_DeleteLumeByLumeID_Call {
+	_c.Call.Return(lume, err)
 	return _c
 }
 
-func (_c *MockLumeQuerier_DeleteLumeByLumeID_Call) RunAndReturn(run func(ctx context.Context, lumeID uuid.UUID) error) *MockLumeQuerier_DeleteLumeByLumeID_Call {
+func (_c *MockLumeQuerier_DeleteLumeByLumeID_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.DeleteLumeByLumeIDParams) (sqlc.Lume, error)) *MockLumeQuerier_DeleteLumeByLumeID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -417,6 +624,72 @@ func (_c *MockLumeQuerier_GetLumeByLumeID_Call) RunAndReturn(run func(ctx contex
 	return _c
 }
 
+// IsLumoTrashed provides a mock function for the type MockLumeQuerier
+func (_mock *MockLumeQuerier) IsLumoTrashed(ctx context.Context, lumoID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, lumoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLumoTrashed")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, lumoID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, lumoID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, lumoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLumeQuerier_IsLumoTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLumoTrashed'
+type MockLumeQuerier_IsLumoTrashed_Call struct {
+	*mock.Call
+}
+
+// IsLumoTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lumoID uuid.UUID
+func (_e *MockLumeQuerier_Expecter) IsLumoTrashed(ctx interface{}, lumoID interface{}) *MockLumeQuerier_IsLumoTrashed_Call {
+	return &MockLumeQuerier_IsLumoTrashed_Call{Call: _e.mock.On("IsLumoTrashed", ctx, lumoID)}
+}
+
+func (_c *MockLumeQuerier_IsLumoTrashed_Call) Run(run func(ctx context.Context, lumoID uuid.UUID)) *MockLumeQuerier_IsLumoTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLumeQuerier_IsLumoTrashed_Call) Return(b bool, err error) *MockLumeQuerier_IsLumoTrashed_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockLumeQuerier_IsLumoTrashed_Call) RunAndReturn(run func(ctx context.Context, lumoID uuid.UUID) (bool, error)) *MockLumeQuerier_IsLumoTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListLumesByLumoID provides a mock function for the type MockLumeQuerier
 func (_mock *MockLumeQuerier) ListLumesByLumoID(ctx context.Context, arg sqlc.ListLumesByLumoIDParams) ([]sqlc.Lume, error) {
 	ret := _mock.Called(ctx, arg)
@@ -553,6 +826,311 @@ func (_c *MockLumeQuerier_ListLumesByType_Call) RunAndReturn(run func(ctx contex
 	return _c
 }
 
+// ListTrashedLumesByLumoID provides a mock function for the type MockLumeQuerier
+func (_mock *MockLumeQuerier) ListTrashedLumesByLumoID(ctx context.Context, arg sqlc.ListTrashedLumesByLumoIDParams) ([]sqlc.Lume, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTrashedLumesByLumoID")
+	}
+
+	var r0 []sqlc.Lume
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.ListTrashedLumesByLumoIDParams) ([]sqlc.Lume, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.ListTrashedLumesByLumoIDParams) []sqlc.Lume); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlc.Lume)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sqlc.ListTrashedLumesByLumoIDParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLumeQuerier_ListTrashedLumesByLumoID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTrashedLumesByLumoID'
+type MockLumeQuerier_ListTrashedLumesByLumoID_Call struct {
+	*mock.Call
+}
+
+// ListTrashedLumesByLumoID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg sqlc.ListTrashedLumesByLumoIDParams
+func (_e *MockLumeQuerier_Expecter) ListTrashedLumesByLumoID(ctx interface{}, arg interface{}) *MockLumeQuerier_ListTrashedLumesByLumoID_Call {
+	return &MockLumeQuerier_ListTrashedLumesByLumoID_Call{Call: _e.mock.On("ListTrashedLumesByLumoID", ctx, arg)}
+}
+
+func (_c *MockLumeQuerier_ListTrashedLumesByLumoID_Call) Run(run func(ctx context.Context, arg sqlc.ListTrashedLumesByLumoIDParams)) *MockLumeQuerier_ListTrashedLumesByLumoID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sqlc.ListTrashedLumesByLumoIDParams
+		if args[1] != nil {
+			arg1 = args[1].(sqlc.ListTrashedLumesByLumoIDParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLumeQuerier_ListTrashedLumesByLumoID_Call) Return(lumes []sqlc.Lume, err error) *MockLumeQuerier_ListTrashedLumesByLumoID_Call {
+	_c.Call.Return(lumes, err)
+	return _c
+}
+
+func (_c *MockLumeQuerier_ListTrashedLumesByLumoID_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.ListTrashedLumesByLumoIDParams) ([]sqlc.Lume, error)) *MockLumeQuerier_ListTrashedLumesByLumoID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockLumoEvents provides a mock function for the type MockLumeQuerier
+func (_mock *MockLumeQuerier) LockLumoEvents(ctx context.Context, lumoID string) error {
+	ret := _mock.Called(ctx, lumoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockLumoEvents")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, lumoID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLumeQuerier_LockLumoEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockLumoEvents'
+type MockLumeQuerier_LockLumoEvents_Call struct {
+	*mock.Call
+}
+
+// LockLumoEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lumoID string
+func (_e *MockLumeQuerier_Expecter) LockLumoEvents(ctx interface{}, lumoID interface{}) *MockLumeQuerier_LockLumoEvents_Call {
+	return &MockLumeQuerier_LockLumoEvents_Call{Call: _e.mock.On("LockLumoEvents", ctx, lumoID)}
+}
+
+func (_c *MockLumeQuerier_LockLumoEvents_Call) Run(run func(ctx context.Context, lumoID string)) *MockLumeQuerier_LockLumoEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLumeQuerier_LockLumoEvents_Call) Return(err error) *MockLumeQuerier_LockLumoEvents_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLumeQuerier_LockLumoEvents_Call) RunAndReturn(run func(ctx context.Context, lumoID string) error) *MockLumeQuerier_LockLumoEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotifyLumoEvent provides a mock function for the type MockLumeQuerier
+func (_mock *MockLumeQuerier) NotifyLumoEvent(ctx context.Context, payload string) error {
+	ret := _mock.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyLumoEvent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLumeQuerier_NotifyLumoEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifyLumoEvent'
+type MockLumeQuerier_NotifyLumoEvent_Call struct {
+	*mock.Call
+}
+
+// NotifyLumoEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payload string
+func (_e *MockLumeQuerier_Expecter) NotifyLumoEvent(ctx interface{}, payload interface{}) *MockLumeQuerier_NotifyLumoEvent_Call {
+	return &MockLumeQuerier_NotifyLumoEvent_Call{Call: _e.mock.On("NotifyLumoEvent", ctx, payload)}
+}
+
+func (_c *MockLumeQuerier_NotifyLumoEvent_Call) Run(run func(ctx context.Context, payload string)) *MockLumeQuerier_NotifyLumoEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLumeQuerier_NotifyLumoEvent_Call) Return(err error) *MockLumeQuerier_NotifyLumoEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLumeQuerier_NotifyLumoEvent_Call) RunAndReturn(run func(ctx context.Context, payload string) error) *MockLumeQuerier_NotifyLumoEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreLinksByLumeID provides a mock function for the type MockLumeQuerier
+func (_mock *MockLumeQuerier) RestoreLinksByLumeID(ctx context.Context, fromLumeID uuid.UUID) error {
+	ret := _mock.Called(ctx, fromLumeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreLinksByLumeID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, fromLumeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLumeQuerier_RestoreLinksByLumeID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreLinksByLumeID'
+type MockLumeQuerier_RestoreLinksByLumeID_Call struct {
+	*mock.Call
+}
+
+// RestoreLinksByLumeID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromLumeID uuid.UUID
+func (_e *MockLumeQuerier_Expecter) RestoreLinksByLumeID(ctx interface{}, fromLumeID interface{}) *MockLumeQuerier_RestoreLinksByLumeID_Call {
+	return &MockLumeQuerier_RestoreLinksByLumeID_Call{Call: _e.mock.On("RestoreLinksByLumeID", ctx, fromLumeID)}
+}
+
+func (_c *MockLumeQuerier_RestoreLinksByLumeID_Call) Run(run func(ctx context.Context, fromLumeID uuid.UUID)) *MockLumeQuerier_RestoreLinksByLumeID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLumeQuerier_RestoreLinksByLumeID_Call) Return(err error) *MockLumeQuerier_RestoreLinksByLumeID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLumeQuerier_RestoreLinksByLumeID_Call) RunAndReturn(run func(ctx context.Context, fromLumeID uuid.UUID) error) *MockLumeQuerier_RestoreLinksByLumeID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreLumeByLumeID provides a mock function for the type MockLumeQuerier
+func (_mock *MockLumeQuerier) RestoreLumeByLumeID(ctx context.Context, lumeID uuid.UUID) (sqlc.Lume, error) {
+	ret := _mock.Called(ctx, lumeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreLumeByLumeID")
+	}
+
+	var r0 sqlc.Lume
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (sqlc.Lume, error)); ok {
+		return returnFunc(ctx, lumeID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) sqlc.Lume); ok {
+		r0 = returnFunc(ctx, lumeID)
+	} else {
+		r0 = ret.Get(0).(sqlc.Lume)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, lumeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLumeQuerier_RestoreLumeByLumeID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreLumeByLumeID'
+type MockLumeQuerier_RestoreLumeByLumeID_Call struct {
+	*mock.Call
+}
+
+// RestoreLumeByLumeID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lumeID uuid.UUID
+func (_e *MockLumeQuerier_Expecter) RestoreLumeByLumeID(ctx interface{}, lumeID interface{}) *MockLumeQuerier_RestoreLumeByLumeID_Call {
+	return &MockLumeQuerier_RestoreLumeByLumeID_Call{Call: _e.mock.On("RestoreLumeByLumeID", ctx, lumeID)}
+}
+
+func (_c *MockLumeQuerier_RestoreLumeByLumeID_Call) Run(run func(ctx context.Context, lumeID uuid.UUID)) *MockLumeQuerier_RestoreLumeByLumeID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLumeQuerier_RestoreLumeByLumeID_Call) Return(lume sqlc.Lume, err error) *MockLumeQuerier_RestoreLumeByLumeID_Call {
+	_c.Call.Return(lume, err)
+	return _c
+}
+
+func (_c *MockLumeQuerier_RestoreLumeByLumeID_Call) RunAndReturn(run func(ctx context.Context, lumeID uuid.UUID) (sqlc.Lume, error)) *MockLumeQuerier_RestoreLumeByLumeID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SearchLumesByLocation provides a mock function for the type MockLumeQuerier
 func (_mock *MockLumeQuerier) SearchLumesByLocation(ctx context.Context, arg sqlc.SearchLumesByLocationParams) ([]sqlc.Lume, error) {
 	ret := _mock.Called(ctx, arg)
@@ -621,6 +1199,63 @@ func (_c *MockLumeQuerier_SearchLumesByLocation_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// TrashLinksByLumeID provides a mock function for the type MockLumeQuerier
+func (_mock *MockLumeQuerier) TrashLinksByLumeID(ctx context.Context, arg sqlc.TrashLinksByLumeIDParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TrashLinksByLumeID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sqlc.TrashLinksByLumeIDParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLumeQuerier_TrashLinksByLumeID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TrashLinksByLumeID'
+type MockLumeQuerier_TrashLinksByLumeID_Call struct {
+	*mock.Call
+}
+
+// TrashLinksByLumeID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg sqlc.TrashLinksByLumeIDParams
+func (_e *MockLumeQuerier_Expecter) TrashLinksByLumeID(ctx interface{}, arg interface{}) *MockLumeQuerier_TrashLinksByLumeID_Call {
+	return &MockLumeQuerier_TrashLinksByLumeID_Call{Call: _e.mock.On("TrashLinksByLumeID", ctx, arg)}
+}
+
+func (_c *MockLumeQuerier_TrashLinksByLumeID_Call) Run(run func(ctx context.Context, arg sqlc.TrashLinksByLumeIDParams)) *MockLumeQuerier_TrashLinksByLumeID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sqlc.TrashLinksByLumeIDParams
+		if args[1] != nil {
+			arg1 = args[1].(sqlc.TrashLinksByLumeIDParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLumeQuerier_TrashLinksByLumeID_Call) Return(err error) *MockLumeQuerier_TrashLinksByLumeID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLumeQuerier_TrashLinksByLumeID_Call) RunAndReturn(run func(ctx context.Context, arg sqlc.TrashLinksByLumeIDParams) error) *MockLumeQuerier_TrashLinksByLumeID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateLume provides a mock function for the type MockLumeQuerier
 func (_mock *MockLumeQuerier) UpdateLume(ctx context.Context, arg sqlc.UpdateLumeParams) (sqlc.Lume, error) {
 	ret := _mock.Called(ctx, arg)
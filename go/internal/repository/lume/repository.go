@@ -3,49 +3,112 @@ package lume
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/event"
+	"github.com/mcdev12/lumo/go/internal/models/history"
 	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/outbox"
+	"github.com/mcdev12/lumo/go/internal/models/trash"
+	"github.com/mcdev12/lumo/go/internal/repository/db"
 	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	eventRepo "github.com/mcdev12/lumo/go/internal/repository/event"
+	historyRepo "github.com/mcdev12/lumo/go/internal/repository/history"
+	outboxRepo "github.com/mcdev12/lumo/go/internal/repository/outbox"
 )
 
 //go:generate mockery
 type LumeQuerier interface {
 	CountLumesByLumo(ctx context.Context, lumoID uuid.UUID) (int64, error)
+	CreateEntityHistory(ctx context.Context, arg sqlc.CreateEntityHistoryParams) (sqlc.EntityHistory, error)
 	CreateLume(ctx context.Context, arg sqlc.CreateLumeParams) (sqlc.Lume, error)
-	DeleteLume(ctx context.Context, id int64) error
-	DeleteLumeByLumeID(ctx context.Context, lumeID uuid.UUID) error
+	CreateLumoEvent(ctx context.Context, arg sqlc.CreateLumoEventParams) (sqlc.LumoEvent, error)
+	CreateOutboxEvent(ctx context.Context, arg sqlc.CreateOutboxEventParams) error
+	DeleteLume(ctx context.Context, arg sqlc.DeleteLumeParams) (sqlc.Lume, error)
+	DeleteLumeByLumeID(ctx context.Context, arg sqlc.DeleteLumeByLumeIDParams) (sqlc.Lume, error)
 	GetLumeByID(ctx context.Context, id int64) (sqlc.Lume, error)
 	GetLumeByLumeID(ctx context.Context, lumeID uuid.UUID) (sqlc.Lume, error)
+	IsLumoTrashed(ctx context.Context, lumoID uuid.UUID) (bool, error)
 	ListLumesByLumoID(ctx context.Context, arg sqlc.ListLumesByLumoIDParams) ([]sqlc.Lume, error)
 	ListLumesByType(ctx context.Context, arg sqlc.ListLumesByTypeParams) ([]sqlc.Lume, error)
+	ListTrashedLumesByLumoID(ctx context.Context, arg sqlc.ListTrashedLumesByLumoIDParams) ([]sqlc.Lume, error)
+	LockLumoEvents(ctx context.Context, lumoID string) error
+	NotifyLumoEvent(ctx context.Context, payload string) error
+	RestoreLinksByLumeID(ctx context.Context, fromLumeID uuid.UUID) error
+	RestoreLumeByLumeID(ctx context.Context, lumeID uuid.UUID) (sqlc.Lume, error)
 	SearchLumesByLocation(ctx context.Context, arg sqlc.SearchLumesByLocationParams) ([]sqlc.Lume, error)
+	TrashLinksByLumeID(ctx context.Context, arg sqlc.TrashLinksByLumeIDParams) error
 	UpdateLume(ctx context.Context, arg sqlc.UpdateLumeParams) (sqlc.Lume, error)
 }
 
 // Repository is the concrete implementation for Lume data access
 type Repository struct {
+	db      sqlc.DBTX
 	queries LumeQuerier
 }
 
 // NewRepository creates a new Repository instance
-func NewRepository(db sqlc.DBTX) *Repository {
+func NewRepository(conn sqlc.DBTX) *Repository {
 	return &Repository{
-		queries: sqlc.New(db),
+		db:      conn,
+		queries: sqlc.New(conn),
+	}
+}
+
+// WithTx returns a Repository that runs its queries on the given transaction
+func (r *Repository) WithTx(tx sqlc.DBTX) *Repository {
+	return &Repository{
+		db:      tx,
+		queries: sqlc.New(tx),
 	}
 }
 
 // CreateLume creates a new Lume record from domain model
 func (r *Repository) CreateLume(ctx context.Context, domainLume *lume.Lume) (*lume.Lume, error) {
+	return r.CreateLumeWithQuota(ctx, domainLume, 0)
+}
+
+// CreateLumeWithQuota creates a new Lume unless its Lumo already holds
+// maxLumes outside the trash, returning lume.ErrTooManyLumes then. A maxLumes
+// of 0 means no limit.
+func (r *Repository) CreateLumeWithQuota(ctx context.Context, domainLume *lume.Lume, maxLumes int64) (*lume.Lume, error) {
 	params := r.domainToCreateParams(domainLume)
 
-	result, err := r.queries.CreateLume(ctx, params)
+	var created *lume.Lume
+	err := db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		if maxLumes > 0 {
+			// Every write to the Lumo takes its event lock, holding it from
+			// the count to the commit keeps concurrent creates from all
+			// taking the last place
+			if err := queries.LockLumoEvents(ctx, params.LumoID.String()); err != nil {
+				return err
+			}
+			count, err := queries.CountLumesByLumo(ctx, params.LumoID)
+			if err != nil {
+				return err
+			}
+			if count >= maxLumes {
+				return lume.ErrTooManyLumes
+			}
+		}
+
+		result, err := queries.CreateLume(ctx, params)
+		if err != nil {
+			return err
+		}
+		created = r.sqlcRowToDomainModel(result)
+
+		return r.recordChange(ctx, queries, event.TypeCreated, created)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return r.sqlcRowToDomainModel(result), nil
+	return created, nil
 }
 
 // GetLumeByID retrieves a Lume by its internal ID
@@ -156,30 +219,170 @@ func (r *Repository) SearchLumesByLocation(ctx context.Context, lumoID string, m
 	return lumes, nil
 }
 
-// UpdateLume updates an existing Lume record
+// UpdateLume updates an existing Lume record. The update only applies if the
+// stored version still matches domainLume.Version, otherwise a
+// *version.ConflictError is returned.
 func (r *Repository) UpdateLume(ctx context.Context, domainLume *lume.Lume) (*lume.Lume, error) {
 	params := r.domainToUpdateParams(domainLume)
 
-	result, err := r.queries.UpdateLume(ctx, params)
+	var updated *lume.Lume
+	err := db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.UpdateLume(ctx, params)
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.VersionConflict(domainLume.Version, err, func() (int64, error) {
+				row, err := queries.GetLumeByLumeID(ctx, params.LumeID)
+				return row.Version, err
+			})
+		}
+		if err != nil {
+			return err
+		}
+		updated = r.sqlcRowToDomainModel(result)
+
+		return r.recordChange(ctx, queries, event.TypeUpdated, updated)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return r.sqlcRowToDomainModel(result), nil
+	return updated, nil
 }
 
-// DeleteLume deletes a Lume by its internal ID
-func (r *Repository) DeleteLume(ctx context.Context, id int64) error {
-	return r.queries.DeleteLume(ctx, id)
+// DeleteLume moves a Lume and its Links to the trash by its internal ID. When
+// expectedVersion is set the delete only applies if the stored version still
+// matches.
+func (r *Repository) DeleteLume(ctx context.Context, id int64, expectedVersion *int64) error {
+	return db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.DeleteLume(ctx, sqlc.DeleteLumeParams{
+			DeletedAt:       time.Now(),
+			ID:              id,
+			ExpectedVersion: db.NullVersion(expectedVersion),
+		})
+		if errors.Is(err, sql.ErrNoRows) && expectedVersion != nil {
+			err = db.VersionConflict(*expectedVersion, err, func() (int64, error) {
+				row, err := queries.GetLumeByID(ctx, id)
+				return row.Version, err
+			})
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			// Nothing to delete
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return r.cascadeDelete(ctx, queries, result)
+	})
 }
 
-// DeleteLumeByLumeID deletes a Lume by its UUID
-func (r *Repository) DeleteLumeByLumeID(ctx context.Context, lumeID string) error {
+// DeleteLumeByLumeID moves a Lume and its Links to the trash by its UUID. When
+// expectedVersion is set the delete only applies if the stored version still
+// matches.
+func (r *Repository) DeleteLumeByLumeID(ctx context.Context, lumeID string, expectedVersion *int64) error {
 	parsedUUID, err := uuid.Parse(lumeID)
 	if err != nil {
 		return err
 	}
-	return r.queries.DeleteLumeByLumeID(ctx, parsedUUID)
+
+	return db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.DeleteLumeByLumeID(ctx, sqlc.DeleteLumeByLumeIDParams{
+			DeletedAt:       time.Now(),
+			LumeID:          parsedUUID,
+			ExpectedVersion: db.NullVersion(expectedVersion),
+		})
+		if errors.Is(err, sql.ErrNoRows) && expectedVersion != nil {
+			err = db.VersionConflict(*expectedVersion, err, func() (int64, error) {
+				row, err := queries.GetLumeByLumeID(ctx, parsedUUID)
+				return row.Version, err
+			})
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			// Nothing to delete
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return r.cascadeDelete(ctx, queries, result)
+	})
+}
+
+// ListTrashedLumesByLumoID retrieves the Lumes of a Lumo that were deleted
+// directly, most recently deleted first
+func (r *Repository) ListTrashedLumesByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*lume.Lume, error) {
+	parsedLumoID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.ListTrashedLumesByLumoIDParams{
+		LumoID: parsedLumoID,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	results, err := r.queries.ListTrashedLumesByLumoID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	lumes := make([]*lume.Lume, len(results))
+	for i, result := range results {
+		lumes[i] = r.sqlcRowToDomainModel(result)
+	}
+
+	return lumes, nil
+}
+
+// RestoreLumeByLumeID takes a Lume out of the trash together with the Links
+// that were trashed along with it. It fails with trash.ErrNotInTrash if the
+// Lume isn't in the trash and trash.ErrParentInTrash while its Lumo is.
+func (r *Repository) RestoreLumeByLumeID(ctx context.Context, lumeID string) (*lume.Lume, error) {
+	parsedUUID, err := uuid.Parse(lumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored *lume.Lume
+	err = db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.RestoreLumeByLumeID(ctx, parsedUUID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return trash.ErrNotInTrash
+		}
+		if err != nil {
+			return err
+		}
+
+		lumoTrashed, err := queries.IsLumoTrashed(ctx, result.LumoID)
+		if err != nil {
+			return err
+		}
+		if lumoTrashed {
+			return trash.ErrParentInTrash
+		}
+
+		if err := queries.RestoreLinksByLumeID(ctx, parsedUUID); err != nil {
+			return err
+		}
+		restored = r.sqlcRowToDomainModel(result)
+
+		return r.recordChange(ctx, queries, event.TypeCreated, restored)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
 }
 
 // CountLumesByLumo returns the total count of Lumes for a Lumo
@@ -191,6 +394,49 @@ func (r *Repository) CountLumesByLumo(ctx context.Context, lumoID string) (int64
 	return r.queries.CountLumesByLumo(ctx, parsedLumoID)
 }
 
+// cascadeDelete records the deletion of a Lume and moves its Links to the trash
+// along with it
+func (r *Repository) cascadeDelete(ctx context.Context, queries LumeQuerier, deleted sqlc.Lume) error {
+	if err := r.recordChange(ctx, queries, event.TypeDeleted, r.sqlcRowToDomainModel(deleted)); err != nil {
+		return err
+	}
+
+	return queries.TrashLinksByLumeID(ctx, sqlc.TrashLinksByLumeIDParams{
+		DeletedAt: deleted.DeletedAt.Time,
+		LumeID:    deleted.LumeID,
+	})
+}
+
+// recordChange records a change to a Lume in its Lumo's event log, history
+// and the outbox. A restore is recorded as a creation. Links trashed or restored
+// together with a Lume get no entries of their own.
+func (r *Repository) recordChange(ctx context.Context, queries LumeQuerier, eventType event.Type, domainLume *lume.Lume) error {
+	domainEntry, err := history.NewEntry(ctx, domainLume.LumoID, history.EntityTypeLume, domainLume.LumeID, history.Operation(eventType), domainLume)
+	if err != nil {
+		return err
+	}
+	if err := historyRepo.Record(ctx, queries, domainEntry); err != nil {
+		return err
+	}
+	if err := outboxRepo.Record(ctx, queries, outbox.NewEvent(domainEntry)); err != nil {
+		return err
+	}
+
+	domainEvent, err := event.NewEvent(domainLume.LumoID, event.EntityTypeLume, domainLume.LumeID, eventType, domainLume)
+	if err != nil {
+		return err
+	}
+	return eventRepo.Record(ctx, queries, domainEvent)
+}
+
+// querierFor returns the querier to use on the given connection
+func (r *Repository) querierFor(conn sqlc.DBTX) LumeQuerier {
+	if conn == nil || conn == r.db {
+		return r.queries
+	}
+	return sqlc.New(conn)
+}
+
 // ensureStringArray ensures empty arrays instead of nil for consistency
 func (r *Repository) ensureStringArray(arr []string) []string {
 	if arr == nil {
@@ -211,6 +457,7 @@ func (r *Repository) domainToCreateParams(domainLume *lume.Lume) sqlc.CreateLume
 		CategoryTags: r.ensureStringArray(domainLume.CategoryTags),
 		CreatedAt:    now,
 		UpdatedAt:    now,
+		TimeZone:     domainLume.TimeZone,
 	}
 
 	// Handle description as sql.NullString
@@ -256,6 +503,8 @@ func (r *Repository) domainToUpdateParams(domainLume *lume.Lume) sqlc.UpdateLume
 		Images:       r.ensureStringArray(domainLume.Images),
 		CategoryTags: r.ensureStringArray(domainLume.CategoryTags),
 		UpdatedAt:    time.Now(),
+		Version:      domainLume.Version,
+		TimeZone:     domainLume.TimeZone,
 	}
 
 	// Handle description as sql.NullString
@@ -304,6 +553,8 @@ func (r *Repository) sqlcRowToDomainModel(row sqlc.Lume) *lume.Lume {
 		CategoryTags: r.ensureStringArray(row.CategoryTags),
 		CreatedAt:    row.CreatedAt,
 		UpdatedAt:    row.UpdatedAt,
+		Version:      row.Version,
+		TimeZone:     row.TimeZone,
 	}
 
 	// Handle description from sql.NullString
@@ -330,6 +581,9 @@ func (r *Repository) sqlcRowToDomainModel(row sqlc.Lume) *lume.Lume {
 	if row.BookingLink.Valid {
 		domainLume.BookingLink = &row.BookingLink.String
 	}
+	if row.DeletedAt.Valid {
+		domainLume.DeletedAt = &row.DeletedAt.Time
+	}
 
 	return domainLume
 }
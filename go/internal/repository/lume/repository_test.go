@@ -8,7 +8,12 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/event"
+	"github.com/mcdev12/lumo/go/internal/models/history"
 	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/outbox"
+	"github.com/mcdev12/lumo/go/internal/models/trash"
+	"github.com/mcdev12/lumo/go/internal/models/version"
 	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
 	"github.com/mcdev12/lumo/go/internal/repository/lume/mocks"
 	"github.com/stretchr/testify/mock"
@@ -89,6 +94,28 @@ func createTestLumeSqlc() sqlc.Lume {
 	}
 }
 
+// Helper function to create a test sqlc.Lume database model that is in the trash
+func createTrashedLumeSqlc() sqlc.Lume {
+	row := createTestLumeSqlc()
+	row.DeletedAt = sql.NullTime{Time: row.CreatedAt.Add(time.Hour), Valid: true}
+	return row
+}
+
+// Helper function to expect a Lume event, history entry and outbox event being recorded in the same write
+func (s *RepositoryTestSuite) expectEvent(eventType event.Type) {
+	s.mockQuerier.On("CreateEntityHistory", mock.Anything, mock.MatchedBy(func(params sqlc.CreateEntityHistoryParams) bool {
+		return params.EntityType == string(history.EntityTypeLume) && params.Operation == string(eventType)
+	})).Return(sqlc.EntityHistory{ID: 1}, nil)
+	s.mockQuerier.On("LockLumoEvents", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+	s.mockQuerier.On("CreateLumoEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateLumoEventParams) bool {
+		return params.EntityType == string(event.EntityTypeLume) && params.EventType == string(eventType)
+	})).Return(sqlc.LumoEvent{ID: 1}, nil)
+	s.mockQuerier.On("NotifyLumoEvent", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+	s.mockQuerier.On("CreateOutboxEvent", mock.Anything, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+		return params.EventType == outbox.TypeOf(history.EntityTypeLume, history.Operation(eventType))
+	})).Return(nil)
+}
+
 // Test CreateLume
 func (s *RepositoryTestSuite) TestCreateLume() {
 	// Arrange
@@ -98,6 +125,7 @@ func (s *RepositoryTestSuite) TestCreateLume() {
 
 	// Set up expectations
 	s.mockQuerier.On("CreateLume", mock.Anything, mock.AnythingOfType("sqlc.CreateLumeParams")).Return(sqlcLume, nil)
+	s.expectEvent(event.TypeCreated)
 
 	// Act
 	result, err := s.repository.CreateLume(ctx, domainLume)
@@ -142,6 +170,50 @@ func (s *RepositoryTestSuite) TestCreateLumeError() {
 	s.mockQuerier.AssertExpectations(s.T())
 }
 
+// Test CreateLumeWithQuota counts the Lumes under the Lumo's lock before creating one
+func (s *RepositoryTestSuite) TestCreateLumeWithQuota() {
+	// Arrange
+	ctx := context.Background()
+	domainLume := createTestLumeDomain()
+	lumoID := uuid.MustParse(domainLume.LumoID)
+	sqlcLume := createTestLumeSqlc()
+
+	// Set up expectations
+	lock := s.mockQuerier.On("LockLumoEvents", mock.Anything, domainLume.LumoID).Return(nil).Once()
+	count := s.mockQuerier.On("CountLumesByLumo", mock.Anything, lumoID).Return(int64(9), nil).NotBefore(lock)
+	s.mockQuerier.On("CreateLume", mock.Anything, mock.AnythingOfType("sqlc.CreateLumeParams")).Return(sqlcLume, nil).NotBefore(count)
+	s.expectEvent(event.TypeCreated)
+
+	// Act
+	result, err := s.repository.CreateLumeWithQuota(ctx, domainLume, 10)
+
+	// Assert
+	s.NoError(err)
+	s.NotNil(result)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test CreateLumeWithQuota refuses to create a Lume in a full Lumo
+func (s *RepositoryTestSuite) TestCreateLumeOverQuota() {
+	// Arrange
+	ctx := context.Background()
+	domainLume := createTestLumeDomain()
+	lumoID := uuid.MustParse(domainLume.LumoID)
+
+	// Set up expectations
+	s.mockQuerier.On("LockLumoEvents", mock.Anything, domainLume.LumoID).Return(nil)
+	s.mockQuerier.On("CountLumesByLumo", mock.Anything, lumoID).Return(int64(10), nil)
+
+	// Act
+	result, err := s.repository.CreateLumeWithQuota(ctx, domainLume, 10)
+
+	// Assert
+	s.ErrorIs(err, lume.ErrTooManyLumes)
+	s.Nil(result)
+	s.mockQuerier.AssertNotCalled(s.T(), "CreateLume", mock.Anything, mock.Anything)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
 // Test GetLumeByID
 func (s *RepositoryTestSuite) TestGetLumeByID() {
 	// Arrange
@@ -423,6 +495,7 @@ func (s *RepositoryTestSuite) TestUpdateLume() {
 
 	// Set up expectations
 	s.mockQuerier.On("UpdateLume", mock.Anything, mock.AnythingOfType("sqlc.UpdateLumeParams")).Return(sqlcLume, nil)
+	s.expectEvent(event.TypeUpdated)
 
 	// Act
 	result, err := s.repository.UpdateLume(ctx, domainLume)
@@ -447,17 +520,55 @@ func (s *RepositoryTestSuite) TestUpdateLume() {
 	s.mockQuerier.AssertExpectations(s.T())
 }
 
+// Test UpdateLume when the Lume was changed concurrently
+func (s *RepositoryTestSuite) TestUpdateLumeVersionConflict() {
+	// Arrange
+	ctx := context.Background()
+	domainLume := createTestLumeDomain()
+	domainLume.Version = 2
+	current := createTestLumeSqlc()
+	current.Version = 3
+
+	// Set up expectations
+	s.mockQuerier.On("UpdateLume", mock.Anything, mock.MatchedBy(func(params sqlc.UpdateLumeParams) bool {
+		return params.Version == 2
+	})).Return(sqlc.Lume{}, sql.ErrNoRows)
+	s.mockQuerier.On("GetLumeByLumeID", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(current, nil)
+
+	// Act
+	result, err := s.repository.UpdateLume(ctx, domainLume)
+
+	// Assert
+	s.Error(err)
+	s.Nil(result)
+	s.ErrorIs(err, version.ErrConflict)
+	currentVersion, ok := version.Current(err)
+	s.True(ok)
+	s.Equal(int64(3), currentVersion)
+	s.mockQuerier.AssertNotCalled(s.T(), "CreateLumoEvent", mock.Anything, mock.Anything)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
 // Test DeleteLume
 func (s *RepositoryTestSuite) TestDeleteLume() {
 	// Arrange
 	ctx := context.Background()
 	id := int64(1)
 
+	trashed := createTrashedLumeSqlc()
+
 	// Set up expectations
-	s.mockQuerier.On("DeleteLume", mock.Anything, id).Return(nil)
+	s.mockQuerier.On("DeleteLume", mock.Anything, mock.MatchedBy(func(params sqlc.DeleteLumeParams) bool {
+		return params.ID == id && !params.DeletedAt.IsZero() && !params.ExpectedVersion.Valid
+	})).Return(trashed, nil)
+	s.expectEvent(event.TypeDeleted)
+	s.mockQuerier.On("TrashLinksByLumeID", mock.Anything, sqlc.TrashLinksByLumeIDParams{
+		DeletedAt: trashed.DeletedAt.Time,
+		LumeID:    trashed.LumeID,
+	}).Return(nil)
 
 	// Act
-	err := s.repository.DeleteLume(ctx, id)
+	err := s.repository.DeleteLume(ctx, id, nil)
 
 	// Assert
 	s.NoError(err)
@@ -471,15 +582,154 @@ func (s *RepositoryTestSuite) TestDeleteLumeByLumeID() {
 	domainLume := createTestLumeDomain()
 	lumeID := uuid.MustParse(domainLume.LumeID)
 	lumeIDStr := lumeID.String()
+	expectedVersion := int64(4)
+	trashed := createTrashedLumeSqlc()
+
+	// Set up expectations
+	s.mockQuerier.On("DeleteLumeByLumeID", mock.Anything, mock.MatchedBy(func(params sqlc.DeleteLumeByLumeIDParams) bool {
+		return params.LumeID == lumeID && !params.DeletedAt.IsZero() &&
+			params.ExpectedVersion == sql.NullInt64{Int64: expectedVersion, Valid: true}
+	})).Return(trashed, nil)
+	s.expectEvent(event.TypeDeleted)
+	s.mockQuerier.On("TrashLinksByLumeID", mock.Anything, sqlc.TrashLinksByLumeIDParams{
+		DeletedAt: trashed.DeletedAt.Time,
+		LumeID:    trashed.LumeID,
+	}).Return(nil)
+
+	// Act
+	err := s.repository.DeleteLumeByLumeID(ctx, lumeIDStr, &expectedVersion)
+
+	// Assert
+	s.NoError(err)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test DeleteLume when the Lume doesn't exist
+func (s *RepositoryTestSuite) TestDeleteLumeNotFound() {
+	// Arrange
+	ctx := context.Background()
+	id := int64(1)
+
+	// Set up expectations
+	s.mockQuerier.On("DeleteLume", mock.Anything, mock.AnythingOfType("sqlc.DeleteLumeParams")).Return(sqlc.Lume{}, sql.ErrNoRows)
+
+	// Act
+	err := s.repository.DeleteLume(ctx, id, nil)
+
+	// Assert
+	s.NoError(err)
+	s.mockQuerier.AssertNotCalled(s.T(), "CreateLumoEvent", mock.Anything, mock.Anything)
+	s.mockQuerier.AssertNotCalled(s.T(), "TrashLinksByLumeID", mock.Anything, mock.Anything)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test DeleteLume when the Lume was changed concurrently
+func (s *RepositoryTestSuite) TestDeleteLumeVersionConflict() {
+	// Arrange
+	ctx := context.Background()
+	id := int64(1)
+	expectedVersion := int64(1)
+	current := createTestLumeSqlc()
+	current.Version = 2
+
+	// Set up expectations
+	s.mockQuerier.On("DeleteLume", mock.Anything, mock.MatchedBy(func(params sqlc.DeleteLumeParams) bool {
+		return params.ExpectedVersion == sql.NullInt64{Int64: expectedVersion, Valid: true}
+	})).Return(sqlc.Lume{}, sql.ErrNoRows)
+	s.mockQuerier.On("GetLumeByID", mock.Anything, id).Return(current, nil)
+
+	// Act
+	err := s.repository.DeleteLume(ctx, id, &expectedVersion)
+
+	// Assert
+	s.ErrorIs(err, version.ErrConflict)
+	s.mockQuerier.AssertNotCalled(s.T(), "CreateLumoEvent", mock.Anything, mock.Anything)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test ListTrashedLumesByLumoID
+func (s *RepositoryTestSuite) TestListTrashedLumesByLumoID() {
+	// Arrange
+	ctx := context.Background()
+	trashed := createTrashedLumeSqlc()
+
+	// Set up expectations
+	params := sqlc.ListTrashedLumesByLumoIDParams{
+		LumoID: trashed.LumoID,
+		Limit:  10,
+		Offset: 0,
+	}
+	s.mockQuerier.On("ListTrashedLumesByLumoID", mock.Anything, params).Return([]sqlc.Lume{trashed}, nil)
+
+	// Act
+	results, err := s.repository.ListTrashedLumesByLumoID(ctx, trashed.LumoID.String(), 10, 0)
+
+	// Assert
+	s.NoError(err)
+	s.Len(results, 1)
+	s.Require().NotNil(results[0].DeletedAt)
+	s.Equal(trashed.DeletedAt.Time, *results[0].DeletedAt)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test RestoreLumeByLumeID brings back the Lume and its Links
+func (s *RepositoryTestSuite) TestRestoreLumeByLumeID() {
+	// Arrange
+	ctx := context.Background()
+	restoredRow := createTestLumeSqlc()
 
 	// Set up expectations
-	s.mockQuerier.On("DeleteLumeByLumeID", mock.Anything, lumeID).Return(nil)
+	s.mockQuerier.On("RestoreLumeByLumeID", mock.Anything, restoredRow.LumeID).Return(restoredRow, nil)
+	s.mockQuerier.On("IsLumoTrashed", mock.Anything, restoredRow.LumoID).Return(false, nil)
+	s.mockQuerier.On("RestoreLinksByLumeID", mock.Anything, restoredRow.LumeID).Return(nil)
+	s.expectEvent(event.TypeCreated)
 
 	// Act
-	err := s.repository.DeleteLumeByLumeID(ctx, lumeIDStr)
+	result, err := s.repository.RestoreLumeByLumeID(ctx, restoredRow.LumeID.String())
 
 	// Assert
 	s.NoError(err)
+	s.Equal(restoredRow.LumeID.String(), result.LumeID)
+	s.Nil(result.DeletedAt)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test RestoreLumeByLumeID when the Lume isn't in the trash
+func (s *RepositoryTestSuite) TestRestoreLumeByLumeIDNotInTrash() {
+	// Arrange
+	ctx := context.Background()
+	lumeID := uuid.New()
+
+	// Set up expectations
+	s.mockQuerier.On("RestoreLumeByLumeID", mock.Anything, lumeID).Return(sqlc.Lume{}, sql.ErrNoRows)
+
+	// Act
+	result, err := s.repository.RestoreLumeByLumeID(ctx, lumeID.String())
+
+	// Assert
+	s.ErrorIs(err, trash.ErrNotInTrash)
+	s.Nil(result)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test RestoreLumeByLumeID while its Lumo is in the trash
+func (s *RepositoryTestSuite) TestRestoreLumeByLumeIDParentInTrash() {
+	// Arrange
+	ctx := context.Background()
+	restoredRow := createTestLumeSqlc()
+
+	// Set up expectations
+	s.mockQuerier.On("RestoreLumeByLumeID", mock.Anything, restoredRow.LumeID).Return(restoredRow, nil)
+	s.mockQuerier.On("IsLumoTrashed", mock.Anything, restoredRow.LumoID).Return(true, nil)
+
+	// Act
+	result, err := s.repository.RestoreLumeByLumeID(ctx, restoredRow.LumeID.String())
+
+	// Assert
+	s.ErrorIs(err, trash.ErrParentInTrash)
+	s.Nil(result)
+	s.mockQuerier.AssertNotCalled(s.T(), "RestoreLinksByLumeID", mock.Anything, mock.Anything)
+	s.mockQuerier.AssertNotCalled(s.T(), "CreateLumoEvent", mock.Anything, mock.Anything)
 	s.mockQuerier.AssertExpectations(s.T())
 }
 
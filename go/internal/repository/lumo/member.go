@@ -0,0 +1,155 @@
+package lumo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/access"
+	"github.com/mcdev12/lumo/go/internal/models/lumo"
+	"github.com/mcdev12/lumo/go/internal/repository/db"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+// CreateMember shares a Lumo with a user. It fails with lumo.ErrAlreadyMember
+// if the user is a member already.
+func (r *Repository) CreateMember(ctx context.Context, domainMember *lumo.Member) (*lumo.Member, error) {
+	lumoID, userID, err := parseMemberKey(domainMember.LumoID, domainMember.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.CreateLumoMember(ctx, sqlc.CreateLumoMemberParams{
+		LumoID:    lumoID,
+		UserID:    userID,
+		Role:      string(domainMember.Role),
+		CreatedAt: domainMember.CreatedAt,
+		UpdatedAt: domainMember.UpdatedAt,
+	})
+	if db.IsUniqueViolation(err) {
+		return nil, lumo.ErrAlreadyMember
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.memberRowToDomainModel(result), nil
+}
+
+// GetMember retrieves the membership of a user in a Lumo
+func (r *Repository) GetMember(ctx context.Context, lumoID, userID string) (*lumo.Member, error) {
+	parsedLumoID, parsedUserID, err := parseMemberKey(lumoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.GetLumoMember(ctx, sqlc.GetLumoMemberParams{
+		LumoID: parsedLumoID,
+		UserID: parsedUserID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, lumo.ErrMemberNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.memberRowToDomainModel(result), nil
+}
+
+// ListMembers retrieves the members of a Lumo, in the order they joined
+func (r *Repository) ListMembers(ctx context.Context, lumoID string, limit, offset int32) ([]*lumo.Member, error) {
+	parsedUUID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := r.queries.ListLumoMembers(ctx, sqlc.ListLumoMembersParams{
+		LumoID: parsedUUID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*lumo.Member, len(results))
+	for i, result := range results {
+		members[i] = r.memberRowToDomainModel(result)
+	}
+
+	return members, nil
+}
+
+// UpdateMemberRole changes the role of a member. It fails with
+// lumo.ErrMemberNotFound if the user isn't a member.
+func (r *Repository) UpdateMemberRole(ctx context.Context, lumoID, userID string, role access.Role) (*lumo.Member, error) {
+	parsedLumoID, parsedUserID, err := parseMemberKey(lumoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.UpdateLumoMemberRole(ctx, sqlc.UpdateLumoMemberRoleParams{
+		LumoID:    parsedLumoID,
+		UserID:    parsedUserID,
+		Role:      string(role),
+		UpdatedAt: time.Now(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, lumo.ErrMemberNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.memberRowToDomainModel(result), nil
+}
+
+// DeleteMember stops sharing a Lumo with a user. It fails with
+// lumo.ErrMemberNotFound if the user isn't a member.
+func (r *Repository) DeleteMember(ctx context.Context, lumoID, userID string) error {
+	parsedLumoID, parsedUserID, err := parseMemberKey(lumoID, userID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := r.queries.DeleteLumoMember(ctx, sqlc.DeleteLumoMemberParams{
+		LumoID: parsedLumoID,
+		UserID: parsedUserID,
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return lumo.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// parseMemberKey parses the Lumo and user UUIDs identifying a membership
+func parseMemberKey(lumoID, userID string) (uuid.UUID, uuid.UUID, error) {
+	parsedLumoID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return parsedLumoID, parsedUserID, nil
+}
+
+// Helper method to convert SQLC member rows to domain model
+func (r *Repository) memberRowToDomainModel(row sqlc.LumoMember) *lumo.Member {
+	return &lumo.Member{
+		ID:        row.ID,
+		LumoID:    row.LumoID.String(),
+		UserID:    row.UserID.String(),
+		Role:      access.Role(row.Role),
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
@@ -2,47 +2,128 @@ package lumo
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/history"
 	"github.com/mcdev12/lumo/go/internal/models/lumo"
+	"github.com/mcdev12/lumo/go/internal/models/outbox"
+	"github.com/mcdev12/lumo/go/internal/models/trash"
+	"github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/mcdev12/lumo/go/internal/repository/db"
 	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	historyRepo "github.com/mcdev12/lumo/go/internal/repository/history"
+	outboxRepo "github.com/mcdev12/lumo/go/internal/repository/outbox"
 )
 
 //go:generate mockery
 type LumoQuerier interface {
+	CreateEntityHistory(ctx context.Context, arg sqlc.CreateEntityHistoryParams) (sqlc.EntityHistory, error)
 	CreateLumo(ctx context.Context, arg sqlc.CreateLumoParams) (sqlc.Lumo, error)
+	CreateOutboxEvent(ctx context.Context, arg sqlc.CreateOutboxEventParams) error
 	GetLumoByID(ctx context.Context, id int64) (sqlc.Lumo, error)
 	GetLumoByLumoID(ctx context.Context, lumoID uuid.UUID) (sqlc.Lumo, error)
 	ListLumosByUserID(ctx context.Context, arg sqlc.ListLumosByUserIDParams) ([]sqlc.Lumo, error)
 	UpdateLumo(ctx context.Context, arg sqlc.UpdateLumoParams) (sqlc.Lumo, error)
-	DeleteLumo(ctx context.Context, id int64) error
-	DeleteLumoByLumoID(ctx context.Context, lumoID uuid.UUID) error
+	DeleteLumo(ctx context.Context, arg sqlc.DeleteLumoParams) (sqlc.Lumo, error)
+	DeleteLumoByLumoID(ctx context.Context, arg sqlc.DeleteLumoByLumoIDParams) (sqlc.Lumo, error)
 	CountLumosByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountLumosOwnedByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	LockLumosOfUser(ctx context.Context, userID string) error
+	TrashLumesByLumoID(ctx context.Context, arg sqlc.TrashLumesByLumoIDParams) error
+	TrashLinksByLumoID(ctx context.Context, arg sqlc.TrashLinksByLumoIDParams) error
+	ListTrashedLumosByUserID(ctx context.Context, arg sqlc.ListTrashedLumosByUserIDParams) ([]sqlc.Lumo, error)
+	RestoreLumoByLumoID(ctx context.Context, lumoID uuid.UUID) (sqlc.Lumo, error)
+	RestoreLumesByLumoID(ctx context.Context, lumoID uuid.UUID) error
+	RestoreLinksByLumoID(ctx context.Context, lumoID uuid.UUID) error
+	UpsertLumoOwner(ctx context.Context, arg sqlc.UpsertLumoOwnerParams) error
+	CreateLumoMember(ctx context.Context, arg sqlc.CreateLumoMemberParams) (sqlc.LumoMember, error)
+	GetLumoMember(ctx context.Context, arg sqlc.GetLumoMemberParams) (sqlc.LumoMember, error)
+	ListLumoMembers(ctx context.Context, arg sqlc.ListLumoMembersParams) ([]sqlc.LumoMember, error)
+	UpdateLumoMemberRole(ctx context.Context, arg sqlc.UpdateLumoMemberRoleParams) (sqlc.LumoMember, error)
+	DeleteLumoMember(ctx context.Context, arg sqlc.DeleteLumoMemberParams) (int64, error)
 }
 
 // Repository is the concrete implementation for Lumo data access
 type Repository struct {
+	db      sqlc.DBTX
 	queries LumoQuerier
 }
 
 // NewRepository creates a new Repository instance
-func NewRepository(db sqlc.DBTX) *Repository {
+func NewRepository(conn sqlc.DBTX) *Repository {
 	return &Repository{
-		queries: sqlc.New(db),
+		db:      conn,
+		queries: sqlc.New(conn),
+	}
+}
+
+// WithTx returns a Repository that runs its queries on the given transaction
+func (r *Repository) WithTx(tx sqlc.DBTX) *Repository {
+	return &Repository{
+		db:      tx,
+		queries: sqlc.New(tx),
 	}
 }
 
 // CreateLumo creates a new Lumo record from domain model
 func (r *Repository) CreateLumo(ctx context.Context, domainLumo *lumo.Lumo) (*lumo.Lumo, error) {
+	return r.CreateLumoWithQuota(ctx, domainLumo, 0)
+}
+
+// CreateLumoWithQuota creates a new Lumo unless its creator already owns
+// maxLumos outside the trash, returning lumo.ErrTooManyLumos then. A maxLumos
+// of 0 means no limit.
+func (r *Repository) CreateLumoWithQuota(ctx context.Context, domainLumo *lumo.Lumo, maxLumos int64) (*lumo.Lumo, error) {
 	params := r.domainToCreateParams(domainLumo)
 
-	result, err := r.queries.CreateLumo(ctx, params)
+	var created *lumo.Lumo
+	err := db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		if maxLumos > 0 {
+			// Held until the commit, so concurrent creates of the same user
+			// can't all take the last place
+			if err := queries.LockLumosOfUser(ctx, params.UserID.String()); err != nil {
+				return err
+			}
+			count, err := queries.CountLumosOwnedByUser(ctx, params.UserID)
+			if err != nil {
+				return err
+			}
+			if count >= maxLumos {
+				return lumo.ErrTooManyLumos
+			}
+		}
+
+		result, err := queries.CreateLumo(ctx, params)
+		if err != nil {
+			return err
+		}
+		created = r.sqlcRowToDomainModel(result)
+
+		// The creator is the Lumo's first member
+		if err := queries.UpsertLumoOwner(ctx, sqlc.UpsertLumoOwnerParams{
+			LumoID:    result.LumoID,
+			UserID:    result.UserID,
+			CreatedAt: result.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+
+		return r.recordChange(ctx, queries, history.OperationCreated, created)
+	})
+	if db.IsForeignKeyViolation(err) {
+		// The only reference a new Lumo makes is to its creator
+		return nil, user.ErrUserNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return r.sqlcRowToDomainModel(result), nil
+	return created, nil
 }
 
 // GetLumoByID retrieves a Lumo by its internal ID
@@ -96,30 +177,100 @@ func (r *Repository) ListLumosByUserID(ctx context.Context, userID string, limit
 	return lumos, nil
 }
 
-// UpdateLumo updates an existing Lumo record
+// UpdateLumo updates an existing Lumo record. The update only applies if the
+// stored version still matches domainLumo.Version, otherwise a
+// *version.ConflictError is returned.
 func (r *Repository) UpdateLumo(ctx context.Context, domainLumo *lumo.Lumo) (*lumo.Lumo, error) {
 	params := r.domainToUpdateParams(domainLumo)
 
-	result, err := r.queries.UpdateLumo(ctx, params)
+	var updated *lumo.Lumo
+	err := db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.UpdateLumo(ctx, params)
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.VersionConflict(domainLumo.Version, err, func() (int64, error) {
+				row, err := queries.GetLumoByLumoID(ctx, params.LumoID)
+				return row.Version, err
+			})
+		}
+		if err != nil {
+			return err
+		}
+		updated = r.sqlcRowToDomainModel(result)
+
+		return r.recordChange(ctx, queries, history.OperationUpdated, updated)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return r.sqlcRowToDomainModel(result), nil
+	return updated, nil
 }
 
-// DeleteLumo deletes a Lumo by its internal ID
-func (r *Repository) DeleteLumo(ctx context.Context, id int64) error {
-	return r.queries.DeleteLumo(ctx, id)
+// DeleteLumo moves a Lumo with its Lumes and Links to the trash by its
+// internal ID. When expectedVersion is set the delete only applies if the
+// stored version still matches.
+func (r *Repository) DeleteLumo(ctx context.Context, id int64, expectedVersion *int64) error {
+	return db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.DeleteLumo(ctx, sqlc.DeleteLumoParams{
+			DeletedAt:       time.Now(),
+			ID:              id,
+			ExpectedVersion: db.NullVersion(expectedVersion),
+		})
+		if errors.Is(err, sql.ErrNoRows) && expectedVersion != nil {
+			err = db.VersionConflict(*expectedVersion, err, func() (int64, error) {
+				row, err := queries.GetLumoByID(ctx, id)
+				return row.Version, err
+			})
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			// Deleting a Lumo that does not exist is a no-op
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return r.cascadeDelete(ctx, queries, result)
+	})
 }
 
-// DeleteLumoByLumoID deletes a Lumo by its UUID
-func (r *Repository) DeleteLumoByLumoID(ctx context.Context, lumoID string) error {
+// DeleteLumoByLumoID moves a Lumo with its Lumes and Links to the trash by
+// its UUID. When expectedVersion is set the delete only applies if the stored
+// version still matches.
+func (r *Repository) DeleteLumoByLumoID(ctx context.Context, lumoID string, expectedVersion *int64) error {
 	parsedUUID, err := uuid.Parse(lumoID)
 	if err != nil {
 		return err
 	}
-	return r.queries.DeleteLumoByLumoID(ctx, parsedUUID)
+
+	return db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.DeleteLumoByLumoID(ctx, sqlc.DeleteLumoByLumoIDParams{
+			DeletedAt:       time.Now(),
+			LumoID:          parsedUUID,
+			ExpectedVersion: db.NullVersion(expectedVersion),
+		})
+		if errors.Is(err, sql.ErrNoRows) && expectedVersion != nil {
+			err = db.VersionConflict(*expectedVersion, err, func() (int64, error) {
+				row, err := queries.GetLumoByLumoID(ctx, parsedUUID)
+				return row.Version, err
+			})
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			// Deleting a Lumo that does not exist is a no-op
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return r.cascadeDelete(ctx, queries, result)
+	})
 }
 
 // CountLumosByUserID returns the total count of Lumos for a user
@@ -131,6 +282,113 @@ func (r *Repository) CountLumosByUserID(ctx context.Context, userID string) (int
 	return r.queries.CountLumosByUserID(ctx, parsedUserID)
 }
 
+// ListTrashedLumosByUserID retrieves the Lumos of a user in the trash, most
+// recently deleted first
+func (r *Repository) ListTrashedLumosByUserID(ctx context.Context, userID string, limit, offset int32) ([]*lumo.Lumo, error) {
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.ListTrashedLumosByUserIDParams{
+		UserID: parsedUserID,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	results, err := r.queries.ListTrashedLumosByUserID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	lumos := make([]*lumo.Lumo, len(results))
+	for i, result := range results {
+		lumos[i] = r.sqlcRowToDomainModel(result)
+	}
+
+	return lumos, nil
+}
+
+// RestoreLumoByLumoID takes a Lumo out of the trash together with the Lumes
+// and Links that were trashed along with it. It fails with
+// trash.ErrNotInTrash if the Lumo isn't in the trash.
+func (r *Repository) RestoreLumoByLumoID(ctx context.Context, lumoID string) (*lumo.Lumo, error) {
+	parsedUUID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored *lumo.Lumo
+	err = db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		result, err := queries.RestoreLumoByLumoID(ctx, parsedUUID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return trash.ErrNotInTrash
+		}
+		if err != nil {
+			return err
+		}
+
+		// Lumes first, Links only come back once both of their Lumes are
+		if err := queries.RestoreLumesByLumoID(ctx, parsedUUID); err != nil {
+			return err
+		}
+		if err := queries.RestoreLinksByLumoID(ctx, parsedUUID); err != nil {
+			return err
+		}
+		restored = r.sqlcRowToDomainModel(result)
+
+		return r.recordChange(ctx, queries, history.OperationCreated, restored)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+// cascadeDelete records the deletion of a Lumo and moves its Lumes and Links
+// to the trash along with it
+func (r *Repository) cascadeDelete(ctx context.Context, queries LumoQuerier, deleted sqlc.Lumo) error {
+	if err := r.recordChange(ctx, queries, history.OperationDeleted, r.sqlcRowToDomainModel(deleted)); err != nil {
+		return err
+	}
+
+	if err := queries.TrashLinksByLumoID(ctx, sqlc.TrashLinksByLumoIDParams{
+		DeletedAt: deleted.DeletedAt.Time,
+		LumoID:    deleted.LumoID,
+	}); err != nil {
+		return err
+	}
+	return queries.TrashLumesByLumoID(ctx, sqlc.TrashLumesByLumoIDParams{
+		DeletedAt: deleted.DeletedAt.Time,
+		LumoID:    deleted.LumoID,
+	})
+}
+
+// recordChange records a change to a Lumo in its history and the outbox. A
+// restore is recorded as a creation. Lumes and Links trashed or restored
+// together with a Lumo get no entries of their own.
+func (r *Repository) recordChange(ctx context.Context, queries LumoQuerier, operation history.Operation, domainLumo *lumo.Lumo) error {
+	domainEntry, err := history.NewEntry(ctx, domainLumo.LumoID, history.EntityTypeLumo, domainLumo.LumoID, operation, domainLumo)
+	if err != nil {
+		return err
+	}
+	if err := historyRepo.Record(ctx, queries, domainEntry); err != nil {
+		return err
+	}
+	return outboxRepo.Record(ctx, queries, outbox.NewEvent(domainEntry))
+}
+
+// querierFor returns the querier to use on the given connection
+func (r *Repository) querierFor(conn sqlc.DBTX) LumoQuerier {
+	if conn == nil || conn == r.db {
+		return r.queries
+	}
+	return sqlc.New(conn)
+}
+
 // Helper method to convert domain Lumo to SQLC CreateLumoParams
 func (r *Repository) domainToCreateParams(domainLumo *lumo.Lumo) sqlc.CreateLumoParams {
 	now := time.Now()
@@ -149,17 +407,26 @@ func (r *Repository) domainToUpdateParams(domainLumo *lumo.Lumo) sqlc.UpdateLumo
 		LumoID:    uuid.MustParse(domainLumo.LumoID),
 		Title:     domainLumo.Title,
 		UpdatedAt: time.Now(),
+		Version:   domainLumo.Version,
 	}
 }
 
 // Helper method to convert SQLC results to domain model
 func (r *Repository) sqlcRowToDomainModel(row sqlc.Lumo) *lumo.Lumo {
-	return &lumo.Lumo{
+	domainLumo := &lumo.Lumo{
 		ID:        row.ID,
 		LumoID:    row.LumoID.String(),
 		UserID:    row.UserID.String(),
 		Title:     row.Title,
 		CreatedAt: row.CreatedAt,
 		UpdatedAt: row.UpdatedAt,
+		Version:   row.Version,
+	}
+
+	// Handle trash timestamp
+	if row.DeletedAt.Valid {
+		domainLumo.DeletedAt = &row.DeletedAt.Time
 	}
+
+	return domainLumo
 }
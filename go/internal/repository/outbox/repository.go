@@ -0,0 +1,141 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/outbox"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+//go:generate mockery
+type OutboxQuerier interface {
+	ClaimOutboxEvents(ctx context.Context, arg sqlc.ClaimOutboxEventsParams) ([]sqlc.Outbox, error)
+	CreateOutboxEvent(ctx context.Context, arg sqlc.CreateOutboxEventParams) error
+	MarkOutboxEventDelivered(ctx context.Context, arg sqlc.MarkOutboxEventDeliveredParams) error
+	MarkOutboxEventFailed(ctx context.Context, arg sqlc.MarkOutboxEventFailedParams) error
+}
+
+// Recorder is the subset of queries write paths need to record an event
+type Recorder interface {
+	CreateOutboxEvent(ctx context.Context, arg sqlc.CreateOutboxEventParams) error
+}
+
+// Repository is the concrete implementation for outbox data access
+type Repository struct {
+	queries OutboxQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(conn sqlc.DBTX) *Repository {
+	return &Repository{
+		queries: sqlc.New(conn),
+	}
+}
+
+// Record stores a domain event in the outbox. It must run on the same
+// transaction as the write it describes, so the event is published if and
+// only if the change is committed.
+func Record(ctx context.Context, q Recorder, domainEvent *outbox.Event) error {
+	parsedEventID, err := uuid.Parse(domainEvent.EventID)
+	if err != nil {
+		return err
+	}
+	parsedLumoID, err := uuid.Parse(domainEvent.LumoID)
+	if err != nil {
+		return err
+	}
+	parsedEntityID, err := uuid.Parse(domainEvent.EntityID)
+	if err != nil {
+		return err
+	}
+
+	return q.CreateOutboxEvent(ctx, sqlc.CreateOutboxEventParams{
+		EventID:   parsedEventID,
+		EventType: domainEvent.Type,
+		LumoID:    parsedLumoID,
+		EntityID:  parsedEntityID,
+		Actor:     sql.NullString{String: domainEvent.Actor, Valid: domainEvent.Actor != ""},
+		Payload:   domainEvent.Payload,
+		CreatedAt: domainEvent.CreatedAt,
+	})
+}
+
+// ClaimEvents leases up to batchSize pending events that are due, oldest
+// first. Other relays skip them until the lease is over, after which they are
+// tried again unless they were marked in the meantime.
+func (r *Repository) ClaimEvents(ctx context.Context, lease time.Duration, batchSize int32) ([]*outbox.Event, error) {
+	now := time.Now()
+	results, err := r.queries.ClaimOutboxEvents(ctx, sqlc.ClaimOutboxEventsParams{
+		LeaseUntil: now.Add(lease),
+		Now:        now,
+		BatchSize:  batchSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// UPDATE ... RETURNING doesn't keep the order of the subquery
+	sort.Slice(results, func(i, j int) bool { return results[i].ID < results[j].ID })
+
+	events := make([]*outbox.Event, len(results))
+	for i, result := range results {
+		events[i] = r.sqlcRowToDomainModel(result)
+	}
+
+	return events, nil
+}
+
+// MarkDelivered records that every sink accepted an event
+func (r *Repository) MarkDelivered(ctx context.Context, id int64) error {
+	return r.queries.MarkOutboxEventDelivered(ctx, sqlc.MarkOutboxEventDeliveredParams{
+		ID:          id,
+		DeliveredAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+}
+
+// MarkRetry records a failed attempt to deliver an event and when to try again
+func (r *Repository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	return r.queries.MarkOutboxEventFailed(ctx, sqlc.MarkOutboxEventFailedParams{
+		ID:            id,
+		Status:        string(outbox.StatusPending),
+		NextAttemptAt: nextAttemptAt,
+		LastError:     sql.NullString{String: lastError, Valid: true},
+	})
+}
+
+// MarkFailed records that delivering an event was given up on
+func (r *Repository) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	return r.queries.MarkOutboxEventFailed(ctx, sqlc.MarkOutboxEventFailedParams{
+		ID:            id,
+		Status:        string(outbox.StatusFailed),
+		NextAttemptAt: time.Now(),
+		LastError:     sql.NullString{String: lastError, Valid: true},
+	})
+}
+
+// sqlcRowToDomainModel converts a sqlc Outbox row to a domain Event
+func (r *Repository) sqlcRowToDomainModel(row sqlc.Outbox) *outbox.Event {
+	domainEvent := &outbox.Event{
+		ID:        row.ID,
+		EventID:   row.EventID.String(),
+		Type:      row.EventType,
+		LumoID:    row.LumoID.String(),
+		EntityID:  row.EntityID.String(),
+		Actor:     row.Actor.String,
+		Payload:   row.Payload,
+		CreatedAt: row.CreatedAt,
+		Status:    outbox.Status(row.Status),
+		Attempts:  row.Attempts,
+		LastError: row.LastError.String,
+	}
+
+	if row.DeliveredAt.Valid {
+		domainEvent.DeliveredAt = &row.DeliveredAt.Time
+	}
+
+	return domainEvent
+}
@@ -0,0 +1,154 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/history"
+	"github.com/mcdev12/lumo/go/internal/models/outbox"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/mcdev12/lumo/go/internal/repository/outbox/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockOutboxQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockOutboxQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Helper function to create a test sqlc Outbox row
+func newOutboxRow(id int64) sqlc.Outbox {
+	return sqlc.Outbox{
+		ID:            id,
+		EventID:       uuid.New(),
+		EventType:     "LumeCreated",
+		LumoID:        uuid.New(),
+		EntityID:      uuid.New(),
+		Payload:       json.RawMessage(`{}`),
+		Status:        string(outbox.StatusPending),
+		Attempts:      1,
+		NextAttemptAt: time.Now(),
+		CreatedAt:     time.Now(),
+	}
+}
+
+// Test Record stores the event of a history entry
+func (s *RepositoryTestSuite) TestRecord() {
+	// Arrange
+	ctx := context.Background()
+	entry := &history.Entry{
+		LumoID:     uuid.New().String(),
+		EntityType: history.EntityTypeLink,
+		EntityID:   uuid.New().String(),
+		Operation:  history.OperationUpdated,
+		Actor:      uuid.New().String(),
+		Snapshot:   json.RawMessage(`{"notes":"Ferry"}`),
+		CreatedAt:  time.Now(),
+	}
+	domainEvent := outbox.NewEvent(entry)
+
+	// Set up expectations
+	s.mockQuerier.On("CreateOutboxEvent", ctx, mock.MatchedBy(func(params sqlc.CreateOutboxEventParams) bool {
+		return params.EventID.String() == domainEvent.EventID &&
+			params.EventType == "LinkUpdated" &&
+			params.LumoID.String() == entry.LumoID &&
+			params.EntityID.String() == entry.EntityID &&
+			params.Actor.Valid && params.Actor.String == entry.Actor &&
+			string(params.Payload) == `{"notes":"Ferry"}`
+	})).Return(nil)
+
+	// Act
+	err := Record(ctx, s.mockQuerier, domainEvent)
+
+	// Assert
+	s.NoError(err)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test ClaimEvents leases due events and returns them oldest first
+func (s *RepositoryTestSuite) TestClaimEvents() {
+	// Arrange
+	ctx := context.Background()
+	lease := 5 * time.Minute
+
+	// Set up expectations
+	s.mockQuerier.On("ClaimOutboxEvents", ctx, mock.MatchedBy(func(params sqlc.ClaimOutboxEventsParams) bool {
+		return params.BatchSize == 10 && params.LeaseUntil.Sub(params.Now) == lease
+	})).Return([]sqlc.Outbox{newOutboxRow(3), newOutboxRow(1), newOutboxRow(2)}, nil)
+
+	// Act
+	events, err := s.repository.ClaimEvents(ctx, lease, 10)
+
+	// Assert
+	s.NoError(err)
+	s.Require().Len(events, 3)
+	s.Equal(int64(1), events[0].ID)
+	s.Equal(int64(2), events[1].ID)
+	s.Equal(int64(3), events[2].ID)
+	s.Equal("LumeCreated", events[0].Type)
+	s.Equal(outbox.StatusPending, events[0].Status)
+	s.Nil(events[0].DeliveredAt)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test ClaimEvents passes database errors through
+func (s *RepositoryTestSuite) TestClaimEventsError() {
+	// Arrange
+	ctx := context.Background()
+	expectedErr := errors.New("database error")
+
+	// Set up expectations
+	s.mockQuerier.On("ClaimOutboxEvents", ctx, mock.Anything).Return(nil, expectedErr)
+
+	// Act
+	events, err := s.repository.ClaimEvents(ctx, time.Minute, 10)
+
+	// Assert
+	s.ErrorIs(err, expectedErr)
+	s.Nil(events)
+}
+
+// Test MarkRetry keeps the event pending while MarkFailed gives up on it
+func (s *RepositoryTestSuite) TestMarkFailedAttempts() {
+	// Arrange
+	ctx := context.Background()
+	nextAttemptAt := time.Now().Add(time.Minute)
+
+	// Set up expectations
+	s.mockQuerier.On("MarkOutboxEventFailed", ctx, mock.MatchedBy(func(params sqlc.MarkOutboxEventFailedParams) bool {
+		return params.ID == 1 && params.Status == string(outbox.StatusPending) &&
+			params.NextAttemptAt.Equal(nextAttemptAt) && params.LastError.String == "timeout"
+	})).Return(nil)
+	s.mockQuerier.On("MarkOutboxEventFailed", ctx, mock.MatchedBy(func(params sqlc.MarkOutboxEventFailedParams) bool {
+		return params.ID == 2 && params.Status == string(outbox.StatusFailed) && params.LastError.String == "refused"
+	})).Return(nil)
+
+	// Act
+	retryErr := s.repository.MarkRetry(ctx, 1, nextAttemptAt, "timeout")
+	failErr := s.repository.MarkFailed(ctx, 2, "refused")
+
+	// Assert
+	s.NoError(retryErr)
+	s.NoError(failErr)
+	s.mockQuerier.AssertExpectations(s.T())
+}
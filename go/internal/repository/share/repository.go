@@ -0,0 +1,202 @@
+package share
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/share"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+//go:generate mockery
+type ShareQuerier interface {
+	CreateShareLink(ctx context.Context, arg sqlc.CreateShareLinkParams) (sqlc.ShareLink, error)
+	GetShareLinkByShareLinkID(ctx context.Context, shareLinkID uuid.UUID) (sqlc.ShareLink, error)
+	ListShareLinksByLumoID(ctx context.Context, arg sqlc.ListShareLinksByLumoIDParams) ([]sqlc.ShareLink, error)
+	RevokeShareLink(ctx context.Context, arg sqlc.RevokeShareLinkParams) (sqlc.ShareLink, error)
+	UseShareLink(ctx context.Context, arg sqlc.UseShareLinkParams) (sqlc.ShareLink, error)
+}
+
+// Repository is the concrete implementation for share link data access
+type Repository struct {
+	queries ShareQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(conn sqlc.DBTX) *Repository {
+	return &Repository{
+		queries: sqlc.New(conn),
+	}
+}
+
+// CreateShareLink creates a new share link record from domain model
+func (r *Repository) CreateShareLink(ctx context.Context, domainLink *share.ShareLink) (*share.ShareLink, error) {
+	params, err := r.domainToCreateParams(domainLink)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.CreateShareLink(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	created := r.sqlcRowToDomainModel(result)
+	created.Token = domainLink.Token
+	return created, nil
+}
+
+// GetShareLinkByShareLinkID retrieves a share link by its UUID
+func (r *Repository) GetShareLinkByShareLinkID(ctx context.Context, shareLinkID string) (*share.ShareLink, error) {
+	parsedUUID, err := uuid.Parse(shareLinkID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.GetShareLinkByShareLinkID(ctx, parsedUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, share.ErrShareLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// ListShareLinksByLumoID retrieves the share links of a Lumo, newest first
+func (r *Repository) ListShareLinksByLumoID(ctx context.Context, lumoID string, limit, offset int32) ([]*share.ShareLink, error) {
+	parsedUUID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.ListShareLinksByLumoIDParams{
+		LumoID: parsedUUID,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	results, err := r.queries.ListShareLinksByLumoID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*share.ShareLink, len(results))
+	for i, result := range results {
+		links[i] = r.sqlcRowToDomainModel(result)
+	}
+
+	return links, nil
+}
+
+// RevokeShareLink stops a share link from working
+func (r *Repository) RevokeShareLink(ctx context.Context, shareLinkID string) (*share.ShareLink, error) {
+	parsedUUID, err := uuid.Parse(shareLinkID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.RevokeShareLink(ctx, sqlc.RevokeShareLinkParams{
+		RevokedAt:   time.Now(),
+		ShareLinkID: parsedUUID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, share.ErrShareLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// UseShareLink looks up the working share link with the given token and
+// counts the access. It fails with share.ErrShareLinkNotFound if there is no
+// such link, or it was revoked or has expired.
+func (r *Repository) UseShareLink(ctx context.Context, token string) (*share.ShareLink, error) {
+	result, err := r.queries.UseShareLink(ctx, sqlc.UseShareLinkParams{
+		AccessedAt: time.Now(),
+		TokenHash:  share.HashToken(token),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, share.ErrShareLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// Helper method to convert domain ShareLink to SQLC CreateShareLinkParams
+func (r *Repository) domainToCreateParams(domainLink *share.ShareLink) (sqlc.CreateShareLinkParams, error) {
+	shareLinkID, err := uuid.Parse(domainLink.ShareLinkID)
+	if err != nil {
+		return sqlc.CreateShareLinkParams{}, err
+	}
+	lumoID, err := uuid.Parse(domainLink.LumoID)
+	if err != nil {
+		return sqlc.CreateShareLinkParams{}, err
+	}
+
+	params := sqlc.CreateShareLinkParams{
+		ShareLinkID:      shareLinkID,
+		LumoID:           lumoID,
+		TokenHash:        domainLink.TokenHash,
+		HideBookingLinks: domainLink.Policy.HideBookingLinks,
+		HideCosts:        domainLink.Policy.HideCosts,
+		HideNotes:        domainLink.Policy.HideNotes,
+		CreatedAt:        domainLink.CreatedAt,
+	}
+
+	// Handle optional fields
+	if domainLink.ExpiresAt != nil {
+		params.ExpiresAt = sql.NullTime{Time: *domainLink.ExpiresAt, Valid: true}
+	}
+	if domainLink.CreatedBy != "" {
+		createdBy, err := uuid.Parse(domainLink.CreatedBy)
+		if err != nil {
+			return sqlc.CreateShareLinkParams{}, err
+		}
+		params.CreatedBy = uuid.NullUUID{UUID: createdBy, Valid: true}
+	}
+
+	return params, nil
+}
+
+// Helper method to convert SQLC results to domain model
+func (r *Repository) sqlcRowToDomainModel(row sqlc.ShareLink) *share.ShareLink {
+	domainLink := &share.ShareLink{
+		ID:          row.ID,
+		ShareLinkID: row.ShareLinkID.String(),
+		LumoID:      row.LumoID.String(),
+		TokenHash:   row.TokenHash,
+		Policy: share.Policy{
+			HideBookingLinks: row.HideBookingLinks,
+			HideCosts:        row.HideCosts,
+			HideNotes:        row.HideNotes,
+		},
+		AccessCount: row.AccessCount,
+		CreatedAt:   row.CreatedAt,
+	}
+
+	// Handle optional fields
+	if row.ExpiresAt.Valid {
+		domainLink.ExpiresAt = &row.ExpiresAt.Time
+	}
+	if row.RevokedAt.Valid {
+		domainLink.RevokedAt = &row.RevokedAt.Time
+	}
+	if row.LastAccessedAt.Valid {
+		domainLink.LastAccessedAt = &row.LastAccessedAt.Time
+	}
+	if row.CreatedBy.Valid {
+		domainLink.CreatedBy = row.CreatedBy.UUID.String()
+	}
+
+	return domainLink
+}
@@ -0,0 +1,165 @@
+package share
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/share"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/mcdev12/lumo/go/internal/repository/share/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockShareQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockShareQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Helper function to create a test sqlc ShareLink row
+func newShareLinkRow() sqlc.ShareLink {
+	return sqlc.ShareLink{
+		ID:          1,
+		ShareLinkID: uuid.New(),
+		LumoID:      uuid.New(),
+		TokenHash:   share.HashToken("token"),
+		HideCosts:   true,
+		AccessCount: 3,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Test CreateShareLink stores the token hash and hands the token back
+func (s *RepositoryTestSuite) TestCreateShareLink() {
+	// Arrange
+	ctx := context.Background()
+	expiresAt := time.Now().Add(24 * time.Hour)
+	createdBy := uuid.New()
+	domainLink, err := share.NewShareLink(uuid.New().String(), createdBy.String(), share.Policy{HideBookingLinks: true}, &expiresAt)
+	s.Require().NoError(err)
+
+	// Set up expectations
+	s.mockQuerier.On("CreateShareLink", ctx, mock.MatchedBy(func(params sqlc.CreateShareLinkParams) bool {
+		return params.ShareLinkID.String() == domainLink.ShareLinkID &&
+			params.TokenHash == share.HashToken(domainLink.Token) &&
+			params.HideBookingLinks && !params.HideCosts &&
+			params.ExpiresAt.Valid && params.ExpiresAt.Time.Equal(expiresAt) &&
+			params.CreatedBy == uuid.NullUUID{UUID: createdBy, Valid: true}
+	})).Return(sqlc.ShareLink{
+		ID:               1,
+		ShareLinkID:      uuid.MustParse(domainLink.ShareLinkID),
+		LumoID:           uuid.MustParse(domainLink.LumoID),
+		TokenHash:        domainLink.TokenHash,
+		HideBookingLinks: true,
+		ExpiresAt:        sql.NullTime{Time: expiresAt, Valid: true},
+		CreatedBy:        uuid.NullUUID{UUID: createdBy, Valid: true},
+		CreatedAt:        domainLink.CreatedAt,
+	}, nil)
+
+	// Act
+	created, err := s.repository.CreateShareLink(ctx, domainLink)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(domainLink.Token, created.Token)
+	s.Equal(domainLink.ShareLinkID, created.ShareLinkID)
+	s.Equal(createdBy.String(), created.CreatedBy)
+	s.Require().NotNil(created.ExpiresAt)
+	s.True(created.ExpiresAt.Equal(expiresAt))
+	s.Nil(created.RevokedAt)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test UseShareLink looks the link up by the token's hash
+func (s *RepositoryTestSuite) TestUseShareLink() {
+	// Arrange
+	ctx := context.Background()
+	row := newShareLinkRow()
+
+	// Set up expectations
+	s.mockQuerier.On("UseShareLink", ctx, mock.MatchedBy(func(params sqlc.UseShareLinkParams) bool {
+		return params.TokenHash == share.HashToken("token") && !params.AccessedAt.IsZero()
+	})).Return(row, nil)
+
+	// Act
+	link, err := s.repository.UseShareLink(ctx, "token")
+
+	// Assert
+	s.NoError(err)
+	s.Equal(row.ShareLinkID.String(), link.ShareLinkID)
+	s.Equal(row.LumoID.String(), link.LumoID)
+	s.Equal(share.Policy{HideCosts: true}, link.Policy)
+	s.Equal(int64(3), link.AccessCount)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test UseShareLink reports unknown, revoked and expired links alike
+func (s *RepositoryTestSuite) TestUseShareLinkNotFound() {
+	// Arrange
+	ctx := context.Background()
+
+	// Set up expectations
+	s.mockQuerier.On("UseShareLink", ctx, mock.Anything).Return(sqlc.ShareLink{}, sql.ErrNoRows)
+
+	// Act
+	link, err := s.repository.UseShareLink(ctx, "revoked")
+
+	// Assert
+	s.ErrorIs(err, share.ErrShareLinkNotFound)
+	s.Nil(link)
+}
+
+// Test UseShareLink passes database errors through
+func (s *RepositoryTestSuite) TestUseShareLinkError() {
+	// Arrange
+	ctx := context.Background()
+	expectedErr := errors.New("database error")
+
+	// Set up expectations
+	s.mockQuerier.On("UseShareLink", ctx, mock.Anything).Return(sqlc.ShareLink{}, expectedErr)
+
+	// Act
+	link, err := s.repository.UseShareLink(ctx, "token")
+
+	// Assert
+	s.ErrorIs(err, expectedErr)
+	s.Nil(link)
+}
+
+// Test RevokeShareLink reports unknown links as not found
+func (s *RepositoryTestSuite) TestRevokeShareLinkNotFound() {
+	// Arrange
+	ctx := context.Background()
+	shareLinkID := uuid.New()
+
+	// Set up expectations
+	s.mockQuerier.On("RevokeShareLink", ctx, mock.MatchedBy(func(params sqlc.RevokeShareLinkParams) bool {
+		return params.ShareLinkID == shareLinkID
+	})).Return(sqlc.ShareLink{}, sql.ErrNoRows)
+
+	// Act
+	link, err := s.repository.RevokeShareLink(ctx, shareLinkID.String())
+
+	// Assert
+	s.ErrorIs(err, share.ErrShareLinkNotFound)
+	s.Nil(link)
+}
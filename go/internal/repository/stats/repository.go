@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mcdev12/lumo/go/internal/models/stats"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+//go:generate mockery
+type StatsQuerier interface {
+	CountLiveEntities(ctx context.Context) (sqlc.CountLiveEntitiesRow, error)
+	GetLumesPerLumoStats(ctx context.Context) (sqlc.GetLumesPerLumoStatsRow, error)
+}
+
+// Repository is the concrete implementation for domain statistics
+type Repository struct {
+	queries StatsQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(conn sqlc.DBTX) *Repository {
+	return &Repository{
+		queries: sqlc.New(conn),
+	}
+}
+
+// GetDomainStats counts what is stored outside the trash
+func (r *Repository) GetDomainStats(ctx context.Context) (*stats.Domain, error) {
+	counts, err := r.queries.CountLiveEntities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count entities: %w", err)
+	}
+
+	lumesPerLumo, err := r.queries.GetLumesPerLumoStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lumes per lumo: %w", err)
+	}
+
+	return &stats.Domain{
+		Lumos: counts.Lumos,
+		Lumes: counts.Lumes,
+		Links: counts.Links,
+		LumesPerLumo: stats.Distribution{
+			Mean: lumesPerLumo.Mean,
+			P50:  lumesPerLumo.P50,
+			P90:  lumesPerLumo.P90,
+			P99:  lumesPerLumo.P99,
+			Max:  float64(lumesPerLumo.Max),
+		},
+	}, nil
+}
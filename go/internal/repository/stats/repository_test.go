@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mcdev12/lumo/go/internal/models/stats"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/mcdev12/lumo/go/internal/repository/stats/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockStatsQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockStatsQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Test GetDomainStats
+func (s *RepositoryTestSuite) TestGetDomainStats() {
+	// Arrange
+	ctx := context.Background()
+
+	// Set up expectations
+	s.mockQuerier.On("CountLiveEntities", ctx).Return(sqlc.CountLiveEntitiesRow{Lumos: 2, Lumes: 7, Links: 4}, nil)
+	s.mockQuerier.On("GetLumesPerLumoStats", ctx).Return(sqlc.GetLumesPerLumoStatsRow{Mean: 3.5, P50: 3.5, P90: 5.5, P99: 5.95, Max: 6}, nil)
+
+	// Act
+	domain, err := s.repository.GetDomainStats(ctx)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(&stats.Domain{
+		Lumos:        2,
+		Lumes:        7,
+		Links:        4,
+		LumesPerLumo: stats.Distribution{Mean: 3.5, P50: 3.5, P90: 5.5, P99: 5.95, Max: 6},
+	}, domain)
+}
+
+// Test GetDomainStats with a database error
+func (s *RepositoryTestSuite) TestGetDomainStatsError() {
+	// Arrange
+	ctx := context.Background()
+	expectedErr := errors.New("database error")
+
+	// Set up expectations
+	s.mockQuerier.On("CountLiveEntities", ctx).Return(sqlc.CountLiveEntitiesRow{}, expectedErr)
+
+	// Act
+	domain, err := s.repository.GetDomainStats(ctx)
+
+	// Assert
+	s.ErrorIs(err, expectedErr)
+	s.Nil(domain)
+}
@@ -0,0 +1,67 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/repository/db"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+//go:generate mockery
+type TrashQuerier interface {
+	PurgeLinks(ctx context.Context, before time.Time) (int64, error)
+	PurgeLumes(ctx context.Context, before time.Time) (int64, error)
+	PurgeLumos(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Repository is the concrete implementation for Trash data access. Listing
+// and restoring go through the Lumo, Lume and Link repositories.
+type Repository struct {
+	db      sqlc.DBTX
+	queries TrashQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(conn sqlc.DBTX) *Repository {
+	return &Repository{
+		db:      conn,
+		queries: sqlc.New(conn),
+	}
+}
+
+// Purge permanently deletes everything that was moved to the trash before
+// the given time and returns how many entities were removed. Links go first
+// so the count doesn't miss the ones their Lumes would take with them.
+func (r *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := db.RunInTx(ctx, r.db, func(tx sqlc.DBTX) error {
+		queries := r.querierFor(tx)
+
+		for _, purge := range []func(context.Context, time.Time) (int64, error){
+			queries.PurgeLinks,
+			queries.PurgeLumes,
+			queries.PurgeLumos,
+		} {
+			count, err := purge(ctx, before)
+			if err != nil {
+				return err
+			}
+			purged += count
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+// querierFor returns the querier to use on the given connection
+func (r *Repository) querierFor(conn sqlc.DBTX) TrashQuerier {
+	if conn == nil || conn == r.db {
+		return r.queries
+	}
+	return sqlc.New(conn)
+}
@@ -0,0 +1,71 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mcdev12/lumo/go/internal/repository/trash/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockTrashQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockTrashQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Test Purge
+func (s *RepositoryTestSuite) TestPurge() {
+	// Arrange
+	ctx := context.Background()
+	before := time.Now().Add(-24 * time.Hour)
+
+	// Set up expectations
+	s.mockQuerier.On("PurgeLinks", ctx, before).Return(int64(3), nil)
+	s.mockQuerier.On("PurgeLumes", ctx, before).Return(int64(2), nil)
+	s.mockQuerier.On("PurgeLumos", ctx, before).Return(int64(1), nil)
+
+	// Act
+	purged, err := s.repository.Purge(ctx, before)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(int64(6), purged)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test Purge stops at the first failure
+func (s *RepositoryTestSuite) TestPurgeError() {
+	// Arrange
+	ctx := context.Background()
+	before := time.Now()
+	expectedErr := errors.New("database error")
+
+	// Set up expectations
+	s.mockQuerier.On("PurgeLinks", ctx, before).Return(int64(0), expectedErr)
+
+	// Act
+	purged, err := s.repository.Purge(ctx, before)
+
+	// Assert
+	s.ErrorIs(err, expectedErr)
+	s.Zero(purged)
+	s.mockQuerier.AssertNotCalled(s.T(), "PurgeLumes", ctx, before)
+	s.mockQuerier.AssertNotCalled(s.T(), "PurgeLumos", ctx, before)
+}
@@ -0,0 +1,165 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/mcdev12/lumo/go/internal/repository/db"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+//go:generate mockery
+type UserQuerier interface {
+	CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error)
+	GetUserByUserID(ctx context.Context, userID uuid.UUID) (sqlc.User, error)
+	GetUserByEmail(ctx context.Context, email sql.NullString) (sqlc.User, error)
+	UpdateUser(ctx context.Context, arg sqlc.UpdateUserParams) (sqlc.User, error)
+}
+
+// Repository is the concrete implementation for user data access
+type Repository struct {
+	queries UserQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(conn sqlc.DBTX) *Repository {
+	return &Repository{
+		queries: sqlc.New(conn),
+	}
+}
+
+// CreateUser creates a new user record from domain model. It fails with
+// user.ErrUserExists if the user has a profile already, and with
+// user.ErrEmailTaken if another user has the email.
+func (r *Repository) CreateUser(ctx context.Context, domainUser *user.User) (*user.User, error) {
+	userID, err := uuid.Parse(domainUser.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.CreateUser(ctx, sqlc.CreateUserParams{
+		UserID:       userID,
+		Email:        r.toNullEmail(domainUser.Email),
+		DisplayName:  domainUser.DisplayName,
+		HomeCurrency: domainUser.Preferences.HomeCurrency,
+		DistanceUnit: string(domainUser.Preferences.DistanceUnit),
+		TimeZone:     domainUser.Preferences.TimeZone,
+		Locale:       domainUser.Preferences.Locale,
+		CreatedAt:    domainUser.CreatedAt,
+		UpdatedAt:    domainUser.UpdatedAt,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// The upsert only fills in users without a profile
+		return nil, user.ErrUserExists
+	}
+	if db.IsUniqueViolation(err) {
+		return nil, user.ErrEmailTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// GetUserByUserID retrieves a user by UUID
+func (r *Repository) GetUserByUserID(ctx context.Context, userID string) (*user.User, error) {
+	parsedUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.GetUserByUserID(ctx, parsedUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// GetUserByEmail retrieves a user by email, ignoring case
+func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	normalized := user.NormalizeEmail(email)
+	if normalized == "" {
+		return nil, user.ErrUserNotFound
+	}
+
+	result, err := r.queries.GetUserByEmail(ctx, r.toNullEmail(normalized))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// UpdateUser updates the profile and preferences of a user
+func (r *Repository) UpdateUser(ctx context.Context, domainUser *user.User) (*user.User, error) {
+	userID, err := uuid.Parse(domainUser.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.UpdateUser(ctx, sqlc.UpdateUserParams{
+		UserID:       userID,
+		Email:        r.toNullEmail(domainUser.Email),
+		DisplayName:  domainUser.DisplayName,
+		HomeCurrency: domainUser.Preferences.HomeCurrency,
+		DistanceUnit: string(domainUser.Preferences.DistanceUnit),
+		TimeZone:     domainUser.Preferences.TimeZone,
+		Locale:       domainUser.Preferences.Locale,
+		UpdatedAt:    domainUser.UpdatedAt,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrUserNotFound
+	}
+	if db.IsUniqueViolation(err) {
+		return nil, user.ErrEmailTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// Helper method to store emails normalized, and missing ones as NULL
+func (r *Repository) toNullEmail(email string) sql.NullString {
+	normalized := user.NormalizeEmail(email)
+	if normalized == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: normalized, Valid: true}
+}
+
+// Helper method to convert SQLC results to domain model
+func (r *Repository) sqlcRowToDomainModel(row sqlc.User) *user.User {
+	domainUser := &user.User{
+		ID:          row.ID,
+		UserID:      row.UserID.String(),
+		DisplayName: row.DisplayName,
+		Preferences: user.Preferences{
+			HomeCurrency: row.HomeCurrency,
+			DistanceUnit: user.DistanceUnit(row.DistanceUnit),
+			TimeZone:     row.TimeZone,
+			Locale:       row.Locale,
+		},
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+
+	// Handle optional email
+	if row.Email.Valid {
+		domainUser.Email = row.Email.String
+	}
+
+	return domainUser
+}
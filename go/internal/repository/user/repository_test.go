@@ -0,0 +1,176 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/mcdev12/lumo/go/internal/repository/user/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockUserQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockUserQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Helper function to create a test sqlc User row
+func newUserRow(email string) sqlc.User {
+	now := time.Now()
+	return sqlc.User{
+		ID:           1,
+		UserID:       uuid.New(),
+		Email:        sql.NullString{String: email, Valid: email != ""},
+		DisplayName:  "Ada",
+		HomeCurrency: "EUR",
+		DistanceUnit: "MILES",
+		TimeZone:     "Europe/Paris",
+		Locale:       "fr-FR",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// Test CreateUser stores the email lowercased along with the preferences
+func (s *RepositoryTestSuite) TestCreateUser() {
+	// Arrange
+	ctx := context.Background()
+	domainUser := user.NewUser(uuid.New().String(), " Ada@Example.com ", "Ada", user.Preferences{
+		HomeCurrency: "EUR",
+		DistanceUnit: user.DistanceUnitMiles,
+		TimeZone:     "Europe/Paris",
+		Locale:       "fr-FR",
+	})
+	row := newUserRow("ada@example.com")
+	row.UserID = uuid.MustParse(domainUser.UserID)
+
+	// Set up expectations
+	s.mockQuerier.On("CreateUser", ctx, mock.MatchedBy(func(params sqlc.CreateUserParams) bool {
+		return params.UserID == row.UserID &&
+			params.Email == sql.NullString{String: "ada@example.com", Valid: true} &&
+			params.HomeCurrency == "EUR" &&
+			params.DistanceUnit == "MILES" &&
+			params.TimeZone == "Europe/Paris" &&
+			params.Locale == "fr-FR"
+	})).Return(row, nil)
+
+	// Act
+	created, err := s.repository.CreateUser(ctx, domainUser)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(domainUser.UserID, created.UserID)
+	s.Equal("ada@example.com", created.Email)
+	s.Equal(user.DistanceUnitMiles, created.Preferences.DistanceUnit)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test CreateUser maps conflicts to domain errors
+func (s *RepositoryTestSuite) TestCreateUserConflicts() {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{"user has a profile", sql.ErrNoRows, user.ErrUserExists},
+		{"email in use", &pq.Error{Code: "23505"}, user.ErrEmailTaken},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Arrange
+			s.SetupTest()
+			ctx := context.Background()
+			domainUser := user.NewUser("", "ada@example.com", "Ada", user.Preferences{})
+
+			// Set up expectations
+			s.mockQuerier.On("CreateUser", ctx, mock.Anything).Return(sqlc.User{}, tt.err)
+
+			// Act
+			created, err := s.repository.CreateUser(ctx, domainUser)
+
+			// Assert
+			s.ErrorIs(err, tt.wantErr)
+			s.Nil(created)
+		})
+	}
+}
+
+// Test GetUserByEmail looks emails up lowercased
+func (s *RepositoryTestSuite) TestGetUserByEmail() {
+	// Arrange
+	ctx := context.Background()
+	row := newUserRow("ada@example.com")
+
+	// Set up expectations
+	s.mockQuerier.On("GetUserByEmail", ctx, sql.NullString{String: "ada@example.com", Valid: true}).Return(row, nil)
+
+	// Act
+	found, err := s.repository.GetUserByEmail(ctx, "ADA@example.com")
+
+	// Assert
+	s.NoError(err)
+	s.Equal(row.UserID.String(), found.UserID)
+	s.Equal(user.Preferences{
+		HomeCurrency: "EUR",
+		DistanceUnit: user.DistanceUnitMiles,
+		TimeZone:     "Europe/Paris",
+		Locale:       "fr-FR",
+	}, found.Preferences)
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test GetUserByUserID reports missing users as user.ErrUserNotFound
+func (s *RepositoryTestSuite) TestGetUserByUserIDNotFound() {
+	// Arrange
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// Set up expectations
+	s.mockQuerier.On("GetUserByUserID", ctx, userID).Return(sqlc.User{}, sql.ErrNoRows)
+
+	// Act
+	found, err := s.repository.GetUserByUserID(ctx, userID.String())
+
+	// Assert
+	s.ErrorIs(err, user.ErrUserNotFound)
+	s.Nil(found)
+}
+
+// Test users backfilled without an email come back with an empty one
+func (s *RepositoryTestSuite) TestGetUserByUserIDWithoutEmail() {
+	// Arrange
+	ctx := context.Background()
+	row := newUserRow("")
+
+	// Set up expectations
+	s.mockQuerier.On("GetUserByUserID", ctx, row.UserID).Return(row, nil)
+
+	// Act
+	found, err := s.repository.GetUserByUserID(ctx, row.UserID.String())
+
+	// Assert
+	s.NoError(err)
+	s.Empty(found.Email)
+}
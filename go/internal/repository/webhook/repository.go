@@ -0,0 +1,510 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/access"
+	"github.com/mcdev12/lumo/go/internal/models/history"
+	"github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/user"
+	"github.com/mcdev12/lumo/go/internal/models/webhook"
+	"github.com/mcdev12/lumo/go/internal/repository/db"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+)
+
+// Foreign keys of the webhook table
+const (
+	userConstraint = "fk_webhook_user"
+	lumoConstraint = "fk_webhook_lumo"
+)
+
+//go:generate mockery
+type WebhookQuerier interface {
+	ClaimWebhookDeliveries(ctx context.Context, arg sqlc.ClaimWebhookDeliveriesParams) ([]sqlc.WebhookDelivery, error)
+	CreateWebhook(ctx context.Context, arg sqlc.CreateWebhookParams) (sqlc.Webhook, error)
+	CreateWebhookDelivery(ctx context.Context, arg sqlc.CreateWebhookDeliveryParams) (sqlc.WebhookDelivery, error)
+	DeleteWebhook(ctx context.Context, webhookID uuid.UUID) (int64, error)
+	GetWebhookByWebhookID(ctx context.Context, webhookID uuid.UUID) (sqlc.Webhook, error)
+	GetWebhookDeliveryByDeliveryID(ctx context.Context, deliveryID uuid.UUID) (sqlc.WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, arg sqlc.ListWebhookDeliveriesParams) ([]sqlc.WebhookDelivery, error)
+	ListWebhooksByUserID(ctx context.Context, arg sqlc.ListWebhooksByUserIDParams) ([]sqlc.Webhook, error)
+	ListWebhooksForEvent(ctx context.Context, lumoID uuid.UUID) ([]sqlc.Webhook, error)
+	MarkWebhookDelivery(ctx context.Context, arg sqlc.MarkWebhookDeliveryParams) error
+	RecordWebhookFailure(ctx context.Context, arg sqlc.RecordWebhookFailureParams) (sqlc.Webhook, error)
+	RecordWebhookSuccess(ctx context.Context, webhookID uuid.UUID) error
+	UpdateWebhook(ctx context.Context, arg sqlc.UpdateWebhookParams) (sqlc.Webhook, error)
+}
+
+// Repository is the concrete implementation for webhook data access
+type Repository struct {
+	queries WebhookQuerier
+}
+
+// NewRepository creates a new Repository instance
+func NewRepository(conn sqlc.DBTX) *Repository {
+	return &Repository{
+		queries: sqlc.New(conn),
+	}
+}
+
+// CreateWebhook creates a new webhook record from domain model. It fails with
+// user.ErrUserNotFound if the webhook's user doesn't exist.
+func (r *Repository) CreateWebhook(ctx context.Context, domainWebhook *webhook.Webhook) (*webhook.Webhook, error) {
+	params, err := r.domainToCreateParams(domainWebhook)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.CreateWebhook(ctx, params)
+	if db.IsForeignKeyViolationOf(err, userConstraint) {
+		return nil, user.ErrUserNotFound
+	}
+	if db.IsForeignKeyViolationOf(err, lumoConstraint) {
+		return nil, access.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// GetWebhookByWebhookID retrieves a webhook by its UUID
+func (r *Repository) GetWebhookByWebhookID(ctx context.Context, webhookID string) (*webhook.Webhook, error) {
+	parsedUUID, err := uuid.Parse(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.GetWebhookByWebhookID(ctx, parsedUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, webhook.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// ListWebhooksByUserID retrieves the webhooks of a user, newest first. A
+// non-empty lumoID only returns the webhooks of that Lumo.
+func (r *Repository) ListWebhooksByUserID(ctx context.Context, userID, lumoID string, limit, offset int32) ([]*webhook.Webhook, error) {
+	parsedUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.ListWebhooksByUserIDParams{
+		UserID: parsedUUID,
+		Limit:  limit,
+		Offset: offset,
+	}
+	if lumoID != "" {
+		parsedLumoID, err := uuid.Parse(lumoID)
+		if err != nil {
+			return nil, err
+		}
+		params.LumoID = uuid.NullUUID{UUID: parsedLumoID, Valid: true}
+	}
+
+	results, err := r.queries.ListWebhooksByUserID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowsToDomainModels(results), nil
+}
+
+// ListWebhooksForEvent retrieves the enabled webhooks the events of a Lumo
+// are sent to
+func (r *Repository) ListWebhooksForEvent(ctx context.Context, lumoID string) ([]*webhook.Webhook, error) {
+	parsedUUID, err := uuid.Parse(lumoID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := r.queries.ListWebhooksForEvent(ctx, parsedUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowsToDomainModels(results), nil
+}
+
+// UpdateWebhook updates an existing webhook
+func (r *Repository) UpdateWebhook(ctx context.Context, domainWebhook *webhook.Webhook) (*webhook.Webhook, error) {
+	parsedUUID, err := uuid.Parse(domainWebhook.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.UpdateWebhookParams{
+		WebhookID:           parsedUUID,
+		Url:                 domainWebhook.URL,
+		Description:         domainWebhook.Description,
+		EntityTypes:         typesToStrings(domainWebhook.Filter.EntityTypes),
+		LumeTypes:           typesToStrings(domainWebhook.Filter.LumeTypes),
+		LinkTypes:           typesToStrings(domainWebhook.Filter.LinkTypes),
+		ConsecutiveFailures: domainWebhook.ConsecutiveFailures,
+		UpdatedAt:           time.Now(),
+	}
+	if domainWebhook.DisabledAt != nil {
+		params.DisabledAt = sql.NullTime{Time: *domainWebhook.DisabledAt, Valid: true}
+	}
+
+	result, err := r.queries.UpdateWebhook(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, webhook.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// DeleteWebhook deletes a webhook together with its deliveries
+func (r *Repository) DeleteWebhook(ctx context.Context, webhookID string) error {
+	parsedUUID, err := uuid.Parse(webhookID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := r.queries.DeleteWebhook(ctx, parsedUUID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return webhook.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// RecordSuccess resets the consecutive failures of a webhook
+func (r *Repository) RecordSuccess(ctx context.Context, webhookID string) error {
+	parsedUUID, err := uuid.Parse(webhookID)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.RecordWebhookSuccess(ctx, parsedUUID)
+}
+
+// RecordFailure counts a failed attempt to reach a webhook, disabling it once
+// disableAfter attempts in a row failed. It returns the webhook as it is now.
+func (r *Repository) RecordFailure(ctx context.Context, webhookID string, disableAfter int32) (*webhook.Webhook, error) {
+	parsedUUID, err := uuid.Parse(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.RecordWebhookFailure(ctx, sqlc.RecordWebhookFailureParams{
+		DisableAfter: disableAfter,
+		Now:          time.Now(),
+		WebhookID:    parsedUUID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, webhook.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcRowToDomainModel(result), nil
+}
+
+// CreateDelivery queues a delivery. It fails with webhook.ErrDeliveryExists
+// if the event is already queued for the webhook and the delivery isn't a
+// redelivery.
+func (r *Repository) CreateDelivery(ctx context.Context, domainDelivery *webhook.Delivery) (*webhook.Delivery, error) {
+	params, err := r.domainToCreateDeliveryParams(domainDelivery)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.CreateWebhookDelivery(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, webhook.ErrDeliveryExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcDeliveryToDomainModel(result), nil
+}
+
+// GetDeliveryByDeliveryID retrieves a delivery by its UUID
+func (r *Repository) GetDeliveryByDeliveryID(ctx context.Context, deliveryID string) (*webhook.Delivery, error) {
+	parsedUUID, err := uuid.Parse(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.GetWebhookDeliveryByDeliveryID(ctx, parsedUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, webhook.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcDeliveryToDomainModel(result), nil
+}
+
+// ListDeliveries retrieves the deliveries to a webhook, newest first
+func (r *Repository) ListDeliveries(ctx context.Context, webhookID string, limit, offset int32) ([]*webhook.Delivery, error) {
+	parsedUUID, err := uuid.Parse(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := r.queries.ListWebhookDeliveries(ctx, sqlc.ListWebhookDeliveriesParams{
+		WebhookID: parsedUUID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sqlcDeliveriesToDomainModels(results), nil
+}
+
+// ClaimDeliveries leases up to batchSize pending deliveries to enabled
+// webhooks that are due, oldest first, counting the attempt about to be made.
+// Other workers skip them until the lease is over.
+func (r *Repository) ClaimDeliveries(ctx context.Context, lease time.Duration, batchSize int32) ([]*webhook.Delivery, error) {
+	now := time.Now()
+	results, err := r.queries.ClaimWebhookDeliveries(ctx, sqlc.ClaimWebhookDeliveriesParams{
+		LeaseUntil: now.Add(lease),
+		Now:        now,
+		BatchSize:  batchSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// UPDATE ... RETURNING doesn't keep the order of the subquery
+	sort.Slice(results, func(i, j int) bool { return results[i].ID < results[j].ID })
+
+	return r.sqlcDeliveriesToDomainModels(results), nil
+}
+
+// MarkDelivered records that the endpoint accepted a delivery
+func (r *Repository) MarkDelivered(ctx context.Context, id int64, responseCode int32) error {
+	now := time.Now()
+	return r.queries.MarkWebhookDelivery(ctx, sqlc.MarkWebhookDeliveryParams{
+		ID:            id,
+		Status:        string(webhook.StatusDelivered),
+		NextAttemptAt: now,
+		ResponseCode:  nullInt32(responseCode),
+		DeliveredAt:   sql.NullTime{Time: now, Valid: true},
+	})
+}
+
+// MarkRetry records a failed attempt and when to try again. A responseCode of
+// 0 means no response was received.
+func (r *Repository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time, responseCode int32, lastError string) error {
+	return r.queries.MarkWebhookDelivery(ctx, sqlc.MarkWebhookDeliveryParams{
+		ID:            id,
+		Status:        string(webhook.StatusPending),
+		NextAttemptAt: nextAttemptAt,
+		ResponseCode:  nullInt32(responseCode),
+		LastError:     sql.NullString{String: lastError, Valid: true},
+	})
+}
+
+// MarkFailed records that a delivery was given up on
+func (r *Repository) MarkFailed(ctx context.Context, id int64, responseCode int32, lastError string) error {
+	return r.queries.MarkWebhookDelivery(ctx, sqlc.MarkWebhookDeliveryParams{
+		ID:            id,
+		Status:        string(webhook.StatusFailed),
+		NextAttemptAt: time.Now(),
+		ResponseCode:  nullInt32(responseCode),
+		LastError:     sql.NullString{String: lastError, Valid: true},
+	})
+}
+
+// Helper method to convert domain Webhook to SQLC CreateWebhookParams
+func (r *Repository) domainToCreateParams(domainWebhook *webhook.Webhook) (sqlc.CreateWebhookParams, error) {
+	webhookID, err := uuid.Parse(domainWebhook.WebhookID)
+	if err != nil {
+		return sqlc.CreateWebhookParams{}, err
+	}
+	userID, err := uuid.Parse(domainWebhook.UserID)
+	if err != nil {
+		return sqlc.CreateWebhookParams{}, err
+	}
+
+	params := sqlc.CreateWebhookParams{
+		WebhookID:   webhookID,
+		UserID:      userID,
+		Url:         domainWebhook.URL,
+		Description: domainWebhook.Description,
+		Secret:      domainWebhook.Secret,
+		EntityTypes: typesToStrings(domainWebhook.Filter.EntityTypes),
+		LumeTypes:   typesToStrings(domainWebhook.Filter.LumeTypes),
+		LinkTypes:   typesToStrings(domainWebhook.Filter.LinkTypes),
+		CreatedAt:   domainWebhook.CreatedAt,
+		UpdatedAt:   domainWebhook.UpdatedAt,
+	}
+
+	// Handle optional fields
+	if domainWebhook.LumoID != "" {
+		lumoID, err := uuid.Parse(domainWebhook.LumoID)
+		if err != nil {
+			return sqlc.CreateWebhookParams{}, err
+		}
+		params.LumoID = uuid.NullUUID{UUID: lumoID, Valid: true}
+	}
+
+	return params, nil
+}
+
+// Helper method to convert domain Delivery to SQLC CreateWebhookDeliveryParams
+func (r *Repository) domainToCreateDeliveryParams(domainDelivery *webhook.Delivery) (sqlc.CreateWebhookDeliveryParams, error) {
+	deliveryID, err := uuid.Parse(domainDelivery.DeliveryID)
+	if err != nil {
+		return sqlc.CreateWebhookDeliveryParams{}, err
+	}
+	webhookID, err := uuid.Parse(domainDelivery.WebhookID)
+	if err != nil {
+		return sqlc.CreateWebhookDeliveryParams{}, err
+	}
+	eventID, err := uuid.Parse(domainDelivery.EventID)
+	if err != nil {
+		return sqlc.CreateWebhookDeliveryParams{}, err
+	}
+
+	params := sqlc.CreateWebhookDeliveryParams{
+		DeliveryID: deliveryID,
+		WebhookID:  webhookID,
+		EventID:    eventID,
+		EventType:  domainDelivery.EventType,
+		Payload:    domainDelivery.Payload,
+		CreatedAt:  domainDelivery.CreatedAt,
+	}
+
+	// Handle optional fields
+	if domainDelivery.RedeliveryOf != "" {
+		redeliveryOf, err := uuid.Parse(domainDelivery.RedeliveryOf)
+		if err != nil {
+			return sqlc.CreateWebhookDeliveryParams{}, err
+		}
+		params.RedeliveryOf = uuid.NullUUID{UUID: redeliveryOf, Valid: true}
+	}
+
+	return params, nil
+}
+
+// Helper method to convert SQLC results to domain model
+func (r *Repository) sqlcRowToDomainModel(row sqlc.Webhook) *webhook.Webhook {
+	domainWebhook := &webhook.Webhook{
+		ID:          row.ID,
+		WebhookID:   row.WebhookID.String(),
+		UserID:      row.UserID.String(),
+		URL:         row.Url,
+		Description: row.Description,
+		Secret:      row.Secret,
+		Filter: webhook.Filter{
+			EntityTypes: stringsToTypes[history.EntityType](row.EntityTypes),
+			LumeTypes:   stringsToTypes[lume.LumeType](row.LumeTypes),
+			LinkTypes:   stringsToTypes[link.LinkType](row.LinkTypes),
+		},
+		ConsecutiveFailures: row.ConsecutiveFailures,
+		CreatedAt:           row.CreatedAt,
+		UpdatedAt:           row.UpdatedAt,
+	}
+
+	// Handle optional fields
+	if row.LumoID.Valid {
+		domainWebhook.LumoID = row.LumoID.UUID.String()
+	}
+	if row.DisabledAt.Valid {
+		domainWebhook.DisabledAt = &row.DisabledAt.Time
+	}
+
+	return domainWebhook
+}
+
+// Helper method to convert a list of SQLC results to domain models
+func (r *Repository) sqlcRowsToDomainModels(rows []sqlc.Webhook) []*webhook.Webhook {
+	webhooks := make([]*webhook.Webhook, len(rows))
+	for i, row := range rows {
+		webhooks[i] = r.sqlcRowToDomainModel(row)
+	}
+	return webhooks
+}
+
+// Helper method to convert a SQLC delivery to domain model
+func (r *Repository) sqlcDeliveryToDomainModel(row sqlc.WebhookDelivery) *webhook.Delivery {
+	domainDelivery := &webhook.Delivery{
+		ID:            row.ID,
+		DeliveryID:    row.DeliveryID.String(),
+		WebhookID:     row.WebhookID.String(),
+		EventID:       row.EventID.String(),
+		EventType:     row.EventType,
+		Payload:       row.Payload,
+		Status:        webhook.Status(row.Status),
+		Attempts:      row.Attempts,
+		NextAttemptAt: row.NextAttemptAt,
+		ResponseCode:  row.ResponseCode.Int32,
+		LastError:     row.LastError.String,
+		CreatedAt:     row.CreatedAt,
+	}
+
+	// Handle optional fields
+	if row.RedeliveryOf.Valid {
+		domainDelivery.RedeliveryOf = row.RedeliveryOf.UUID.String()
+	}
+	if row.DeliveredAt.Valid {
+		domainDelivery.DeliveredAt = &row.DeliveredAt.Time
+	}
+
+	return domainDelivery
+}
+
+// Helper method to convert a list of SQLC deliveries to domain models
+func (r *Repository) sqlcDeliveriesToDomainModels(rows []sqlc.WebhookDelivery) []*webhook.Delivery {
+	deliveries := make([]*webhook.Delivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = r.sqlcDeliveryToDomainModel(row)
+	}
+	return deliveries
+}
+
+// nullInt32 stores 0 as NULL
+func nullInt32(v int32) sql.NullInt32 {
+	return sql.NullInt32{Int32: v, Valid: v != 0}
+}
+
+// typesToStrings converts a list of enum values to the TEXT[] stored,
+// never nil so the NOT NULL columns are satisfied
+func typesToStrings[T ~string](types []T) []string {
+	strs := make([]string, len(types))
+	for i, t := range types {
+		strs[i] = string(t)
+	}
+	return strs
+}
+
+// stringsToTypes converts a stored TEXT[] to a list of enum values, nil if
+// it's empty
+func stringsToTypes[T ~string](strs []string) []T {
+	if len(strs) == 0 {
+		return nil
+	}
+	types := make([]T, len(strs))
+	for i, s := range strs {
+		types[i] = T(s)
+	}
+	return types
+}
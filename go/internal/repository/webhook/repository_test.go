@@ -0,0 +1,237 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcdev12/lumo/go/internal/models/history"
+	"github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/outbox"
+	"github.com/mcdev12/lumo/go/internal/models/webhook"
+	"github.com/mcdev12/lumo/go/internal/repository/db/sqlc"
+	"github.com/mcdev12/lumo/go/internal/repository/webhook/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RepositoryTestSuite is a test suite for the Repository
+type RepositoryTestSuite struct {
+	suite.Suite
+	mockQuerier *mocks.MockWebhookQuerier
+	repository  *Repository
+}
+
+// SetupTest is called before each test
+func (s *RepositoryTestSuite) SetupTest() {
+	s.mockQuerier = mocks.NewMockWebhookQuerier(s.T())
+	s.repository = &Repository{
+		queries: s.mockQuerier,
+	}
+}
+
+// TestRepositorySuite runs the test suite
+func TestRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// Helper function to create a test sqlc Webhook row
+func newWebhookRow() sqlc.Webhook {
+	return sqlc.Webhook{
+		ID:          1,
+		WebhookID:   uuid.New(),
+		UserID:      uuid.New(),
+		Url:         "https://example.com/hooks",
+		Secret:      "whsec_secret",
+		EntityTypes: []string{},
+		LumeTypes:   []string{},
+		LinkTypes:   []string{},
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// Helper function to create a test sqlc WebhookDelivery row
+func newDeliveryRow(id int64) sqlc.WebhookDelivery {
+	return sqlc.WebhookDelivery{
+		ID:            id,
+		DeliveryID:    uuid.New(),
+		WebhookID:     uuid.New(),
+		EventID:       uuid.New(),
+		EventType:     "LumeCreated",
+		Payload:       json.RawMessage(`{}`),
+		Status:        string(webhook.StatusPending),
+		Attempts:      1,
+		NextAttemptAt: time.Now(),
+		CreatedAt:     time.Now(),
+	}
+}
+
+// Test CreateWebhook stores the filter and the Lumo the webhook is limited to
+func (s *RepositoryTestSuite) TestCreateWebhook() {
+	// Arrange
+	ctx := context.Background()
+	lumoID := uuid.New().String()
+	filter := webhook.Filter{
+		EntityTypes: []history.EntityType{history.EntityTypeLume},
+		LumeTypes:   []lume.LumeType{lume.LumeTypeCity},
+	}
+	domainWebhook, err := webhook.NewWebhook(uuid.New().String(), lumoID, "https://example.com/hooks", "Slack", filter)
+	s.Require().NoError(err)
+
+	row := newWebhookRow()
+	row.WebhookID = uuid.MustParse(domainWebhook.WebhookID)
+	row.LumoID = uuid.NullUUID{UUID: uuid.MustParse(lumoID), Valid: true}
+	row.EntityTypes = []string{"LUME"}
+	row.LumeTypes = []string{"LUME_TYPE_CITY"}
+
+	// Set up expectations
+	s.mockQuerier.On("CreateWebhook", ctx, mock.MatchedBy(func(params sqlc.CreateWebhookParams) bool {
+		return params.WebhookID == row.WebhookID &&
+			params.LumoID == row.LumoID &&
+			params.Secret == domainWebhook.Secret &&
+			len(params.EntityTypes) == 1 && params.EntityTypes[0] == "LUME" &&
+			len(params.LumeTypes) == 1 && params.LumeTypes[0] == "LUME_TYPE_CITY" &&
+			params.LinkTypes != nil && len(params.LinkTypes) == 0
+	})).Return(row, nil)
+
+	// Act
+	result, err := s.repository.CreateWebhook(ctx, domainWebhook)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(domainWebhook.WebhookID, result.WebhookID)
+	s.Equal(lumoID, result.LumoID)
+	s.Equal(filter, result.Filter)
+	s.True(result.Enabled())
+	s.mockQuerier.AssertExpectations(s.T())
+}
+
+// Test a missing webhook is reported as ErrWebhookNotFound
+func (s *RepositoryTestSuite) TestGetWebhookByWebhookIDNotFound() {
+	// Arrange
+	ctx := context.Background()
+	webhookID := uuid.New()
+
+	// Set up expectations
+	s.mockQuerier.On("GetWebhookByWebhookID", ctx, webhookID).Return(sqlc.Webhook{}, sql.ErrNoRows)
+
+	// Act
+	result, err := s.repository.GetWebhookByWebhookID(ctx, webhookID.String())
+
+	// Assert
+	s.ErrorIs(err, webhook.ErrWebhookNotFound)
+	s.Nil(result)
+}
+
+// Test DeleteWebhook reports a webhook that wasn't there
+func (s *RepositoryTestSuite) TestDeleteWebhookNotFound() {
+	// Arrange
+	ctx := context.Background()
+	webhookID := uuid.New()
+
+	// Set up expectations
+	s.mockQuerier.On("DeleteWebhook", ctx, webhookID).Return(int64(0), nil)
+
+	// Act
+	err := s.repository.DeleteWebhook(ctx, webhookID.String())
+
+	// Assert
+	s.ErrorIs(err, webhook.ErrWebhookNotFound)
+}
+
+// Test RecordFailure returns the webhook with its disabled time
+func (s *RepositoryTestSuite) TestRecordFailure() {
+	// Arrange
+	ctx := context.Background()
+	row := newWebhookRow()
+	row.ConsecutiveFailures = 20
+	row.DisabledAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	// Set up expectations
+	s.mockQuerier.On("RecordWebhookFailure", ctx, mock.MatchedBy(func(params sqlc.RecordWebhookFailureParams) bool {
+		return params.WebhookID == row.WebhookID && params.DisableAfter == 20
+	})).Return(row, nil)
+
+	// Act
+	result, err := s.repository.RecordFailure(ctx, row.WebhookID.String(), 20)
+
+	// Assert
+	s.NoError(err)
+	s.False(result.Enabled())
+	s.Equal(int32(20), result.ConsecutiveFailures)
+}
+
+// Test CreateDelivery reports an event that is already queued for the webhook
+func (s *RepositoryTestSuite) TestCreateDeliveryExists() {
+	// Arrange
+	ctx := context.Background()
+	event := &outbox.Event{EventID: uuid.New().String(), Type: "LumeCreated"}
+	delivery := webhook.NewDelivery(uuid.New().String(), event, []byte(`{}`))
+
+	// Set up expectations
+	s.mockQuerier.On("CreateWebhookDelivery", ctx, mock.MatchedBy(func(params sqlc.CreateWebhookDeliveryParams) bool {
+		return params.EventID.String() == event.EventID && !params.RedeliveryOf.Valid
+	})).Return(sqlc.WebhookDelivery{}, sql.ErrNoRows)
+
+	// Act
+	result, err := s.repository.CreateDelivery(ctx, delivery)
+
+	// Assert
+	s.ErrorIs(err, webhook.ErrDeliveryExists)
+	s.Nil(result)
+}
+
+// Test ClaimDeliveries returns the leased deliveries oldest first
+func (s *RepositoryTestSuite) TestClaimDeliveries() {
+	// Arrange
+	ctx := context.Background()
+	lease := time.Minute
+
+	// Set up expectations
+	s.mockQuerier.On("ClaimWebhookDeliveries", ctx, mock.MatchedBy(func(params sqlc.ClaimWebhookDeliveriesParams) bool {
+		return params.BatchSize == 10 && params.LeaseUntil.Sub(params.Now) == lease
+	})).Return([]sqlc.WebhookDelivery{newDeliveryRow(2), newDeliveryRow(1)}, nil)
+
+	// Act
+	deliveries, err := s.repository.ClaimDeliveries(ctx, lease, 10)
+
+	// Assert
+	s.NoError(err)
+	s.Require().Len(deliveries, 2)
+	s.Equal(int64(1), deliveries[0].ID)
+	s.Equal(int64(2), deliveries[1].ID)
+	s.Zero(deliveries[0].ResponseCode)
+	s.Empty(deliveries[0].RedeliveryOf)
+}
+
+// Test MarkRetry stores the response code while MarkDelivered records when
+func (s *RepositoryTestSuite) TestMarkDelivery() {
+	// Arrange
+	ctx := context.Background()
+	nextAttemptAt := time.Now().Add(time.Minute)
+
+	// Set up expectations
+	s.mockQuerier.On("MarkWebhookDelivery", ctx, mock.MatchedBy(func(params sqlc.MarkWebhookDeliveryParams) bool {
+		return params.ID == 1 && params.Status == string(webhook.StatusPending) &&
+			params.NextAttemptAt.Equal(nextAttemptAt) &&
+			params.ResponseCode == sql.NullInt32{Int32: 503, Valid: true} &&
+			params.LastError.String == "unexpected status 503" && !params.DeliveredAt.Valid
+	})).Return(nil)
+	s.mockQuerier.On("MarkWebhookDelivery", ctx, mock.MatchedBy(func(params sqlc.MarkWebhookDeliveryParams) bool {
+		return params.ID == 2 && params.Status == string(webhook.StatusDelivered) &&
+			params.ResponseCode.Int32 == 204 && params.DeliveredAt.Valid && !params.LastError.Valid
+	})).Return(nil)
+
+	// Act
+	retryErr := s.repository.MarkRetry(ctx, 1, nextAttemptAt, 503, "unexpected status 503")
+	deliveredErr := s.repository.MarkDelivered(ctx, 2, 204)
+
+	// Assert
+	s.NoError(retryErr)
+	s.NoError(deliveredErr)
+	s.mockQuerier.AssertExpectations(s.T())
+}
@@ -0,0 +1,146 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"connectrpc.com/connect"
+
+	appapikey "github.com/mcdev12/lumo/go/internal/app/apikey"
+	pb "github.com/mcdev12/lumo/go/internal/genproto/apikey/v1"
+	modelapikey "github.com/mcdev12/lumo/go/internal/models/apikey"
+)
+
+// APIKeyApp defines what the service layer needs from the app layer
+type APIKeyApp interface {
+	CreateAPIKey(ctx context.Context, req appapikey.CreateAPIKeyRequest) (*modelapikey.APIKey, error)
+	ListAPIKeys(ctx context.Context, req appapikey.ListAPIKeysRequest) ([]*modelapikey.APIKey, error)
+	RevokeAPIKey(ctx context.Context, apiKeyID string) (*modelapikey.APIKey, error)
+	RotateAPIKey(ctx context.Context, apiKeyID string) (*modelapikey.APIKey, error)
+}
+
+// Service implements the ApiKeyServiceHandler interface
+type Service struct {
+	app APIKeyApp
+}
+
+// NewService creates a new APIKey service
+func NewService(app APIKeyApp) *Service {
+	return &Service{
+		app: app,
+	}
+}
+
+// CreateApiKey creates an API key and returns its secret
+func (s *Service) CreateApiKey(ctx context.Context, req *connect.Request[pb.CreateApiKeyRequest]) (*connect.Response[pb.CreateApiKeyResponse], error) {
+	created, err := s.app.CreateAPIKey(ctx, appapikey.CreateAPIKeyRequest{
+		UserID: req.Msg.GetUserId(),
+		Name:   req.Msg.GetName(),
+		Scope:  modelapikey.ProtoScopeToDomain(req.Msg.GetScope()),
+		LumoID: req.Msg.GetLumoId(),
+	})
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.CreateApiKeyResponse{
+		ApiKey: modelapikey.DomainToProto(created),
+		Secret: created.Secret,
+	}), nil
+}
+
+// ListApiKeys lists the API keys of a user, newest first
+func (s *Service) ListApiKeys(ctx context.Context, req *connect.Request[pb.ListApiKeysRequest]) (*connect.Response[pb.ListApiKeysResponse], error) {
+	// Convert page_size to limit and page_token to offset
+	limit := req.Msg.GetPageSize()
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+
+	offset := int32(0)
+	if req.Msg.GetPageToken() != "" {
+		parsedOffset, err := strconv.ParseInt(req.Msg.GetPageToken(), 10, 32)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid page token"))
+		}
+		offset = int32(parsedOffset)
+	}
+
+	keys, err := s.app.ListAPIKeys(ctx, appapikey.ListAPIKeysRequest{
+		UserID: req.Msg.GetUserId(),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	pbKeys := make([]*pb.ApiKey, len(keys))
+	for i, key := range keys {
+		pbKeys[i] = modelapikey.DomainToProto(key)
+	}
+
+	var nextPageToken string
+	if len(pbKeys) == int(limit) {
+		nextPageToken = strconv.FormatInt(int64(offset+limit), 10)
+	}
+
+	return connect.NewResponse(&pb.ListApiKeysResponse{
+		ApiKeys:       pbKeys,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// RevokeApiKey stops an API key from working
+func (s *Service) RevokeApiKey(ctx context.Context, req *connect.Request[pb.RevokeApiKeyRequest]) (*connect.Response[pb.RevokeApiKeyResponse], error) {
+	revoked, err := s.app.RevokeAPIKey(ctx, req.Msg.GetApiKeyId())
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.RevokeApiKeyResponse{
+		ApiKey: modelapikey.DomainToProto(revoked),
+	}), nil
+}
+
+// RotateApiKey replaces the secret of an API key and returns the new one
+func (s *Service) RotateApiKey(ctx context.Context, req *connect.Request[pb.RotateApiKeyRequest]) (*connect.Response[pb.RotateApiKeyResponse], error) {
+	rotated, err := s.app.RotateAPIKey(ctx, req.Msg.GetApiKeyId())
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.RotateApiKeyResponse{
+		ApiKey: modelapikey.DomainToProto(rotated),
+		Secret: rotated.Secret,
+	}), nil
+}
+
+// mapErrorToConnectError maps domain errors to Connect errors
+func (s *Service) mapErrorToConnectError(err error) error {
+	switch {
+	case errors.Is(err, appapikey.ErrInvalidUserID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appapikey.ErrInvalidAPIKeyID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appapikey.ErrInvalidLumoID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appapikey.ErrEmptyName):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appapikey.ErrInvalidScope):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appapikey.ErrLumoScope):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appapikey.ErrAPIKeyNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, appapikey.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, appapikey.ErrUserNotFound):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, appapikey.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
@@ -0,0 +1,165 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/mcdev12/lumo/go/internal/auth"
+	modelaudit "github.com/mcdev12/lumo/go/internal/models/audit"
+)
+
+// Recorder stores audit log entries
+type Recorder interface {
+	Record(ctx context.Context, entry *modelaudit.Entry) error
+}
+
+// Interceptor records every call to a procedure with side effects in the
+// audit log: who made it, what it was about, a digest of the request, how it
+// ended and how long it took. Calls are recorded whether they succeed or not,
+// so it belongs after authentication but before validation. Failing to
+// record a call is logged and doesn't change its outcome.
+type Interceptor struct {
+	recorder Recorder
+}
+
+// NewInterceptor creates a new audit Interceptor
+func NewInterceptor(recorder Recorder) *Interceptor {
+	return &Interceptor{
+		recorder: recorder,
+	}
+}
+
+// WrapUnary records unary calls that aren't declared free of side effects
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		spec := req.Spec()
+		if spec.IsClient || spec.IdempotencyLevel == connect.IdempotencyNoSideEffects {
+			return next(ctx, req)
+		}
+
+		start := time.Now()
+		ctx, subject := modelaudit.WithSubject(ctx)
+		res, err := next(ctx, req)
+
+		entry := newEntry(ctx, spec.Procedure, subject, req, res, err, start)
+		// The call is over either way, a client going away shouldn't lose its entry
+		if recordErr := i.recorder.Record(context.WithoutCancel(ctx), entry); recordErr != nil {
+			slog.WarnContext(ctx, "failed to record call in the audit log", slog.String("procedure", spec.Procedure), slog.Any("error", recordErr))
+		}
+
+		return res, err
+	}
+}
+
+// WrapStreamingClient leaves outgoing streams alone
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler leaves streams alone, none of them change anything
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// newEntry describes a finished call
+func newEntry(ctx context.Context, procedure string, subject *modelaudit.Subject, req connect.AnyRequest, res connect.AnyResponse, err error, start time.Time) *modelaudit.Entry {
+	entry := &modelaudit.Entry{
+		Procedure: procedure,
+		LumoID:    subject.LumoID(),
+		Code:      modelaudit.CodeOK,
+		Latency:   time.Since(start),
+		CreatedAt: start,
+	}
+	if err != nil {
+		entry.Code = connect.CodeOf(err).String()
+	}
+
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		entry.Actor = identity.UserID
+		entry.APIKeyID = identity.APIKeyID
+	}
+
+	ids := &idCollector{seen: make(map[string]bool)}
+	if msg, ok := req.Any().(proto.Message); ok {
+		entry.RequestDigest = digest(msg)
+		ids.collect(msg.ProtoReflect())
+	}
+	if err == nil && res != nil {
+		if msg, ok := res.Any().(proto.Message); ok {
+			ids.collect(msg.ProtoReflect())
+		}
+	}
+	entry.TargetIDs = ids.ids
+
+	// Calls that never got as far as authorization, like creating a Lumo,
+	// still name their Lumo
+	if entry.LumoID == "" {
+		entry.LumoID = ids.lumoID
+	}
+
+	return entry
+}
+
+// digest returns the hex SHA-256 of a message's deterministic encoding
+func digest(msg proto.Message) string {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// idCollector gathers the IDs named in messages: the values of string fields
+// called id or uuid or ending in _id, in the order they are found
+type idCollector struct {
+	ids    []string
+	seen   map[string]bool
+	lumoID string
+}
+
+// collect adds the IDs of a message and the messages within it
+func (c *idCollector) collect(msg protoreflect.Message) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			list := v.List()
+			for j := 0; j < list.Len(); j++ {
+				c.collect(list.Get(j).Message())
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			c.collect(v.Message())
+		case fd.Kind() == protoreflect.StringKind && !fd.IsList() && isIDField(fd.Name()):
+			c.add(string(fd.Name()), v.String())
+		}
+		return true
+	})
+}
+
+// add records an ID, and the first Lumo ID seen
+func (c *idCollector) add(field, id string) {
+	if id == "" {
+		return
+	}
+	if c.lumoID == "" && (field == "lumo_id" || field == "lumo_uuid") {
+		c.lumoID = id
+	}
+	if !c.seen[id] {
+		c.seen[id] = true
+		c.ids = append(c.ids, id)
+	}
+}
+
+// isIDField reports whether a field holds the ID of something
+func isIDField(name protoreflect.Name) bool {
+	return name == "id" || name == "uuid" || strings.HasSuffix(string(name), "_id") || strings.HasSuffix(string(name), "_uuid")
+}
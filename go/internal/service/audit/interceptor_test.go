@@ -0,0 +1,161 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mcdev12/lumo/go/internal/auth"
+	lumev1 "github.com/mcdev12/lumo/go/internal/genproto/lume/v1"
+	"github.com/mcdev12/lumo/go/internal/genproto/lume/v1/lumev1connect"
+	modelaudit "github.com/mcdev12/lumo/go/internal/models/audit"
+)
+
+// stubRecorder keeps the entries it records, or fails with err
+type stubRecorder struct {
+	mu      sync.Mutex
+	entries []*modelaudit.Entry
+	err     error
+}
+
+// Record stores the entry
+func (r *stubRecorder) Record(_ context.Context, entry *modelaudit.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// stubLumeService answers CreateLume and GetLume, failing with err if set
+type stubLumeService struct {
+	lumev1connect.UnimplementedLumeServiceHandler
+	err error
+}
+
+// CreateLume returns a Lume with a new ID
+func (s *stubLumeService) CreateLume(_ context.Context, req *connect.Request[lumev1.CreateLumeRequest]) (*connect.Response[lumev1.CreateLumeResponse], error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return connect.NewResponse(&lumev1.CreateLumeResponse{
+		Lume: &lumev1.Lume{LumeId: "lume-1", LumoId: req.Msg.GetLumoId(), Name: req.Msg.GetName()},
+	}), nil
+}
+
+// GetLume returns the Lume asked for
+func (s *stubLumeService) GetLume(_ context.Context, req *connect.Request[lumev1.GetLumeRequest]) (*connect.Response[lumev1.GetLumeResponse], error) {
+	return connect.NewResponse(&lumev1.GetLumeResponse{
+		Lume: &lumev1.Lume{LumeId: req.Msg.GetLumeId(), LumoId: "lumo-1"},
+	}), nil
+}
+
+// InterceptorTestSuite is a test suite for the audit Interceptor
+type InterceptorTestSuite struct {
+	suite.Suite
+	recorder *stubRecorder
+	service  *stubLumeService
+	client   lumev1connect.LumeServiceClient
+	server   *httptest.Server
+}
+
+// SetupTest is called before each test
+func (s *InterceptorTestSuite) SetupTest() {
+	s.recorder = &stubRecorder{}
+	s.service = &stubLumeService{}
+
+	// The caller is authenticated before the call reaches the audit log
+	authenticate := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			ctx = auth.WithIdentity(ctx, &auth.Identity{UserID: "user-1", APIKeyID: "key-1"})
+			return next(ctx, req)
+		}
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle(lumev1connect.NewLumeServiceHandler(s.service, connect.WithInterceptors(authenticate, NewInterceptor(s.recorder))))
+	s.server = httptest.NewServer(mux)
+	s.client = lumev1connect.NewLumeServiceClient(s.server.Client(), s.server.URL)
+}
+
+// TearDownTest is called after each test
+func (s *InterceptorTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// TestInterceptorSuite runs the test suite
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorTestSuite))
+}
+
+// Test a successful mutation is recorded once with its caller, procedure and IDs
+func (s *InterceptorTestSuite) TestRecordsMutation() {
+	// Arrange
+	req := connect.NewRequest(&lumev1.CreateLumeRequest{LumoId: "lumo-1", Name: "Louvre"})
+
+	// Act
+	res, err := s.client.CreateLume(context.Background(), req)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("lume-1", res.Msg.GetLume().GetLumeId())
+	s.Require().Len(s.recorder.entries, 1)
+	entry := s.recorder.entries[0]
+	s.Equal(lumev1connect.LumeServiceCreateLumeProcedure, entry.Procedure)
+	s.Equal("user-1", entry.Actor)
+	s.Equal("key-1", entry.APIKeyID)
+	s.Equal("lumo-1", entry.LumoID)
+	s.Equal([]string{"lumo-1", "lume-1"}, entry.TargetIDs)
+	s.Equal(modelaudit.CodeOK, entry.Code)
+	s.Equal(digest(req.Msg), entry.RequestDigest)
+	s.False(entry.CreatedAt.IsZero())
+}
+
+// Test a failed mutation is recorded with its code and only the IDs of the request
+func (s *InterceptorTestSuite) TestRecordsFailure() {
+	// Arrange
+	s.service.err = connect.NewError(connect.CodeNotFound, errors.New("lumo not found"))
+
+	// Act
+	_, err := s.client.CreateLume(context.Background(), connect.NewRequest(&lumev1.CreateLumeRequest{LumoId: "lumo-1"}))
+
+	// Assert
+	s.Equal(connect.CodeNotFound, connect.CodeOf(err))
+	s.Require().Len(s.recorder.entries, 1)
+	entry := s.recorder.entries[0]
+	s.Equal(connect.CodeNotFound.String(), entry.Code)
+	s.Equal("user-1", entry.Actor)
+	s.Equal([]string{"lumo-1"}, entry.TargetIDs)
+}
+
+// Test procedures without side effects aren't recorded
+func (s *InterceptorTestSuite) TestSkipsReads() {
+	// Act
+	_, err := s.client.GetLume(context.Background(), connect.NewRequest(&lumev1.GetLumeRequest{LumeId: "lume-1"}))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Empty(s.recorder.entries)
+}
+
+// Test a failure to write the audit log is logged but doesn't fail the call
+func (s *InterceptorTestSuite) TestRecordFailureKeepsResponse() {
+	// Arrange
+	s.recorder.err = errors.New("database is down")
+
+	// Act
+	res, err := s.client.CreateLume(context.Background(), connect.NewRequest(&lumev1.CreateLumeRequest{LumoId: "lumo-1"}))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("lume-1", res.Msg.GetLume().GetLumeId())
+	s.Empty(s.recorder.entries)
+}
@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"connectrpc.com/connect"
+
+	appaudit "github.com/mcdev12/lumo/go/internal/app/audit"
+	pb "github.com/mcdev12/lumo/go/internal/genproto/audit/v1"
+	modelaudit "github.com/mcdev12/lumo/go/internal/models/audit"
+)
+
+// AuditApp defines what the service layer needs from the app layer
+type AuditApp interface {
+	ListAuditLog(ctx context.Context, req appaudit.ListAuditLogRequest) ([]*modelaudit.Entry, error)
+}
+
+// Service implements the AuditServiceHandler interface
+type Service struct {
+	app AuditApp
+}
+
+// NewService creates a new Audit service
+func NewService(app AuditApp) *Service {
+	return &Service{
+		app: app,
+	}
+}
+
+// ListAuditLog lists audit log entries, newest first
+func (s *Service) ListAuditLog(ctx context.Context, req *connect.Request[pb.ListAuditLogRequest]) (*connect.Response[pb.ListAuditLogResponse], error) {
+	// Convert page_size to limit and page_token to offset
+	limit := req.Msg.GetPageSize()
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+
+	offset := int32(0)
+	if req.Msg.GetPageToken() != "" {
+		parsedOffset, err := strconv.ParseInt(req.Msg.GetPageToken(), 10, 32)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid page token"))
+		}
+		offset = int32(parsedOffset)
+	}
+
+	appReq := appaudit.ListAuditLogRequest{
+		LumoID: req.Msg.GetLumoId(),
+		Actor:  req.Msg.GetActor(),
+		Limit:  limit,
+		Offset: offset,
+	}
+	if req.Msg.GetStartTime() != nil {
+		startTime := req.Msg.GetStartTime().AsTime()
+		appReq.StartTime = &startTime
+	}
+	if req.Msg.GetEndTime() != nil {
+		endTime := req.Msg.GetEndTime().AsTime()
+		appReq.EndTime = &endTime
+	}
+
+	entries, err := s.app.ListAuditLog(ctx, appReq)
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	pbEntries := make([]*pb.AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		pbEntries[i] = modelaudit.DomainToProto(entry)
+	}
+
+	var nextPageToken string
+	if len(pbEntries) == int(limit) {
+		nextPageToken = strconv.FormatInt(int64(offset+limit), 10)
+	}
+
+	return connect.NewResponse(&pb.ListAuditLogResponse{
+		Entries:       pbEntries,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// mapErrorToConnectError maps domain errors to Connect errors
+func (s *Service) mapErrorToConnectError(err error) error {
+	switch {
+	case errors.Is(err, appaudit.ErrInvalidLumoID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appaudit.ErrInvalidTimeRange):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appaudit.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, appaudit.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
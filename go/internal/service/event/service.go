@@ -0,0 +1,71 @@
+package event
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	appevent "github.com/mcdev12/lumo/go/internal/app/event"
+	pb "github.com/mcdev12/lumo/go/internal/genproto/event/v1"
+	modelevent "github.com/mcdev12/lumo/go/internal/models/event"
+)
+
+// EventApp defines what the service layer needs from the app layer
+type EventApp interface {
+	WatchLumo(ctx context.Context, req appevent.WatchLumoRequest, send func(*modelevent.Event) error) error
+}
+
+// Service implements the EventServiceHandler interface
+type Service struct {
+	app EventApp
+}
+
+// NewService creates a new Event service
+func NewService(app EventApp) *Service {
+	return &Service{
+		app: app,
+	}
+}
+
+// WatchLumo streams change events for the Lumes and Links of a Lumo
+func (s *Service) WatchLumo(ctx context.Context, req *connect.Request[pb.WatchLumoRequest], stream *connect.ServerStream[pb.WatchLumoResponse]) error {
+	pbRequest := req.Msg
+	if pbRequest == nil {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("request is empty"))
+	}
+
+	appReq := appevent.WatchLumoRequest{
+		LumoID:      pbRequest.GetLumoId(),
+		ResumeToken: pbRequest.ResumeToken,
+	}
+
+	err := s.app.WatchLumo(ctx, appReq, func(domainEvent *modelevent.Event) error {
+		pbEvent, err := modelevent.DomainToProto(domainEvent)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&pb.WatchLumoResponse{Event: pbEvent})
+	})
+	if err != nil {
+		return s.mapErrorToConnectError(err)
+	}
+
+	return nil
+}
+
+// mapErrorToConnectError maps domain errors to Connect errors
+func (s *Service) mapErrorToConnectError(err error) error {
+	switch {
+	case errors.Is(err, appevent.ErrInvalidLumoID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appevent.ErrInvalidResumeToken):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appevent.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, appevent.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
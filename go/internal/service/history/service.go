@@ -0,0 +1,141 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"connectrpc.com/connect"
+
+	apphistory "github.com/mcdev12/lumo/go/internal/app/history"
+	pb "github.com/mcdev12/lumo/go/internal/genproto/history/v1"
+	linkpb "github.com/mcdev12/lumo/go/internal/genproto/link/v1"
+	lumepb "github.com/mcdev12/lumo/go/internal/genproto/lume/v1"
+	modelhistory "github.com/mcdev12/lumo/go/internal/models/history"
+	modellink "github.com/mcdev12/lumo/go/internal/models/link"
+	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+	modellumo "github.com/mcdev12/lumo/go/internal/models/lumo"
+)
+
+// HistoryApp defines what the service layer needs from the app layer
+type HistoryApp interface {
+	ListHistory(ctx context.Context, req apphistory.ListHistoryRequest) ([]*modelhistory.Entry, error)
+	RestoreLumo(ctx context.Context, req apphistory.RestoreLumoRequest) (*modelhistory.Graph, error)
+}
+
+// Service implements the HistoryServiceHandler interface
+type Service struct {
+	app HistoryApp
+}
+
+// NewService creates a new History service
+func NewService(app HistoryApp) *Service {
+	return &Service{
+		app: app,
+	}
+}
+
+// ListHistory lists the changes to a Lumo or a single entity, newest first
+func (s *Service) ListHistory(ctx context.Context, req *connect.Request[pb.ListHistoryRequest]) (*connect.Response[pb.ListHistoryResponse], error) {
+	// Convert page_size to limit and page_token to offset
+	limit := req.Msg.GetPageSize()
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+
+	offset := int32(0)
+	if req.Msg.GetPageToken() != "" {
+		parsedOffset, err := strconv.ParseInt(req.Msg.GetPageToken(), 10, 32)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid page token"))
+		}
+		offset = int32(parsedOffset)
+	}
+
+	appReq := apphistory.ListHistoryRequest{
+		LumoID:   req.Msg.GetLumoId(),
+		EntityID: req.Msg.GetEntityId(),
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	domainEntries, err := s.app.ListHistory(ctx, appReq)
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	pbEntries := make([]*pb.HistoryEntry, len(domainEntries))
+	for i, domainEntry := range domainEntries {
+		pbEntry, err := modelhistory.DomainToProto(domainEntry)
+		if err != nil {
+			return nil, s.mapErrorToConnectError(err)
+		}
+		pbEntries[i] = pbEntry
+	}
+
+	// Calculate next page token
+	var nextPageToken string
+	if len(pbEntries) == int(limit) {
+		nextPageToken = strconv.FormatInt(int64(offset+limit), 10)
+	}
+
+	return connect.NewResponse(&pb.ListHistoryResponse{
+		Entries:       pbEntries,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// RestoreLumo rebuilds a Lumo, its Lumes and Links as they were at a past instant
+func (s *Service) RestoreLumo(ctx context.Context, req *connect.Request[pb.RestoreLumoRequest]) (*connect.Response[pb.RestoreLumoResponse], error) {
+	if req.Msg.GetAtTime() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at_time is required"))
+	}
+
+	appReq := apphistory.RestoreLumoRequest{
+		LumoID: req.Msg.GetLumoId(),
+		At:     req.Msg.GetAtTime().AsTime(),
+	}
+
+	graph, err := s.app.RestoreLumo(ctx, appReq)
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	pbLumes := make([]*lumepb.Lume, len(graph.Lumes))
+	for i, domainLume := range graph.Lumes {
+		pbLumes[i] = modellume.DomainToProto(domainLume)
+	}
+
+	pbLinks := make([]*linkpb.Link, len(graph.Links))
+	for i, domainLink := range graph.Links {
+		pbLinks[i] = modellink.DomainToProto(domainLink)
+	}
+
+	return connect.NewResponse(&pb.RestoreLumoResponse{
+		Lumo:  modellumo.DomainToProto(graph.Lumo),
+		Lumes: pbLumes,
+		Links: pbLinks,
+	}), nil
+}
+
+// mapErrorToConnectError maps domain errors to Connect errors
+func (s *Service) mapErrorToConnectError(err error) error {
+	switch {
+	case errors.Is(err, apphistory.ErrInvalidLumoID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, apphistory.ErrInvalidEntityID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, apphistory.ErrMissingTarget):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, apphistory.ErrInvalidTime):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, apphistory.ErrNotRestorable):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, apphistory.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, apphistory.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
@@ -0,0 +1,158 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+
+	appidempotency "github.com/mcdev12/lumo/go/internal/app/idempotency"
+	"github.com/mcdev12/lumo/go/internal/auth"
+)
+
+const (
+	// KeyHeader carries the idempotency key of a create call, as an
+	// alternative to the request's idempotency_key field
+	KeyHeader = "Idempotency-Key"
+
+	// ReplayedHeader is set on responses replayed for a retried key
+	ReplayedHeader = "Idempotent-Replayed"
+
+	// keyField is the name of the request field carrying the key
+	keyField = "idempotency_key"
+
+	// maxKeyLength is the longest key accepted, as in the request field
+	maxKeyLength = 255
+)
+
+// Store remembers which keys were used for which requests and the responses
+// to replay
+type Store interface {
+	Begin(ctx context.Context, scope, key string, requestHash []byte) ([]byte, error)
+	Complete(ctx context.Context, scope, key string, requestHash, response []byte) error
+	Release(ctx context.Context, scope, key string, requestHash []byte) error
+}
+
+// Do runs create once per idempotency key. The key is taken from the
+// Idempotency-Key header or, without the header, from field, the request's
+// idempotency_key. Without a key, or without a store, create simply runs.
+//
+// The first call with a key runs create and, if it succeeds, stores its
+// response; retries with the same key and request get that response again,
+// marked with the Idempotent-Replayed header. Failed calls free the key so
+// they can be retried. Keys belong to the calling user and can't be reused
+// for a different request until they expire.
+func Do[Res any](ctx context.Context, store Store, req connect.AnyRequest, field string, create func() (*connect.Response[Res], error)) (*connect.Response[Res], error) {
+	key, err := resolveKey(req.Header().Get(KeyHeader), field)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if key == "" || store == nil {
+		return create()
+	}
+
+	scope := ""
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		scope = identity.UserID
+	}
+	requestHash, err := hashRequest(req)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	stored, err := store.Begin(ctx, scope, key, requestHash)
+	if err != nil {
+		return nil, mapErrorToConnectError(err)
+	}
+	if stored != nil {
+		return replay[Res](stored)
+	}
+
+	res, err := create()
+	// Whatever happened, the key must not stay claimed because the client left
+	ctx = context.WithoutCancel(ctx)
+	if err != nil {
+		if releaseErr := store.Release(ctx, scope, key, requestHash); releaseErr != nil {
+			slog.WarnContext(ctx, "failed to release idempotency key", slog.String("procedure", req.Spec().Procedure), slog.Any("error", releaseErr))
+		}
+		return nil, err
+	}
+
+	response, err := proto.Marshal(any(res.Msg).(proto.Message))
+	if err == nil {
+		err = store.Complete(ctx, scope, key, requestHash, response)
+	}
+	if err != nil {
+		// The call did succeed, retries are turned away until the key expires
+		slog.WarnContext(ctx, "failed to store response for idempotency key", slog.String("procedure", req.Spec().Procedure), slog.Any("error", err))
+	}
+	return res, nil
+}
+
+// resolveKey picks the key from the header or the request field, which must
+// agree if both are given
+func resolveKey(header, field string) (string, error) {
+	if header != "" && field != "" && header != field {
+		return "", fmt.Errorf("%s header and %s differ", KeyHeader, keyField)
+	}
+	key := header
+	if key == "" {
+		key = field
+	}
+	if len(key) > maxKeyLength {
+		return "", fmt.Errorf("idempotency key is longer than %d characters", maxKeyLength)
+	}
+	return key, nil
+}
+
+// hashRequest returns the SHA-256 of the procedure and the deterministically
+// serialized request, leaving out the key itself so a key sent in the field
+// matches the same key sent in the header
+func hashRequest(req connect.AnyRequest) ([]byte, error) {
+	msg, ok := req.Any().(proto.Message)
+	if !ok {
+		return nil, errors.New("request is not a protobuf message")
+	}
+	msg = proto.Clone(msg)
+	reflected := msg.ProtoReflect()
+	if fd := reflected.Descriptor().Fields().ByName(keyField); fd != nil {
+		reflected.Clear(fd)
+	}
+
+	serialized, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	hash.Write([]byte(req.Spec().Procedure))
+	hash.Write([]byte{0})
+	hash.Write(serialized)
+	return hash.Sum(nil), nil
+}
+
+// replay rebuilds a stored response
+func replay[Res any](stored []byte) (*connect.Response[Res], error) {
+	msg := new(Res)
+	if err := proto.Unmarshal(stored, any(msg).(proto.Message)); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to replay response: %w", err))
+	}
+	res := connect.NewResponse(msg)
+	res.Header().Set(ReplayedHeader, "true")
+	return res, nil
+}
+
+// mapErrorToConnectError maps the errors of claiming a key to Connect errors
+func mapErrorToConnectError(err error) error {
+	switch {
+	case errors.Is(err, appidempotency.ErrKeyReused):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, appidempotency.ErrInProgress):
+		return connect.NewError(connect.CodeAborted, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
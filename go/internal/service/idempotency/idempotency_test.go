@@ -0,0 +1,172 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/suite"
+
+	appidempotency "github.com/mcdev12/lumo/go/internal/app/idempotency"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	pb "github.com/mcdev12/lumo/go/internal/genproto/lume/v1"
+)
+
+// storedKey is a key remembered by memoryStore
+type storedKey struct {
+	hash     []byte
+	response []byte
+}
+
+// memoryStore keeps keys in memory, without expiry
+type memoryStore struct {
+	keys map[string]*storedKey
+}
+
+// Begin claims a free key or returns the stored response
+func (m *memoryStore) Begin(_ context.Context, scope, key string, requestHash []byte) ([]byte, error) {
+	stored, ok := m.keys[scope+"/"+key]
+	switch {
+	case !ok:
+		m.keys[scope+"/"+key] = &storedKey{hash: requestHash}
+		return nil, nil
+	case string(stored.hash) != string(requestHash):
+		return nil, appidempotency.ErrKeyReused
+	case stored.response == nil:
+		return nil, appidempotency.ErrInProgress
+	}
+	return stored.response, nil
+}
+
+// Complete stores the response
+func (m *memoryStore) Complete(_ context.Context, scope, key string, _, response []byte) error {
+	m.keys[scope+"/"+key].response = response
+	return nil
+}
+
+// Release frees the key
+func (m *memoryStore) Release(_ context.Context, scope, key string, _ []byte) error {
+	delete(m.keys, scope+"/"+key)
+	return nil
+}
+
+// IdempotencyTestSuite is a test suite for Do
+type IdempotencyTestSuite struct {
+	suite.Suite
+	store *memoryStore
+	calls int
+}
+
+// SetupTest is called before each test
+func (s *IdempotencyTestSuite) SetupTest() {
+	s.store = &memoryStore{keys: map[string]*storedKey{}}
+	s.calls = 0
+}
+
+// TestIdempotencySuite runs the test suite
+func TestIdempotencySuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyTestSuite))
+}
+
+// do calls Do with a create function that counts its calls and fails with err
+func (s *IdempotencyTestSuite) do(ctx context.Context, req *connect.Request[pb.CreateLumeRequest], err error) (*connect.Response[pb.CreateLumeResponse], error) {
+	return Do(ctx, s.store, req, req.Msg.GetIdempotencyKey(), func() (*connect.Response[pb.CreateLumeResponse], error) {
+		s.calls++
+		if err != nil {
+			return nil, err
+		}
+		return connect.NewResponse(&pb.CreateLumeResponse{}), nil
+	})
+}
+
+// Test a retry with the same key gets the stored response, whether the key
+// was sent in the header or the request field
+func (s *IdempotencyTestSuite) TestReplay() {
+	// Arrange
+	ctx := context.Background()
+	first := connect.NewRequest(&pb.CreateLumeRequest{Name: "Louvre", IdempotencyKey: "key-1"})
+	retry := connect.NewRequest(&pb.CreateLumeRequest{Name: "Louvre"})
+	retry.Header().Set(KeyHeader, "key-1")
+
+	// Act
+	res1, err1 := s.do(ctx, first, nil)
+	res2, err2 := s.do(ctx, retry, nil)
+
+	// Assert
+	s.NoError(err1)
+	s.Empty(res1.Header().Get(ReplayedHeader))
+	s.NoError(err2)
+	s.Equal("true", res2.Header().Get(ReplayedHeader))
+	s.Equal(1, s.calls)
+}
+
+// Test a key can't be reused for a different request
+func (s *IdempotencyTestSuite) TestKeyReused() {
+	// Arrange
+	ctx := context.Background()
+	_, err := s.do(ctx, connect.NewRequest(&pb.CreateLumeRequest{Name: "Louvre", IdempotencyKey: "key-1"}), nil)
+	s.Require().NoError(err)
+
+	// Act
+	_, err = s.do(ctx, connect.NewRequest(&pb.CreateLumeRequest{Name: "Orsay", IdempotencyKey: "key-1"}), nil)
+
+	// Assert
+	s.Equal(connect.CodeFailedPrecondition, connect.CodeOf(err))
+	s.Equal(1, s.calls)
+}
+
+// Test failed calls free the key so the retry runs again
+func (s *IdempotencyTestSuite) TestFailedCallReleasesKey() {
+	// Arrange
+	ctx := context.Background()
+	req := connect.NewRequest(&pb.CreateLumeRequest{Name: "Louvre", IdempotencyKey: "key-1"})
+	failure := connect.NewError(connect.CodeUnavailable, errors.New("database is down"))
+
+	// Act
+	_, err1 := s.do(ctx, req, failure)
+	_, err2 := s.do(ctx, req, nil)
+
+	// Assert
+	s.Equal(connect.CodeUnavailable, connect.CodeOf(err1))
+	s.NoError(err2)
+	s.Equal(2, s.calls)
+}
+
+// Test keys belong to the calling user
+func (s *IdempotencyTestSuite) TestKeysAreScopedToUsers() {
+	// Arrange
+	ctxAlice := auth.WithIdentity(context.Background(), &auth.Identity{UserID: "alice"})
+	ctxBob := auth.WithIdentity(context.Background(), &auth.Identity{UserID: "bob"})
+	req := connect.NewRequest(&pb.CreateLumeRequest{Name: "Louvre", IdempotencyKey: "key-1"})
+
+	// Act
+	_, err1 := s.do(ctxAlice, req, nil)
+	res, err2 := s.do(ctxBob, req, nil)
+
+	// Assert
+	s.NoError(err1)
+	s.NoError(err2)
+	s.Empty(res.Header().Get(ReplayedHeader))
+	s.Equal(2, s.calls)
+}
+
+// Test calls without a key always run, and conflicting keys are rejected
+func (s *IdempotencyTestSuite) TestKeys() {
+	// Arrange
+	ctx := context.Background()
+	conflicting := connect.NewRequest(&pb.CreateLumeRequest{IdempotencyKey: "key-1"})
+	conflicting.Header().Set(KeyHeader, "key-2")
+
+	// Act
+	_, err1 := s.do(ctx, connect.NewRequest(&pb.CreateLumeRequest{}), nil)
+	_, err2 := s.do(ctx, connect.NewRequest(&pb.CreateLumeRequest{}), nil)
+	_, err3 := s.do(ctx, conflicting, nil)
+
+	// Assert
+	s.NoError(err1)
+	s.NoError(err2)
+	s.Equal(connect.CodeInvalidArgument, connect.CodeOf(err3))
+	s.Equal(2, s.calls)
+	s.Empty(s.store.keys)
+}
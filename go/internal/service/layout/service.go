@@ -0,0 +1,142 @@
+package layout
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	applayout "github.com/mcdev12/lumo/go/internal/app/layout"
+	pb "github.com/mcdev12/lumo/go/internal/genproto/layout/v1"
+	modellayout "github.com/mcdev12/lumo/go/internal/models/layout"
+)
+
+// LayoutApp defines what the service layer needs from the app layer
+type LayoutApp interface {
+	GetLayout(ctx context.Context, lumoID string) (*modellayout.Layout, error)
+	SaveLayout(ctx context.Context, req applayout.SaveLayoutRequest) (*modellayout.Layout, error)
+	AutoLayout(ctx context.Context, req applayout.AutoLayoutRequest) ([]*modellayout.NodeLayout, error)
+}
+
+// Service implements the LayoutServiceHandler interface
+type Service struct {
+	app LayoutApp
+}
+
+// NewService creates a new Layout service
+func NewService(app LayoutApp) *Service {
+	return &Service{
+		app: app,
+	}
+}
+
+// GetLayout retrieves the saved canvas layout of a Lumo
+func (s *Service) GetLayout(ctx context.Context, req *connect.Request[pb.GetLayoutRequest]) (*connect.Response[pb.GetLayoutResponse], error) {
+	domainLayout, err := s.app.GetLayout(ctx, req.Msg.GetLumoId())
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.GetLayoutResponse{
+		Layout: modellayout.DomainToProto(domainLayout),
+	}), nil
+}
+
+// SaveLayout saves a batch of node placements and/or the viewport of a Lumo
+func (s *Service) SaveLayout(ctx context.Context, req *connect.Request[pb.SaveLayoutRequest]) (*connect.Response[pb.SaveLayoutResponse], error) {
+	pbRequest := req.Msg
+	if pbRequest == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("request is empty"))
+	}
+
+	saved, err := s.app.SaveLayout(ctx, s.toAppSaveRequest(pbRequest))
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	resp := &pb.SaveLayoutResponse{
+		Nodes: modellayout.NodesToProto(saved.Nodes),
+	}
+	if saved.Viewport != nil {
+		resp.Viewport = modellayout.ViewportToProto(saved.Viewport)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// AutoLayout computes node placements for a Lumo's graph, optionally saving them
+func (s *Service) AutoLayout(ctx context.Context, req *connect.Request[pb.AutoLayoutRequest]) (*connect.Response[pb.AutoLayoutResponse], error) {
+	pbRequest := req.Msg
+	if pbRequest == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("request is empty"))
+	}
+
+	nodes, err := s.app.AutoLayout(ctx, applayout.AutoLayoutRequest{
+		LumoID:    pbRequest.GetLumoId(),
+		Algorithm: modellayout.ProtoAlgorithmToDomain(pbRequest.GetAlgorithm()),
+		Persist:   pbRequest.GetPersist(),
+	})
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.AutoLayoutResponse{
+		Nodes: modellayout.NodesToProto(nodes),
+	}), nil
+}
+
+// toAppSaveRequest converts a protobuf SaveLayoutRequest to an app SaveLayoutRequest
+func (s *Service) toAppSaveRequest(pbRequest *pb.SaveLayoutRequest) applayout.SaveLayoutRequest {
+	nodes := make([]applayout.NodeLayoutUpdate, len(pbRequest.GetNodes()))
+	for i, pbNode := range pbRequest.GetNodes() {
+		nodes[i] = applayout.NodeLayoutUpdate{
+			LumeID:    pbNode.GetLumeId(),
+			X:         pbNode.X,
+			Y:         pbNode.Y,
+			Width:     pbNode.Width,
+			Height:    pbNode.Height,
+			Collapsed: pbNode.Collapsed,
+		}
+	}
+
+	appReq := applayout.SaveLayoutRequest{
+		LumoID: pbRequest.GetLumoId(),
+		Nodes:  nodes,
+	}
+
+	if pbViewport := pbRequest.GetViewport(); pbViewport != nil {
+		appReq.Viewport = &applayout.ViewportUpdate{
+			X:    pbViewport.GetX(),
+			Y:    pbViewport.GetY(),
+			Zoom: pbViewport.GetZoom(),
+		}
+	}
+
+	return appReq
+}
+
+// mapErrorToConnectError maps domain errors to Connect errors
+func (s *Service) mapErrorToConnectError(err error) error {
+	switch {
+	case errors.Is(err, applayout.ErrInvalidLumoID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applayout.ErrInvalidLumeID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applayout.ErrLumeNotInLumo):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applayout.ErrInvalidSize):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applayout.ErrInvalidZoom):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applayout.ErrTooManyNodes):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applayout.ErrInvalidAlgorithm):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applayout.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, applayout.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
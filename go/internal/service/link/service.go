@@ -10,6 +10,7 @@ import (
 	applink "github.com/mcdev12/lumo/go/internal/app/link"
 	pb "github.com/mcdev12/lumo/go/internal/genproto/link/v1"
 	modellink "github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/service/idempotency"
 )
 
 // Domain errors
@@ -25,28 +26,38 @@ type LinkApp interface {
 	ListLinksByFromLumeID(ctx context.Context, fromLumeID string, req applink.ListLinksRequest) ([]*modellink.Link, error)
 	ListLinksByToLumeID(ctx context.Context, toLumeID string, req applink.ListLinksRequest) ([]*modellink.Link, error)
 	ListLinksByEitherLumeID(ctx context.Context, lumeID string, req applink.ListLinksRequest) ([]*modellink.Link, error)
+	ListLinksByLumoID(ctx context.Context, lumoID string, req applink.ListLinksRequest) ([]*modellink.Link, error)
 	UpdateLink(ctx context.Context, id int64, req applink.UpdateLinkRequest) (*modellink.Link, error)
 	UpdateLinkByLinkID(ctx context.Context, linkID string, req applink.UpdateLinkRequest) (*modellink.Link, error)
-	DeleteLink(ctx context.Context, id int64) error
-	DeleteLinkByLinkID(ctx context.Context, linkID string) error
+	DeleteLink(ctx context.Context, id int64, expectedVersion *int64) error
+	DeleteLinkByLinkID(ctx context.Context, linkID string, expectedVersion *int64) error
 	CountLinksByLumeID(ctx context.Context, lumeID string) (int64, error)
 }
 
 // Service implements the LinkServiceHandler interface
 type Service struct {
-	app LinkApp
+	app         LinkApp
+	idempotency idempotency.Store
 }
 
 // NewService creates a new Link service
-func NewService(app LinkApp) *Service {
+func NewService(app LinkApp, idempotency idempotency.Store) *Service {
 	return &Service{
-		app: app,
+		app:         app,
+		idempotency: idempotency,
 	}
 }
 
-// CreateLink creates a new Link
+// CreateLink creates a new Link. Retries with the same idempotency key get the
+// response of the first call.
 func (s *Service) CreateLink(ctx context.Context, req *connect.Request[pb.CreateLinkRequest]) (*connect.Response[pb.CreateLinkResponse], error) {
-	pbLinkCreate := req.Msg
+	return idempotency.Do(ctx, s.idempotency, req, req.Msg.GetIdempotencyKey(), func() (*connect.Response[pb.CreateLinkResponse], error) {
+		return s.createLink(ctx, req.Msg)
+	})
+}
+
+// createLink creates a new Link
+func (s *Service) createLink(ctx context.Context, pbLinkCreate *pb.CreateLinkRequest) (*connect.Response[pb.CreateLinkResponse], error) {
 	if pbLinkCreate == nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("link is required"))
 	}
@@ -134,10 +145,10 @@ func (s *Service) DeleteLink(ctx context.Context, req *connect.Request[pb.Delete
 
 	if id, parseErr := strconv.ParseInt(linkID, 10, 64); parseErr == nil {
 		// It's an internal ID
-		err = s.app.DeleteLink(ctx, id)
+		err = s.app.DeleteLink(ctx, id, req.Msg.ExpectedVersion)
 	} else {
 		// Try as UUID string
-		err = s.app.DeleteLinkByLinkID(ctx, linkID)
+		err = s.app.DeleteLinkByLinkID(ctx, linkID, req.Msg.ExpectedVersion)
 	}
 
 	if err != nil {
@@ -199,9 +210,7 @@ func (s *Service) ListLinks(ctx context.Context, req *connect.Request[pb.ListLin
 	} else if toLumeID != "" {
 		domainLinks, err = s.app.ListLinksByToLumeID(ctx, toLumeID, appReq)
 	} else if lumoUUID != "" {
-		// This would require a different query that's not directly supported
-		// For now, we'll just return an error
-		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("filtering by lumo_uuid is not implemented"))
+		domainLinks, err = s.app.ListLinksByLumoID(ctx, lumoUUID, appReq)
 	} else {
 		// No filters, return an error as we don't want to return all links
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one filter is required"))
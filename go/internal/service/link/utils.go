@@ -2,12 +2,14 @@ package link
 
 import (
 	"errors"
+	"strconv"
 
 	"connectrpc.com/connect"
 
 	applink "github.com/mcdev12/lumo/go/internal/app/link"
 	pb "github.com/mcdev12/lumo/go/internal/genproto/link/v1"
 	modellink "github.com/mcdev12/lumo/go/internal/models/link"
+	"github.com/mcdev12/lumo/go/internal/models/version"
 )
 
 // toAppCreateRequest converts a protobuf Link to an app CreateLinkRequest
@@ -34,6 +36,7 @@ func (s *Service) toAppCreateRequest(pbLink *pb.CreateLinkRequest) (applink.Crea
 			DurationSec:    pbLink.GetTravel().GetDurationSec(),
 			CostEstimate:   pbLink.GetTravel().GetCostEstimate(),
 			DistanceMeters: pbLink.GetTravel().GetDistanceMeters(),
+			Currency:       pbLink.GetTravel().GetCurrency(),
 		}
 	}
 
@@ -71,6 +74,7 @@ func (s *Service) toAppUpdateRequest(pbLink *pb.UpdateLinkRequest) (applink.Upda
 			DurationSec:    pbLink.GetTravel().GetDurationSec(),
 			CostEstimate:   pbLink.GetTravel().GetCostEstimate(),
 			DistanceMeters: pbLink.GetTravel().GetDistanceMeters(),
+			Currency:       pbLink.GetTravel().GetCurrency(),
 		}
 	}
 
@@ -87,6 +91,8 @@ func (s *Service) toAppUpdateRequest(pbLink *pb.UpdateLinkRequest) (applink.Upda
 		SequenceIndex: sequenceIndex,
 		TravelDetails: travelDetails,
 		UpdateFields:  updateFields,
+
+		ExpectedVersion: pbLink.ExpectedVersion,
 	}, nil
 }
 
@@ -99,15 +105,37 @@ func (s *Service) mapErrorToConnectError(err error) error {
 		return connect.NewError(connect.CodeInvalidArgument, err)
 	case errors.Is(err, applink.ErrInvalidLumeID):
 		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applink.ErrInvalidLumoID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
 	case errors.Is(err, applink.ErrInvalidLinkType):
 		return connect.NewError(connect.CodeInvalidArgument, err)
 	case errors.Is(err, applink.ErrInvalidTravelMode):
 		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applink.ErrInvalidCurrency):
+		return connect.NewError(connect.CodeInvalidArgument, err)
 	case errors.Is(err, applink.ErrEmptyNotes):
 		return connect.NewError(connect.CodeInvalidArgument, err)
 	case errors.Is(err, ErrInvalidID):
 		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applink.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, applink.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	case errors.Is(err, applink.ErrCrossLumoLink):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applink.ErrVersionConflict):
+		return versionConflictError(err)
 	default:
 		return connect.NewError(connect.CodeInternal, err)
 	}
 }
+
+// versionConflictError reports a version mismatch as Aborted, with the current
+// version in the error metadata so the client can re-read and retry
+func versionConflictError(err error) error {
+	connectErr := connect.NewError(connect.CodeAborted, err)
+	if current, ok := version.Current(err); ok {
+		connectErr.Meta().Set(version.MetadataKey, strconv.FormatInt(current, 10))
+	}
+	return connectErr
+}
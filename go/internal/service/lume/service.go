@@ -9,6 +9,7 @@ import (
 	applume "github.com/mcdev12/lumo/go/internal/app/lume"
 	pb "github.com/mcdev12/lumo/go/internal/genproto/lume/v1"
 	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/service/idempotency"
 )
 
 var (
@@ -25,26 +26,35 @@ type LumeApp interface {
 	SearchLumesByLocation(ctx context.Context, req applume.SearchLumesByLocationRequest) ([]*modellume.Lume, error)
 	UpdateLume(ctx context.Context, id int64, req applume.UpdateLumeRequest) (*modellume.Lume, error)
 	UpdateLumeByLumeID(ctx context.Context, lumeID string, req applume.UpdateLumeRequest) (*modellume.Lume, error)
-	DeleteLume(ctx context.Context, id int64) error
-	DeleteLumeByLumeID(ctx context.Context, lumeID string) error
+	DeleteLume(ctx context.Context, id int64, expectedVersion *int64) error
+	DeleteLumeByLumeID(ctx context.Context, lumeID string, expectedVersion *int64) error
 	CountLumesByLumo(ctx context.Context, lumoID string) (int64, error)
 }
 
 // Service implements the LumeServiceHandler interface
 type Service struct {
-	app LumeApp
+	app         LumeApp
+	idempotency idempotency.Store
 }
 
 // NewService creates a new Lume service
-func NewService(app LumeApp) *Service {
+func NewService(app LumeApp, idempotency idempotency.Store) *Service {
 	return &Service{
-		app: app,
+		app:         app,
+		idempotency: idempotency,
 	}
 }
 
-// CreateLume creates a new Lume
+// CreateLume creates a new Lume. Retries with the same idempotency key get the
+// response of the first call.
 func (s *Service) CreateLume(ctx context.Context, req *connect.Request[pb.CreateLumeRequest]) (*connect.Response[pb.CreateLumeResponse], error) {
-	pbRequest := req.Msg
+	return idempotency.Do(ctx, s.idempotency, req, req.Msg.GetIdempotencyKey(), func() (*connect.Response[pb.CreateLumeResponse], error) {
+		return s.createLume(ctx, req.Msg)
+	})
+}
+
+// createLume creates a new Lume
+func (s *Service) createLume(ctx context.Context, pbRequest *pb.CreateLumeRequest) (*connect.Response[pb.CreateLumeResponse], error) {
 	if pbRequest == nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("request is empty"))
 	}
@@ -60,7 +70,7 @@ func (s *Service) CreateLume(ctx context.Context, req *connect.Request[pb.Create
 	}
 
 	return connect.NewResponse(&pb.CreateLumeResponse{
-		Lume: domainToProto(domainLume),
+		Lume: modellume.DomainToProto(domainLume),
 	}), nil
 }
 
@@ -84,7 +94,7 @@ func (s *Service) GetLume(ctx context.Context, req *connect.Request[pb.GetLumeRe
 	}
 
 	return connect.NewResponse(&pb.GetLumeResponse{
-		Lume: domainToProto(domainLume),
+		Lume: modellume.DomainToProto(domainLume),
 	}), nil
 }
 
@@ -136,7 +146,7 @@ func (s *Service) ListLumes(ctx context.Context, req *connect.Request[pb.ListLum
 
 	pbLumes := make([]*pb.Lume, len(domainLumes))
 	for i, domainLume := range domainLumes {
-		pbLumes[i] = domainToProto(domainLume)
+		pbLumes[i] = modellume.DomainToProto(domainLume)
 
 	}
 
@@ -171,7 +181,7 @@ func (s *Service) UpdateLume(ctx context.Context, req *connect.Request[pb.Update
 	}
 
 	return connect.NewResponse(&pb.UpdateLumeResponse{
-		Lume: domainToProto(domainLume),
+		Lume: modellume.DomainToProto(domainLume),
 	}), nil
 }
 
@@ -184,10 +194,10 @@ func (s *Service) DeleteLume(ctx context.Context, req *connect.Request[pb.Delete
 
 	if id, parseErr := strconv.ParseInt(requestID, 10, 64); parseErr == nil {
 		// It's an internal ID
-		err = s.app.DeleteLume(ctx, id)
+		err = s.app.DeleteLume(ctx, id, req.Msg.ExpectedVersion)
 	} else {
 		// Try as UUID string
-		err = s.app.DeleteLumeByLumeID(ctx, requestID)
+		err = s.app.DeleteLumeByLumeID(ctx, requestID, req.Msg.ExpectedVersion)
 	}
 
 	if err != nil {
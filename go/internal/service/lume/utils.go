@@ -2,59 +2,16 @@ package lume
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
-	"google.golang.org/protobuf/types/known/timestamppb"
-
 	"connectrpc.com/connect"
 	applume "github.com/mcdev12/lumo/go/internal/app/lume"
 	lumepb "github.com/mcdev12/lumo/go/internal/genproto/lume/v1"
 	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+	"github.com/mcdev12/lumo/go/internal/models/version"
 )
 
-// domainToProto converts domain Lume to protobuf Lume
-func domainToProto(domainLume *modellume.Lume) *lumepb.Lume {
-	proto := &lumepb.Lume{
-		LumeId:       domainLume.LumeID,
-		LumoId:       domainLume.LumoID,
-		Type:         domainLumeTypeToProto(domainLume.Type),
-		Name:         domainLume.Name,
-		Description:  domainLume.Description,
-		Images:       domainLume.Images,
-		CategoryTags: domainLume.CategoryTags,
-		CreatedAt:    timestamppb.New(domainLume.CreatedAt),
-		UpdatedAt:    timestamppb.New(domainLume.UpdatedAt),
-	}
-
-	// Handle optional timestamps
-	if domainLume.DateStart != nil {
-		proto.DateStart = timestamppb.New(*domainLume.DateStart)
-	}
-	if domainLume.DateEnd != nil {
-		proto.DateEnd = timestamppb.New(*domainLume.DateEnd)
-	}
-
-	// Handle optional coordinates
-	if domainLume.Latitude != nil {
-		proto.Latitude = *domainLume.Latitude
-	}
-	if domainLume.Longitude != nil {
-		proto.Longitude = *domainLume.Longitude
-	}
-
-	// Handle optional address
-	if domainLume.Address != nil {
-		proto.Address = *domainLume.Address
-	}
-
-	// Handle optional booking link
-	if domainLume.BookingLink != nil {
-		proto.BookingLink = *domainLume.BookingLink
-	}
-
-	return proto
-}
-
 // toAppCreateRequest converts a protobuf Lume to an app CreateLumeRequest
 func (s *Service) toAppCreateRequest(pbLume *lumepb.CreateLumeRequest) (applume.CreateLumeRequest, error) {
 	// Convert timestamps to time.Time pointers
@@ -102,6 +59,7 @@ func (s *Service) toAppCreateRequest(pbLume *lumepb.CreateLumeRequest) (applume.
 		Images:       pbLume.GetImages(),
 		CategoryTags: pbLume.GetCategoryTags(),
 		BookingLink:  bookingLink,
+		TimeZone:     pbLume.GetTimeZone(),
 	}, nil
 }
 
@@ -164,34 +122,11 @@ func (s *Service) toAppUpdateRequest(pbLume *lumepb.UpdateLumeRequest) (applume.
 		Images:       pbLume.GetImages(),
 		CategoryTags: pbLume.GetCategoryTags(),
 		BookingLink:  bookingLink,
+		TimeZone:     pbLume.GetTimeZone(),
 		UpdateFields: updateFields,
-	}, nil
-}
 
-// domainLumeTypeToProto LumeType conversion
-func domainLumeTypeToProto(dt modellume.LumeType) lumepb.LumeType {
-	switch dt {
-	case modellume.LumeTypeCity:
-		return lumepb.LumeType_LUME_TYPE_CITY
-	case modellume.LumeTypeAttraction:
-		return lumepb.LumeType_LUME_TYPE_ATTRACTION
-	case modellume.LumeTypeAccommodation:
-		return lumepb.LumeType_LUME_TYPE_ACCOMMODATION
-	case modellume.LumeTypeRestaurant:
-		return lumepb.LumeType_LUME_TYPE_RESTAURANT
-	case modellume.LumeTypeTransportHub:
-		return lumepb.LumeType_LUME_TYPE_TRANSPORT_HUB
-	case modellume.LumeTypeActivity:
-		return lumepb.LumeType_LUME_TYPE_ACTIVITY
-	case modellume.LumeTypeShopping:
-		return lumepb.LumeType_LUME_TYPE_SHOPPING
-	case modellume.LumeTypeEntertainment:
-		return lumepb.LumeType_LUME_TYPE_ENTERTAINMENT
-	case modellume.LumeTypeCustom:
-		return lumepb.LumeType_LUME_TYPE_CUSTOM
-	default:
-		return lumepb.LumeType_LUME_TYPE_UNSPECIFIED
-	}
+		ExpectedVersion: pbLume.ExpectedVersion,
+	}, nil
 }
 
 // mapErrorToConnectError maps domain errors to Connect errors
@@ -209,9 +144,29 @@ func mapErrorToConnectError(err error) error {
 		return connect.NewError(connect.CodeInvalidArgument, err)
 	case errors.Is(err, applume.ErrInvalidMetadata):
 		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applume.ErrInvalidTimeZone):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applume.ErrTooManyLumes):
+		return connect.NewError(connect.CodeResourceExhausted, err)
 	case errors.Is(err, ErrInvalidID):
 		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applume.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, applume.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	case errors.Is(err, applume.ErrVersionConflict):
+		return versionConflictError(err)
 	default:
 		return connect.NewError(connect.CodeInternal, err)
 	}
 }
+
+// versionConflictError reports a version mismatch as Aborted, with the current
+// version in the error metadata so the client can re-read and retry
+func versionConflictError(err error) error {
+	connectErr := connect.NewError(connect.CodeAborted, err)
+	if current, ok := version.Current(err); ok {
+		connectErr.Meta().Set(version.MetadataKey, strconv.FormatInt(current, 10))
+	}
+	return connectErr
+}
@@ -8,8 +8,11 @@ import (
 	"connectrpc.com/connect"
 
 	applumo "github.com/mcdev12/lumo/go/internal/app/lumo"
+	"github.com/mcdev12/lumo/go/internal/auth"
 	pb "github.com/mcdev12/lumo/go/internal/genproto/lumo/v1"
 	modellumo "github.com/mcdev12/lumo/go/internal/models/lumo"
+	"github.com/mcdev12/lumo/go/internal/models/version"
+	"github.com/mcdev12/lumo/go/internal/service/idempotency"
 )
 
 // Domain errors
@@ -25,32 +28,52 @@ type LumoApp interface {
 	ListLumosByUserID(ctx context.Context, req applumo.ListLumosRequest) ([]*modellumo.Lumo, error)
 	UpdateLumo(ctx context.Context, id int64, req applumo.UpdateLumoRequest) (*modellumo.Lumo, error)
 	UpdateLumoByLumoID(ctx context.Context, lumoID string, req applumo.UpdateLumoRequest) (*modellumo.Lumo, error)
-	DeleteLumo(ctx context.Context, id int64) error
-	DeleteLumoByLumoID(ctx context.Context, lumoID string) error
+	DeleteLumo(ctx context.Context, id int64, expectedVersion *int64) error
+	DeleteLumoByLumoID(ctx context.Context, lumoID string, expectedVersion *int64) error
 	CountLumosByUserID(ctx context.Context, userID string) (int64, error)
+	ShareLumo(ctx context.Context, req applumo.ShareLumoRequest) (*modellumo.Member, error)
+	ListMembers(ctx context.Context, req applumo.ListMembersRequest) ([]*modellumo.Member, error)
+	UpdateMemberRole(ctx context.Context, req applumo.UpdateMemberRoleRequest) (*modellumo.Member, error)
+	RemoveMember(ctx context.Context, lumoID, userID string) error
 }
 
 // Service implements the LumoServiceHandler interface
 type Service struct {
-	app LumoApp
+	app         LumoApp
+	idempotency idempotency.Store
 }
 
 // NewService creates a new Lumo service
-func NewService(app LumoApp) *Service {
+func NewService(app LumoApp, idempotency idempotency.Store) *Service {
 	return &Service{
-		app: app,
+		app:         app,
+		idempotency: idempotency,
 	}
 }
 
-// CreateLumo creates a new Lumo
+// CreateLumo creates a new Lumo. Retries with the same idempotency key get the
+// response of the first call.
 func (s *Service) CreateLumo(ctx context.Context, req *connect.Request[pb.CreateLumoRequest]) (*connect.Response[pb.CreateLumoResponse], error) {
-	pbLumo := req.Msg.GetLumo()
+	return idempotency.Do(ctx, s.idempotency, req, req.Msg.GetIdempotencyKey(), func() (*connect.Response[pb.CreateLumoResponse], error) {
+		return s.createLumo(ctx, req.Msg)
+	})
+}
+
+// createLumo creates a new Lumo
+func (s *Service) createLumo(ctx context.Context, pbRequest *pb.CreateLumoRequest) (*connect.Response[pb.CreateLumoResponse], error) {
+	pbLumo := pbRequest.GetLumo()
 	if pbLumo == nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("lumo is required"))
 	}
 
+	// The owner is the caller, whatever user the request names
+	userID, err := auth.ResolveUserID(ctx, pbLumo.GetUserId())
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
 	appReq := applumo.CreateLumoRequest{
-		UserID: pbLumo.GetUserId(),
+		UserID: userID,
 		Title:  pbLumo.GetTitle(),
 	}
 
@@ -110,9 +133,14 @@ func (s *Service) ListLumos(ctx context.Context, req *connect.Request[pb.ListLum
 		offset = int32(parsedOffset)
 	}
 
-	// List all lumos for the user
+	// List all lumos for the user, who is the caller when authenticated
+	userID, err := auth.ResolveUserID(ctx, req.Msg.GetUserId())
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
 	listReq := applumo.ListLumosRequest{
-		UserID: req.Msg.GetUserId(),
+		UserID: userID,
 		Limit:  limit,
 		Offset: offset,
 	}
@@ -146,7 +174,8 @@ func (s *Service) UpdateLumo(ctx context.Context, req *connect.Request[pb.Update
 	}
 
 	appReq := applumo.UpdateLumoRequest{
-		Title: pbLumo.GetTitle(),
+		Title:           pbLumo.GetTitle(),
+		ExpectedVersion: req.Msg.ExpectedVersion,
 	}
 
 	// Use LumoId (UUID) for updates, not internal ID
@@ -171,10 +200,10 @@ func (s *Service) DeleteLumo(ctx context.Context, req *connect.Request[pb.Delete
 
 	if id, parseErr := strconv.ParseInt(requestID, 10, 64); parseErr == nil {
 		// It's an internal ID
-		err = s.app.DeleteLumo(ctx, id)
+		err = s.app.DeleteLumo(ctx, id, req.Msg.ExpectedVersion)
 	} else {
 		// Try as UUID string
-		err = s.app.DeleteLumoByLumoID(ctx, requestID)
+		err = s.app.DeleteLumoByLumoID(ctx, requestID, req.Msg.ExpectedVersion)
 	}
 
 	if err != nil {
@@ -184,20 +213,137 @@ func (s *Service) DeleteLumo(ctx context.Context, req *connect.Request[pb.Delete
 	return connect.NewResponse(&pb.DeleteLumoResponse{}), nil
 }
 
+// ShareLumo makes a user a member of a Lumo
+func (s *Service) ShareLumo(ctx context.Context, req *connect.Request[pb.ShareLumoRequest]) (*connect.Response[pb.ShareLumoResponse], error) {
+	appReq := applumo.ShareLumoRequest{
+		LumoID: req.Msg.GetLumoId(),
+		UserID: req.Msg.GetUserId(),
+		Role:   modellumo.ProtoRoleToDomain(req.Msg.GetRole()),
+	}
+
+	domainMember, err := s.app.ShareLumo(ctx, appReq)
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.ShareLumoResponse{
+		Member: modellumo.MemberToProto(domainMember),
+	}), nil
+}
+
+// ListMembers retrieves the members of a Lumo
+func (s *Service) ListMembers(ctx context.Context, req *connect.Request[pb.ListMembersRequest]) (*connect.Response[pb.ListMembersResponse], error) {
+	// Convert page_size to limit and page_token to offset
+	limit := req.Msg.GetPageSize()
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+
+	offset := int32(0)
+	if req.Msg.GetPageToken() != "" {
+		parsedOffset, err := strconv.ParseInt(req.Msg.GetPageToken(), 10, 32)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid page token"))
+		}
+		offset = int32(parsedOffset)
+	}
+
+	listReq := applumo.ListMembersRequest{
+		LumoID: req.Msg.GetLumoId(),
+		Limit:  limit,
+		Offset: offset,
+	}
+	domainMembers, err := s.app.ListMembers(ctx, listReq)
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	pbMembers := make([]*pb.LumoMember, len(domainMembers))
+	for i, domainMember := range domainMembers {
+		pbMembers[i] = modellumo.MemberToProto(domainMember)
+	}
+
+	// Calculate next page token
+	var nextPageToken string
+	if len(pbMembers) == int(limit) {
+		nextPageToken = strconv.FormatInt(int64(offset+limit), 10)
+	}
+
+	return connect.NewResponse(&pb.ListMembersResponse{
+		Members:       pbMembers,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// UpdateMemberRole changes the role of a member
+func (s *Service) UpdateMemberRole(ctx context.Context, req *connect.Request[pb.UpdateMemberRoleRequest]) (*connect.Response[pb.UpdateMemberRoleResponse], error) {
+	appReq := applumo.UpdateMemberRoleRequest{
+		LumoID: req.Msg.GetLumoId(),
+		UserID: req.Msg.GetUserId(),
+		Role:   modellumo.ProtoRoleToDomain(req.Msg.GetRole()),
+	}
+
+	domainMember, err := s.app.UpdateMemberRole(ctx, appReq)
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.UpdateMemberRoleResponse{
+		Member: modellumo.MemberToProto(domainMember),
+	}), nil
+}
+
+// RemoveMember stops sharing a Lumo with a user
+func (s *Service) RemoveMember(ctx context.Context, req *connect.Request[pb.RemoveMemberRequest]) (*connect.Response[pb.RemoveMemberResponse], error) {
+	if err := s.app.RemoveMember(ctx, req.Msg.GetLumoId(), req.Msg.GetUserId()); err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.RemoveMemberResponse{}), nil
+}
+
 // mapErrorToConnectError maps domain errors to Connect errors
 func (s *Service) mapErrorToConnectError(err error) error {
 	switch {
 	case errors.Is(err, applumo.ErrLumoNotFound):
 		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, applumo.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
 	case errors.Is(err, applumo.ErrInvalidUserID):
 		return connect.NewError(connect.CodeInvalidArgument, err)
 	case errors.Is(err, applumo.ErrInvalidLumoID):
 		return connect.NewError(connect.CodeInvalidArgument, err)
 	case errors.Is(err, applumo.ErrEmptyTitle):
 		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applumo.ErrInvalidRole):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applumo.ErrCreatorRole):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, applumo.ErrTooManyLumos):
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	case errors.Is(err, applumo.ErrMemberNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, applumo.ErrAlreadyMember):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, applumo.ErrUserNotFound):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
 	case errors.Is(err, ErrInvalidID):
 		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, applumo.ErrVersionConflict):
+		return versionConflictError(err)
+	case errors.Is(err, auth.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
 	default:
 		return connect.NewError(connect.CodeInternal, err)
 	}
 }
+
+// versionConflictError reports a version mismatch as Aborted, with the current
+// version in the error metadata so the client can re-read and retry
+func versionConflictError(err error) error {
+	connectErr := connect.NewError(connect.CodeAborted, err)
+	if current, ok := version.Current(err); ok {
+		connectErr.Meta().Set(version.MetadataKey, strconv.FormatInt(current, 10))
+	}
+	return connectErr
+}
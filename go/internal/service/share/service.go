@@ -0,0 +1,160 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"connectrpc.com/connect"
+
+	appshare "github.com/mcdev12/lumo/go/internal/app/share"
+	linkpb "github.com/mcdev12/lumo/go/internal/genproto/link/v1"
+	lumepb "github.com/mcdev12/lumo/go/internal/genproto/lume/v1"
+	pb "github.com/mcdev12/lumo/go/internal/genproto/share/v1"
+	modellink "github.com/mcdev12/lumo/go/internal/models/link"
+	modellume "github.com/mcdev12/lumo/go/internal/models/lume"
+	modellumo "github.com/mcdev12/lumo/go/internal/models/lumo"
+	modelshare "github.com/mcdev12/lumo/go/internal/models/share"
+)
+
+// ShareApp defines what the service layer needs from the app layer
+type ShareApp interface {
+	CreateShareLink(ctx context.Context, req appshare.CreateShareLinkRequest) (*modelshare.ShareLink, error)
+	ListShareLinks(ctx context.Context, req appshare.ListShareLinksRequest) ([]*modelshare.ShareLink, error)
+	RevokeShareLink(ctx context.Context, shareLinkID string) (*modelshare.ShareLink, error)
+	GetSharedLumo(ctx context.Context, token string) (*modelshare.SharedLumo, error)
+}
+
+// Service implements the ShareServiceHandler interface
+type Service struct {
+	app ShareApp
+}
+
+// NewService creates a new Share service
+func NewService(app ShareApp) *Service {
+	return &Service{
+		app: app,
+	}
+}
+
+// CreateShareLink creates a read-only link to a Lumo and returns its token
+func (s *Service) CreateShareLink(ctx context.Context, req *connect.Request[pb.CreateShareLinkRequest]) (*connect.Response[pb.CreateShareLinkResponse], error) {
+	appReq := appshare.CreateShareLinkRequest{
+		LumoID: req.Msg.GetLumoId(),
+		Policy: modelshare.ProtoPolicyToDomain(req.Msg.GetPolicy()),
+	}
+	if req.Msg.ExpiresAt != nil {
+		expiresAt := req.Msg.GetExpiresAt().AsTime()
+		appReq.ExpiresAt = &expiresAt
+	}
+
+	created, err := s.app.CreateShareLink(ctx, appReq)
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.CreateShareLinkResponse{
+		ShareLink: modelshare.DomainToProto(created),
+		Token:     created.Token,
+	}), nil
+}
+
+// ListShareLinks lists the share links of a Lumo, newest first
+func (s *Service) ListShareLinks(ctx context.Context, req *connect.Request[pb.ListShareLinksRequest]) (*connect.Response[pb.ListShareLinksResponse], error) {
+	// Convert page_size to limit and page_token to offset
+	limit := req.Msg.GetPageSize()
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+
+	offset := int32(0)
+	if req.Msg.GetPageToken() != "" {
+		parsedOffset, err := strconv.ParseInt(req.Msg.GetPageToken(), 10, 32)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid page token"))
+		}
+		offset = int32(parsedOffset)
+	}
+
+	links, err := s.app.ListShareLinks(ctx, appshare.ListShareLinksRequest{
+		LumoID: req.Msg.GetLumoId(),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	pbLinks := make([]*pb.ShareLink, len(links))
+	for i, link := range links {
+		pbLinks[i] = modelshare.DomainToProto(link)
+	}
+
+	var nextPageToken string
+	if len(pbLinks) == int(limit) {
+		nextPageToken = strconv.FormatInt(int64(offset+limit), 10)
+	}
+
+	return connect.NewResponse(&pb.ListShareLinksResponse{
+		ShareLinks:    pbLinks,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// RevokeShareLink stops a share link from working
+func (s *Service) RevokeShareLink(ctx context.Context, req *connect.Request[pb.RevokeShareLinkRequest]) (*connect.Response[pb.RevokeShareLinkResponse], error) {
+	revoked, err := s.app.RevokeShareLink(ctx, req.Msg.GetShareLinkId())
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.RevokeShareLinkResponse{
+		ShareLink: modelshare.DomainToProto(revoked),
+	}), nil
+}
+
+// GetSharedLumo returns the redacted Lumo graph behind a share token
+func (s *Service) GetSharedLumo(ctx context.Context, req *connect.Request[pb.GetSharedLumoRequest]) (*connect.Response[pb.GetSharedLumoResponse], error) {
+	shared, err := s.app.GetSharedLumo(ctx, req.Msg.GetToken())
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	pbLumes := make([]*lumepb.Lume, len(shared.Lumes))
+	for i, lume := range shared.Lumes {
+		pbLumes[i] = modellume.DomainToProto(lume)
+	}
+
+	pbLinks := make([]*linkpb.Link, len(shared.Links))
+	for i, link := range shared.Links {
+		pbLinks[i] = modellink.DomainToProto(link)
+	}
+
+	return connect.NewResponse(&pb.GetSharedLumoResponse{
+		Lumo:  modellumo.DomainToProto(shared.Lumo),
+		Lumes: pbLumes,
+		Links: pbLinks,
+	}), nil
+}
+
+// mapErrorToConnectError maps domain errors to Connect errors
+func (s *Service) mapErrorToConnectError(err error) error {
+	switch {
+	case errors.Is(err, appshare.ErrInvalidLumoID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appshare.ErrInvalidShareLinkID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appshare.ErrEmptyToken):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appshare.ErrExpiryInPast):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appshare.ErrShareLinkNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, appshare.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, appshare.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
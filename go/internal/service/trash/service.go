@@ -0,0 +1,133 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"connectrpc.com/connect"
+
+	apptrash "github.com/mcdev12/lumo/go/internal/app/trash"
+	"github.com/mcdev12/lumo/go/internal/auth"
+	pb "github.com/mcdev12/lumo/go/internal/genproto/trash/v1"
+	modeltrash "github.com/mcdev12/lumo/go/internal/models/trash"
+)
+
+// TrashApp defines what the service layer needs from the app layer
+type TrashApp interface {
+	ListTrash(ctx context.Context, req apptrash.ListTrashRequest) ([]*modeltrash.Item, error)
+	Restore(ctx context.Context, req apptrash.RestoreRequest) (*modeltrash.Item, error)
+}
+
+// Service implements the TrashServiceHandler interface
+type Service struct {
+	app TrashApp
+}
+
+// NewService creates a new Trash service
+func NewService(app TrashApp) *Service {
+	return &Service{
+		app: app,
+	}
+}
+
+// ListTrash lists what was deleted from a Lumo or by a user, most recently deleted first
+func (s *Service) ListTrash(ctx context.Context, req *connect.Request[pb.ListTrashRequest]) (*connect.Response[pb.ListTrashResponse], error) {
+	// Convert page_size to limit and page_token to offset
+	limit := req.Msg.GetPageSize()
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+
+	offset := int32(0)
+	if req.Msg.GetPageToken() != "" {
+		parsedOffset, err := strconv.ParseInt(req.Msg.GetPageToken(), 10, 32)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid page token"))
+		}
+		offset = int32(parsedOffset)
+	}
+
+	// Without a Lumo it's the caller's own trash
+	userID := req.Msg.GetUserId()
+	if req.Msg.GetLumoId() == "" {
+		resolved, err := auth.ResolveUserID(ctx, userID)
+		if err != nil {
+			return nil, s.mapErrorToConnectError(err)
+		}
+		userID = resolved
+	}
+
+	appReq := apptrash.ListTrashRequest{
+		LumoID: req.Msg.GetLumoId(),
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	domainItems, err := s.app.ListTrash(ctx, appReq)
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	pbItems := make([]*pb.TrashItem, len(domainItems))
+	for i, domainItem := range domainItems {
+		pbItems[i] = modeltrash.DomainToProto(domainItem)
+	}
+
+	// Calculate next page token
+	var nextPageToken string
+	if len(pbItems) == int(limit) {
+		nextPageToken = strconv.FormatInt(int64(offset+limit), 10)
+	}
+
+	return connect.NewResponse(&pb.ListTrashResponse{
+		Items:         pbItems,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// Restore takes a Lumo, Lume or Link out of the trash
+func (s *Service) Restore(ctx context.Context, req *connect.Request[pb.RestoreRequest]) (*connect.Response[pb.RestoreResponse], error) {
+	appReq := apptrash.RestoreRequest{
+		EntityType: modeltrash.ProtoEntityTypeToDomain(req.Msg.GetEntityType()),
+		EntityID:   req.Msg.GetEntityId(),
+	}
+
+	domainItem, err := s.app.Restore(ctx, appReq)
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.RestoreResponse{
+		Item: modeltrash.DomainToProto(domainItem),
+	}), nil
+}
+
+// mapErrorToConnectError maps domain errors to Connect errors
+func (s *Service) mapErrorToConnectError(err error) error {
+	switch {
+	case errors.Is(err, apptrash.ErrInvalidLumoID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, apptrash.ErrInvalidUserID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, apptrash.ErrInvalidEntityID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, apptrash.ErrInvalidEntityType):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, apptrash.ErrMissingTarget):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, apptrash.ErrNotInTrash):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, apptrash.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, apptrash.ErrParentInTrash):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, apptrash.ErrConflict):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, auth.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
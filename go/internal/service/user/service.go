@@ -0,0 +1,124 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	appuser "github.com/mcdev12/lumo/go/internal/app/user"
+	pb "github.com/mcdev12/lumo/go/internal/genproto/user/v1"
+	modeluser "github.com/mcdev12/lumo/go/internal/models/user"
+)
+
+// UserApp defines what the service layer needs from the app layer
+type UserApp interface {
+	CreateUser(ctx context.Context, req appuser.CreateUserRequest) (*modeluser.User, error)
+	GetUser(ctx context.Context, userID string) (*modeluser.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*modeluser.User, error)
+	UpdateUser(ctx context.Context, req appuser.UpdateUserRequest) (*modeluser.User, error)
+}
+
+// Service implements the UserServiceHandler interface
+type Service struct {
+	app UserApp
+}
+
+// NewService creates a new User service
+func NewService(app UserApp) *Service {
+	return &Service{
+		app: app,
+	}
+}
+
+// CreateUser creates the profile of a user
+func (s *Service) CreateUser(ctx context.Context, req *connect.Request[pb.CreateUserRequest]) (*connect.Response[pb.CreateUserResponse], error) {
+	created, err := s.app.CreateUser(ctx, appuser.CreateUserRequest{
+		UserID:      req.Msg.GetUserId(),
+		Email:       req.Msg.GetEmail(),
+		DisplayName: req.Msg.GetDisplayName(),
+		Preferences: modeluser.ProtoPreferencesToDomain(req.Msg.GetPreferences()),
+	})
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.CreateUserResponse{
+		User: modeluser.DomainToProto(created),
+	}), nil
+}
+
+// GetUser retrieves a user by UUID
+func (s *Service) GetUser(ctx context.Context, req *connect.Request[pb.GetUserRequest]) (*connect.Response[pb.GetUserResponse], error) {
+	found, err := s.app.GetUser(ctx, req.Msg.GetUserId())
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.GetUserResponse{
+		User: modeluser.DomainToProto(found),
+	}), nil
+}
+
+// GetUserByEmail retrieves a user by email
+func (s *Service) GetUserByEmail(ctx context.Context, req *connect.Request[pb.GetUserByEmailRequest]) (*connect.Response[pb.GetUserByEmailResponse], error) {
+	found, err := s.app.GetUserByEmail(ctx, req.Msg.GetEmail())
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.GetUserByEmailResponse{
+		User: modeluser.DomainToProto(found),
+	}), nil
+}
+
+// UpdateUser updates the profile and preferences of a user
+func (s *Service) UpdateUser(ctx context.Context, req *connect.Request[pb.UpdateUserRequest]) (*connect.Response[pb.UpdateUserResponse], error) {
+	updateFields := make([]string, 0)
+	if req.Msg.GetUpdateMask() != nil {
+		updateFields = req.Msg.GetUpdateMask().GetPaths()
+	}
+
+	updated, err := s.app.UpdateUser(ctx, appuser.UpdateUserRequest{
+		UserID:       req.Msg.GetUserId(),
+		Email:        req.Msg.GetEmail(),
+		DisplayName:  req.Msg.GetDisplayName(),
+		Preferences:  modeluser.ProtoPreferencesToDomain(req.Msg.GetPreferences()),
+		UpdateFields: updateFields,
+	})
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.UpdateUserResponse{
+		User: modeluser.DomainToProto(updated),
+	}), nil
+}
+
+// mapErrorToConnectError maps domain errors to Connect errors
+func (s *Service) mapErrorToConnectError(err error) error {
+	switch {
+	case errors.Is(err, appuser.ErrInvalidUserID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appuser.ErrInvalidEmail):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appuser.ErrInvalidCurrency):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appuser.ErrInvalidDistanceUnit):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appuser.ErrInvalidTimeZone):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appuser.ErrInvalidLocale):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appuser.ErrUserNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, appuser.ErrUserExists):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, appuser.ErrEmailTaken):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, appuser.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
@@ -0,0 +1,214 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"connectrpc.com/connect"
+
+	appwebhook "github.com/mcdev12/lumo/go/internal/app/webhook"
+	pb "github.com/mcdev12/lumo/go/internal/genproto/webhook/v1"
+	modelwebhook "github.com/mcdev12/lumo/go/internal/models/webhook"
+)
+
+// WebhookApp defines what the service layer needs from the app layer
+type WebhookApp interface {
+	CreateWebhook(ctx context.Context, req appwebhook.CreateWebhookRequest) (*modelwebhook.Webhook, error)
+	ListWebhooks(ctx context.Context, req appwebhook.ListWebhooksRequest) ([]*modelwebhook.Webhook, error)
+	UpdateWebhook(ctx context.Context, req appwebhook.UpdateWebhookRequest) (*modelwebhook.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	ListDeliveries(ctx context.Context, req appwebhook.ListDeliveriesRequest) ([]*modelwebhook.Delivery, error)
+	RedeliverDelivery(ctx context.Context, deliveryID string) (*modelwebhook.Delivery, error)
+}
+
+// Service implements the WebhookServiceHandler interface
+type Service struct {
+	app WebhookApp
+}
+
+// NewService creates a new Webhook service
+func NewService(app WebhookApp) *Service {
+	return &Service{
+		app: app,
+	}
+}
+
+// CreateWebhook registers a webhook and returns its signing secret
+func (s *Service) CreateWebhook(ctx context.Context, req *connect.Request[pb.CreateWebhookRequest]) (*connect.Response[pb.CreateWebhookResponse], error) {
+	created, err := s.app.CreateWebhook(ctx, appwebhook.CreateWebhookRequest{
+		UserID:      req.Msg.GetUserId(),
+		LumoID:      req.Msg.GetLumoId(),
+		URL:         req.Msg.GetUrl(),
+		Description: req.Msg.GetDescription(),
+		Filter:      modelwebhook.ProtoFilterToDomain(req.Msg.GetFilter()),
+	})
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.CreateWebhookResponse{
+		Webhook: modelwebhook.DomainToProto(created),
+		Secret:  created.Secret,
+	}), nil
+}
+
+// ListWebhooks lists the webhooks of a user, newest first
+func (s *Service) ListWebhooks(ctx context.Context, req *connect.Request[pb.ListWebhooksRequest]) (*connect.Response[pb.ListWebhooksResponse], error) {
+	// Convert page_size to limit and page_token to offset
+	limit := req.Msg.GetPageSize()
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+
+	offset := int32(0)
+	if req.Msg.GetPageToken() != "" {
+		parsedOffset, err := strconv.ParseInt(req.Msg.GetPageToken(), 10, 32)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid page token"))
+		}
+		offset = int32(parsedOffset)
+	}
+
+	webhooks, err := s.app.ListWebhooks(ctx, appwebhook.ListWebhooksRequest{
+		UserID: req.Msg.GetUserId(),
+		LumoID: req.Msg.GetLumoId(),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	pbWebhooks := make([]*pb.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		pbWebhooks[i] = modelwebhook.DomainToProto(webhook)
+	}
+
+	var nextPageToken string
+	if len(pbWebhooks) == int(limit) {
+		nextPageToken = strconv.FormatInt(int64(offset+limit), 10)
+	}
+
+	return connect.NewResponse(&pb.ListWebhooksResponse{
+		Webhooks:      pbWebhooks,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// UpdateWebhook updates a webhook, enabling or disabling it
+func (s *Service) UpdateWebhook(ctx context.Context, req *connect.Request[pb.UpdateWebhookRequest]) (*connect.Response[pb.UpdateWebhookResponse], error) {
+	updateFields := make([]string, 0)
+	if req.Msg.GetUpdateMask() != nil {
+		updateFields = req.Msg.GetUpdateMask().GetPaths()
+	}
+
+	updated, err := s.app.UpdateWebhook(ctx, appwebhook.UpdateWebhookRequest{
+		WebhookID:    req.Msg.GetWebhookId(),
+		URL:          req.Msg.GetUrl(),
+		Description:  req.Msg.GetDescription(),
+		Filter:       modelwebhook.ProtoFilterToDomain(req.Msg.GetFilter()),
+		Enabled:      req.Msg.GetEnabled(),
+		UpdateFields: updateFields,
+	})
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.UpdateWebhookResponse{
+		Webhook: modelwebhook.DomainToProto(updated),
+	}), nil
+}
+
+// DeleteWebhook deletes a webhook and its delivery log
+func (s *Service) DeleteWebhook(ctx context.Context, req *connect.Request[pb.DeleteWebhookRequest]) (*connect.Response[pb.DeleteWebhookResponse], error) {
+	if err := s.app.DeleteWebhook(ctx, req.Msg.GetWebhookId()); err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.DeleteWebhookResponse{}), nil
+}
+
+// ListWebhookDeliveries lists the deliveries to a webhook, newest first
+func (s *Service) ListWebhookDeliveries(ctx context.Context, req *connect.Request[pb.ListWebhookDeliveriesRequest]) (*connect.Response[pb.ListWebhookDeliveriesResponse], error) {
+	// Convert page_size to limit and page_token to offset
+	limit := req.Msg.GetPageSize()
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+
+	offset := int32(0)
+	if req.Msg.GetPageToken() != "" {
+		parsedOffset, err := strconv.ParseInt(req.Msg.GetPageToken(), 10, 32)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid page token"))
+		}
+		offset = int32(parsedOffset)
+	}
+
+	deliveries, err := s.app.ListDeliveries(ctx, appwebhook.ListDeliveriesRequest{
+		WebhookID: req.Msg.GetWebhookId(),
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	pbDeliveries := make([]*pb.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		pbDeliveries[i] = modelwebhook.DeliveryToProto(delivery)
+	}
+
+	var nextPageToken string
+	if len(pbDeliveries) == int(limit) {
+		nextPageToken = strconv.FormatInt(int64(offset+limit), 10)
+	}
+
+	return connect.NewResponse(&pb.ListWebhookDeliveriesResponse{
+		Deliveries:    pbDeliveries,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// RedeliverWebhookDelivery queues the event of a delivery once more
+func (s *Service) RedeliverWebhookDelivery(ctx context.Context, req *connect.Request[pb.RedeliverWebhookDeliveryRequest]) (*connect.Response[pb.RedeliverWebhookDeliveryResponse], error) {
+	redelivery, err := s.app.RedeliverDelivery(ctx, req.Msg.GetDeliveryId())
+	if err != nil {
+		return nil, s.mapErrorToConnectError(err)
+	}
+
+	return connect.NewResponse(&pb.RedeliverWebhookDeliveryResponse{
+		Delivery: modelwebhook.DeliveryToProto(redelivery),
+	}), nil
+}
+
+// mapErrorToConnectError maps domain errors to Connect errors
+func (s *Service) mapErrorToConnectError(err error) error {
+	switch {
+	case errors.Is(err, appwebhook.ErrInvalidUserID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appwebhook.ErrInvalidLumoID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appwebhook.ErrInvalidWebhookID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appwebhook.ErrInvalidDeliveryID):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appwebhook.ErrInvalidURL):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appwebhook.ErrInvalidFilter):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, appwebhook.ErrWebhookNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, appwebhook.ErrDeliveryNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, appwebhook.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, appwebhook.ErrUserNotFound):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, appwebhook.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingTestSuite is a test suite for the span helpers
+type TracingTestSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+	provider *sdktrace.TracerProvider
+	previous trace.TracerProvider
+}
+
+// SetupTest is called before each test
+func (s *TracingTestSuite) SetupTest() {
+	s.recorder = tracetest.NewSpanRecorder()
+	s.provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder))
+	s.previous = otel.GetTracerProvider()
+	otel.SetTracerProvider(s.provider)
+}
+
+// TearDownTest is called after each test
+func (s *TracingTestSuite) TearDownTest() {
+	otel.SetTracerProvider(s.previous)
+}
+
+// TestTracingSuite runs the test suite
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
+
+// Test spans are only started inside a trace
+func (s *TracingTestSuite) TestStartWithoutParent() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	spanCtx, span := Start(ctx, "lume.App.GetLume")
+	End(span, nil)
+
+	// Assert
+	s.Equal(ctx, spanCtx)
+	s.Empty(s.recorder.Ended())
+}
+
+// Test spans are children of the span in the context and record errors
+func (s *TracingTestSuite) TestStartAndEnd() {
+	// Arrange
+	ctx, parent := s.provider.Tracer("test").Start(context.Background(), "rpc")
+	err := errors.New("lume not found")
+
+	// Act
+	_, span := Start(ctx, "lume.App.GetLume")
+	End(span, &err)
+	parent.End()
+
+	// Assert
+	s.Require().Len(s.recorder.Ended(), 2)
+	ended := s.recorder.Ended()[0]
+	s.Equal("lume.App.GetLume", ended.Name())
+	s.Equal(parent.SpanContext().SpanID(), ended.Parent().SpanID())
+	s.Equal(codes.Error, ended.Status().Code)
+	s.Equal("lume not found", ended.Status().Description)
+	s.Equal(codes.Unset, s.recorder.Ended()[1].Status().Code)
+}
+
+// Test exporters are picked by name
+func (s *TracingTestSuite) TestNewExporter() {
+	exporter, err := newExporter(context.Background(), ExporterNone, nil)
+	s.NoError(err)
+	s.Nil(exporter)
+
+	exporter, err = newExporter(context.Background(), ExporterStdout, &bytes.Buffer{})
+	s.NoError(err)
+	s.NotNil(exporter)
+
+	_, err = newExporter(context.Background(), "zipkin", nil)
+	s.ErrorContains(err, `unknown trace exporter "zipkin"`)
+}
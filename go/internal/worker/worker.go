@@ -0,0 +1,27 @@
+// Package worker runs the server's background jobs
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// Every returns a worker calling run right away and then on every interval
+// until ctx is done. run is expected to log its own failures, the next call
+// retries whatever it couldn't do.
+func Every(interval time.Duration, run func(context.Context)) func(context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
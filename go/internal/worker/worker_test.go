@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// WorkerTestSuite is a test suite for Every
+type WorkerTestSuite struct {
+	suite.Suite
+}
+
+// TestWorkerSuite runs the test suite
+func TestWorkerSuite(t *testing.T) {
+	suite.Run(t, new(WorkerTestSuite))
+}
+
+// Test the function runs right away and then on every interval
+func (s *WorkerTestSuite) TestEveryRepeats() {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := make(chan struct{}, 3)
+	run := Every(time.Millisecond, func(context.Context) {
+		select {
+		case calls <- struct{}{}:
+		default:
+			cancel()
+		}
+	})
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		run(ctx)
+		close(done)
+	}()
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("worker didn't stop")
+	}
+	s.Len(calls, 3)
+}
+
+// Test the function runs once even when ctx is already done
+func (s *WorkerTestSuite) TestEveryRunsOnce() {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+
+	// Act
+	Every(time.Hour, func(context.Context) { calls++ })(ctx)
+
+	// Assert
+	s.Equal(1, calls)
+}